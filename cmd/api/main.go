@@ -1,7 +1,7 @@
 package main
 
 import (
-	"log"
+	"log/slog"
 	"os"
 	"os/signal"
 	"syscall"
@@ -16,7 +16,8 @@ func main() {
 	// Iniciar el servidor en una goroutine
 	go func() {
 		if err := srv.Start(); err != nil {
-			log.Fatalf("Error al iniciar el servidor: %v", err)
+			slog.Error("error al iniciar el servidor", slog.Any("error", err))
+			os.Exit(1)
 		}
 	}()
 
@@ -27,6 +28,7 @@ func main() {
 
 	// Cerrar el servidor de manera elegante
 	if err := srv.Shutdown(); err != nil {
-		log.Fatalf("Error al cerrar el servidor: %v", err)
+		slog.Error("error al cerrar el servidor", slog.Any("error", err))
+		os.Exit(1)
 	}
 } 
\ No newline at end of file
@@ -0,0 +1,59 @@
+package entities
+
+import "context"
+
+// Role representa el rol de un usuario dentro del sistema
+type Role string
+
+const (
+	// RoleAnonymous identifica a los clientes sin credenciales
+	RoleAnonymous Role = "anonymous"
+
+	// RoleCitizen identifica a los ciudadanos registrados que reportan delitos
+	RoleCitizen Role = "citizen"
+
+	// RoleModerator identifica a los moderadores que verifican los reportes
+	RoleModerator Role = "moderator"
+
+	// RoleAdmin identifica a los administradores del sistema
+	RoleAdmin Role = "admin"
+)
+
+// Actor representa al usuario que ejecuta una operación
+type Actor struct {
+	ID   string `json:"id"`
+	Role Role   `json:"role"`
+}
+
+// AnonymousActor representa a un cliente sin credenciales
+var AnonymousActor = Actor{Role: RoleAnonymous}
+
+// IsValid indica si el rol es uno de los roles conocidos
+func (r Role) IsValid() bool {
+	switch r {
+	case RoleAnonymous, RoleCitizen, RoleModerator, RoleAdmin:
+		return true
+	}
+	return false
+}
+
+// IsStaff indica si el actor puede ver y moderar delitos no públicos
+func (a Actor) IsStaff() bool {
+	return a.Role == RoleModerator || a.Role == RoleAdmin
+}
+
+// actorContextKey es el tipo de la clave del actor en el contexto
+type actorContextKey struct{}
+
+// ContextWithActor retorna un contexto que transporta el actor de la operación
+func ContextWithActor(ctx context.Context, actor Actor) context.Context {
+	return context.WithValue(ctx, actorContextKey{}, actor)
+}
+
+// ActorFromContext obtiene el actor del contexto, anónimo si no existe
+func ActorFromContext(ctx context.Context) Actor {
+	if actor, ok := ctx.Value(actorContextKey{}).(Actor); ok {
+		return actor
+	}
+	return AnonymousActor
+}
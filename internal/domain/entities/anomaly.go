@@ -0,0 +1,18 @@
+package entities
+
+import "time"
+
+// Anomaly representa un aumento inusual de los delitos de una zona o de un tipo respecto de
+// las ventanas anteriores de la misma serie
+type Anomaly struct {
+	ID          string    `json:"id"`
+	ZoneID      string    `json:"zone_id,omitempty"` // Zona de la serie; vacío para toda la ciudad
+	Type        string    `json:"type,omitempty"`    // Tipo de delito de la serie; vacío para todos
+	WindowStart time.Time `json:"window_start"`
+	WindowEnd   time.Time `json:"window_end"` // Fin de la ventana, excluido
+	Count       int       `json:"count"`      // Delitos de la ventana
+	Baseline    float64   `json:"baseline"`   // Promedio de delitos de las ventanas anteriores
+	StdDev      float64   `json:"std_dev"`    // Desviación estándar de las ventanas anteriores
+	ZScore      float64   `json:"z_score"`    // Desvíos por encima del promedio
+	DetectedAt  time.Time `json:"detected_at"`
+}
@@ -0,0 +1,22 @@
+package entities
+
+// BoundingBox representa un rectángulo geográfico delimitado por latitud y longitud
+type BoundingBox struct {
+	MinLatitude  float64 `json:"min_latitude"`
+	MinLongitude float64 `json:"min_longitude"`
+	MaxLatitude  float64 `json:"max_latitude"`
+	MaxLongitude float64 `json:"max_longitude"`
+}
+
+// IsValid indica si los límites están dentro de los rangos válidos y el mínimo no supera al máximo
+func (b BoundingBox) IsValid() bool {
+	return b.MinLatitude >= -90 && b.MaxLatitude <= 90 &&
+		b.MinLongitude >= -180 && b.MaxLongitude <= 180 &&
+		b.MinLatitude <= b.MaxLatitude && b.MinLongitude <= b.MaxLongitude
+}
+
+// Contains indica si la ubicación está dentro del rectángulo, bordes incluidos
+func (b BoundingBox) Contains(location Location) bool {
+	return location.Latitude >= b.MinLatitude && location.Latitude <= b.MaxLatitude &&
+		location.Longitude >= b.MinLongitude && location.Longitude <= b.MaxLongitude
+}
@@ -4,13 +4,20 @@ import "time"
 
 // Crime representa un delito reportado en el sistema
 type Crime struct {
-	ID          string    `json:"id"`
-	Type        string    `json:"type"`        // Tipo de delito (robo, asalto, etc.)
-	Description string    `json:"description"` // Descripción detallada del delito
-	Location    Location  `json:"location"`    // Ubicación donde ocurrió el delito
-	Date        time.Time `json:"date"`        // Fecha y hora del delito
-	CreatedAt   time.Time `json:"created_at"`  // Fecha de creación del registro
-	UpdatedAt   time.Time `json:"updated_at"`  // Fecha de última actualización
+	ID              string      `json:"id"`
+	Type            string      `json:"type"`             // Tipo de delito (robo, asalto, etc.)
+	Description     string      `json:"description"`      // Descripción detallada del delito
+	Location        Location    `json:"location"`         // Ubicación donde ocurrió el delito
+	Date            time.Time   `json:"date"`             // Fecha y hora del delito
+	Status          CrimeStatus `json:"status"`           // Estado de verificación del delito
+	ZoneID          string      `json:"zone_id"`          // Zona que contiene la ubicación, vacío si ninguna
+	Severity        Severity    `json:"severity"`         // Gravedad del delito
+	Weapon          Weapon      `json:"weapon"`           // Arma involucrada
+	Violent         bool        `json:"violent"`          // Hubo violencia física contra las personas
+	VictimCount     int         `json:"victim_count"`     // Cantidad de víctimas, 0 si se desconoce
+	AddressMismatch bool        `json:"address_mismatch"` // La dirección informada está lejos de las coordenadas
+	CreatedAt       time.Time   `json:"created_at"`       // Fecha de creación del registro
+	UpdatedAt       time.Time   `json:"updated_at"`       // Fecha de última actualización
 }
 
 // Location representa la ubicación geográfica de un delito
@@ -0,0 +1,83 @@
+package entities
+
+// Severity representa la gravedad de un delito
+type Severity string
+
+const (
+	// SeverityLow corresponde a los delitos menores contra la propiedad, como un grafiti
+	SeverityLow Severity = "low"
+
+	// SeverityMedium corresponde a los delitos sin violencia contra las personas o con daño relevante
+	SeverityMedium Severity = "medium"
+
+	// SeverityHigh corresponde a los delitos con violencia o amenaza contra las personas
+	SeverityHigh Severity = "high"
+
+	// SeverityCritical corresponde a los delitos con armas de fuego, heridos graves o víctimas fatales
+	SeverityCritical Severity = "critical"
+)
+
+// Severities contiene todas las gravedades, de menor a mayor
+var Severities = []Severity{SeverityLow, SeverityMedium, SeverityHigh, SeverityCritical}
+
+// severityWeights asigna el peso de cada gravedad en las estadísticas ponderadas; cada nivel
+// pesa el doble que el anterior
+var severityWeights = map[Severity]float64{
+	SeverityLow:      1,
+	SeverityMedium:   2,
+	SeverityHigh:     4,
+	SeverityCritical: 8,
+}
+
+// IsValid indica si la gravedad es una de las gravedades conocidas
+func (s Severity) IsValid() bool {
+	_, ok := severityWeights[s]
+	return ok
+}
+
+// Weight retorna el peso de la gravedad en las estadísticas ponderadas; las gravedades
+// desconocidas pesan como SeverityLow
+func (s Severity) Weight() float64 {
+	if weight, ok := severityWeights[s]; ok {
+		return weight
+	}
+	return severityWeights[SeverityLow]
+}
+
+// Weapon representa el tipo de arma involucrada en un delito
+type Weapon string
+
+const (
+	// WeaponNone indica que no se usó ni exhibió un arma
+	WeaponNone Weapon = "none"
+
+	// WeaponBlunt indica un objeto contundente
+	WeaponBlunt Weapon = "blunt"
+
+	// WeaponKnife indica un arma blanca
+	WeaponKnife Weapon = "knife"
+
+	// WeaponFirearm indica un arma de fuego
+	WeaponFirearm Weapon = "firearm"
+
+	// WeaponOther indica cualquier otra arma
+	WeaponOther Weapon = "other"
+)
+
+// Weapons contiene todos los tipos de arma
+var Weapons = []Weapon{WeaponNone, WeaponBlunt, WeaponKnife, WeaponFirearm, WeaponOther}
+
+// IsValid indica si el tipo de arma es uno de los conocidos
+func (w Weapon) IsValid() bool {
+	for _, weapon := range Weapons {
+		if w == weapon {
+			return true
+		}
+	}
+	return false
+}
+
+// Armed indica si el delito involucró un arma
+func (w Weapon) Armed() bool {
+	return w != "" && w != WeaponNone
+}
@@ -0,0 +1,94 @@
+package entities
+
+import "time"
+
+// RevisionAction representa la operación que generó una revisión de un delito
+type RevisionAction string
+
+const (
+	// RevisionCreated se registra al reportar el delito
+	RevisionCreated RevisionAction = "created"
+
+	// RevisionUpdated se registra al modificar los datos del delito
+	RevisionUpdated RevisionAction = "updated"
+
+	// RevisionStatusChanged se registra al cambiar el estado del delito
+	RevisionStatusChanged RevisionAction = "status_changed"
+
+	// RevisionDeleted se registra al eliminar el delito
+	RevisionDeleted RevisionAction = "deleted"
+)
+
+// CrimeRevision representa una entrada inmutable del historial de versiones de un delito
+type CrimeRevision struct {
+	ID         string         `json:"id"`
+	CrimeID    string         `json:"crime_id"`
+	Version    int            `json:"version"` // Número correlativo de la revisión dentro del delito
+	Action     RevisionAction `json:"action"`
+	ActorID    string         `json:"actor_id,omitempty"` // Vacío para los actores anónimos
+	ActorRole  Role           `json:"actor_role"`
+	RequestID  string         `json:"request_id,omitempty"` // Solicitud HTTP que originó el cambio
+	Changes    []FieldChange  `json:"changes"`
+	Snapshot   *Crime         `json:"snapshot,omitempty"` // Estado del delito tras el cambio, nil al eliminarlo
+	RecordedAt time.Time      `json:"recorded_at"`
+}
+
+// FieldChange representa el valor anterior y el nuevo de un campo modificado
+type FieldChange struct {
+	Field  string `json:"field"`
+	Before any    `json:"before"`
+	After  any    `json:"after"`
+}
+
+// DiffCrimes compara dos versiones de un delito y retorna los campos que cambiaron.
+// before es nil al crear el delito y after es nil al eliminarlo.
+func DiffCrimes(before, after *Crime) []FieldChange {
+	beforeFields := crimeFields(before)
+	afterFields := crimeFields(after)
+
+	changes := []FieldChange{}
+	for _, field := range crimeFieldNames {
+		b, a := beforeFields[field], afterFields[field]
+		if b != a {
+			changes = append(changes, FieldChange{Field: field, Before: b, After: a})
+		}
+	}
+	return changes
+}
+
+// crimeFieldNames define los campos auditados y el orden en que se listan los cambios
+var crimeFieldNames = []string{
+	"type",
+	"description",
+	"location.latitude",
+	"location.longitude",
+	"location.address",
+	"date",
+	"status",
+	"severity",
+	"weapon",
+	"violent",
+	"victim_count",
+	"address_mismatch",
+}
+
+// crimeFields aplana los campos auditados de un delito; los valores son comparables con ==
+func crimeFields(crime *Crime) map[string]any {
+	if crime == nil {
+		return map[string]any{}
+	}
+	return map[string]any{
+		"type":               crime.Type,
+		"description":        crime.Description,
+		"location.latitude":  crime.Location.Latitude,
+		"location.longitude": crime.Location.Longitude,
+		"location.address":   crime.Location.Address,
+		"date":               crime.Date.UTC().Format(time.RFC3339Nano),
+		"status":             string(crime.Status),
+		"severity":           string(crime.Severity),
+		"weapon":             string(crime.Weapon),
+		"violent":            crime.Violent,
+		"victim_count":       crime.VictimCount,
+		"address_mismatch":   crime.AddressMismatch,
+	}
+}
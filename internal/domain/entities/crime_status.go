@@ -0,0 +1,113 @@
+package entities
+
+import "time"
+
+// CrimeStatus representa el estado de verificación de un delito
+type CrimeStatus string
+
+const (
+	// CrimeStatusReported es el estado inicial de un delito recién reportado
+	CrimeStatusReported CrimeStatus = "reported"
+
+	// CrimeStatusVerified indica que un moderador confirmó el delito
+	CrimeStatusVerified CrimeStatus = "verified"
+
+	// CrimeStatusRejected indica que el reporte se descartó por falso o inválido
+	CrimeStatusRejected CrimeStatus = "rejected"
+
+	// CrimeStatusResolved indica que un delito verificado fue resuelto
+	CrimeStatusResolved CrimeStatus = "resolved"
+)
+
+// CrimeStatuses contiene todos los estados válidos en el orden del flujo
+var CrimeStatuses = []CrimeStatus{
+	CrimeStatusReported,
+	CrimeStatusVerified,
+	CrimeStatusRejected,
+	CrimeStatusResolved,
+}
+
+// PublicCrimeStatuses contiene los estados que se muestran en el mapa público
+var PublicCrimeStatuses = []CrimeStatus{
+	CrimeStatusVerified,
+	CrimeStatusResolved,
+}
+
+// StatusTransition define un cambio de estado permitido y quién puede realizarlo
+type StatusTransition struct {
+	From           CrimeStatus
+	To             CrimeStatus
+	Roles          []Role // Roles autorizados a realizar la transición
+	RequiresReason bool   // La transición exige un motivo
+}
+
+// statusTransitions define la máquina de estados de los delitos
+var statusTransitions = []StatusTransition{
+	{From: CrimeStatusReported, To: CrimeStatusVerified, Roles: []Role{RoleModerator, RoleAdmin}},
+	{From: CrimeStatusReported, To: CrimeStatusRejected, Roles: []Role{RoleModerator, RoleAdmin}, RequiresReason: true},
+	{From: CrimeStatusVerified, To: CrimeStatusResolved, Roles: []Role{RoleModerator, RoleAdmin}},
+	{From: CrimeStatusVerified, To: CrimeStatusRejected, Roles: []Role{RoleModerator, RoleAdmin}, RequiresReason: true},
+	{From: CrimeStatusRejected, To: CrimeStatusReported, Roles: []Role{RoleAdmin}, RequiresReason: true},
+}
+
+// IsValid indica si el estado es uno de los estados conocidos
+func (s CrimeStatus) IsValid() bool {
+	for _, status := range CrimeStatuses {
+		if s == status {
+			return true
+		}
+	}
+	return false
+}
+
+// IsPublic indica si los delitos en este estado se muestran en el mapa público
+func (s CrimeStatus) IsPublic() bool {
+	for _, status := range PublicCrimeStatuses {
+		if s == status {
+			return true
+		}
+	}
+	return false
+}
+
+// Transition obtiene la regla para pasar del estado actual al estado indicado
+func (s CrimeStatus) Transition(to CrimeStatus) (StatusTransition, bool) {
+	for _, transition := range statusTransitions {
+		if transition.From == s && transition.To == to {
+			return transition, true
+		}
+	}
+	return StatusTransition{}, false
+}
+
+// AllowedTransitions retorna los estados a los que se puede pasar desde el estado actual
+func (s CrimeStatus) AllowedTransitions() []StatusTransition {
+	var transitions []StatusTransition
+	for _, transition := range statusTransitions {
+		if transition.From == s {
+			transitions = append(transitions, transition)
+		}
+	}
+	return transitions
+}
+
+// AllowsRole indica si el rol puede realizar la transición
+func (t StatusTransition) AllowsRole(role Role) bool {
+	for _, allowed := range t.Roles {
+		if role == allowed {
+			return true
+		}
+	}
+	return false
+}
+
+// CrimeStatusChange representa una fila del historial de estados de un delito
+type CrimeStatusChange struct {
+	ID        string      `json:"id"`
+	CrimeID   string      `json:"crime_id"`
+	From      CrimeStatus `json:"from"`
+	To        CrimeStatus `json:"to"`
+	Reason    string      `json:"reason,omitempty"` // Motivo del cambio, obligatorio al rechazar
+	ActorID   string      `json:"actor_id"`         // Usuario que realizó el cambio
+	ChangedAt time.Time   `json:"changed_at"`
+}
@@ -0,0 +1,298 @@
+package entities
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+
+	"go-crime_map_backend/pkg/geojson"
+)
+
+// earthRadiusMeters es el radio medio de la Tierra usado para calcular distancias
+const earthRadiusMeters = 6371008.8
+
+// Coordinate representa un punto geográfico
+type Coordinate struct {
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+}
+
+// IsValid indica si la latitud y la longitud están dentro de los rangos válidos
+func (c Coordinate) IsValid() bool {
+	return c.Latitude >= -90 && c.Latitude <= 90 && c.Longitude >= -180 && c.Longitude <= 180
+}
+
+// DistanceMeters calcula la distancia en metros entre dos puntos con la fórmula del haversine
+func DistanceMeters(a, b Coordinate) float64 {
+	lat1, lat2 := a.Latitude*math.Pi/180, b.Latitude*math.Pi/180
+	dLat := lat2 - lat1
+	dLon := (b.Longitude - a.Longitude) * math.Pi / 180
+	h := math.Sin(dLat/2)*math.Sin(dLat/2) + math.Cos(lat1)*math.Cos(lat2)*math.Sin(dLon/2)*math.Sin(dLon/2)
+	return 2 * earthRadiusMeters * math.Asin(math.Min(1, math.Sqrt(h)))
+}
+
+// Circle representa un área circular alrededor de un punto
+type Circle struct {
+	Center       Coordinate `json:"center"`
+	RadiusMeters float64    `json:"radius_meters"`
+}
+
+// Contains indica si la ubicación está dentro del círculo, borde incluido
+func (c Circle) Contains(location Location) bool {
+	return DistanceMeters(c.Center, Coordinate{Latitude: location.Latitude, Longitude: location.Longitude}) <= c.RadiusMeters
+}
+
+// Bounds retorna el rectángulo que contiene al círculo
+func (c Circle) Bounds() BoundingBox {
+	dLat := c.RadiusMeters / earthRadiusMeters * 180 / math.Pi
+	dLon := 180.0
+	if cos := math.Cos(c.Center.Latitude * math.Pi / 180); cos > 1e-9 {
+		dLon = math.Min(180, dLat/cos)
+	}
+	return BoundingBox{
+		MinLatitude:  math.Max(-90, c.Center.Latitude-dLat),
+		MinLongitude: math.Max(-180, c.Center.Longitude-dLon),
+		MaxLatitude:  math.Min(90, c.Center.Latitude+dLat),
+		MaxLongitude: math.Min(180, c.Center.Longitude+dLon),
+	}
+}
+
+// Polygon representa un área delimitada por sus vértices; el anillo se cierra implícitamente
+type Polygon []Coordinate
+
+// IsValid indica si el polígono tiene al menos tres vértices válidos
+func (p Polygon) IsValid() bool {
+	if len(p) < 3 {
+		return false
+	}
+	for _, vertex := range p {
+		if !vertex.IsValid() {
+			return false
+		}
+	}
+	return true
+}
+
+// Contains indica si la ubicación está dentro del polígono usando el algoritmo de ray casting
+// sobre el plano latitud/longitud, adecuado para áreas de escala urbana
+func (p Polygon) Contains(location Location) bool {
+	inside := false
+	x, y := location.Longitude, location.Latitude
+	for i, j := 0, len(p)-1; i < len(p); j, i = i, i+1 {
+		xi, yi := p[i].Longitude, p[i].Latitude
+		xj, yj := p[j].Longitude, p[j].Latitude
+		if (yi > y) != (yj > y) && x < (xj-xi)*(y-yi)/(yj-yi)+xi {
+			inside = !inside
+		}
+	}
+	return inside
+}
+
+// Bounds retorna el rectángulo que contiene al polígono
+func (p Polygon) Bounds() BoundingBox {
+	box := BoundingBox{MinLatitude: 90, MinLongitude: 180, MaxLatitude: -90, MaxLongitude: -180}
+	for _, vertex := range p {
+		box.MinLatitude = math.Min(box.MinLatitude, vertex.Latitude)
+		box.MinLongitude = math.Min(box.MinLongitude, vertex.Longitude)
+		box.MaxLatitude = math.Max(box.MaxLatitude, vertex.Latitude)
+		box.MaxLongitude = math.Max(box.MaxLongitude, vertex.Longitude)
+	}
+	return box
+}
+
+// areaSquareMeters calcula el área del anillo con la fórmula del área de Gauss sobre una
+// proyección equirectangular centrada en el anillo, adecuada para áreas de escala urbana
+func (p Polygon) areaSquareMeters() float64 {
+	if len(p) < 3 {
+		return 0
+	}
+	bounds := p.Bounds()
+	scale := math.Cos((bounds.MinLatitude + bounds.MaxLatitude) / 2 * math.Pi / 180)
+	sum := 0.0
+	for i, j := 0, len(p)-1; i < len(p); j, i = i, i+1 {
+		sum += p[j].Longitude*p[i].Latitude - p[i].Longitude*p[j].Latitude
+	}
+	metersPerDegree := earthRadiusMeters * math.Pi / 180
+	return math.Abs(sum) / 2 * scale * metersPerDegree * metersPerDegree
+}
+
+// MultiPolygon representa un área formada por uno o más polígonos. En cada polígono el
+// primer anillo es el contorno y los siguientes son huecos. Se serializa como una
+// geometría GeoJSON MultiPolygon
+type MultiPolygon [][]Polygon
+
+// IsValid indica si hay al menos un polígono y todos sus anillos son válidos
+func (m MultiPolygon) IsValid() bool {
+	if len(m) == 0 {
+		return false
+	}
+	for _, rings := range m {
+		if len(rings) == 0 {
+			return false
+		}
+		for _, ring := range rings {
+			if !ring.IsValid() {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// Contains indica si la ubicación está dentro de algún contorno y fuera de sus huecos
+func (m MultiPolygon) Contains(location Location) bool {
+	for _, rings := range m {
+		if len(rings) == 0 || !rings[0].Contains(location) {
+			continue
+		}
+		inHole := false
+		for _, hole := range rings[1:] {
+			if hole.Contains(location) {
+				inHole = true
+				break
+			}
+		}
+		if !inHole {
+			return true
+		}
+	}
+	return false
+}
+
+// Bounds retorna el rectángulo que contiene a todos los contornos
+func (m MultiPolygon) Bounds() BoundingBox {
+	box := BoundingBox{MinLatitude: 90, MinLongitude: 180, MaxLatitude: -90, MaxLongitude: -180}
+	for _, rings := range m {
+		if len(rings) == 0 {
+			continue
+		}
+		outer := rings[0].Bounds()
+		box.MinLatitude = math.Min(box.MinLatitude, outer.MinLatitude)
+		box.MinLongitude = math.Min(box.MinLongitude, outer.MinLongitude)
+		box.MaxLatitude = math.Max(box.MaxLatitude, outer.MaxLatitude)
+		box.MaxLongitude = math.Max(box.MaxLongitude, outer.MaxLongitude)
+	}
+	return box
+}
+
+// AreaSquareMeters retorna el área aproximada en metros cuadrados, descontando los huecos
+func (m MultiPolygon) AreaSquareMeters() float64 {
+	area := 0.0
+	for _, rings := range m {
+		for i, ring := range rings {
+			if i == 0 {
+				area += ring.areaSquareMeters()
+			} else {
+				area -= ring.areaSquareMeters()
+			}
+		}
+	}
+	return math.Max(0, area)
+}
+
+// Centroid retorna el centro de masa del área calculado sobre el plano latitud/longitud,
+// adecuado para áreas de escala urbana. Los huecos se descuentan; si el área es nula retorna
+// el centro de sus límites
+func (m MultiPolygon) Centroid() Coordinate {
+	area, lat, lon := 0.0, 0.0, 0.0
+	for _, rings := range m {
+		for i, ring := range rings {
+			sign := 1.0
+			if i > 0 {
+				sign = -1
+			}
+			// Cada anillo aporta su área con signo según su orientación; se normaliza al contorno
+			ringArea, ringLat, ringLon := 0.0, 0.0, 0.0
+			for k, j := 0, len(ring)-1; k < len(ring); j, k = k, k+1 {
+				cross := ring[j].Longitude*ring[k].Latitude - ring[k].Longitude*ring[j].Latitude
+				ringArea += cross
+				ringLon += (ring[j].Longitude + ring[k].Longitude) * cross
+				ringLat += (ring[j].Latitude + ring[k].Latitude) * cross
+			}
+			if ringArea < 0 {
+				ringArea, ringLat, ringLon = -ringArea, -ringLat, -ringLon
+			}
+			area += sign * ringArea / 2
+			lat += sign * ringLat / 6
+			lon += sign * ringLon / 6
+		}
+	}
+	if area <= 0 {
+		box := m.Bounds()
+		return Coordinate{Latitude: (box.MinLatitude + box.MaxLatitude) / 2, Longitude: (box.MinLongitude + box.MaxLongitude) / 2}
+	}
+	return Coordinate{Latitude: lat / area, Longitude: lon / area}
+}
+
+// Vertices retorna la cantidad total de vértices de todos los anillos
+func (m MultiPolygon) Vertices() int {
+	count := 0
+	for _, rings := range m {
+		for _, ring := range rings {
+			count += len(ring)
+		}
+	}
+	return count
+}
+
+// GeoJSON retorna la geometría GeoJSON equivalente, con los anillos cerrados
+func (m MultiPolygon) GeoJSON() geojson.Geometry {
+	polygons := make([][][]geojson.Position, len(m))
+	for i, rings := range m {
+		polygons[i] = make([][]geojson.Position, len(rings))
+		for j, ring := range rings {
+			positions := make([]geojson.Position, 0, len(ring)+1)
+			for _, vertex := range ring {
+				positions = append(positions, geojson.Position{vertex.Longitude, vertex.Latitude})
+			}
+			if len(positions) > 0 {
+				positions = append(positions, positions[0])
+			}
+			polygons[i][j] = positions
+		}
+	}
+	return geojson.NewMultiPolygon(polygons)
+}
+
+// MultiPolygonFromGeoJSON convierte una geometría GeoJSON Polygon o MultiPolygon. El vértice
+// que cierra cada anillo se descarta porque Polygon cierra el anillo implícitamente
+func MultiPolygonFromGeoJSON(geometry geojson.Geometry) (MultiPolygon, error) {
+	polygons, err := geometry.MultiPolygon()
+	if err != nil {
+		return nil, err
+	}
+	m := make(MultiPolygon, len(polygons))
+	for i, rings := range polygons {
+		m[i] = make([]Polygon, len(rings))
+		for j, positions := range rings {
+			if n := len(positions); n > 1 && positions[0] == positions[n-1] {
+				positions = positions[:n-1]
+			}
+			ring := make(Polygon, len(positions))
+			for k, position := range positions {
+				ring[k] = Coordinate{Latitude: position[1], Longitude: position[0]}
+			}
+			m[i][j] = ring
+		}
+	}
+	return m, nil
+}
+
+// MarshalJSON serializa el área como una geometría GeoJSON
+func (m MultiPolygon) MarshalJSON() ([]byte, error) {
+	return json.Marshal(m.GeoJSON())
+}
+
+// UnmarshalJSON lee una geometría GeoJSON Polygon o MultiPolygon
+func (m *MultiPolygon) UnmarshalJSON(data []byte) error {
+	var geometry geojson.Geometry
+	if err := json.Unmarshal(data, &geometry); err != nil {
+		return fmt.Errorf("%w: %v", geojson.ErrInvalidGeoJSON, err)
+	}
+	parsed, err := MultiPolygonFromGeoJSON(geometry)
+	if err != nil {
+		return err
+	}
+	*m = parsed
+	return nil
+}
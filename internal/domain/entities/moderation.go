@@ -0,0 +1,23 @@
+package entities
+
+import "time"
+
+// ModerationClaim representa la reserva temporal de un delito por un moderador para revisarlo
+type ModerationClaim struct {
+	CrimeID     string    `json:"crime_id"`
+	ModeratorID string    `json:"moderator_id"` // Moderador que tomó el delito
+	ClaimedAt   time.Time `json:"claimed_at"`
+	ExpiresAt   time.Time `json:"expires_at"` // Vencimiento de la reserva, luego vuelve a estar disponible
+}
+
+// IsExpired indica si la reserva venció en el instante indicado
+func (c *ModerationClaim) IsExpired(now time.Time) bool {
+	return !now.Before(c.ExpiresAt)
+}
+
+// ModerationItem representa un delito pendiente en la cola de moderación
+type ModerationItem struct {
+	Crime    *Crime           `json:"crime"`
+	Priority int              `json:"priority"`        // Prioridad de revisión, mayor es más urgente
+	Claim    *ModerationClaim `json:"claim,omitempty"` // Reserva vigente, nil si está disponible
+}
@@ -0,0 +1,60 @@
+package entities
+
+import "time"
+
+// NotificationChannel representa el medio por el que se avisa a un usuario
+type NotificationChannel string
+
+const (
+	// NotificationInbox guarda el aviso en la bandeja de la aplicación
+	NotificationInbox NotificationChannel = "inbox"
+
+	// NotificationEmail envía el aviso por correo electrónico
+	NotificationEmail NotificationChannel = "email"
+
+	// NotificationWebhook envía el aviso con un POST a la URL del usuario
+	NotificationWebhook NotificationChannel = "webhook"
+)
+
+// IsValid indica si el canal es uno de los canales conocidos
+func (c NotificationChannel) IsValid() bool {
+	switch c {
+	case NotificationInbox, NotificationEmail, NotificationWebhook:
+		return true
+	}
+	return false
+}
+
+// NotificationStatus representa el estado del envío de un aviso
+type NotificationStatus string
+
+const (
+	// NotificationPending indica que el aviso está pendiente o se va a reintentar
+	NotificationPending NotificationStatus = "pending"
+
+	// NotificationSent indica que el aviso se entregó
+	NotificationSent NotificationStatus = "sent"
+
+	// NotificationFailed indica que se agotaron los reintentos
+	NotificationFailed NotificationStatus = "failed"
+)
+
+// Notification representa el aviso a un usuario de un delito ocurrido en una de sus zonas
+type Notification struct {
+	ID            string              `json:"id"`
+	UserID        string              `json:"user_id"`
+	AreaID        string              `json:"area_id"`
+	CrimeID       string              `json:"crime_id"`
+	Channel       NotificationChannel `json:"channel"`
+	Target        string              `json:"-"` // Email o URL de destino, según el canal
+	Title         string              `json:"title"`
+	Body          string              `json:"body"`
+	Crime         *Crime              `json:"crime"` // Delito tal como estaba al generar el aviso
+	Status        NotificationStatus  `json:"status"`
+	Attempts      int                 `json:"attempts"`
+	LastError     string              `json:"last_error,omitempty"`
+	NextAttemptAt *time.Time          `json:"next_attempt_at,omitempty"`
+	CreatedAt     time.Time           `json:"created_at"`
+	SentAt        *time.Time          `json:"sent_at,omitempty"`
+	ReadAt        *time.Time          `json:"read_at,omitempty"`
+}
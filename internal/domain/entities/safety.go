@@ -0,0 +1,34 @@
+package entities
+
+// SafetyLevel es la categoría de riesgo de un puntaje de seguridad
+type SafetyLevel string
+
+const (
+	SafetyLevelLow      SafetyLevel = "low"       // Puntaje menor a 25
+	SafetyLevelModerate SafetyLevel = "moderate"  // Puntaje de 25 a 50
+	SafetyLevelHigh     SafetyLevel = "high"      // Puntaje de 50 a 75
+	SafetyLevelVeryHigh SafetyLevel = "very_high" // Puntaje de 75 o más
+)
+
+// SafetyLevelFor retorna la categoría de riesgo del puntaje, de 0 a 100
+func SafetyLevelFor(score float64) SafetyLevel {
+	switch {
+	case score < 25:
+		return SafetyLevelLow
+	case score < 50:
+		return SafetyLevelModerate
+	case score < 75:
+		return SafetyLevelHigh
+	default:
+		return SafetyLevelVeryHigh
+	}
+}
+
+// SafetyFactor es el aporte de un tipo de delito a un puntaje de seguridad
+type SafetyFactor struct {
+	Type     string  `json:"type"`
+	Count    int     `json:"count"`    // Delitos del tipo dentro del área
+	Severity float64 `json:"severity"` // Peso de gravedad promedio de sus delitos
+	Weight   float64 `json:"weight"`   // Suma de gravedad por decaimiento temporal de sus delitos
+	Share    float64 `json:"share"`    // Fracción del peso total, de 0 a 1
+}
@@ -0,0 +1,101 @@
+package entities
+
+import "time"
+
+// ZoneStats resume los delitos de una zona en un período y su variación respecto del
+// período anterior de la misma duración
+type ZoneStats struct {
+	ZoneID        string         `json:"zone_id"`
+	Name          string         `json:"name"`
+	Kind          string         `json:"kind"`
+	Population    int            `json:"population"`
+	Count         int            `json:"count"`          // Delitos del período
+	SeverityScore float64        `json:"severity_score"` // Suma de los pesos de gravedad de los delitos del período
+	RatePer1000   *float64       `json:"rate_per_1000"`  // Delitos cada 1000 habitantes, nil si se desconoce la población
+	ByType        map[string]int `json:"by_type"`        // Delitos del período por tipo
+	PreviousCount int            `json:"previous_count"` // Delitos del período anterior
+	Change        int            `json:"change"`         // Count - PreviousCount
+	ChangePercent *float64       `json:"change_percent"` // Variación porcentual, nil si el período anterior no tuvo delitos
+}
+
+// TimeBucket es el intervalo en que se agrupa una serie temporal
+type TimeBucket string
+
+const (
+	// TimeBucketHour agrupa por hora
+	TimeBucketHour TimeBucket = "hour"
+
+	// TimeBucketDay agrupa por día
+	TimeBucketDay TimeBucket = "day"
+
+	// TimeBucketWeek agrupa por semana, de lunes a domingo
+	TimeBucketWeek TimeBucket = "week"
+
+	// TimeBucketMonth agrupa por mes calendario
+	TimeBucketMonth TimeBucket = "month"
+)
+
+// IsValid indica si el intervalo existe
+func (b TimeBucket) IsValid() bool {
+	switch b {
+	case TimeBucketHour, TimeBucketDay, TimeBucketWeek, TimeBucketMonth:
+		return true
+	default:
+		return false
+	}
+}
+
+// Truncate retorna el inicio del intervalo que contiene t, según la hora local de su zona horaria
+func (b TimeBucket) Truncate(t time.Time) time.Time {
+	year, month, day := t.Date()
+	switch b {
+	case TimeBucketHour:
+		return time.Date(year, month, day, t.Hour(), 0, 0, 0, t.Location())
+	case TimeBucketWeek:
+		// Las semanas empiezan el lunes, como en ISO 8601
+		return time.Date(year, month, day-(int(t.Weekday())+6)%7, 0, 0, 0, 0, t.Location())
+	case TimeBucketMonth:
+		return time.Date(year, month, 1, 0, 0, 0, 0, t.Location())
+	default:
+		return time.Date(year, month, day, 0, 0, 0, 0, t.Location())
+	}
+}
+
+// Next retorna el inicio del intervalo siguiente al que empieza en start
+func (b TimeBucket) Next(start time.Time) time.Time {
+	year, month, day := start.Date()
+	switch b {
+	case TimeBucketHour:
+		next := time.Date(year, month, day, start.Hour()+1, 0, 0, 0, start.Location())
+		// Al atrasar la hora la misma hora local se repite; se salta al intervalo siguiente
+		for !next.After(start) {
+			next = next.Add(time.Hour)
+		}
+		return next
+	case TimeBucketWeek:
+		return time.Date(year, month, day+7, 0, 0, 0, 0, start.Location())
+	case TimeBucketMonth:
+		return time.Date(year, month+1, 1, 0, 0, 0, 0, start.Location())
+	default:
+		return time.Date(year, month, day+1, 0, 0, 0, 0, start.Location())
+	}
+}
+
+// TimeSeriesPoint es la cantidad de delitos de un intervalo de una serie temporal
+type TimeSeriesPoint struct {
+	Start         time.Time `json:"start"` // Inicio del intervalo en la zona horaria de la serie
+	Count         int       `json:"count"`
+	SeverityScore float64   `json:"severity_score"` // Suma de los pesos de gravedad de los delitos
+}
+
+// Hotspot representa una concentración de delitos detectada por un análisis de densidad
+type Hotspot struct {
+	Rank          int            `json:"rank"`                   // Posición por cantidad de delitos, desde 1
+	Centroid      Coordinate     `json:"centroid"`               // Promedio de las ubicaciones de sus delitos
+	Count         int            `json:"count"`                  // Delitos que lo forman
+	SeverityScore float64        `json:"severity_score"`         // Suma de los pesos de gravedad de sus delitos
+	ByType        map[string]int `json:"by_type"`                // Delitos por tipo
+	Area          MultiPolygon   `json:"area,omitempty"`         // Envolvente convexa; se omite si sus delitos están alineados
+	AreaSqM       float64        `json:"area_sq_m"`              // Superficie de Area en metros cuadrados
+	PeakDensity   *float64       `json:"peak_density,omitempty"` // Densidad máxima en delitos por km², solo por densidad de kernel
+}
@@ -0,0 +1,117 @@
+package entities
+
+import (
+	"fmt"
+	"time"
+)
+
+// WatchArea representa una zona en la que un usuario quiere ser avisado de los delitos.
+// La zona es un círculo o un polígono, nunca ambos
+type WatchArea struct {
+	ID         string                `json:"id"`
+	OwnerID    string                `json:"owner_id"`
+	Name       string                `json:"name"`
+	Circle     *Circle               `json:"circle,omitempty"`
+	Polygon    Polygon               `json:"polygon,omitempty"`
+	CrimeTypes []string              `json:"crime_types,omitempty"` // Tipos de delito de interés, vacío para todos
+	QuietHours *QuietHours           `json:"quiet_hours,omitempty"`
+	Channels   []NotificationChannel `json:"channels"`
+	Email      string                `json:"email,omitempty"`       // Destinatario del canal email
+	WebhookURL string                `json:"webhook_url,omitempty"` // Destinatario del canal webhook
+	Active     bool                  `json:"active"`
+	CreatedAt  time.Time             `json:"created_at"`
+	UpdatedAt  time.Time             `json:"updated_at"`
+}
+
+// Bounds retorna el rectángulo que contiene a la zona
+func (a *WatchArea) Bounds() BoundingBox {
+	if a.Circle != nil {
+		return a.Circle.Bounds()
+	}
+	return a.Polygon.Bounds()
+}
+
+// Contains indica si la ubicación está dentro de la zona
+func (a *WatchArea) Contains(location Location) bool {
+	if a.Circle != nil {
+		return a.Circle.Contains(location)
+	}
+	return a.Polygon.Contains(location)
+}
+
+// Matches indica si la zona está activa y el delito es de interés para ella
+func (a *WatchArea) Matches(crime *Crime) bool {
+	if !a.Active {
+		return false
+	}
+	if len(a.CrimeTypes) > 0 && !containsValue(a.CrimeTypes, crime.Type) {
+		return false
+	}
+	return a.Contains(crime.Location)
+}
+
+// Target retorna el destinatario del canal indicado
+func (a *WatchArea) Target(channel NotificationChannel) string {
+	switch channel {
+	case NotificationEmail:
+		return a.Email
+	case NotificationWebhook:
+		return a.WebhookURL
+	}
+	return a.OwnerID
+}
+
+// QuietHours representa la franja horaria diaria en la que no se envían avisos por email ni
+// webhook; los avisos se postergan hasta el final de la franja. Start y End tienen el formato
+// HH:MM en la zona horaria indicada; si Start es posterior a End la franja cruza la medianoche
+type QuietHours struct {
+	Start    string `json:"start"`
+	End      string `json:"end"`
+	TimeZone string `json:"time_zone,omitempty"` // Nombre IANA, UTC si se omite
+}
+
+// IsValid indica si los horarios y la zona horaria son válidos y la franja no está vacía
+func (q QuietHours) IsValid() bool {
+	start, errStart := parseClock(q.Start)
+	end, errEnd := parseClock(q.End)
+	_, errZone := time.LoadLocation(q.TimeZone)
+	return errStart == nil && errEnd == nil && errZone == nil && start != end
+}
+
+// Until indica si el instante está dentro de la franja y, en ese caso, cuándo termina
+func (q QuietHours) Until(t time.Time) (time.Time, bool) {
+	start, errStart := parseClock(q.Start)
+	end, errEnd := parseClock(q.End)
+	location, errZone := time.LoadLocation(q.TimeZone)
+	if errStart != nil || errEnd != nil || errZone != nil {
+		return time.Time{}, false
+	}
+
+	local := t.In(location)
+	minute := local.Hour()*60 + local.Minute()
+	inside := minute >= start && minute < end
+	if start > end {
+		inside = minute >= start || minute < end
+	}
+	if !inside {
+		return time.Time{}, false
+	}
+
+	until := time.Date(local.Year(), local.Month(), local.Day(), end/60, end%60, 0, 0, location)
+	if !until.After(local) {
+		until = time.Date(local.Year(), local.Month(), local.Day()+1, end/60, end%60, 0, 0, location)
+	}
+	return until, true
+}
+
+// parseClock interpreta un horario HH:MM y retorna los minutos desde la medianoche
+func parseClock(value string) (int, error) {
+	var hour, minute int
+	if _, err := fmt.Sscanf(value, "%d:%d", &hour, &minute); err != nil || len(value) != 5 {
+		return 0, fmt.Errorf("horario inválido: %q", value)
+	}
+	if hour < 0 || hour > 23 || minute < 0 || minute > 59 {
+		return 0, fmt.Errorf("horario inválido: %q", value)
+	}
+	return hour*60 + minute, nil
+}
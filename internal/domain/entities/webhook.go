@@ -0,0 +1,81 @@
+package entities
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// WebhookSubscription representa un sistema externo que recibe los eventos de los delitos
+type WebhookSubscription struct {
+	ID          string       `json:"id"`
+	URL         string       `json:"url"`
+	EventTypes  []string     `json:"event_types"`            // Tipos de evento notificados
+	BoundingBox *BoundingBox `json:"bounding_box,omitempty"` // Zona de interés, nil para todas
+	CrimeTypes  []string     `json:"crime_types,omitempty"`  // Tipos de delito notificados, vacío para todos
+	Secret      string       `json:"-"`                      // Clave con la que se firman las entregas
+	CreatedBy   string       `json:"created_by"`
+	CreatedAt   time.Time    `json:"created_at"`
+}
+
+// Matches indica si la suscripción debe recibir el evento del delito indicado
+func (s *WebhookSubscription) Matches(eventType string, crime *Crime) bool {
+	if !containsValue(s.EventTypes, eventType) {
+		return false
+	}
+	if len(s.CrimeTypes) > 0 && (crime == nil || !containsValue(s.CrimeTypes, crime.Type)) {
+		return false
+	}
+	if s.BoundingBox != nil && (crime == nil || !s.BoundingBox.Contains(crime.Location)) {
+		return false
+	}
+	return true
+}
+
+// WebhookDeliveryStatus representa el estado de la entrega de un evento a una suscripción
+type WebhookDeliveryStatus string
+
+const (
+	// WebhookDeliveryPending indica que la entrega está pendiente o se va a reintentar
+	WebhookDeliveryPending WebhookDeliveryStatus = "pending"
+
+	// WebhookDeliverySucceeded indica que el receptor respondió con un código 2xx
+	WebhookDeliverySucceeded WebhookDeliveryStatus = "succeeded"
+
+	// WebhookDeliveryDead indica que se agotaron los reintentos; solo se reenvía manualmente
+	WebhookDeliveryDead WebhookDeliveryStatus = "dead"
+)
+
+// IsValid indica si el estado de la entrega es uno de los estados conocidos
+func (s WebhookDeliveryStatus) IsValid() bool {
+	switch s {
+	case WebhookDeliveryPending, WebhookDeliverySucceeded, WebhookDeliveryDead:
+		return true
+	}
+	return false
+}
+
+// WebhookDelivery representa la entrega de un evento a una suscripción y el resultado del último intento
+type WebhookDelivery struct {
+	ID             string                `json:"id"`
+	SubscriptionID string                `json:"subscription_id"`
+	EventID        string                `json:"event_id"`
+	EventType      string                `json:"event_type"`
+	Payload        json.RawMessage       `json:"payload"` // Cuerpo enviado, igual en cada intento
+	Status         WebhookDeliveryStatus `json:"status"`
+	Attempts       int                   `json:"attempts"`
+	NextAttemptAt  *time.Time            `json:"next_attempt_at,omitempty"`
+	LastStatusCode int                   `json:"last_status_code,omitempty"` // Código HTTP de la última respuesta
+	LastError      string                `json:"last_error,omitempty"`
+	CreatedAt      time.Time             `json:"created_at"`
+	DeliveredAt    *time.Time            `json:"delivered_at,omitempty"`
+}
+
+// containsValue indica si el valor está en la lista
+func containsValue(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,19 @@
+package entities
+
+import "time"
+
+// Zone representa un área administrativa de la ciudad, como un barrio o una comuna
+type Zone struct {
+	ID         string       `json:"id"`
+	Name       string       `json:"name"`       // Nombre de la zona, único dentro de su tipo
+	Kind       string       `json:"kind"`       // Tipo de zona (barrio, comuna, etc.)
+	Population int          `json:"population"` // Cantidad de habitantes, 0 si se desconoce
+	Geometry   MultiPolygon `json:"geometry"`   // Límites de la zona como GeoJSON MultiPolygon
+	CreatedAt  time.Time    `json:"created_at"`
+	UpdatedAt  time.Time    `json:"updated_at"`
+}
+
+// Contains indica si la ubicación está dentro de la zona
+func (z *Zone) Contains(location Location) bool {
+	return z.Geometry.Contains(location)
+}
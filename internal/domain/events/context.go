@@ -0,0 +1,20 @@
+package events
+
+import "context"
+
+// contextKey es el tipo de la clave de los eventos en el contexto
+type contextKey struct{}
+
+// WithEvents retorna un contexto que transporta los eventos que el repositorio debe
+// guardar en el outbox dentro de la misma transacción que la escritura
+func WithEvents(ctx context.Context, events ...Event) context.Context {
+	pending := append(FromContext(ctx), events...)
+	return context.WithValue(ctx, contextKey{}, pending)
+}
+
+// FromContext obtiene los eventos pendientes del contexto
+func FromContext(ctx context.Context) []Event {
+	pending, _ := ctx.Value(contextKey{}).([]Event)
+	// Copiar para que los contextos derivados no compartan el arreglo subyacente
+	return append([]Event(nil), pending...)
+}
@@ -0,0 +1,94 @@
+package events
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"go-crime_map_backend/internal/domain/entities"
+)
+
+// Type identifica el tipo de un evento de dominio
+type Type string
+
+const (
+	// CrimeReported se emite al reportar un delito
+	CrimeReported Type = "crime.reported"
+
+	// CrimeUpdated se emite al modificar los datos de un delito
+	CrimeUpdated Type = "crime.updated"
+
+	// CrimeDeleted se emite al eliminar un delito
+	CrimeDeleted Type = "crime.deleted"
+
+	// CrimeStatusChanged se emite al cambiar el estado de un delito
+	CrimeStatusChanged Type = "crime.status_changed"
+
+	// AnomalyDetected se emite al detectar un aumento inusual de delitos
+	AnomalyDetected Type = "anomaly.detected"
+)
+
+// Types contiene todos los tipos de eventos de dominio
+var Types = []Type{CrimeReported, CrimeUpdated, CrimeDeleted, CrimeStatusChanged, AnomalyDetected}
+
+// Event representa un hecho del dominio ya ocurrido. El payload se guarda serializado
+// porque el evento se persiste en el outbox antes de entregarse
+type Event struct {
+	ID          string          `json:"id"`
+	Type        Type            `json:"type"`
+	AggregateID string          `json:"aggregate_id"` // ID del delito o de la anomalía a la que se refiere el evento
+	ActorID     string          `json:"actor_id,omitempty"`
+	RequestID   string          `json:"request_id,omitempty"`
+	OccurredAt  time.Time       `json:"occurred_at"`
+	Payload     json.RawMessage `json:"payload"`
+}
+
+// CrimeReportedPayload es el contenido del evento CrimeReported
+type CrimeReportedPayload struct {
+	Crime *entities.Crime `json:"crime"`
+}
+
+// CrimeUpdatedPayload es el contenido del evento CrimeUpdated
+type CrimeUpdatedPayload struct {
+	Crime   *entities.Crime        `json:"crime"`
+	Changes []entities.FieldChange `json:"changes"`
+}
+
+// CrimeDeletedPayload es el contenido del evento CrimeDeleted
+type CrimeDeletedPayload struct {
+	Crime *entities.Crime `json:"crime"` // Última versión del delito antes de eliminarlo
+}
+
+// CrimeStatusChangedPayload es el contenido del evento CrimeStatusChanged
+type CrimeStatusChangedPayload struct {
+	Crime  *entities.Crime             `json:"crime"`
+	Change *entities.CrimeStatusChange `json:"change"`
+}
+
+// AnomalyDetectedPayload es el contenido del evento AnomalyDetected
+type AnomalyDetectedPayload struct {
+	Anomaly *entities.Anomaly `json:"anomaly"`
+}
+
+// New crea un evento serializando su payload
+func New(id string, eventType Type, aggregateID string, payload any, occurredAt time.Time) (Event, error) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return Event{}, fmt.Errorf("error al serializar el evento %s: %w", eventType, err)
+	}
+	return Event{
+		ID:          id,
+		Type:        eventType,
+		AggregateID: aggregateID,
+		OccurredAt:  occurredAt,
+		Payload:     data,
+	}, nil
+}
+
+// Decode deserializa el payload del evento en target
+func (e Event) Decode(target any) error {
+	if err := json.Unmarshal(e.Payload, target); err != nil {
+		return fmt.Errorf("error al leer el evento %s: %w", e.Type, err)
+	}
+	return nil
+}
@@ -0,0 +1,18 @@
+package events
+
+import "context"
+
+// Handler procesa los eventos de dominio entregados por el despachador. La entrega es
+// al menos una vez: un mismo evento puede llegar más de una vez y debe procesarse de
+// forma idempotente, por ejemplo usando Event.ID
+type Handler interface {
+	Handle(ctx context.Context, event Event) error
+}
+
+// HandlerFunc adapta una función al tipo Handler
+type HandlerFunc func(ctx context.Context, event Event) error
+
+// Handle llama a la función
+func (f HandlerFunc) Handle(ctx context.Context, event Event) error {
+	return f(ctx, event)
+}
@@ -0,0 +1,54 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"go-crime_map_backend/internal/domain/entities"
+)
+
+// NotificationFilter define los criterios para listar los avisos de un usuario
+type NotificationFilter struct {
+	Channel    entities.NotificationChannel // Canal de los avisos, vacío para todos
+	UnreadOnly bool                         // Solo los avisos sin leer
+	Limit      int                          // Cantidad máxima de avisos
+}
+
+// AlertRepository define las operaciones sobre las zonas vigiladas y los avisos a sus usuarios
+type AlertRepository interface {
+	// CreateWatchArea guarda una nueva zona
+	CreateWatchArea(ctx context.Context, area *entities.WatchArea) error
+
+	// GetWatchArea obtiene una zona por su ID, nil si no existe
+	GetWatchArea(ctx context.Context, id string) (*entities.WatchArea, error)
+
+	// ListWatchAreas obtiene las zonas del usuario ordenadas por fecha de creación
+	ListWatchAreas(ctx context.Context, ownerID string) ([]*entities.WatchArea, error)
+
+	// UpdateWatchArea reemplaza los datos de la zona
+	UpdateWatchArea(ctx context.Context, area *entities.WatchArea) error
+
+	// DeleteWatchArea elimina la zona y sus avisos. Retorna false si no existía
+	DeleteWatchArea(ctx context.Context, id string) (bool, error)
+
+	// FindWatchAreas obtiene las zonas activas cuyo rectángulo contiene la ubicación usando un
+	// índice espacial. Son candidatas: hay que verificar que la zona contenga la ubicación
+	FindWatchAreas(ctx context.Context, location entities.Location) ([]*entities.WatchArea, error)
+
+	// EnqueueNotifications guarda los avisos. Ignora los que ya existen para la misma zona,
+	// delito y canal, ya que un evento puede procesarse más de una vez
+	EnqueueNotifications(ctx context.Context, notifications []*entities.Notification) error
+
+	// ClaimDueNotifications reserva hasta limit avisos pendientes cuyo intento vence en now,
+	// del más antiguo al más reciente. Quedan reservados durante lease
+	ClaimDueNotifications(ctx context.Context, now time.Time, limit int, lease time.Duration) ([]*entities.Notification, error)
+
+	// UpdateNotification guarda el resultado de un intento y libera la reserva
+	UpdateNotification(ctx context.Context, notification *entities.Notification) error
+
+	// ListNotifications obtiene los avisos del usuario, del más reciente al más antiguo
+	ListNotifications(ctx context.Context, userID string, filter NotificationFilter) ([]*entities.Notification, error)
+
+	// MarkNotificationRead marca el aviso del usuario como leído. Retorna false si no existe
+	MarkNotificationRead(ctx context.Context, userID, id string, at time.Time) (bool, error)
+}
@@ -0,0 +1,29 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"go-crime_map_backend/internal/domain/entities"
+)
+
+// AnomalyFilter define los criterios para listar las anomalías detectadas
+type AnomalyFilter struct {
+	ZoneID string    // Zona de la serie, vacío para todas
+	Type   string    // Tipo de delito de la serie, vacío para todos
+	From   time.Time // Inicio mínimo de la ventana, cero no filtra
+	To     time.Time // Inicio máximo de la ventana, excluido; cero no filtra
+	Limit  int       // Cantidad máxima de anomalías
+}
+
+// AnomalyRepository define las operaciones sobre las anomalías detectadas. Las guarda el
+// repositorio de delitos para agregar sus eventos al outbox en la misma transacción
+type AnomalyRepository interface {
+	// SaveAnomaly guarda la anomalía junto con los eventos del contexto. Retorna false sin
+	// guardar nada si ya existe una anomalía para la misma zona, tipo e inicio de ventana
+	SaveAnomaly(ctx context.Context, anomaly *entities.Anomaly) (bool, error)
+
+	// ListAnomalies obtiene las anomalías del filtro, de la ventana más reciente a la más
+	// antigua y, dentro de cada ventana, de mayor a menor z-score
+	ListAnomalies(ctx context.Context, filter AnomalyFilter) ([]*entities.Anomaly, error)
+}
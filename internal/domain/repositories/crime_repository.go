@@ -2,9 +2,31 @@ package repositories
 
 import (
 	"context"
+	"errors"
+	"time"
+
 	"go-crime_map_backend/internal/domain/entities"
 )
 
+// ErrStatusConflict se retorna cuando el estado del delito cambió mientras se intentaba actualizarlo
+var ErrStatusConflict = errors.New("el estado del delito fue modificado por otra operación")
+
+// CrimeFilter define los criterios para listar delitos; los campos vacíos no filtran
+type CrimeFilter struct {
+	Statuses []entities.CrimeStatus // Estados incluidos en el listado
+	Types    []string               // Tipos de delito incluidos en el listado
+	ZoneIDs  []string               // Zonas incluidas en el listado
+}
+
+// NearbyQuery define los delitos buscados alrededor de una fecha y unas coordenadas
+type NearbyQuery struct {
+	Date      time.Time
+	Window    time.Duration // Diferencia máxima entre las fechas
+	Latitude  float64
+	Longitude float64
+	Tolerance float64 // Diferencia máxima entre las coordenadas, en grados
+}
+
 // CrimeRepository define las operaciones que se pueden realizar con los delitos
 type CrimeRepository interface {
 	// Create guarda un nuevo delito en el repositorio
@@ -16,9 +38,28 @@ type CrimeRepository interface {
 	// GetAll obtiene todos los delitos
 	GetAll(ctx context.Context) ([]*entities.Crime, error)
 
+	// List obtiene los delitos que cumplen el filtro, ordenados por fecha descendente
+	List(ctx context.Context, filter CrimeFilter) ([]*entities.Crime, error)
+
+	// FindNear obtiene los delitos cuya fecha y coordenadas están dentro de las tolerancias
+	// de la consulta, sin recorrer todos los delitos
+	FindNear(ctx context.Context, query NearbyQuery) ([]*entities.Crime, error)
+
 	// Update actualiza un delito existente
 	Update(ctx context.Context, crime *entities.Crime) error
 
+	// UpdateStatus cambia el estado de un delito y registra el cambio en el historial
+	// en una misma operación. Retorna ErrStatusConflict si el estado actual no es change.From
+	UpdateStatus(ctx context.Context, change *entities.CrimeStatusChange) error
+
+	// GetStatusHistory obtiene el historial de estados de un delito en orden cronológico
+	GetStatusHistory(ctx context.Context, crimeID string) ([]*entities.CrimeStatusChange, error)
+
 	// Delete elimina un delito por su ID
 	Delete(ctx context.Context, id string) error
+
+	// AssignZones cambia la zona de los delitos indicados, de ID de delito a ID de zona;
+	// una zona vacía deja al delito sin zona. La zona se deriva de la ubicación, por lo
+	// que el cambio no genera revisiones ni eventos
+	AssignZones(ctx context.Context, zoneIDs map[string]string) error
 }
@@ -0,0 +1,42 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go-crime_map_backend/internal/domain/entities"
+)
+
+// ErrClaimConflict se retorna cuando el delito está reservado por otro moderador o ya no está pendiente
+var ErrClaimConflict = errors.New("el delito está reservado por otro moderador")
+
+// ModerationQueueFilter define los criterios para listar la cola de moderación
+type ModerationQueueFilter struct {
+	Now             time.Time      // Instante en que se evalúan las reservas vigentes
+	Priorities      map[string]int // Prioridad por tipo de delito; si no es nil ordena primero por prioridad
+	DefaultPriority int            // Prioridad de los tipos que no están en Priorities
+	AvailableTo     string         // Si no es vacío excluye los delitos reservados por otros moderadores
+	Limit           int            // Cantidad máxima de elementos
+}
+
+// ModerationRepository define las operaciones de la cola de moderación sobre los delitos reportados
+type ModerationRepository interface {
+	// ListPending obtiene hasta filter.Limit delitos en estado reported, del más antiguo al más
+	// reciente, con su reserva si sigue vigente en filter.Now
+	ListPending(ctx context.Context, filter ModerationQueueFilter) ([]*entities.ModerationItem, error)
+
+	// GetClaim obtiene la reserva vigente en now de un delito, nil si no tiene
+	GetClaim(ctx context.Context, crimeID string, now time.Time) (*entities.ModerationClaim, error)
+
+	// Claim reserva un delito pendiente para el moderador. Se puede reservar si está libre,
+	// si la reserva anterior venció o si ya era del mismo moderador, en cuyo caso se renueva.
+	// Retorna ErrClaimConflict en cualquier otro caso
+	Claim(ctx context.Context, claim *entities.ModerationClaim) error
+
+	// ReleaseClaim libera la reserva del moderador. Retorna ErrClaimConflict si no la tiene
+	ReleaseClaim(ctx context.Context, crimeID, moderatorID string) error
+
+	// ReleaseExpiredClaims libera las reservas vencidas en now y retorna cuántas liberó
+	ReleaseExpiredClaims(ctx context.Context, now time.Time) (int64, error)
+}
@@ -0,0 +1,38 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"go-crime_map_backend/internal/domain/events"
+)
+
+// OutboxEntry representa un evento del outbox pendiente de entrega
+type OutboxEntry struct {
+	Event    events.Event
+	Attempts int // Entregas fallidas previas
+}
+
+// OutboxCursor es una posición en el orden de ocurrencia de los eventos del outbox
+type OutboxCursor struct {
+	OccurredAt time.Time
+	EventID    string // Desempata los eventos de la misma fecha; vacío para empezar en la fecha
+}
+
+// OutboxRepository define las operaciones del outbox de eventos de dominio. Los eventos
+// los agrega el CrimeRepository en la misma transacción que la escritura que los origina
+type OutboxRepository interface {
+	// ClaimPending reserva hasta limit eventos listos para entregar en now, en orden de
+	// ocurrencia. Quedan reservados durante lease para que otra instancia no los tome
+	ClaimPending(ctx context.Context, now time.Time, limit int, lease time.Duration) ([]*OutboxEntry, error)
+
+	// MarkDispatched registra que el evento se entregó a todos los handlers
+	MarkDispatched(ctx context.Context, eventID string, at time.Time) error
+
+	// MarkFailed registra una entrega fallida. Si nextAttemptAt es nil el evento no se reintenta
+	MarkFailed(ctx context.Context, eventID string, attempts int, nextAttemptAt *time.Time, lastError string) error
+
+	// ListAfter obtiene hasta limit eventos posteriores al cursor en orden de ocurrencia, sin
+	// importar si ya se entregaron. Cada instancia lo usa para recibir todos los eventos
+	ListAfter(ctx context.Context, after OutboxCursor, limit int) ([]events.Event, error)
+}
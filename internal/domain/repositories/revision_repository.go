@@ -0,0 +1,18 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"go-crime_map_backend/internal/domain/entities"
+)
+
+// RevisionRepository define las consultas sobre el historial de versiones de los delitos.
+// Las revisiones las registra el CrimeRepository en la misma operación que cada cambio.
+type RevisionRepository interface {
+	// GetRevisions obtiene las revisiones de un delito ordenadas por versión
+	GetRevisions(ctx context.Context, crimeID string) ([]*entities.CrimeRevision, error)
+
+	// GetRevisionAt obtiene la última revisión de un delito registrada hasta at, nil si no existe
+	GetRevisionAt(ctx context.Context, crimeID string, at time.Time) (*entities.CrimeRevision, error)
+}
@@ -0,0 +1,93 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"go-crime_map_backend/internal/domain/entities"
+)
+
+// StatsFilter define los delitos incluidos en una estadística: los que cumplen el filtro
+// y tienen fecha dentro del período [From, To)
+type StatsFilter struct {
+	CrimeFilter
+	BoundingBox *entities.BoundingBox // Rectángulo que contiene la ubicación, nil no filtra
+	From        time.Time             // Inicio del período, incluido
+	To          time.Time             // Fin del período, excluido
+}
+
+// ZoneTypeCount es la cantidad de delitos de un tipo en una zona
+type ZoneTypeCount struct {
+	ZoneID string
+	Type   string
+	Count  int
+	Weight float64 // Suma de los pesos de gravedad de los delitos
+}
+
+// BucketCount es la cantidad de delitos de un intervalo de una serie temporal
+type BucketCount struct {
+	Start  time.Time // Inicio del intervalo, en la zona horaria de la consulta
+	Count  int
+	Weight float64 // Suma de los pesos de gravedad de los delitos
+}
+
+// WeekdayHourCount es la cantidad de delitos de una hora de un día de la semana
+type WeekdayHourCount struct {
+	Weekday time.Weekday
+	Hour    int
+	Count   int
+	Weight  float64 // Suma de los pesos de gravedad de los delitos
+}
+
+// WindowCount es la cantidad de delitos de un tipo en una zona durante una ventana de tiempo
+type WindowCount struct {
+	Window int    // Número de ventana contando desde el inicio del período, desde 0
+	ZoneID string // Vacío para los delitos sin zona
+	Type   string
+	Count  int
+}
+
+// CrimePoint es la ubicación, el tipo, la gravedad y la fecha de un delito, para los análisis espaciales
+type CrimePoint struct {
+	ID       string
+	Type     string
+	Severity entities.Severity
+	Location entities.Coordinate
+	Date     time.Time
+}
+
+// CrimeCursor es la posición del último delito leído en un recorrido por fecha e ID
+type CrimeCursor struct {
+	Date time.Time
+	ID   string
+}
+
+// StatsRepository define las consultas agregadas y los recorridos sobre los delitos de un
+// período. Los conteos incluyen la suma de los pesos de gravedad (entities.Severity.Weight)
+// para las estadísticas ponderadas
+type StatsRepository interface {
+	// CountByZone cuenta los delitos del filtro por zona y tipo, según la fecha del delito.
+	// Los delitos sin zona no se cuentan
+	CountByZone(ctx context.Context, filter StatsFilter) ([]ZoneTypeCount, error)
+
+	// CountByBucket cuenta los delitos del filtro por intervalo según la hora local de la
+	// fecha del delito en location. Solo retorna los intervalos con delitos
+	CountByBucket(ctx context.Context, filter StatsFilter, bucket entities.TimeBucket, location *time.Location) ([]BucketCount, error)
+
+	// CountByWeekdayHour cuenta los delitos del filtro por día de la semana y hora según la
+	// hora local de la fecha del delito en location. Solo retorna las horas con delitos
+	CountByWeekdayHour(ctx context.Context, filter StatsFilter, location *time.Location) ([]WeekdayHourCount, error)
+
+	// ListPoints obtiene la ubicación de los delitos del filtro, de los más recientes a los
+	// más antiguos y hasta limit delitos
+	ListPoints(ctx context.Context, filter StatsFilter, limit int) ([]CrimePoint, error)
+
+	// CountByWindow cuenta los delitos del filtro por zona, tipo y ventana consecutiva de
+	// duración window desde filter.From. Solo retorna las combinaciones con delitos
+	CountByWindow(ctx context.Context, filter StatsFilter, window time.Duration) ([]WindowCount, error)
+
+	// ListPage obtiene hasta limit delitos completos del filtro posteriores a after, de los más
+	// antiguos a los más recientes y por ID a igual fecha; after nil empieza por el primero.
+	// Permite recorrer períodos grandes por páginas sin cargarlos en memoria
+	ListPage(ctx context.Context, filter StatsFilter, after *CrimeCursor, limit int) ([]*entities.Crime, error)
+}
@@ -0,0 +1,46 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"go-crime_map_backend/internal/domain/entities"
+)
+
+// WebhookDeliveryFilter define los criterios para listar las entregas de una suscripción
+type WebhookDeliveryFilter struct {
+	Status entities.WebhookDeliveryStatus // Estado de las entregas, vacío para todos
+	Limit  int                            // Cantidad máxima de entregas
+}
+
+// WebhookRepository define las operaciones sobre las suscripciones de webhooks y sus entregas
+type WebhookRepository interface {
+	// CreateSubscription guarda una nueva suscripción
+	CreateSubscription(ctx context.Context, subscription *entities.WebhookSubscription) error
+
+	// GetSubscription obtiene una suscripción por su ID, nil si no existe
+	GetSubscription(ctx context.Context, id string) (*entities.WebhookSubscription, error)
+
+	// ListSubscriptions obtiene todas las suscripciones ordenadas por fecha de creación
+	ListSubscriptions(ctx context.Context) ([]*entities.WebhookSubscription, error)
+
+	// DeleteSubscription elimina la suscripción y sus entregas. Retorna false si no existía
+	DeleteSubscription(ctx context.Context, id string) (bool, error)
+
+	// EnqueueDeliveries agrega las entregas pendientes. Ignora las que ya existen para el
+	// mismo evento y suscripción, ya que un evento puede procesarse más de una vez
+	EnqueueDeliveries(ctx context.Context, deliveries []*entities.WebhookDelivery) error
+
+	// ClaimDueDeliveries reserva hasta limit entregas pendientes cuyo intento vence en now,
+	// de la más antigua a la más reciente. Quedan reservadas durante lease
+	ClaimDueDeliveries(ctx context.Context, now time.Time, limit int, lease time.Duration) ([]*entities.WebhookDelivery, error)
+
+	// UpdateDelivery guarda el resultado de un intento y libera la reserva
+	UpdateDelivery(ctx context.Context, delivery *entities.WebhookDelivery) error
+
+	// GetDelivery obtiene una entrega de la suscripción por su ID, nil si no existe
+	GetDelivery(ctx context.Context, subscriptionID, deliveryID string) (*entities.WebhookDelivery, error)
+
+	// ListDeliveries obtiene las entregas de la suscripción, de la más reciente a la más antigua
+	ListDeliveries(ctx context.Context, subscriptionID string, filter WebhookDeliveryFilter) ([]*entities.WebhookDelivery, error)
+}
@@ -0,0 +1,30 @@
+package repositories
+
+import (
+	"context"
+
+	"go-crime_map_backend/internal/domain/entities"
+)
+
+// ZoneRepository define las operaciones sobre las zonas administrativas
+type ZoneRepository interface {
+	// CreateZone guarda una nueva zona
+	CreateZone(ctx context.Context, zone *entities.Zone) error
+
+	// GetZone obtiene una zona por su ID, nil si no existe
+	GetZone(ctx context.Context, id string) (*entities.Zone, error)
+
+	// ListZones obtiene las zonas del tipo indicado, o todas si kind está vacío,
+	// ordenadas por tipo y nombre
+	ListZones(ctx context.Context, kind string) ([]*entities.Zone, error)
+
+	// UpdateZone reemplaza los datos de la zona
+	UpdateZone(ctx context.Context, zone *entities.Zone) error
+
+	// DeleteZone elimina la zona. Retorna false si no existía
+	DeleteZone(ctx context.Context, id string) (bool, error)
+
+	// FindZones obtiene las zonas cuyo rectángulo contiene la ubicación usando un índice
+	// espacial. Son candidatas: hay que verificar que la zona contenga la ubicación
+	FindZones(ctx context.Context, location entities.Location) ([]*entities.Zone, error)
+}
@@ -0,0 +1,350 @@
+package config
+
+import (
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Config agrupa la configuración de la aplicación leída del entorno
+type Config struct {
+	LogLevel       string
+	LogFormat      string
+	TrustedProxies []string
+	APIKeys        []APIKeyConfig
+	RateLimit      RateLimitConfig
+	CORS           CORSConfig
+	Security       SecurityConfig
+	Tracing        TracingConfig
+	Moderation     ModerationConfig
+	Outbox         OutboxConfig
+	Webhooks       WebhookConfig
+	Stream         StreamConfig
+	Alerts         AlertConfig
+	SMTP           SMTPConfig
+	Hotspots       HotspotConfig
+	Anomalies      AnomalyConfig
+	Safety         SafetyConfig
+	Tiles          TileConfig
+	Geocoding      GeocodingConfig
+}
+
+// APIKeyConfig representa una clave de API y el usuario y rol que identifica
+type APIKeyConfig struct {
+	Key    string
+	UserID string
+	Role   string
+}
+
+// RateLimitConfig representa la configuración del limitador de solicitudes
+type RateLimitConfig struct {
+	Enabled    bool
+	ReadRate   float64 // Solicitudes por segundo permitidas en lecturas
+	ReadBurst  int     // Ráfaga máxima permitida en lecturas
+	WriteRate  float64 // Solicitudes por segundo permitidas en escrituras
+	WriteBurst int     // Ráfaga máxima permitida en escrituras
+	TileRate   float64 // Solicitudes por segundo permitidas en los tiles del mapa
+	TileBurst  int     // Ráfaga máxima permitida en los tiles del mapa
+}
+
+// CORSConfig representa la configuración de CORS para los clientes web
+type CORSConfig struct {
+	AllowedOrigins   []string
+	AllowedMethods   []string
+	AllowedHeaders   []string
+	ExposedHeaders   []string
+	AllowCredentials bool
+	MaxAge           int // Segundos que el navegador puede cachear la respuesta preflight
+}
+
+// SecurityConfig representa la configuración de las cabeceras de seguridad
+type SecurityConfig struct {
+	HSTSMaxAge            int // Segundos de Strict-Transport-Security, 0 la deshabilita
+	HSTSIncludeSubdomains bool
+	FrameOptions          string
+	ContentSecurityPolicy string
+}
+
+// TracingConfig representa la configuración de la trazabilidad distribuida
+type TracingConfig struct {
+	ServiceName  string
+	Exporter     string // none, stdout u otlp
+	OTLPEndpoint string
+	OTLPInsecure bool
+	SampleRatio  float64
+}
+
+// ModerationConfig representa la configuración de la cola de moderación
+type ModerationConfig struct {
+	ClaimTTL       time.Duration // Duración de la reserva de un delito por un moderador
+	ReaperInterval time.Duration // Frecuencia con la que se liberan las reservas vencidas
+}
+
+// OutboxConfig representa la configuración del despachador de eventos de dominio
+type OutboxConfig struct {
+	PollInterval time.Duration // Frecuencia con la que se buscan eventos pendientes
+	BatchSize    int           // Eventos entregados por ejecución
+	MaxAttempts  int           // Entregas fallidas tras las que un evento se abandona
+	// BroadcastOverlap es el margen con el que cada instancia relee el outbox para recibir
+	// los eventos cuya transacción confirmó después de otros más recientes
+	BroadcastOverlap time.Duration
+}
+
+// WebhookConfig representa la configuración del envío de webhooks
+type WebhookConfig struct {
+	DeliveryInterval time.Duration // Frecuencia con la que se envían las entregas pendientes
+	Timeout          time.Duration // Tiempo de espera de cada envío
+	BatchSize        int           // Entregas enviadas por ejecución
+	MaxAttempts      int           // Intentos tras los que una entrega pasa a dead
+}
+
+// StreamConfig representa la configuración del feed de delitos en tiempo real
+type StreamConfig struct {
+	HeartbeatInterval time.Duration // Frecuencia de los pings de cada conexión
+	WriteTimeout      time.Duration // Tiempo máximo de cada escritura a un cliente
+	BufferSize        int           // Eventos pendientes por conexión antes de desconectarla
+	HistorySize       int           // Eventos conservados para reanudar con Last-Event-ID
+}
+
+// AlertConfig representa la configuración de los avisos de las zonas vigiladas
+type AlertConfig struct {
+	DeliveryInterval time.Duration // Frecuencia con la que se envían los avisos pendientes
+	Timeout          time.Duration // Tiempo de espera de cada envío por webhook
+	BatchSize        int           // Avisos enviados por ejecución
+	MaxAttempts      int           // Intentos tras los que un aviso pasa a failed
+	MaxWatchAreas    int           // Zonas vigiladas por usuario
+}
+
+// SMTPConfig representa el servidor de correo saliente; sin Host el canal email queda deshabilitado
+type SMTPConfig struct {
+	Host     string
+	Port     int
+	Username string
+	Password string
+	From     string
+}
+
+// HotspotConfig representa la configuración del análisis de zonas calientes
+type HotspotConfig struct {
+	CacheTTL  time.Duration // Tiempo durante el que se reutiliza un análisis con los mismos parámetros
+	CacheSize int           // Análisis conservados en la caché
+	MaxCrimes int           // Delitos analizados como máximo por consulta
+}
+
+// AnomalyConfig representa la configuración de la detección de anomalías
+type AnomalyConfig struct {
+	Interval        time.Duration // Frecuencia con la que se analiza la última ventana
+	Window          time.Duration // Duración de cada ventana
+	BaselineWindows int           // Ventanas anteriores con las que se calcula el promedio
+	Threshold       float64       // Desvíos por encima del promedio a partir de los que hay anomalía
+	MinCount        int           // Delitos mínimos de la ventana para considerarla anómala
+}
+
+// SafetyConfig representa la configuración del puntaje de seguridad
+type SafetyConfig struct {
+	DensityScale float64       // Densidad de delitos ponderados por km² que da un puntaje de 63
+	Lookback     time.Duration // Antigüedad máxima de los delitos considerados
+	MaxCrimes    int           // Delitos considerados como máximo por consulta
+}
+
+// TileConfig representa la configuración de los tiles vectoriales del mapa
+type TileConfig struct {
+	CacheTTL       time.Duration // Tiempo durante el que se reutiliza un tile sin cambios
+	CacheSize      int           // Tiles conservados en la caché
+	MaxCrimes      int           // Delitos por tile como máximo
+	ClusterMaxZoom int           // Último zoom en que se agrupan los delitos cercanos
+}
+
+// GeocodingConfig representa la configuración de la geocodificación de las direcciones; sin
+// GazetteerPath los delitos requieren coordenadas y sus direcciones no se verifican
+type GeocodingConfig struct {
+	GazetteerPath  string  // Nomenclador de direcciones en CSV
+	ReverseMeters  float64 // Distancia máxima a la dirección más cercana a unas coordenadas
+	MismatchMeters float64 // Distancia a partir de la cual la dirección no coincide con las coordenadas
+}
+
+// Load construye la configuración a partir de las variables de entorno
+func Load() *Config {
+	return &Config{
+		LogLevel:       getEnvOrDefault("LOG_LEVEL", "info"),
+		LogFormat:      getEnvOrDefault("LOG_FORMAT", "json"),
+		TrustedProxies: getEnvList("TRUSTED_PROXIES"),
+		APIKeys:        parseAPIKeys(getEnvList("API_KEYS")),
+		RateLimit: RateLimitConfig{
+			Enabled:    getEnvBool("RATE_LIMIT_ENABLED", true),
+			ReadRate:   getEnvFloat("RATE_LIMIT_READ_RPS", 10),
+			ReadBurst:  getEnvInt("RATE_LIMIT_READ_BURST", 30),
+			WriteRate:  getEnvFloat("RATE_LIMIT_WRITE_RPS", 0.2),
+			WriteBurst: getEnvInt("RATE_LIMIT_WRITE_BURST", 5),
+			TileRate:   getEnvFloat("RATE_LIMIT_TILE_RPS", 50),
+			TileBurst:  getEnvInt("RATE_LIMIT_TILE_BURST", 200),
+		},
+		CORS: CORSConfig{
+			AllowedOrigins:   getEnvList("CORS_ALLOWED_ORIGINS"),
+			AllowedMethods:   getEnvListOrDefault("CORS_ALLOWED_METHODS", []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"}),
+			AllowedHeaders:   getEnvListOrDefault("CORS_ALLOWED_HEADERS", []string{"Origin", "Content-Type", "Accept", "Authorization", "X-API-Key", "X-Request-ID"}),
+			ExposedHeaders:   getEnvListOrDefault("CORS_EXPOSED_HEADERS", []string{"Retry-After", "X-RateLimit-Limit", "X-RateLimit-Remaining", "X-RateLimit-Reset", "X-Request-ID"}),
+			AllowCredentials: getEnvBool("CORS_ALLOW_CREDENTIALS", false),
+			MaxAge:           getEnvInt("CORS_MAX_AGE", 600),
+		},
+		Security: SecurityConfig{
+			HSTSMaxAge:            getEnvInt("HSTS_MAX_AGE", 31536000),
+			HSTSIncludeSubdomains: getEnvBool("HSTS_INCLUDE_SUBDOMAINS", true),
+			FrameOptions:          getEnvOrDefault("FRAME_OPTIONS", "DENY"),
+			ContentSecurityPolicy: getEnvOrDefault("CONTENT_SECURITY_POLICY", "default-src 'none'; frame-ancestors 'none'"),
+		},
+		Tracing: TracingConfig{
+			ServiceName:  getEnvOrDefault("OTEL_SERVICE_NAME", "crime-map-backend"),
+			Exporter:     getEnvOrDefault("TRACING_EXPORTER", "none"),
+			OTLPEndpoint: os.Getenv("TRACING_OTLP_ENDPOINT"),
+			OTLPInsecure: getEnvBool("TRACING_OTLP_INSECURE", false),
+			SampleRatio:  getEnvFloat("TRACING_SAMPLE_RATIO", 1),
+		},
+		Moderation: ModerationConfig{
+			ClaimTTL:       getEnvDuration("MODERATION_CLAIM_TTL", 15*time.Minute),
+			ReaperInterval: getEnvDuration("MODERATION_REAPER_INTERVAL", time.Minute),
+		},
+		Outbox: OutboxConfig{
+			PollInterval: getEnvDuration("OUTBOX_POLL_INTERVAL", time.Second),
+			BatchSize:    getEnvInt("OUTBOX_BATCH_SIZE", 100),
+			MaxAttempts:  getEnvInt("OUTBOX_MAX_ATTEMPTS", 10),
+
+			BroadcastOverlap: getEnvDuration("OUTBOX_BROADCAST_OVERLAP", 30*time.Second),
+		},
+		Webhooks: WebhookConfig{
+			DeliveryInterval: getEnvDuration("WEBHOOK_DELIVERY_INTERVAL", time.Second),
+			Timeout:          getEnvDuration("WEBHOOK_TIMEOUT", 10*time.Second),
+			BatchSize:        getEnvInt("WEBHOOK_BATCH_SIZE", 50),
+			MaxAttempts:      getEnvInt("WEBHOOK_MAX_ATTEMPTS", 8),
+		},
+		Stream: StreamConfig{
+			HeartbeatInterval: getEnvDuration("STREAM_HEARTBEAT_INTERVAL", 15*time.Second),
+			WriteTimeout:      getEnvDuration("STREAM_WRITE_TIMEOUT", 10*time.Second),
+			BufferSize:        getEnvInt("STREAM_BUFFER_SIZE", 64),
+			HistorySize:       getEnvInt("STREAM_HISTORY_SIZE", 1000),
+		},
+		Alerts: AlertConfig{
+			DeliveryInterval: getEnvDuration("ALERT_DELIVERY_INTERVAL", 5*time.Second),
+			Timeout:          getEnvDuration("ALERT_TIMEOUT", 10*time.Second),
+			BatchSize:        getEnvInt("ALERT_BATCH_SIZE", 50),
+			MaxAttempts:      getEnvInt("ALERT_MAX_ATTEMPTS", 5),
+			MaxWatchAreas:    getEnvInt("ALERT_MAX_WATCH_AREAS", 20),
+		},
+		SMTP: SMTPConfig{
+			Host:     os.Getenv("SMTP_HOST"),
+			Port:     getEnvInt("SMTP_PORT", 587),
+			Username: os.Getenv("SMTP_USERNAME"),
+			Password: os.Getenv("SMTP_PASSWORD"),
+			From:     getEnvOrDefault("SMTP_FROM", "alertas@crime-map.local"),
+		},
+		Hotspots: HotspotConfig{
+			CacheTTL:  getEnvDuration("HOTSPOT_CACHE_TTL", 5*time.Minute),
+			CacheSize: getEnvInt("HOTSPOT_CACHE_SIZE", 100),
+			MaxCrimes: getEnvInt("HOTSPOT_MAX_CRIMES", 50000),
+		},
+		Anomalies: AnomalyConfig{
+			Interval:        getEnvDuration("ANOMALY_INTERVAL", time.Hour),
+			Window:          getEnvDuration("ANOMALY_WINDOW", 24*time.Hour),
+			BaselineWindows: getEnvInt("ANOMALY_BASELINE_WINDOWS", 28),
+			Threshold:       getEnvFloat("ANOMALY_THRESHOLD", 3),
+			MinCount:        getEnvInt("ANOMALY_MIN_COUNT", 5),
+		},
+		Safety: SafetyConfig{
+			DensityScale: getEnvFloat("SAFETY_DENSITY_SCALE", 50),
+			Lookback:     getEnvDuration("SAFETY_LOOKBACK", 365*24*time.Hour),
+			MaxCrimes:    getEnvInt("SAFETY_MAX_CRIMES", 50000),
+		},
+		Tiles: TileConfig{
+			CacheTTL:       getEnvDuration("TILE_CACHE_TTL", 5*time.Minute),
+			CacheSize:      getEnvInt("TILE_CACHE_SIZE", 1000),
+			MaxCrimes:      getEnvInt("TILE_MAX_CRIMES", 20000),
+			ClusterMaxZoom: getEnvInt("TILE_CLUSTER_MAX_ZOOM", 13),
+		},
+		Geocoding: GeocodingConfig{
+			GazetteerPath:  getEnvOrDefault("GAZETTEER_PATH", ""),
+			ReverseMeters:  getEnvFloat("GEOCODING_REVERSE_METERS", 150),
+			MismatchMeters: getEnvFloat("GEOCODING_MISMATCH_METERS", 300),
+		},
+	}
+}
+
+// parseAPIKeys interpreta entradas con el formato clave:usuario:rol, ignorando las incompletas
+func parseAPIKeys(entries []string) []APIKeyConfig {
+	var keys []APIKeyConfig
+	for _, entry := range entries {
+		parts := strings.SplitN(entry, ":", 3)
+		if len(parts) != 3 || parts[0] == "" || parts[1] == "" {
+			continue
+		}
+		keys = append(keys, APIKeyConfig{Key: parts[0], UserID: parts[1], Role: parts[2]})
+	}
+	return keys
+}
+
+// getEnvOrDefault obtiene una variable de entorno o retorna un valor por defecto
+func getEnvOrDefault(key, defaultValue string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return defaultValue
+}
+
+// getEnvList obtiene una lista separada por comas de una variable de entorno
+func getEnvList(key string) []string {
+	value := os.Getenv(key)
+	if value == "" {
+		return nil
+	}
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// getEnvListOrDefault obtiene una lista de una variable de entorno o retorna un valor por defecto
+func getEnvListOrDefault(key string, defaultValue []string) []string {
+	if items := getEnvList(key); items != nil {
+		return items
+	}
+	return defaultValue
+}
+
+// getEnvInt obtiene un entero de una variable de entorno o retorna un valor por defecto
+func getEnvInt(key string, defaultValue int) int {
+	value, err := strconv.Atoi(os.Getenv(key))
+	if err != nil {
+		return defaultValue
+	}
+	return value
+}
+
+// getEnvFloat obtiene un decimal de una variable de entorno o retorna un valor por defecto
+func getEnvFloat(key string, defaultValue float64) float64 {
+	value, err := strconv.ParseFloat(os.Getenv(key), 64)
+	if err != nil {
+		return defaultValue
+	}
+	return value
+}
+
+// getEnvBool obtiene un booleano de una variable de entorno o retorna un valor por defecto
+func getEnvBool(key string, defaultValue bool) bool {
+	value, err := strconv.ParseBool(os.Getenv(key))
+	if err != nil {
+		return defaultValue
+	}
+	return value
+}
+
+// getEnvDuration obtiene una duración (por ejemplo 15m) de una variable de entorno o retorna un valor por defecto
+func getEnvDuration(key string, defaultValue time.Duration) time.Duration {
+	value, err := time.ParseDuration(os.Getenv(key))
+	if err != nil || value <= 0 {
+		return defaultValue
+	}
+	return value
+}
@@ -8,6 +8,20 @@ CREATE TABLE locations (
     updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
 );
 
+-- Crear la tabla de zonas administrativas (barrios, comunas). La geometría se guarda como
+-- GeoJSON MultiPolygon y su rectángulo en bounds, indexado para encontrar las zonas de un punto
+CREATE TABLE zones (
+    id UUID PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    kind VARCHAR(50) NOT NULL,
+    population INTEGER NOT NULL DEFAULT 0 CHECK (population >= 0),
+    geometry JSONB NOT NULL,
+    bounds BOX NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    UNIQUE (kind, name)
+);
+
 -- Crear la tabla de delitos
 CREATE TABLE crimes (
     id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
@@ -15,14 +29,188 @@ CREATE TABLE crimes (
     description TEXT NOT NULL,
     location_id INTEGER NOT NULL REFERENCES locations(id),
     date TIMESTAMP WITH TIME ZONE NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'reported'
+        CHECK (status IN ('reported', 'verified', 'rejected', 'resolved')),
+    zone_id UUID REFERENCES zones(id) ON DELETE SET NULL,
+    severity VARCHAR(20) NOT NULL DEFAULT 'low'
+        CHECK (severity IN ('low', 'medium', 'high', 'critical')),
+    weapon VARCHAR(20) NOT NULL DEFAULT 'none'
+        CHECK (weapon IN ('none', 'blunt', 'knife', 'firearm', 'other')),
+    violent BOOLEAN NOT NULL DEFAULT FALSE,
+    victim_count INTEGER NOT NULL DEFAULT 0 CHECK (victim_count BETWEEN 0 AND 1000),
+    address_mismatch BOOLEAN NOT NULL DEFAULT FALSE,
     created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
     updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
 );
 
+-- Crear la tabla del historial de estados de los delitos
+CREATE TABLE crime_status_history (
+    id UUID PRIMARY KEY,
+    crime_id UUID NOT NULL REFERENCES crimes(id) ON DELETE CASCADE,
+    from_status VARCHAR(20) NOT NULL,
+    to_status VARCHAR(20) NOT NULL,
+    reason TEXT NOT NULL DEFAULT '',
+    actor_id VARCHAR(100) NOT NULL,
+    changed_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Crear la tabla de reservas de la cola de moderación
+CREATE TABLE moderation_claims (
+    crime_id UUID PRIMARY KEY REFERENCES crimes(id) ON DELETE CASCADE,
+    moderator_id VARCHAR(100) NOT NULL,
+    claimed_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+-- Crear la tabla de revisiones de los delitos. Es de solo inserción y no referencia
+-- a crimes para conservar el historial de los delitos eliminados
+CREATE TABLE crime_revisions (
+    id UUID PRIMARY KEY,
+    crime_id UUID NOT NULL,
+    version INTEGER NOT NULL,
+    action VARCHAR(20) NOT NULL
+        CHECK (action IN ('created', 'updated', 'status_changed', 'deleted')),
+    actor_id VARCHAR(100) NOT NULL DEFAULT '',
+    actor_role VARCHAR(20) NOT NULL,
+    request_id VARCHAR(128) NOT NULL DEFAULT '',
+    changes JSONB NOT NULL,
+    snapshot JSONB,
+    recorded_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    UNIQUE (crime_id, version)
+);
+
+-- Crear la tabla del outbox de eventos de dominio. Los eventos se insertan en la misma
+-- transacción que la escritura que los origina; next_attempt_at NULL indica que el
+-- evento se abandonó tras agotar los reintentos
+CREATE TABLE outbox_events (
+    id UUID PRIMARY KEY,
+    event_type VARCHAR(50) NOT NULL,
+    aggregate_id UUID NOT NULL,
+    actor_id VARCHAR(100) NOT NULL DEFAULT '',
+    request_id VARCHAR(128) NOT NULL DEFAULT '',
+    payload JSONB NOT NULL,
+    occurred_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    attempts INTEGER NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMP WITH TIME ZONE,
+    locked_until TIMESTAMP WITH TIME ZONE,
+    dispatched_at TIMESTAMP WITH TIME ZONE,
+    last_error TEXT NOT NULL DEFAULT ''
+);
+
+-- Crear la tabla de suscripciones de webhooks de sistemas externos
+CREATE TABLE webhook_subscriptions (
+    id UUID PRIMARY KEY,
+    url TEXT NOT NULL,
+    event_types TEXT[] NOT NULL,
+    min_latitude DOUBLE PRECISION,
+    min_longitude DOUBLE PRECISION,
+    max_latitude DOUBLE PRECISION,
+    max_longitude DOUBLE PRECISION,
+    crime_types TEXT[] NOT NULL DEFAULT '{}',
+    secret TEXT NOT NULL,
+    created_by VARCHAR(100) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+-- Crear la tabla de entregas de webhooks, una por evento y suscripción. Las entregas en
+-- estado dead agotaron los reintentos y solo se reenvían manualmente
+CREATE TABLE webhook_deliveries (
+    id UUID PRIMARY KEY,
+    subscription_id UUID NOT NULL REFERENCES webhook_subscriptions(id) ON DELETE CASCADE,
+    event_id UUID NOT NULL,
+    event_type VARCHAR(50) NOT NULL,
+    payload JSONB NOT NULL,
+    status VARCHAR(20) NOT NULL
+        CHECK (status IN ('pending', 'succeeded', 'dead')),
+    attempts INTEGER NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMP WITH TIME ZONE,
+    locked_until TIMESTAMP WITH TIME ZONE,
+    last_status_code INTEGER NOT NULL DEFAULT 0,
+    last_error TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    delivered_at TIMESTAMP WITH TIME ZONE,
+    UNIQUE (subscription_id, event_id)
+);
+
+-- Crear la tabla de zonas vigiladas por los usuarios. La geometría (círculo o polígono) se
+-- guarda en JSON y su rectángulo en bounds, indexado para encontrar las zonas de un punto
+CREATE TABLE watch_areas (
+    id UUID PRIMARY KEY,
+    owner_id VARCHAR(100) NOT NULL,
+    name VARCHAR(100) NOT NULL DEFAULT '',
+    geometry JSONB NOT NULL,
+    bounds BOX NOT NULL,
+    crime_types TEXT[] NOT NULL DEFAULT '{}',
+    quiet_hours JSONB,
+    channels TEXT[] NOT NULL,
+    email TEXT NOT NULL DEFAULT '',
+    webhook_url TEXT NOT NULL DEFAULT '',
+    active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+-- Crear la tabla de avisos a los usuarios, uno por zona, delito y canal. Los avisos de la
+-- bandeja se guardan como enviados; los de email y webhook se envían en segundo plano
+CREATE TABLE notifications (
+    id UUID PRIMARY KEY,
+    user_id VARCHAR(100) NOT NULL,
+    area_id UUID NOT NULL REFERENCES watch_areas(id) ON DELETE CASCADE,
+    crime_id UUID NOT NULL,
+    channel VARCHAR(20) NOT NULL
+        CHECK (channel IN ('inbox', 'email', 'webhook')),
+    target TEXT NOT NULL,
+    title TEXT NOT NULL,
+    body TEXT NOT NULL,
+    crime JSONB NOT NULL,
+    status VARCHAR(20) NOT NULL
+        CHECK (status IN ('pending', 'sent', 'failed')),
+    attempts INTEGER NOT NULL DEFAULT 0,
+    last_error TEXT NOT NULL DEFAULT '',
+    next_attempt_at TIMESTAMP WITH TIME ZONE,
+    locked_until TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    sent_at TIMESTAMP WITH TIME ZONE,
+    read_at TIMESTAMP WITH TIME ZONE,
+    UNIQUE (area_id, crime_id, channel)
+);
+
+-- Crear la tabla de anomalías detectadas, una por serie (zona y tipo) y ventana. Una zona o un
+-- tipo vacío indican que la serie agrega todas las zonas o todos los tipos
+CREATE TABLE anomalies (
+    id UUID PRIMARY KEY,
+    zone_id UUID REFERENCES zones(id) ON DELETE CASCADE,
+    crime_type VARCHAR(100) NOT NULL DEFAULT '',
+    window_start TIMESTAMP WITH TIME ZONE NOT NULL,
+    window_end TIMESTAMP WITH TIME ZONE NOT NULL,
+    count INTEGER NOT NULL,
+    baseline DOUBLE PRECISION NOT NULL,
+    std_dev DOUBLE PRECISION NOT NULL,
+    z_score DOUBLE PRECISION NOT NULL,
+    detected_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
 -- Crear índices para mejorar el rendimiento
 CREATE INDEX idx_crimes_type ON crimes(type);
 CREATE INDEX idx_crimes_date ON crimes(date);
+CREATE INDEX idx_crimes_status ON crimes(status);
+CREATE INDEX idx_crimes_zone ON crimes(zone_id);
+CREATE INDEX idx_crimes_moderation_queue ON crimes(created_at) WHERE status = 'reported';
+CREATE INDEX idx_moderation_claims_expires ON moderation_claims(expires_at);
+CREATE INDEX idx_crime_status_history_crime ON crime_status_history(crime_id, changed_at);
+CREATE INDEX idx_crime_revisions_crime_recorded ON crime_revisions(crime_id, recorded_at);
+CREATE INDEX idx_outbox_events_pending ON outbox_events(next_attempt_at, occurred_at) WHERE dispatched_at IS NULL;
+CREATE INDEX idx_outbox_events_occurred ON outbox_events(occurred_at);
+CREATE INDEX idx_webhook_deliveries_due ON webhook_deliveries(next_attempt_at) WHERE status = 'pending';
+CREATE INDEX idx_webhook_deliveries_subscription ON webhook_deliveries(subscription_id, created_at);
+CREATE INDEX idx_watch_areas_bounds ON watch_areas USING GIST (bounds) WHERE active;
+CREATE INDEX idx_watch_areas_owner ON watch_areas(owner_id, created_at);
+CREATE INDEX idx_notifications_due ON notifications(next_attempt_at) WHERE status = 'pending';
+CREATE INDEX idx_notifications_user ON notifications(user_id, created_at);
 CREATE INDEX idx_locations_coordinates ON locations(latitude, longitude);
+CREATE INDEX idx_zones_bounds ON zones USING GIST (bounds);
+CREATE UNIQUE INDEX idx_anomalies_series_window ON anomalies(COALESCE(zone_id::text, ''), crime_type, window_start);
+CREATE INDEX idx_anomalies_window ON anomalies(window_start);
 
 -- Crear función para actualizar el campo updated_at automáticamente
 CREATE OR REPLACE FUNCTION update_updated_at_column()
@@ -42,4 +230,17 @@ CREATE TRIGGER update_crimes_updated_at
 CREATE TRIGGER update_locations_updated_at
     BEFORE UPDATE ON locations
     FOR EACH ROW
-    EXECUTE FUNCTION update_updated_at_column(); 
\ No newline at end of file
+    EXECUTE FUNCTION update_updated_at_column();
+
+-- Impedir que se modifiquen o eliminen las revisiones registradas
+CREATE OR REPLACE FUNCTION prevent_crime_revision_changes()
+RETURNS TRIGGER AS $$
+BEGIN
+    RAISE EXCEPTION 'crime_revisions es de solo inserción';
+END;
+$$ language 'plpgsql';
+
+CREATE TRIGGER crime_revisions_append_only
+    BEFORE UPDATE OR DELETE ON crime_revisions
+    FOR EACH ROW
+    EXECUTE FUNCTION prevent_crime_revision_changes();
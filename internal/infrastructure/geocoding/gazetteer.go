@@ -0,0 +1,353 @@
+// Package geocoding implementa geocodificadores de direcciones sin servicios externos
+package geocoding
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"go-crime_map_backend/internal/domain/entities"
+	"go-crime_map_backend/internal/usecases"
+)
+
+// cellDegrees es el tamaño de las celdas del índice de la geocodificación inversa, unos
+// 110 m de latitud
+const cellDegrees = 0.001
+
+// metersPerDegree es la longitud aproximada de un grado de latitud
+const metersPerDegree = 111320.0
+
+// defaultReverseMeters es la distancia máxima por defecto de la geocodificación inversa
+const defaultReverseMeters = 150.0
+
+// columnNames son los nombres aceptados para cada columna del nomenclador, incluidos los de
+// las etiquetas de OpenStreetMap
+var columnNames = map[string][]string{
+	"street":    {"street", "calle", "addr:street"},
+	"number":    {"number", "numero", "altura", "housenumber", "addr:housenumber"},
+	"latitude":  {"latitude", "latitud", "lat"},
+	"longitude": {"longitude", "longitud", "lon", "lng"},
+	"locality":  {"locality", "localidad", "city", "addr:city"},
+}
+
+// addressPoint es una dirección del nomenclador
+type addressPoint struct {
+	number    int
+	latitude  float64
+	longitude float64
+}
+
+// street agrupa las direcciones de una calle en una localidad, ordenadas por altura
+type street struct {
+	name     string // Nombre tal como figura en el nomenclador
+	locality string
+	points   []addressPoint
+}
+
+// pointRef identifica una dirección en el índice de la geocodificación inversa
+type pointRef struct {
+	street *street
+	index  int
+}
+
+// cellKey identifica una celda del índice
+type cellKey struct {
+	lat, lon int64
+}
+
+// Gazetteer es un geocodificador sobre un nomenclador de direcciones en memoria, como un
+// extracto de OpenStreetMap convertido a CSV. Las alturas que no figuran se interpolan entre
+// las conocidas de la misma calle. Es seguro para uso concurrente
+type Gazetteer struct {
+	streets       map[string][]*street // Calles por clave, una por localidad
+	aliases       map[string][]*street // Calles por clave sin títulos
+	cells         map[cellKey][]pointRef
+	reverseMeters float64
+	size          int
+}
+
+// LoadGazetteer carga el nomenclador del archivo CSV indicado
+func LoadGazetteer(path string, reverseMeters float64) (*Gazetteer, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return NewGazetteer(file, reverseMeters)
+}
+
+// NewGazetteer lee un nomenclador CSV con encabezado y las columnas street, number,
+// latitude, longitude y, opcionalmente, locality. Las filas sin altura o con coordenadas
+// inválidas se descartan. La geocodificación inversa busca direcciones a menos de
+// reverseMeters metros; sin distancia se usan 150 m
+func NewGazetteer(r io.Reader, reverseMeters float64) (*Gazetteer, error) {
+	if reverseMeters <= 0 {
+		reverseMeters = defaultReverseMeters
+	}
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.ReuseRecord = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("nomenclador sin encabezado: %w", err)
+	}
+	columns, err := headerColumns(header)
+	if err != nil {
+		return nil, err
+	}
+
+	g := &Gazetteer{
+		streets:       make(map[string][]*street),
+		aliases:       make(map[string][]*street),
+		cells:         make(map[cellKey][]pointRef),
+		reverseMeters: reverseMeters,
+	}
+	byKey := make(map[string]*street)
+	var ordered []*street
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("nomenclador inválido: %w", err)
+		}
+		value := func(column string) string {
+			if i, ok := columns[column]; ok && i < len(record) {
+				return strings.TrimSpace(record[i])
+			}
+			return ""
+		}
+
+		name := value("street")
+		words := normalizeWords(name)
+		key := streetKey(words)
+		number, ok := houseNumber(value("number"))
+		if key == "" || !ok {
+			continue
+		}
+		latitude, errLat := strconv.ParseFloat(value("latitude"), 64)
+		longitude, errLon := strconv.ParseFloat(value("longitude"), 64)
+		coordinate := entities.Coordinate{Latitude: latitude, Longitude: longitude}
+		if errLat != nil || errLon != nil || !coordinate.IsValid() {
+			continue
+		}
+
+		locality := value("locality")
+		s, exists := byKey[key+"|"+localityKey(locality)]
+		if !exists {
+			s = &street{name: name, locality: locality}
+			byKey[key+"|"+localityKey(locality)] = s
+			ordered = append(ordered, s)
+			g.streets[key] = append(g.streets[key], s)
+			alias := aliasKey(words)
+			g.aliases[alias] = append(g.aliases[alias], s)
+		}
+		s.points = append(s.points, addressPoint{number: number, latitude: latitude, longitude: longitude})
+	}
+
+	for _, s := range ordered {
+		sort.SliceStable(s.points, func(i, j int) bool { return s.points[i].number < s.points[j].number })
+		// Las direcciones repetidas, como los nodos y edificios de una misma altura, se
+		// reducen a la primera
+		unique := s.points[:0]
+		for _, point := range s.points {
+			if len(unique) > 0 && unique[len(unique)-1].number == point.number {
+				continue
+			}
+			unique = append(unique, point)
+		}
+		s.points = unique
+		for i, point := range s.points {
+			cell := cellOf(point.latitude, point.longitude)
+			g.cells[cell] = append(g.cells[cell], pointRef{street: s, index: i})
+		}
+		g.size += len(s.points)
+	}
+	if g.size == 0 {
+		return nil, errors.New("el nomenclador no tiene direcciones válidas")
+	}
+	return g, nil
+}
+
+// headerColumns ubica las columnas del nomenclador en el encabezado
+func headerColumns(header []string) (map[string]int, error) {
+	columns := make(map[string]int)
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))
+		for column, aliases := range columnNames {
+			for _, alias := range aliases {
+				if _, found := columns[column]; !found && name == alias {
+					columns[column] = i
+				}
+			}
+		}
+	}
+	for _, column := range []string{"street", "number", "latitude", "longitude"} {
+		if _, found := columns[column]; !found {
+			return nil, fmt.Errorf("al nomenclador le falta la columna %s", column)
+		}
+	}
+	return columns, nil
+}
+
+// Len retorna la cantidad de direcciones del nomenclador
+func (g *Gazetteer) Len() int {
+	return g.size
+}
+
+// Geocode ubica una dirección con el formato "calle altura, localidad". La localidad es
+// opcional si el nomenclador tiene una sola calle con ese nombre; si tiene varias y la
+// localidad no coincide con ninguna la dirección es ambigua y no se ubica. Una calle que no
+// se encuentra con su nombre completo se busca sin títulos como "General" o "Doctor"
+func (g *Gazetteer) Geocode(_ context.Context, address string) (entities.Location, error) {
+	parts := strings.Split(address, ",")
+	words := normalizeWords(parts[0])
+	localities := make([]string, 0, len(parts)-1)
+	for _, part := range parts[1:] {
+		if key := localityKey(part); key != "" {
+			localities = append(localities, key)
+		}
+	}
+
+	// La altura es el primer número después del nombre; se prueban todos porque hay calles
+	// con números en el nombre, como "25 de Mayo" o "Calle 50"
+	for i := 1; i < len(words); i++ {
+		if !isNumber(words[i]) {
+			continue
+		}
+		name := words[:i]
+		for len(name) > 1 && numberPrefixes[name[len(name)-1]] {
+			name = name[:len(name)-1]
+		}
+		candidates, found := g.streets[streetKey(name)]
+		if !found {
+			candidates = g.aliases[aliasKey(name)]
+		}
+		s := pickStreet(candidates, localities)
+		if s == nil {
+			continue
+		}
+		number, err := strconv.Atoi(words[i])
+		if err != nil {
+			continue
+		}
+		if point, ok := s.locate(number); ok {
+			return s.location(point), nil
+		}
+	}
+	return entities.Location{}, usecases.ErrAddressNotFound
+}
+
+// pickStreet elige entre las calles homónimas la de la localidad indicada o, si no coincide
+// ninguna, la única que exista
+func pickStreet(candidates []*street, localities []string) *street {
+	for _, candidate := range candidates {
+		key := localityKey(candidate.locality)
+		for _, locality := range localities {
+			if key != "" && key == locality {
+				return candidate
+			}
+		}
+	}
+	if len(candidates) == 1 {
+		return candidates[0]
+	}
+	return nil
+}
+
+// locate ubica la altura en la calle: la dirección exacta si existe y si no la interpolación
+// entre las alturas vecinas, preferentemente de la misma vereda. Las alturas fuera del rango
+// conocido no se ubican
+func (s *street) locate(number int) (addressPoint, bool) {
+	i := sort.Search(len(s.points), func(i int) bool { return s.points[i].number >= number })
+	if i < len(s.points) && s.points[i].number == number {
+		return s.points[i], true
+	}
+	if i == 0 || i == len(s.points) {
+		return addressPoint{}, false
+	}
+
+	// Las alturas pares e impares están en veredas opuestas
+	lower, upper := -1, -1
+	for j := i - 1; j >= 0; j-- {
+		if s.points[j].number%2 == number%2 {
+			lower = j
+			break
+		}
+	}
+	for j := i; j < len(s.points); j++ {
+		if s.points[j].number%2 == number%2 {
+			upper = j
+			break
+		}
+	}
+	if lower < 0 || upper < 0 {
+		lower, upper = i-1, i
+	}
+
+	a, b := s.points[lower], s.points[upper]
+	t := float64(number-a.number) / float64(b.number-a.number)
+	return addressPoint{
+		number:    number,
+		latitude:  a.latitude + t*(b.latitude-a.latitude),
+		longitude: a.longitude + t*(b.longitude-a.longitude),
+	}, true
+}
+
+// location arma la ubicación de una dirección de la calle con su dirección normalizada
+func (s *street) location(point addressPoint) entities.Location {
+	address := s.name + " " + strconv.Itoa(point.number)
+	if s.locality != "" {
+		address += ", " + s.locality
+	}
+	return entities.Location{Latitude: point.latitude, Longitude: point.longitude, Address: address}
+}
+
+// Reverse retorna la dirección del nomenclador más cercana a las coordenadas
+func (g *Gazetteer) Reverse(_ context.Context, latitude, longitude float64) (entities.Location, error) {
+	origin := entities.Coordinate{Latitude: latitude, Longitude: longitude}
+	dLat := g.reverseMeters / metersPerDegree
+	dLon := 180.0
+	if cos := math.Cos(latitude * math.Pi / 180); cos > 1e-9 {
+		dLon = math.Min(dLat/cos, 180)
+	}
+	minCell := cellOf(latitude-dLat, longitude-dLon)
+	maxCell := cellOf(latitude+dLat, longitude+dLon)
+
+	var nearest *pointRef
+	best := g.reverseMeters
+	for lat := minCell.lat; lat <= maxCell.lat; lat++ {
+		for lon := minCell.lon; lon <= maxCell.lon; lon++ {
+			for _, ref := range g.cells[cellKey{lat, lon}] {
+				point := ref.street.points[ref.index]
+				distance := entities.DistanceMeters(origin, entities.Coordinate{Latitude: point.latitude, Longitude: point.longitude})
+				if distance < best {
+					best = distance
+					ref := ref
+					nearest = &ref
+				}
+			}
+		}
+	}
+	if nearest == nil {
+		return entities.Location{}, usecases.ErrAddressNotFound
+	}
+	return nearest.street.location(nearest.street.points[nearest.index]), nil
+}
+
+// cellOf retorna la celda del índice que contiene las coordenadas
+func cellOf(latitude, longitude float64) cellKey {
+	return cellKey{
+		lat: int64(math.Floor(latitude / cellDegrees)),
+		lon: int64(math.Floor(longitude / cellDegrees)),
+	}
+}
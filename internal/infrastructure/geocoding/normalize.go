@@ -0,0 +1,159 @@
+package geocoding
+
+import (
+	"strings"
+	"unicode"
+)
+
+var (
+	// removeAccents quita las tildes y la diéresis de un texto en minúsculas
+	removeAccents = strings.NewReplacer(
+		"á", "a", "à", "a", "ä", "a", "â", "a",
+		"é", "e", "è", "e", "ë", "e", "ê", "e",
+		"í", "i", "ì", "i", "ï", "i", "î", "i",
+		"ó", "o", "ò", "o", "ö", "o", "ô", "o",
+		"ú", "u", "ù", "u", "ü", "u", "û", "u",
+		"ñ", "n", "ç", "c",
+	)
+
+	// abbreviations expande las abreviaturas habituales de los nombres de calles
+	abbreviations = map[string]string{
+		"av":      "avenida",
+		"avda":    "avenida",
+		"avd":     "avenida",
+		"bv":      "boulevard",
+		"bvd":     "boulevard",
+		"blvd":    "boulevard",
+		"bulevar": "boulevard",
+		"pje":     "pasaje",
+		"psje":    "pasaje",
+		"diag":    "diagonal",
+		"gral":    "general",
+		"grl":     "general",
+		"pte":     "presidente",
+		"pres":    "presidente",
+		"dr":      "doctor",
+		"dra":     "doctora",
+		"ing":     "ingeniero",
+		"cnel":    "coronel",
+		"tte":     "teniente",
+		"sgto":    "sargento",
+		"cmte":    "comandante",
+		"alte":    "almirante",
+		"gdor":    "gobernador",
+		"int":     "intendente",
+		"prof":    "profesor",
+		"sta":     "santa",
+		"sto":     "santo",
+		"mons":    "monseñor",
+	}
+
+	// streetTypes son los tipos de vía que se omiten al comparar nombres, para que
+	// "Av. Corrientes" y "Corrientes" sean la misma calle
+	streetTypes = map[string]bool{
+		"calle":     true,
+		"avenida":   true,
+		"pasaje":    true,
+		"boulevard": true,
+	}
+
+	// titles son los títulos que se omiten al buscar una calle que no se encontró con su
+	// nombre completo, para que "Gral. San Martín" y "San Martín" sean la misma calle
+	titles = map[string]bool{
+		"general":    true,
+		"presidente": true,
+		"doctor":     true,
+		"doctora":    true,
+		"ingeniero":  true,
+		"coronel":    true,
+		"teniente":   true,
+		"sargento":   true,
+		"comandante": true,
+		"almirante":  true,
+		"gobernador": true,
+		"intendente": true,
+		"profesor":   true,
+		"monsenor":   true,
+	}
+
+	// numberPrefixes son las palabras que pueden preceder a la altura
+	numberPrefixes = map[string]bool{
+		"al":     true,
+		"n":      true,
+		"no":     true,
+		"nro":    true,
+		"num":    true,
+		"numero": true,
+	}
+)
+
+// normalizeWords pasa el texto a minúsculas sin tildes, lo separa en palabras descartando la
+// puntuación y expande las abreviaturas
+func normalizeWords(text string) []string {
+	text = removeAccents.Replace(strings.ToLower(text))
+	words := strings.FieldsFunc(text, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	for i, word := range words {
+		if expanded, ok := abbreviations[word]; ok {
+			words[i] = removeAccents.Replace(expanded)
+		}
+	}
+	return words
+}
+
+// streetKey arma la clave de búsqueda de una calle a partir de sus palabras normalizadas,
+// sin el tipo de vía inicial
+func streetKey(words []string) string {
+	if len(words) > 1 && streetTypes[words[0]] {
+		words = words[1:]
+	}
+	return strings.Join(words, " ")
+}
+
+// aliasKey arma la clave alternativa de una calle, sin el tipo de vía ni los títulos iniciales
+func aliasKey(words []string) string {
+	if len(words) > 1 && streetTypes[words[0]] {
+		words = words[1:]
+	}
+	for len(words) > 1 && titles[words[0]] {
+		words = words[1:]
+	}
+	return strings.Join(words, " ")
+}
+
+// localityKey arma la clave de búsqueda de una localidad
+func localityKey(text string) string {
+	return strings.Join(normalizeWords(text), " ")
+}
+
+// isNumber indica si la palabra es una altura
+func isNumber(word string) bool {
+	if word == "" {
+		return false
+	}
+	for _, r := range word {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// houseNumber lee la altura de una dirección del nomenclador, que puede tener sufijos como
+// "1234A" o rangos como "12-14"; retorna false si no empieza con un número
+func houseNumber(text string) (int, bool) {
+	text = strings.TrimSpace(text)
+	number, digits := 0, 0
+	for _, r := range text {
+		if r < '0' || r > '9' {
+			break
+		}
+		number = number*10 + int(r-'0')
+		digits++
+		if digits > 6 {
+			return 0, false
+		}
+	}
+	return number, digits > 0
+}
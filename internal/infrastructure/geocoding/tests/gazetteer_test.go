@@ -0,0 +1,70 @@
+package tests
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"go-crime_map_backend/internal/infrastructure/geocoding"
+	"go-crime_map_backend/internal/usecases"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// gazetteerCSV es un nomenclador de prueba con las columnas de un extracto de OpenStreetMap
+const gazetteerCSV = `addr:street,addr:housenumber,lat,lon,addr:city
+Av. Corrientes,1000,-34.6035,-58.3800,Buenos Aires
+Av. Corrientes,1001,-34.6040,-58.3800,Buenos Aires
+Av. Corrientes,1200,-34.6035,-58.3840,Buenos Aires
+Av. Corrientes,1201,-34.6040,-58.3840,Buenos Aires
+Av. Corrientes,1200,-34.6036,-58.3841,Buenos Aires
+25 de Mayo,300,-34.6030,-58.3720,Buenos Aires
+San Martín,100,-34.6050,-58.3750,Buenos Aires
+San Martín,100,-32.9500,-60.6400,Rosario
+Sin altura,s/n,-34.6000,-58.3700,Buenos Aires
+Fuera de rango,10,-134.6000,-58.3700,Buenos Aires
+`
+
+func TestGazetteer(t *testing.T) {
+	ctx := context.Background()
+	gazetteer, err := geocoding.NewGazetteer(strings.NewReader(gazetteerCSV), 100)
+	require.NoError(t, err)
+	assert.Equal(t, 7, gazetteer.Len(), "sin repetidos ni filas inválidas")
+
+	// Las alturas que no figuran se interpolan sobre la misma vereda
+	even, err := gazetteer.Geocode(ctx, "Avenida Corrientes 1100, Buenos Aires")
+	require.NoError(t, err)
+	assert.InDelta(t, -34.6035, even.Latitude, 1e-9)
+	assert.InDelta(t, -58.3820, even.Longitude, 1e-9)
+	assert.Equal(t, "Av. Corrientes 1100, Buenos Aires", even.Address)
+
+	odd, err := gazetteer.Geocode(ctx, "corrientes al 1101")
+	require.NoError(t, err)
+	assert.InDelta(t, -34.6040, odd.Latitude, 1e-9)
+
+	exact, err := gazetteer.Geocode(ctx, "25 DE MAYO N° 300")
+	require.NoError(t, err)
+	assert.Equal(t, "25 de Mayo 300, Buenos Aires", exact.Address)
+
+	// Las calles homónimas requieren la localidad
+	_, err = gazetteer.Geocode(ctx, "San Martin 100")
+	assert.ErrorIs(t, err, usecases.ErrAddressNotFound)
+	rosario, err := gazetteer.Geocode(ctx, "Gral. San Martín 100, Rosario, Santa Fe")
+	require.NoError(t, err, "sin la calle con el título se busca sin él")
+	assert.InDelta(t, -32.95, rosario.Latitude, 1e-9)
+
+	_, err = gazetteer.Geocode(ctx, "Corrientes 5000")
+	assert.ErrorIs(t, err, usecases.ErrAddressNotFound, "fuera de las alturas conocidas")
+	_, err = gazetteer.Geocode(ctx, "Corrientes")
+	assert.ErrorIs(t, err, usecases.ErrAddressNotFound, "sin altura")
+
+	nearest, err := gazetteer.Reverse(ctx, -34.60352, -58.38405)
+	require.NoError(t, err)
+	assert.Equal(t, "Av. Corrientes 1200, Buenos Aires", nearest.Address)
+	_, err = gazetteer.Reverse(ctx, -34.6200, -58.4000)
+	assert.ErrorIs(t, err, usecases.ErrAddressNotFound)
+
+	_, err = geocoding.NewGazetteer(strings.NewReader("calle,altura\nCorrientes,1000\n"), 0)
+	assert.Error(t, err)
+}
@@ -0,0 +1,60 @@
+package jobs
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+// Task es una tarea periódica; el contexto se cancela al detener el planificador
+type Task func(ctx context.Context) error
+
+// Scheduler ejecuta tareas periódicas en segundo plano hasta que se detiene
+type Scheduler struct {
+	logger *slog.Logger
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// NewScheduler crea un planificador sin tareas
+func NewScheduler(logger *slog.Logger) *Scheduler {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Scheduler{
+		logger: logger,
+		ctx:    ctx,
+		cancel: cancel,
+	}
+}
+
+// Every ejecuta la tarea cada interval en una goroutine propia. Los errores se registran
+// en el log y no detienen las ejecuciones siguientes.
+func (s *Scheduler) Every(name string, interval time.Duration, task Task) {
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-s.ctx.Done():
+				return
+			case <-ticker.C:
+				if err := task(s.ctx); err != nil && s.ctx.Err() == nil {
+					s.logger.Error("error al ejecutar la tarea periódica",
+						slog.String("task", name),
+						slog.Any("error", err),
+					)
+				}
+			}
+		}
+	}()
+}
+
+// Stop cancela las tareas y espera a que terminen las ejecuciones en curso
+func (s *Scheduler) Stop() {
+	s.cancel()
+	s.wg.Wait()
+}
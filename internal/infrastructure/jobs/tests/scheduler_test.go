@@ -0,0 +1,32 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"go-crime_map_backend/internal/infrastructure/jobs"
+)
+
+func TestSchedulerRunsUntilStopped(t *testing.T) {
+	scheduler := jobs.NewScheduler(slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	var runs atomic.Int32
+	scheduler.Every("test", 5*time.Millisecond, func(ctx context.Context) error {
+		runs.Add(1)
+		return errors.New("los errores no detienen la tarea")
+	})
+
+	assert.Eventually(t, func() bool { return runs.Load() >= 3 }, time.Second, 5*time.Millisecond)
+
+	scheduler.Stop()
+	stopped := runs.Load()
+	time.Sleep(20 * time.Millisecond)
+	assert.Equal(t, stopped, runs.Load())
+}
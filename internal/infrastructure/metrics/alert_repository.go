@@ -0,0 +1,113 @@
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"go-crime_map_backend/internal/domain/entities"
+	"go-crime_map_backend/internal/domain/repositories"
+)
+
+// InstrumentedAlertRepository decora un AlertRepository registrando la duración de cada operación
+type InstrumentedAlertRepository struct {
+	next    repositories.AlertRepository
+	name    string
+	metrics *Metrics
+}
+
+// NewInstrumentedAlertRepository crea el decorador del repositorio; name identifica la implementación
+func NewInstrumentedAlertRepository(next repositories.AlertRepository, name string, metrics *Metrics) *InstrumentedAlertRepository {
+	return &InstrumentedAlertRepository{
+		next:    next,
+		name:    name,
+		metrics: metrics,
+	}
+}
+
+// CreateWatchArea guarda una nueva zona
+func (r *InstrumentedAlertRepository) CreateWatchArea(ctx context.Context, area *entities.WatchArea) error {
+	start := time.Now()
+	err := r.next.CreateWatchArea(ctx, area)
+	r.metrics.observeQuery(r.name, "create_watch_area", start, err)
+	return err
+}
+
+// GetWatchArea obtiene una zona por su ID
+func (r *InstrumentedAlertRepository) GetWatchArea(ctx context.Context, id string) (*entities.WatchArea, error) {
+	start := time.Now()
+	area, err := r.next.GetWatchArea(ctx, id)
+	r.metrics.observeQuery(r.name, "get_watch_area", start, err)
+	return area, err
+}
+
+// ListWatchAreas obtiene las zonas del usuario
+func (r *InstrumentedAlertRepository) ListWatchAreas(ctx context.Context, ownerID string) ([]*entities.WatchArea, error) {
+	start := time.Now()
+	areas, err := r.next.ListWatchAreas(ctx, ownerID)
+	r.metrics.observeQuery(r.name, "list_watch_areas", start, err)
+	return areas, err
+}
+
+// UpdateWatchArea reemplaza los datos de la zona
+func (r *InstrumentedAlertRepository) UpdateWatchArea(ctx context.Context, area *entities.WatchArea) error {
+	start := time.Now()
+	err := r.next.UpdateWatchArea(ctx, area)
+	r.metrics.observeQuery(r.name, "update_watch_area", start, err)
+	return err
+}
+
+// DeleteWatchArea elimina la zona y sus avisos
+func (r *InstrumentedAlertRepository) DeleteWatchArea(ctx context.Context, id string) (bool, error) {
+	start := time.Now()
+	deleted, err := r.next.DeleteWatchArea(ctx, id)
+	r.metrics.observeQuery(r.name, "delete_watch_area", start, err)
+	return deleted, err
+}
+
+// FindWatchAreas obtiene las zonas activas candidatas para la ubicación
+func (r *InstrumentedAlertRepository) FindWatchAreas(ctx context.Context, location entities.Location) ([]*entities.WatchArea, error) {
+	start := time.Now()
+	areas, err := r.next.FindWatchAreas(ctx, location)
+	r.metrics.observeQuery(r.name, "find_watch_areas", start, err)
+	return areas, err
+}
+
+// EnqueueNotifications guarda los avisos ignorando los repetidos
+func (r *InstrumentedAlertRepository) EnqueueNotifications(ctx context.Context, notifications []*entities.Notification) error {
+	start := time.Now()
+	err := r.next.EnqueueNotifications(ctx, notifications)
+	r.metrics.observeQuery(r.name, "enqueue_notifications", start, err)
+	return err
+}
+
+// ClaimDueNotifications reserva los avisos pendientes cuyo intento vence
+func (r *InstrumentedAlertRepository) ClaimDueNotifications(ctx context.Context, now time.Time, limit int, lease time.Duration) ([]*entities.Notification, error) {
+	start := time.Now()
+	notifications, err := r.next.ClaimDueNotifications(ctx, now, limit, lease)
+	r.metrics.observeQuery(r.name, "claim_due_notifications", start, err)
+	return notifications, err
+}
+
+// UpdateNotification guarda el resultado de un intento
+func (r *InstrumentedAlertRepository) UpdateNotification(ctx context.Context, notification *entities.Notification) error {
+	start := time.Now()
+	err := r.next.UpdateNotification(ctx, notification)
+	r.metrics.observeQuery(r.name, "update_notification", start, err)
+	return err
+}
+
+// ListNotifications obtiene los avisos del usuario
+func (r *InstrumentedAlertRepository) ListNotifications(ctx context.Context, userID string, filter repositories.NotificationFilter) ([]*entities.Notification, error) {
+	start := time.Now()
+	notifications, err := r.next.ListNotifications(ctx, userID, filter)
+	r.metrics.observeQuery(r.name, "list_notifications", start, err)
+	return notifications, err
+}
+
+// MarkNotificationRead marca el aviso del usuario como leído
+func (r *InstrumentedAlertRepository) MarkNotificationRead(ctx context.Context, userID, id string, at time.Time) (bool, error) {
+	start := time.Now()
+	found, err := r.next.MarkNotificationRead(ctx, userID, id, at)
+	r.metrics.observeQuery(r.name, "mark_notification_read", start, err)
+	return found, err
+}
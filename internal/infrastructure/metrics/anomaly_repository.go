@@ -0,0 +1,41 @@
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"go-crime_map_backend/internal/domain/entities"
+	"go-crime_map_backend/internal/domain/repositories"
+)
+
+// InstrumentedAnomalyRepository decora un AnomalyRepository registrando la duración de cada operación
+type InstrumentedAnomalyRepository struct {
+	next    repositories.AnomalyRepository
+	name    string
+	metrics *Metrics
+}
+
+// NewInstrumentedAnomalyRepository crea el decorador del repositorio; name identifica la implementación
+func NewInstrumentedAnomalyRepository(next repositories.AnomalyRepository, name string, metrics *Metrics) *InstrumentedAnomalyRepository {
+	return &InstrumentedAnomalyRepository{
+		next:    next,
+		name:    name,
+		metrics: metrics,
+	}
+}
+
+// SaveAnomaly guarda una anomalía con sus eventos
+func (r *InstrumentedAnomalyRepository) SaveAnomaly(ctx context.Context, anomaly *entities.Anomaly) (bool, error) {
+	start := time.Now()
+	saved, err := r.next.SaveAnomaly(ctx, anomaly)
+	r.metrics.observeQuery(r.name, "save_anomaly", start, err)
+	return saved, err
+}
+
+// ListAnomalies obtiene las anomalías del filtro
+func (r *InstrumentedAnomalyRepository) ListAnomalies(ctx context.Context, filter repositories.AnomalyFilter) ([]*entities.Anomaly, error) {
+	start := time.Now()
+	anomalies, err := r.next.ListAnomalies(ctx, filter)
+	r.metrics.observeQuery(r.name, "list_anomalies", start, err)
+	return anomalies, err
+}
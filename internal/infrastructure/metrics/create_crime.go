@@ -0,0 +1,39 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+
+	"go-crime_map_backend/internal/domain/entities"
+	"go-crime_map_backend/internal/usecases"
+)
+
+// InstrumentedCreateCrime decora el caso de uso de creación de delitos con métricas
+type InstrumentedCreateCrime struct {
+	next    usecases.CreateCrimeExecutor
+	metrics *Metrics
+}
+
+// NewInstrumentedCreateCrime crea el decorador del caso de uso
+func NewInstrumentedCreateCrime(next usecases.CreateCrimeExecutor, metrics *Metrics) *InstrumentedCreateCrime {
+	return &InstrumentedCreateCrime{
+		next:    next,
+		metrics: metrics,
+	}
+}
+
+// Execute ejecuta el caso de uso y registra su resultado
+func (d *InstrumentedCreateCrime) Execute(ctx context.Context, input usecases.CreateCrimeInput) (*entities.Crime, error) {
+	crime, err := d.next.Execute(ctx, input)
+	switch {
+	case err == nil:
+		d.metrics.crimesCreated.Inc()
+	case errors.Is(err, usecases.ErrDuplicateCrime):
+		d.metrics.duplicatesRejected.Inc()
+	default:
+		if code, ok := usecases.ValidationErrorCode(err); ok {
+			d.metrics.validationFailures.WithLabelValues(code).Inc()
+		}
+	}
+	return crime, err
+}
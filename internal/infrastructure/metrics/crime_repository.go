@@ -0,0 +1,120 @@
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"go-crime_map_backend/internal/domain/entities"
+	"go-crime_map_backend/internal/domain/repositories"
+)
+
+// InstrumentedCrimeRepository decora un CrimeRepository registrando la duración de cada operación
+type InstrumentedCrimeRepository struct {
+	next    repositories.CrimeRepository
+	name    string
+	metrics *Metrics
+}
+
+// NewInstrumentedCrimeRepository crea el decorador del repositorio; name identifica la implementación
+func NewInstrumentedCrimeRepository(next repositories.CrimeRepository, name string, metrics *Metrics) *InstrumentedCrimeRepository {
+	return &InstrumentedCrimeRepository{
+		next:    next,
+		name:    name,
+		metrics: metrics,
+	}
+}
+
+// Create guarda un nuevo delito en el repositorio
+func (r *InstrumentedCrimeRepository) Create(ctx context.Context, crime *entities.Crime) error {
+	start := time.Now()
+	err := r.next.Create(ctx, crime)
+	r.observe("create", start, err)
+	return err
+}
+
+// GetByID obtiene un delito por su ID
+func (r *InstrumentedCrimeRepository) GetByID(ctx context.Context, id string) (*entities.Crime, error) {
+	start := time.Now()
+	crime, err := r.next.GetByID(ctx, id)
+	r.observe("get_by_id", start, err)
+	return crime, err
+}
+
+// GetAll obtiene todos los delitos
+func (r *InstrumentedCrimeRepository) GetAll(ctx context.Context) ([]*entities.Crime, error) {
+	start := time.Now()
+	crimes, err := r.next.GetAll(ctx)
+	r.observe("get_all", start, err)
+	return crimes, err
+}
+
+// List obtiene los delitos que cumplen el filtro
+func (r *InstrumentedCrimeRepository) List(ctx context.Context, filter repositories.CrimeFilter) ([]*entities.Crime, error) {
+	start := time.Now()
+	crimes, err := r.next.List(ctx, filter)
+	r.observe("list", start, err)
+	return crimes, err
+}
+
+// FindNear obtiene los delitos cercanos a una fecha y unas coordenadas
+func (r *InstrumentedCrimeRepository) FindNear(ctx context.Context, query repositories.NearbyQuery) ([]*entities.Crime, error) {
+	start := time.Now()
+	crimes, err := r.next.FindNear(ctx, query)
+	r.observe("find_near", start, err)
+	return crimes, err
+}
+
+// Update actualiza un delito existente
+func (r *InstrumentedCrimeRepository) Update(ctx context.Context, crime *entities.Crime) error {
+	start := time.Now()
+	err := r.next.Update(ctx, crime)
+	r.observe("update", start, err)
+	return err
+}
+
+// UpdateStatus cambia el estado de un delito y registra el cambio en el historial
+func (r *InstrumentedCrimeRepository) UpdateStatus(ctx context.Context, change *entities.CrimeStatusChange) error {
+	start := time.Now()
+	err := r.next.UpdateStatus(ctx, change)
+	r.observe("update_status", start, err)
+	return err
+}
+
+// GetStatusHistory obtiene el historial de estados de un delito
+func (r *InstrumentedCrimeRepository) GetStatusHistory(ctx context.Context, crimeID string) ([]*entities.CrimeStatusChange, error) {
+	start := time.Now()
+	history, err := r.next.GetStatusHistory(ctx, crimeID)
+	r.observe("get_status_history", start, err)
+	return history, err
+}
+
+// Delete elimina un delito por su ID
+func (r *InstrumentedCrimeRepository) Delete(ctx context.Context, id string) error {
+	start := time.Now()
+	err := r.next.Delete(ctx, id)
+	r.observe("delete", start, err)
+	return err
+}
+
+// AssignZones cambia la zona de los delitos indicados
+func (r *InstrumentedCrimeRepository) AssignZones(ctx context.Context, zoneIDs map[string]string) error {
+	start := time.Now()
+	err := r.next.AssignZones(ctx, zoneIDs)
+	r.observe("assign_zones", start, err)
+	return err
+}
+
+// observe registra la duración y el resultado de una operación
+func (r *InstrumentedCrimeRepository) observe(operation string, start time.Time, err error) {
+	r.metrics.observeQuery(r.name, operation, start, err)
+}
+
+// observeQuery registra la duración y el resultado de una operación de un repositorio
+func (m *Metrics) observeQuery(repository, operation string, start time.Time, err error) {
+	outcome := "success"
+	if err != nil {
+		outcome = "error"
+	}
+	m.dbQueries.WithLabelValues(repository, operation, outcome).Inc()
+	m.dbQueryDuration.WithLabelValues(repository, operation).Observe(time.Since(start).Seconds())
+}
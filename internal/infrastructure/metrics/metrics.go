@@ -0,0 +1,109 @@
+package metrics
+
+import (
+	"database/sql"
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// namespace es el prefijo de todas las métricas de la aplicación
+const namespace = "crime_map"
+
+// Metrics agrupa los colectores de Prometheus de la aplicación
+type Metrics struct {
+	registry *prometheus.Registry
+
+	httpRequests *prometheus.CounterVec
+	httpDuration *prometheus.HistogramVec
+
+	crimesCreated      prometheus.Counter
+	duplicatesRejected prometheus.Counter
+	validationFailures *prometheus.CounterVec
+
+	dbQueries       *prometheus.CounterVec
+	dbQueryDuration *prometheus.HistogramVec
+
+	domainEvents *prometheus.CounterVec
+}
+
+// New crea los colectores y los registra en un registro propio
+func New() *Metrics {
+	m := &Metrics{
+		registry: prometheus.NewRegistry(),
+		httpRequests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "http_requests_total",
+			Help:      "Cantidad de solicitudes HTTP por método, ruta y código de estado.",
+		}, []string{"method", "route", "status"}),
+		httpDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "http_request_duration_seconds",
+			Help:      "Latencia de las solicitudes HTTP por método, ruta y código de estado.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"method", "route", "status"}),
+		crimesCreated: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "crimes_created_total",
+			Help:      "Cantidad de delitos creados.",
+		}),
+		duplicatesRejected: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "crimes_duplicates_rejected_total",
+			Help:      "Cantidad de delitos rechazados por estar duplicados.",
+		}),
+		validationFailures: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "crimes_validation_failures_total",
+			Help:      "Cantidad de delitos rechazados por validación, por código de error.",
+		}, []string{"code"}),
+		dbQueries: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "db_queries_total",
+			Help:      "Cantidad de operaciones de base de datos por repositorio, operación y resultado.",
+		}, []string{"repository", "operation", "outcome"}),
+		dbQueryDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "db_query_duration_seconds",
+			Help:      "Duración de las operaciones de base de datos por repositorio y operación.",
+			Buckets:   []float64{.001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5},
+		}, []string{"repository", "operation"}),
+		domainEvents: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "domain_events_total",
+			Help:      "Cantidad de eventos de dominio entregados por tipo.",
+		}, []string{"type"}),
+	}
+
+	m.registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		m.httpRequests,
+		m.httpDuration,
+		m.crimesCreated,
+		m.duplicatesRejected,
+		m.validationFailures,
+		m.dbQueries,
+		m.dbQueryDuration,
+		m.domainEvents,
+	)
+
+	return m
+}
+
+// RegisterDB registra las estadísticas del pool de conexiones de database/sql
+func (m *Metrics) RegisterDB(db *sql.DB, dbName string) {
+	m.registry.MustRegister(collectors.NewDBStatsCollector(db, dbName))
+}
+
+// Handler retorna el handler HTTP que expone las métricas en formato de texto de Prometheus
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{Registry: m.registry})
+}
+
+// Registry retorna el registro de Prometheus, útil para registrar colectores adicionales
+func (m *Metrics) Registry() *prometheus.Registry {
+	return m.registry
+}
@@ -0,0 +1,65 @@
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"go-crime_map_backend/internal/domain/entities"
+	"go-crime_map_backend/internal/domain/repositories"
+)
+
+// InstrumentedModerationRepository decora un ModerationRepository registrando la duración de cada operación
+type InstrumentedModerationRepository struct {
+	next    repositories.ModerationRepository
+	name    string
+	metrics *Metrics
+}
+
+// NewInstrumentedModerationRepository crea el decorador del repositorio; name identifica la implementación
+func NewInstrumentedModerationRepository(next repositories.ModerationRepository, name string, metrics *Metrics) *InstrumentedModerationRepository {
+	return &InstrumentedModerationRepository{
+		next:    next,
+		name:    name,
+		metrics: metrics,
+	}
+}
+
+// ListPending obtiene los delitos pendientes de moderación
+func (r *InstrumentedModerationRepository) ListPending(ctx context.Context, filter repositories.ModerationQueueFilter) ([]*entities.ModerationItem, error) {
+	start := time.Now()
+	items, err := r.next.ListPending(ctx, filter)
+	r.metrics.observeQuery(r.name, "list_pending", start, err)
+	return items, err
+}
+
+// GetClaim obtiene la reserva vigente de un delito
+func (r *InstrumentedModerationRepository) GetClaim(ctx context.Context, crimeID string, now time.Time) (*entities.ModerationClaim, error) {
+	start := time.Now()
+	claim, err := r.next.GetClaim(ctx, crimeID, now)
+	r.metrics.observeQuery(r.name, "get_claim", start, err)
+	return claim, err
+}
+
+// Claim reserva un delito pendiente para un moderador
+func (r *InstrumentedModerationRepository) Claim(ctx context.Context, claim *entities.ModerationClaim) error {
+	start := time.Now()
+	err := r.next.Claim(ctx, claim)
+	r.metrics.observeQuery(r.name, "claim", start, err)
+	return err
+}
+
+// ReleaseClaim libera la reserva de un moderador
+func (r *InstrumentedModerationRepository) ReleaseClaim(ctx context.Context, crimeID, moderatorID string) error {
+	start := time.Now()
+	err := r.next.ReleaseClaim(ctx, crimeID, moderatorID)
+	r.metrics.observeQuery(r.name, "release_claim", start, err)
+	return err
+}
+
+// ReleaseExpiredClaims libera las reservas vencidas
+func (r *InstrumentedModerationRepository) ReleaseExpiredClaims(ctx context.Context, now time.Time) (int64, error) {
+	start := time.Now()
+	released, err := r.next.ReleaseExpiredClaims(ctx, now)
+	r.metrics.observeQuery(r.name, "release_expired_claims", start, err)
+	return released, err
+}
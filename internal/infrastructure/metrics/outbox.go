@@ -0,0 +1,65 @@
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"go-crime_map_backend/internal/domain/events"
+	"go-crime_map_backend/internal/domain/repositories"
+)
+
+// InstrumentedOutboxRepository decora un OutboxRepository registrando la duración de cada operación
+type InstrumentedOutboxRepository struct {
+	next    repositories.OutboxRepository
+	name    string
+	metrics *Metrics
+}
+
+// NewInstrumentedOutboxRepository crea el decorador del repositorio; name identifica la implementación
+func NewInstrumentedOutboxRepository(next repositories.OutboxRepository, name string, metrics *Metrics) *InstrumentedOutboxRepository {
+	return &InstrumentedOutboxRepository{
+		next:    next,
+		name:    name,
+		metrics: metrics,
+	}
+}
+
+// ClaimPending reserva los eventos listos para entregar
+func (r *InstrumentedOutboxRepository) ClaimPending(ctx context.Context, now time.Time, limit int, lease time.Duration) ([]*repositories.OutboxEntry, error) {
+	start := time.Now()
+	entries, err := r.next.ClaimPending(ctx, now, limit, lease)
+	r.metrics.observeQuery(r.name, "claim_outbox_events", start, err)
+	return entries, err
+}
+
+// MarkDispatched registra la entrega de un evento
+func (r *InstrumentedOutboxRepository) MarkDispatched(ctx context.Context, eventID string, at time.Time) error {
+	start := time.Now()
+	err := r.next.MarkDispatched(ctx, eventID, at)
+	r.metrics.observeQuery(r.name, "mark_outbox_event_dispatched", start, err)
+	return err
+}
+
+// MarkFailed registra una entrega fallida
+func (r *InstrumentedOutboxRepository) MarkFailed(ctx context.Context, eventID string, attempts int, nextAttemptAt *time.Time, lastError string) error {
+	start := time.Now()
+	err := r.next.MarkFailed(ctx, eventID, attempts, nextAttemptAt, lastError)
+	r.metrics.observeQuery(r.name, "mark_outbox_event_failed", start, err)
+	return err
+}
+
+// ListAfter obtiene los eventos posteriores al cursor
+func (r *InstrumentedOutboxRepository) ListAfter(ctx context.Context, after repositories.OutboxCursor, limit int) ([]events.Event, error) {
+	start := time.Now()
+	list, err := r.next.ListAfter(ctx, after, limit)
+	r.metrics.observeQuery(r.name, "list_outbox_events", start, err)
+	return list, err
+}
+
+// EventCounter retorna un handler que cuenta los eventos de dominio entregados por tipo
+func (m *Metrics) EventCounter() events.Handler {
+	return events.HandlerFunc(func(ctx context.Context, event events.Event) error {
+		m.domainEvents.WithLabelValues(string(event.Type)).Inc()
+		return nil
+	})
+}
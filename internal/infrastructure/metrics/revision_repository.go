@@ -0,0 +1,41 @@
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"go-crime_map_backend/internal/domain/entities"
+	"go-crime_map_backend/internal/domain/repositories"
+)
+
+// InstrumentedRevisionRepository decora un RevisionRepository registrando la duración de cada operación
+type InstrumentedRevisionRepository struct {
+	next    repositories.RevisionRepository
+	name    string
+	metrics *Metrics
+}
+
+// NewInstrumentedRevisionRepository crea el decorador del repositorio; name identifica la implementación
+func NewInstrumentedRevisionRepository(next repositories.RevisionRepository, name string, metrics *Metrics) *InstrumentedRevisionRepository {
+	return &InstrumentedRevisionRepository{
+		next:    next,
+		name:    name,
+		metrics: metrics,
+	}
+}
+
+// GetRevisions obtiene las revisiones de un delito
+func (r *InstrumentedRevisionRepository) GetRevisions(ctx context.Context, crimeID string) ([]*entities.CrimeRevision, error) {
+	start := time.Now()
+	revisions, err := r.next.GetRevisions(ctx, crimeID)
+	r.metrics.observeQuery(r.name, "get_revisions", start, err)
+	return revisions, err
+}
+
+// GetRevisionAt obtiene la última revisión de un delito hasta un instante
+func (r *InstrumentedRevisionRepository) GetRevisionAt(ctx context.Context, crimeID string, at time.Time) (*entities.CrimeRevision, error) {
+	start := time.Now()
+	revision, err := r.next.GetRevisionAt(ctx, crimeID, at)
+	r.metrics.observeQuery(r.name, "get_revision_at", start, err)
+	return revision, err
+}
@@ -0,0 +1,73 @@
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"go-crime_map_backend/internal/domain/entities"
+	"go-crime_map_backend/internal/domain/repositories"
+)
+
+// InstrumentedStatsRepository decora un StatsRepository registrando la duración de cada consulta
+type InstrumentedStatsRepository struct {
+	next    repositories.StatsRepository
+	name    string
+	metrics *Metrics
+}
+
+// NewInstrumentedStatsRepository crea el decorador del repositorio; name identifica la implementación
+func NewInstrumentedStatsRepository(next repositories.StatsRepository, name string, metrics *Metrics) *InstrumentedStatsRepository {
+	return &InstrumentedStatsRepository{
+		next:    next,
+		name:    name,
+		metrics: metrics,
+	}
+}
+
+// CountByZone cuenta los delitos por zona y tipo
+func (r *InstrumentedStatsRepository) CountByZone(ctx context.Context, filter repositories.StatsFilter) ([]repositories.ZoneTypeCount, error) {
+	start := time.Now()
+	counts, err := r.next.CountByZone(ctx, filter)
+	r.metrics.observeQuery(r.name, "count_by_zone", start, err)
+	return counts, err
+}
+
+// ListPage obtiene una página de los delitos del período
+func (r *InstrumentedStatsRepository) ListPage(ctx context.Context, filter repositories.StatsFilter, after *repositories.CrimeCursor, limit int) ([]*entities.Crime, error) {
+	start := time.Now()
+	crimes, err := r.next.ListPage(ctx, filter, after, limit)
+	r.metrics.observeQuery(r.name, "list_page", start, err)
+	return crimes, err
+}
+
+// CountByBucket cuenta los delitos por intervalo de tiempo
+func (r *InstrumentedStatsRepository) CountByBucket(ctx context.Context, filter repositories.StatsFilter, bucket entities.TimeBucket, location *time.Location) ([]repositories.BucketCount, error) {
+	start := time.Now()
+	counts, err := r.next.CountByBucket(ctx, filter, bucket, location)
+	r.metrics.observeQuery(r.name, "count_by_bucket", start, err)
+	return counts, err
+}
+
+// CountByWeekdayHour cuenta los delitos por día de la semana y hora
+func (r *InstrumentedStatsRepository) CountByWeekdayHour(ctx context.Context, filter repositories.StatsFilter, location *time.Location) ([]repositories.WeekdayHourCount, error) {
+	start := time.Now()
+	counts, err := r.next.CountByWeekdayHour(ctx, filter, location)
+	r.metrics.observeQuery(r.name, "count_by_weekday_hour", start, err)
+	return counts, err
+}
+
+// ListPoints obtiene la ubicación de los delitos
+func (r *InstrumentedStatsRepository) ListPoints(ctx context.Context, filter repositories.StatsFilter, limit int) ([]repositories.CrimePoint, error) {
+	start := time.Now()
+	points, err := r.next.ListPoints(ctx, filter, limit)
+	r.metrics.observeQuery(r.name, "list_points", start, err)
+	return points, err
+}
+
+// CountByWindow cuenta los delitos por zona, tipo y ventana de tiempo
+func (r *InstrumentedStatsRepository) CountByWindow(ctx context.Context, filter repositories.StatsFilter, window time.Duration) ([]repositories.WindowCount, error) {
+	start := time.Now()
+	counts, err := r.next.CountByWindow(ctx, filter, window)
+	r.metrics.observeQuery(r.name, "count_by_window", start, err)
+	return counts, err
+}
@@ -0,0 +1,74 @@
+package tests
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go-crime_map_backend/internal/infrastructure/metrics"
+	"go-crime_map_backend/internal/infrastructure/repositories"
+	crimeHttp "go-crime_map_backend/internal/interfaces/http"
+	"go-crime_map_backend/internal/usecases"
+)
+
+func postCrime(t *testing.T, router *gin.Engine, payload map[string]interface{}) int {
+	body, err := json.Marshal(payload)
+	require.NoError(t, err)
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/crimes/", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w.Code
+}
+
+func TestMetrics(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	appMetrics := metrics.New()
+
+	repo := metrics.NewInstrumentedCrimeRepository(
+		repositories.NewMemoryCrimeRepository(), "MemoryCrimeRepository", appMetrics)
+	useCase := metrics.NewInstrumentedCreateCrime(usecases.NewCreateCrimeUseCase(repo), appMetrics)
+	controller := crimeHttp.NewCrimeController(useCase)
+
+	router := gin.New()
+	router.Use(appMetrics.GinMiddleware())
+	router.GET("/metrics", gin.WrapH(appMetrics.Handler()))
+	router.POST("/api/v1/crimes/", controller.Create)
+
+	payload := map[string]interface{}{
+		"type":        "ROBO",
+		"description": "Robo a mano armada",
+		"location": map[string]interface{}{
+			"latitude":  -34.603722,
+			"longitude": -58.381592,
+			"address":   "Av. Corrientes 1234, CABA",
+		},
+		"date": time.Now().Add(-time.Hour).Format(time.RFC3339),
+	}
+	assert.Equal(t, http.StatusCreated, postCrime(t, router, payload))
+	assert.Equal(t, http.StatusConflict, postCrime(t, router, payload))
+
+	payload["type"] = "INVALIDO"
+	assert.Equal(t, http.StatusBadRequest, postCrime(t, router, payload))
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+	body := w.Body.String()
+
+	assert.Contains(t, body, `crime_map_http_requests_total{method="POST",route="/api/v1/crimes/",status="201"} 1`)
+	assert.Contains(t, body, `crime_map_http_requests_total{method="POST",route="/api/v1/crimes/",status="409"} 1`)
+	assert.Contains(t, body, `crime_map_http_request_duration_seconds_bucket{method="POST",route="/api/v1/crimes/",status="400"`)
+	assert.Contains(t, body, `crime_map_crimes_created_total 1`)
+	assert.Contains(t, body, `crime_map_crimes_duplicates_rejected_total 1`)
+	assert.Contains(t, body, `crime_map_crimes_validation_failures_total{code="invalid_type"} 1`)
+	assert.Contains(t, body, `crime_map_db_queries_total{operation="create",outcome="success",repository="MemoryCrimeRepository"} 1`)
+	assert.Contains(t, body, `crime_map_db_queries_total{operation="find_near",outcome="success",repository="MemoryCrimeRepository"} 2`)
+}
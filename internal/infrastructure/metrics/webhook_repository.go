@@ -0,0 +1,97 @@
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"go-crime_map_backend/internal/domain/entities"
+	"go-crime_map_backend/internal/domain/repositories"
+)
+
+// InstrumentedWebhookRepository decora un WebhookRepository registrando la duración de cada operación
+type InstrumentedWebhookRepository struct {
+	next    repositories.WebhookRepository
+	name    string
+	metrics *Metrics
+}
+
+// NewInstrumentedWebhookRepository crea el decorador del repositorio; name identifica la implementación
+func NewInstrumentedWebhookRepository(next repositories.WebhookRepository, name string, metrics *Metrics) *InstrumentedWebhookRepository {
+	return &InstrumentedWebhookRepository{
+		next:    next,
+		name:    name,
+		metrics: metrics,
+	}
+}
+
+// CreateSubscription guarda una nueva suscripción
+func (r *InstrumentedWebhookRepository) CreateSubscription(ctx context.Context, subscription *entities.WebhookSubscription) error {
+	start := time.Now()
+	err := r.next.CreateSubscription(ctx, subscription)
+	r.metrics.observeQuery(r.name, "create_webhook_subscription", start, err)
+	return err
+}
+
+// GetSubscription obtiene una suscripción por su ID
+func (r *InstrumentedWebhookRepository) GetSubscription(ctx context.Context, id string) (*entities.WebhookSubscription, error) {
+	start := time.Now()
+	subscription, err := r.next.GetSubscription(ctx, id)
+	r.metrics.observeQuery(r.name, "get_webhook_subscription", start, err)
+	return subscription, err
+}
+
+// ListSubscriptions obtiene todas las suscripciones
+func (r *InstrumentedWebhookRepository) ListSubscriptions(ctx context.Context) ([]*entities.WebhookSubscription, error) {
+	start := time.Now()
+	subscriptions, err := r.next.ListSubscriptions(ctx)
+	r.metrics.observeQuery(r.name, "list_webhook_subscriptions", start, err)
+	return subscriptions, err
+}
+
+// DeleteSubscription elimina una suscripción
+func (r *InstrumentedWebhookRepository) DeleteSubscription(ctx context.Context, id string) (bool, error) {
+	start := time.Now()
+	deleted, err := r.next.DeleteSubscription(ctx, id)
+	r.metrics.observeQuery(r.name, "delete_webhook_subscription", start, err)
+	return deleted, err
+}
+
+// EnqueueDeliveries agrega las entregas pendientes
+func (r *InstrumentedWebhookRepository) EnqueueDeliveries(ctx context.Context, deliveries []*entities.WebhookDelivery) error {
+	start := time.Now()
+	err := r.next.EnqueueDeliveries(ctx, deliveries)
+	r.metrics.observeQuery(r.name, "enqueue_webhook_deliveries", start, err)
+	return err
+}
+
+// ClaimDueDeliveries reserva las entregas pendientes
+func (r *InstrumentedWebhookRepository) ClaimDueDeliveries(ctx context.Context, now time.Time, limit int, lease time.Duration) ([]*entities.WebhookDelivery, error) {
+	start := time.Now()
+	deliveries, err := r.next.ClaimDueDeliveries(ctx, now, limit, lease)
+	r.metrics.observeQuery(r.name, "claim_webhook_deliveries", start, err)
+	return deliveries, err
+}
+
+// UpdateDelivery guarda el resultado de un intento
+func (r *InstrumentedWebhookRepository) UpdateDelivery(ctx context.Context, delivery *entities.WebhookDelivery) error {
+	start := time.Now()
+	err := r.next.UpdateDelivery(ctx, delivery)
+	r.metrics.observeQuery(r.name, "update_webhook_delivery", start, err)
+	return err
+}
+
+// GetDelivery obtiene una entrega por su ID
+func (r *InstrumentedWebhookRepository) GetDelivery(ctx context.Context, subscriptionID, deliveryID string) (*entities.WebhookDelivery, error) {
+	start := time.Now()
+	delivery, err := r.next.GetDelivery(ctx, subscriptionID, deliveryID)
+	r.metrics.observeQuery(r.name, "get_webhook_delivery", start, err)
+	return delivery, err
+}
+
+// ListDeliveries obtiene las entregas de una suscripción
+func (r *InstrumentedWebhookRepository) ListDeliveries(ctx context.Context, subscriptionID string, filter repositories.WebhookDeliveryFilter) ([]*entities.WebhookDelivery, error) {
+	start := time.Now()
+	deliveries, err := r.next.ListDeliveries(ctx, subscriptionID, filter)
+	r.metrics.observeQuery(r.name, "list_webhook_deliveries", start, err)
+	return deliveries, err
+}
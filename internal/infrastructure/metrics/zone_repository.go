@@ -0,0 +1,73 @@
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"go-crime_map_backend/internal/domain/entities"
+	"go-crime_map_backend/internal/domain/repositories"
+)
+
+// InstrumentedZoneRepository decora un ZoneRepository registrando la duración de cada operación
+type InstrumentedZoneRepository struct {
+	next    repositories.ZoneRepository
+	name    string
+	metrics *Metrics
+}
+
+// NewInstrumentedZoneRepository crea el decorador del repositorio; name identifica la implementación
+func NewInstrumentedZoneRepository(next repositories.ZoneRepository, name string, metrics *Metrics) *InstrumentedZoneRepository {
+	return &InstrumentedZoneRepository{
+		next:    next,
+		name:    name,
+		metrics: metrics,
+	}
+}
+
+// CreateZone guarda una nueva zona
+func (r *InstrumentedZoneRepository) CreateZone(ctx context.Context, zone *entities.Zone) error {
+	start := time.Now()
+	err := r.next.CreateZone(ctx, zone)
+	r.metrics.observeQuery(r.name, "create_zone", start, err)
+	return err
+}
+
+// GetZone obtiene una zona por su ID
+func (r *InstrumentedZoneRepository) GetZone(ctx context.Context, id string) (*entities.Zone, error) {
+	start := time.Now()
+	zone, err := r.next.GetZone(ctx, id)
+	r.metrics.observeQuery(r.name, "get_zone", start, err)
+	return zone, err
+}
+
+// ListZones obtiene las zonas del tipo indicado
+func (r *InstrumentedZoneRepository) ListZones(ctx context.Context, kind string) ([]*entities.Zone, error) {
+	start := time.Now()
+	zones, err := r.next.ListZones(ctx, kind)
+	r.metrics.observeQuery(r.name, "list_zones", start, err)
+	return zones, err
+}
+
+// UpdateZone reemplaza los datos de la zona
+func (r *InstrumentedZoneRepository) UpdateZone(ctx context.Context, zone *entities.Zone) error {
+	start := time.Now()
+	err := r.next.UpdateZone(ctx, zone)
+	r.metrics.observeQuery(r.name, "update_zone", start, err)
+	return err
+}
+
+// DeleteZone elimina la zona
+func (r *InstrumentedZoneRepository) DeleteZone(ctx context.Context, id string) (bool, error) {
+	start := time.Now()
+	deleted, err := r.next.DeleteZone(ctx, id)
+	r.metrics.observeQuery(r.name, "delete_zone", start, err)
+	return deleted, err
+}
+
+// FindZones obtiene las zonas candidatas a contener la ubicación
+func (r *InstrumentedZoneRepository) FindZones(ctx context.Context, location entities.Location) ([]*entities.Zone, error) {
+	start := time.Now()
+	zones, err := r.next.FindZones(ctx, location)
+	r.metrics.observeQuery(r.name, "find_zones", start, err)
+	return zones, err
+}
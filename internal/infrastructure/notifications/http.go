@@ -0,0 +1,66 @@
+package notifications
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"go-crime_map_backend/internal/domain/entities"
+)
+
+// HeaderNotification contiene el ID del aviso, igual en cada reintento
+const HeaderNotification = "X-Notification-ID"
+
+// maxResponseBody limita lo que se lee de la respuesta del receptor
+const maxResponseBody = 64 << 10
+
+// HTTPSender envía los avisos con un POST del aviso en JSON a la URL del usuario
+type HTTPSender struct {
+	client *http.Client
+}
+
+// NewHTTPSender crea un emisor con el tiempo de espera indicado por envío
+func NewHTTPSender(timeout time.Duration) *HTTPSender {
+	return &HTTPSender{
+		client: &http.Client{
+			Timeout: timeout,
+			// Las redirecciones no se siguen para que la URL configurada sea la única destinataria
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+	}
+}
+
+// Send envía el aviso; cualquier respuesta que no sea 2xx se considera un fallo
+func (s *HTTPSender) Send(ctx context.Context, notification *entities.Notification) error {
+	body, err := json.Marshal(notification)
+	if err != nil {
+		return fmt.Errorf("error al serializar el aviso: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, notification.Target, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("error al crear la solicitud del aviso: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "crime-map-alerts/1.0")
+	req.Header.Set(HeaderNotification, notification.ID)
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("error al enviar el aviso: %w", err)
+	}
+	defer resp.Body.Close()
+	// Leer la respuesta permite reutilizar la conexión
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, maxResponseBody))
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("el receptor respondió con el código HTTP %d", resp.StatusCode)
+	}
+	return nil
+}
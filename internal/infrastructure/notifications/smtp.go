@@ -0,0 +1,72 @@
+// Package notifications implementa los canales por los que se avisa a los usuarios
+package notifications
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"mime"
+	"net"
+	"net/smtp"
+	"strconv"
+	"time"
+
+	"go-crime_map_backend/internal/domain/entities"
+)
+
+// SMTPOptions configura el servidor de correo saliente
+type SMTPOptions struct {
+	Host     string
+	Port     int
+	Username string // Vacío para enviar sin autenticación
+	Password string
+	From     string
+}
+
+// SMTPSender envía los avisos por correo electrónico. Usa STARTTLS si el servidor lo ofrece
+type SMTPSender struct {
+	opts SMTPOptions
+	now  func() time.Time
+}
+
+// NewSMTPSender crea un emisor de correos con el servidor indicado
+func NewSMTPSender(opts SMTPOptions) *SMTPSender {
+	return &SMTPSender{
+		opts: opts,
+		now:  time.Now,
+	}
+}
+
+// Send envía el aviso a la dirección de destino como texto plano en UTF-8
+func (s *SMTPSender) Send(ctx context.Context, notification *entities.Notification) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	var auth smtp.Auth
+	if s.opts.Username != "" {
+		auth = smtp.PlainAuth("", s.opts.Username, s.opts.Password, s.opts.Host)
+	}
+	addr := net.JoinHostPort(s.opts.Host, strconv.Itoa(s.opts.Port))
+	if err := smtp.SendMail(addr, auth, s.opts.From, []string{notification.Target}, s.message(notification)); err != nil {
+		return fmt.Errorf("error al enviar el correo: %w", err)
+	}
+	return nil
+}
+
+// message arma el mensaje RFC 5322 del aviso
+func (s *SMTPSender) message(notification *entities.Notification) []byte {
+	var msg bytes.Buffer
+	fmt.Fprintf(&msg, "From: %s\r\n", s.opts.From)
+	fmt.Fprintf(&msg, "To: %s\r\n", notification.Target)
+	fmt.Fprintf(&msg, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", notification.Title))
+	fmt.Fprintf(&msg, "Date: %s\r\n", s.now().Format(time.RFC1123Z))
+	fmt.Fprintf(&msg, "Message-ID: <%s@crime-map>\r\n", notification.ID)
+	msg.WriteString("MIME-Version: 1.0\r\n")
+	msg.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+	msg.WriteString("Content-Transfer-Encoding: 8bit\r\n")
+	msg.WriteString("\r\n")
+	msg.WriteString(notification.Body)
+	msg.WriteString("\r\n")
+	return msg.Bytes()
+}
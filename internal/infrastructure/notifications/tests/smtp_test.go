@@ -0,0 +1,146 @@
+package tests
+
+import (
+	"bufio"
+	"context"
+	"net"
+	"strings"
+	"sync"
+	"testing"
+
+	"go-crime_map_backend/internal/domain/entities"
+	"go-crime_map_backend/internal/infrastructure/notifications"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// smtpStandIn es un servidor SMTP mínimo que acepta los mensajes y los guarda en memoria
+type smtpStandIn struct {
+	listener net.Listener
+	mu       sync.Mutex
+	messages []smtpMessage
+}
+
+type smtpMessage struct {
+	from string
+	to   []string
+	data string
+}
+
+func newSMTPStandIn(t *testing.T) *smtpStandIn {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	server := &smtpStandIn{listener: listener}
+	t.Cleanup(func() { listener.Close() })
+	go server.serve()
+	return server
+}
+
+func (s *smtpStandIn) port() int {
+	return s.listener.Addr().(*net.TCPAddr).Port
+}
+
+func (s *smtpStandIn) received() []smtpMessage {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]smtpMessage(nil), s.messages...)
+}
+
+func (s *smtpStandIn) serve() {
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+		go s.handle(conn)
+	}
+}
+
+func (s *smtpStandIn) handle(conn net.Conn) {
+	defer conn.Close()
+	reader := bufio.NewReader(conn)
+	reply := func(line string) { _, _ = conn.Write([]byte(line + "\r\n")) }
+
+	var message smtpMessage
+	reply("220 stand-in ESMTP")
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			return
+		}
+		command := strings.ToUpper(strings.TrimSpace(line))
+		switch {
+		case strings.HasPrefix(command, "EHLO"), strings.HasPrefix(command, "HELO"):
+			reply("250 stand-in")
+		case strings.HasPrefix(command, "MAIL FROM:"):
+			message.from = strings.Trim(strings.TrimSpace(line)[10:], "<>")
+			reply("250 OK")
+		case strings.HasPrefix(command, "RCPT TO:"):
+			message.to = append(message.to, strings.Trim(strings.TrimSpace(line)[8:], "<>"))
+			reply("250 OK")
+		case command == "DATA":
+			reply("354 fin con <CRLF>.<CRLF>")
+			var data strings.Builder
+			for {
+				dataLine, err := reader.ReadString('\n')
+				if err != nil {
+					return
+				}
+				if dataLine == ".\r\n" {
+					break
+				}
+				data.WriteString(dataLine)
+			}
+			message.data = data.String()
+			s.mu.Lock()
+			s.messages = append(s.messages, message)
+			s.mu.Unlock()
+			message = smtpMessage{}
+			reply("250 OK")
+		case command == "QUIT":
+			reply("221 chau")
+			return
+		default:
+			reply("250 OK")
+		}
+	}
+}
+
+func TestSMTPSender(t *testing.T) {
+	server := newSMTPStandIn(t)
+	sender := notifications.NewSMTPSender(notifications.SMTPOptions{
+		Host: "127.0.0.1",
+		Port: server.port(),
+		From: "alertas@crime-map.test",
+	})
+
+	err := sender.Send(context.Background(), &entities.Notification{
+		ID:     "notification-1",
+		Target: "vecina@example.com",
+		Title:  "Nuevo delito en Casa",
+		Body:   "ROBO en Av. Corrientes 1234, CABA el 02/01/2025 15:04",
+	})
+	require.NoError(t, err)
+
+	messages := server.received()
+	require.Len(t, messages, 1)
+	assert.Equal(t, "alertas@crime-map.test", messages[0].from)
+	assert.Equal(t, []string{"vecina@example.com"}, messages[0].to)
+	assert.Contains(t, messages[0].data, "Subject: Nuevo delito en Casa\r\n")
+	assert.Contains(t, messages[0].data, "Message-ID: <notification-1@crime-map>\r\n")
+	assert.Contains(t, messages[0].data, "\r\n\r\nROBO en Av. Corrientes 1234, CABA el 02/01/2025 15:04\r\n")
+
+	// Un servidor que no responde se informa como error para reintentar
+	unreachable := notifications.NewSMTPSender(notifications.SMTPOptions{Host: "127.0.0.1", Port: closedPort(t), From: "alertas@crime-map.test"})
+	assert.Error(t, unreachable.Send(context.Background(), &entities.Notification{ID: "n", Target: "vecina@example.com"}))
+}
+
+// closedPort retorna un puerto local en el que no escucha nadie
+func closedPort(t *testing.T) int {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	port := listener.Addr().(*net.TCPAddr).Port
+	require.NoError(t, listener.Close())
+	return port
+}
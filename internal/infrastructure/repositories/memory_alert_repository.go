@@ -0,0 +1,230 @@
+package repositories
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"go-crime_map_backend/internal/domain/entities"
+	"go-crime_map_backend/internal/domain/repositories"
+	"go-crime_map_backend/pkg/spatial"
+)
+
+// watchAreaCellDegrees es el tamaño de las celdas del índice espacial, unos 5 km de lado
+const watchAreaCellDegrees = 0.05
+
+// MemoryAlertRepository implementa el repositorio de zonas vigiladas y avisos en memoria
+type MemoryAlertRepository struct {
+	mu            sync.Mutex
+	areas         map[string]*entities.WatchArea
+	index         *spatial.Grid // Rectángulos de las zonas activas
+	notifications []*memoryNotification
+}
+
+// memoryNotification es un aviso en memoria con su reserva
+type memoryNotification struct {
+	notification entities.Notification
+	lockedUntil  time.Time
+}
+
+// NewMemoryAlertRepository crea una nueva instancia del repositorio en memoria
+func NewMemoryAlertRepository() *MemoryAlertRepository {
+	return &MemoryAlertRepository{
+		areas: make(map[string]*entities.WatchArea),
+		index: spatial.NewGrid(watchAreaCellDegrees),
+	}
+}
+
+// CreateWatchArea guarda una nueva zona
+func (r *MemoryAlertRepository) CreateWatchArea(ctx context.Context, area *entities.WatchArea) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.storeArea(area)
+	return nil
+}
+
+// GetWatchArea obtiene una zona por su ID, nil si no existe
+func (r *MemoryAlertRepository) GetWatchArea(ctx context.Context, id string) (*entities.WatchArea, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if area, exists := r.areas[id]; exists {
+		copied := *area
+		return &copied, nil
+	}
+	return nil, nil
+}
+
+// ListWatchAreas obtiene las zonas del usuario ordenadas por fecha de creación
+func (r *MemoryAlertRepository) ListWatchAreas(ctx context.Context, ownerID string) ([]*entities.WatchArea, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	areas := []*entities.WatchArea{}
+	for _, area := range r.areas {
+		if area.OwnerID == ownerID {
+			copied := *area
+			areas = append(areas, &copied)
+		}
+	}
+	sort.Slice(areas, func(i, j int) bool {
+		return areas[i].CreatedAt.Before(areas[j].CreatedAt)
+	})
+	return areas, nil
+}
+
+// UpdateWatchArea reemplaza los datos de la zona
+func (r *MemoryAlertRepository) UpdateWatchArea(ctx context.Context, area *entities.WatchArea) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, exists := r.areas[area.ID]; exists {
+		r.storeArea(area)
+	}
+	return nil
+}
+
+// DeleteWatchArea elimina la zona y sus avisos. Retorna false si no existía
+func (r *MemoryAlertRepository) DeleteWatchArea(ctx context.Context, id string) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, exists := r.areas[id]; !exists {
+		return false, nil
+	}
+	delete(r.areas, id)
+	r.index.Remove(id)
+	kept := r.notifications[:0]
+	for _, stored := range r.notifications {
+		if stored.notification.AreaID != id {
+			kept = append(kept, stored)
+		}
+	}
+	r.notifications = kept
+	return true, nil
+}
+
+// FindWatchAreas obtiene las zonas activas cuyo rectángulo contiene la ubicación
+func (r *MemoryAlertRepository) FindWatchAreas(ctx context.Context, location entities.Location) ([]*entities.WatchArea, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	areas := []*entities.WatchArea{}
+	for _, id := range r.index.Query(location.Longitude, location.Latitude) {
+		copied := *r.areas[id]
+		areas = append(areas, &copied)
+	}
+	return areas, nil
+}
+
+// EnqueueNotifications guarda los avisos ignorando los repetidos
+func (r *MemoryAlertRepository) EnqueueNotifications(ctx context.Context, notifications []*entities.Notification) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, notification := range notifications {
+		if r.hasNotification(notification) {
+			continue
+		}
+		r.notifications = append(r.notifications, &memoryNotification{notification: *notification})
+	}
+	return nil
+}
+
+// ClaimDueNotifications reserva hasta limit avisos pendientes cuyo intento vence en now
+func (r *MemoryAlertRepository) ClaimDueNotifications(ctx context.Context, now time.Time, limit int, lease time.Duration) ([]*entities.Notification, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var due []*memoryNotification
+	for _, stored := range r.notifications {
+		notification := stored.notification
+		if notification.Status != entities.NotificationPending || notification.NextAttemptAt == nil ||
+			notification.NextAttemptAt.After(now) || stored.lockedUntil.After(now) {
+			continue
+		}
+		due = append(due, stored)
+	}
+	sort.SliceStable(due, func(i, j int) bool {
+		return due[i].notification.NextAttemptAt.Before(*due[j].notification.NextAttemptAt)
+	})
+
+	notifications := make([]*entities.Notification, 0, limit)
+	for _, stored := range due {
+		if len(notifications) == limit {
+			break
+		}
+		stored.lockedUntil = now.Add(lease)
+		notification := stored.notification
+		notifications = append(notifications, &notification)
+	}
+	return notifications, nil
+}
+
+// UpdateNotification guarda el resultado de un intento y libera la reserva
+func (r *MemoryAlertRepository) UpdateNotification(ctx context.Context, notification *entities.Notification) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, stored := range r.notifications {
+		if stored.notification.ID == notification.ID {
+			stored.notification = *notification
+			stored.lockedUntil = time.Time{}
+			return nil
+		}
+	}
+	return nil
+}
+
+// ListNotifications obtiene los avisos del usuario, del más reciente al más antiguo
+func (r *MemoryAlertRepository) ListNotifications(ctx context.Context, userID string, filter repositories.NotificationFilter) ([]*entities.Notification, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	notifications := []*entities.Notification{}
+	for i := len(r.notifications) - 1; i >= 0 && (filter.Limit <= 0 || len(notifications) < filter.Limit); i-- {
+		notification := r.notifications[i].notification
+		if notification.UserID != userID ||
+			(filter.Channel != "" && notification.Channel != filter.Channel) ||
+			(filter.UnreadOnly && notification.ReadAt != nil) {
+			continue
+		}
+		notifications = append(notifications, &notification)
+	}
+	return notifications, nil
+}
+
+// MarkNotificationRead marca el aviso del usuario como leído. Retorna false si no existe
+func (r *MemoryAlertRepository) MarkNotificationRead(ctx context.Context, userID, id string, at time.Time) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, stored := range r.notifications {
+		if stored.notification.ID == id && stored.notification.UserID == userID {
+			if stored.notification.ReadAt == nil {
+				stored.notification.ReadAt = &at
+			}
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// storeArea guarda una copia de la zona y actualiza el índice espacial; requiere tener el lock tomado
+func (r *MemoryAlertRepository) storeArea(area *entities.WatchArea) {
+	copied := *area
+	r.areas[area.ID] = &copied
+	if !area.Active {
+		r.index.Remove(area.ID)
+		return
+	}
+	bounds := area.Bounds()
+	r.index.Insert(area.ID, spatial.Rect{
+		MinX: bounds.MinLongitude,
+		MinY: bounds.MinLatitude,
+		MaxX: bounds.MaxLongitude,
+		MaxY: bounds.MaxLatitude,
+	})
+}
+
+// hasNotification indica si ya existe el aviso para la zona, el delito y el canal; requiere tener el lock tomado
+func (r *MemoryAlertRepository) hasNotification(notification *entities.Notification) bool {
+	for _, stored := range r.notifications {
+		existing := stored.notification
+		if existing.AreaID == notification.AreaID && existing.CrimeID == notification.CrimeID && existing.Channel == notification.Channel {
+			return true
+		}
+	}
+	return false
+}
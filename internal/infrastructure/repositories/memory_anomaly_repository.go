@@ -0,0 +1,63 @@
+package repositories
+
+import (
+	"context"
+	"sort"
+
+	"go-crime_map_backend/internal/domain/entities"
+	"go-crime_map_backend/internal/domain/repositories"
+)
+
+// SaveAnomaly guarda la anomalía y los eventos del contexto. Retorna false si ya existía una
+// anomalía para la misma serie y ventana
+func (r *MemoryCrimeRepository) SaveAnomaly(ctx context.Context, anomaly *entities.Anomaly) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, existing := range r.anomalies {
+		if existing.ZoneID == anomaly.ZoneID && existing.Type == anomaly.Type && existing.WindowStart.Equal(anomaly.WindowStart) {
+			return false, nil
+		}
+	}
+	stored := *anomaly
+	r.anomalies = append(r.anomalies, &stored)
+	r.appendOutbox(ctx)
+	return true, nil
+}
+
+// ListAnomalies obtiene las anomalías del filtro, de la ventana más reciente a la más antigua
+func (r *MemoryCrimeRepository) ListAnomalies(ctx context.Context, filter repositories.AnomalyFilter) ([]*entities.Anomaly, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	anomalies := []*entities.Anomaly{}
+	for _, anomaly := range r.anomalies {
+		if filter.ZoneID != "" && anomaly.ZoneID != filter.ZoneID {
+			continue
+		}
+		if filter.Type != "" && anomaly.Type != filter.Type {
+			continue
+		}
+		if !filter.From.IsZero() && anomaly.WindowStart.Before(filter.From) {
+			continue
+		}
+		if !filter.To.IsZero() && !anomaly.WindowStart.Before(filter.To) {
+			continue
+		}
+		copied := *anomaly
+		anomalies = append(anomalies, &copied)
+	}
+	sort.Slice(anomalies, func(i, j int) bool {
+		a, b := anomalies[i], anomalies[j]
+		if !a.WindowStart.Equal(b.WindowStart) {
+			return a.WindowStart.After(b.WindowStart)
+		}
+		if a.ZScore != b.ZScore {
+			return a.ZScore > b.ZScore
+		}
+		return a.ID < b.ID
+	})
+	if filter.Limit > 0 && len(anomalies) > filter.Limit {
+		anomalies = anomalies[:filter.Limit]
+	}
+	return anomalies, nil
+}
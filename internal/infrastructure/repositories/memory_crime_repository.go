@@ -2,21 +2,34 @@ package repositories
 
 import (
 	"context"
+	"math"
+	"sort"
 	"sync"
 
 	"go-crime_map_backend/internal/domain/entities"
+	"go-crime_map_backend/internal/domain/repositories"
 )
 
 // MemoryCrimeRepository implementa el repositorio de delitos en memoria
 type MemoryCrimeRepository struct {
-	mu     sync.RWMutex
-	crimes map[string]*entities.Crime
+	mu      sync.RWMutex
+	crimes  map[string]*entities.Crime
+	history map[string][]*entities.CrimeStatusChange
+	claims  map[string]*entities.ModerationClaim
+	// revisions se conserva al eliminar el delito, como la tabla crime_revisions
+	revisions map[string][]*entities.CrimeRevision
+	// outbox guarda los eventos de dominio en orden de escritura
+	outbox    []*outboxRecord
+	anomalies []*entities.Anomaly
 }
 
 // NewMemoryCrimeRepository crea una nueva instancia del repositorio en memoria
 func NewMemoryCrimeRepository() *MemoryCrimeRepository {
 	return &MemoryCrimeRepository{
-		crimes: make(map[string]*entities.Crime),
+		crimes:    make(map[string]*entities.Crime),
+		history:   make(map[string][]*entities.CrimeStatusChange),
+		claims:    make(map[string]*entities.ModerationClaim),
+		revisions: make(map[string][]*entities.CrimeRevision),
 	}
 }
 
@@ -25,6 +38,8 @@ func (r *MemoryCrimeRepository) Create(ctx context.Context, crime *entities.Crim
 	r.mu.Lock()
 	defer r.mu.Unlock()
 	r.crimes[crime.ID] = crime
+	r.recordRevision(newRevision(ctx, entities.RevisionCreated, crime.ID, nil, snapshot(crime)))
+	r.appendOutbox(ctx)
 	return nil
 }
 
@@ -49,21 +64,141 @@ func (r *MemoryCrimeRepository) GetAll(ctx context.Context) ([]*entities.Crime,
 	return crimes, nil
 }
 
+// List obtiene los delitos que cumplen el filtro, ordenados por fecha descendente
+func (r *MemoryCrimeRepository) List(ctx context.Context, filter repositories.CrimeFilter) ([]*entities.Crime, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	crimes := make([]*entities.Crime, 0, len(r.crimes))
+	for _, crime := range r.crimes {
+		if matchesFilter(crime, filter) {
+			crimes = append(crimes, crime)
+		}
+	}
+	sort.Slice(crimes, func(i, j int) bool {
+		return crimes[i].Date.After(crimes[j].Date)
+	})
+	return crimes, nil
+}
+
+// FindNear obtiene los delitos cuya fecha y coordenadas están dentro de las tolerancias
+// de la consulta
+func (r *MemoryCrimeRepository) FindNear(ctx context.Context, query repositories.NearbyQuery) ([]*entities.Crime, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	var crimes []*entities.Crime
+	for _, crime := range r.crimes {
+		if crime.Date.Before(query.Date.Add(-query.Window)) || crime.Date.After(query.Date.Add(query.Window)) {
+			continue
+		}
+		if math.Abs(crime.Location.Latitude-query.Latitude) > query.Tolerance ||
+			math.Abs(crime.Location.Longitude-query.Longitude) > query.Tolerance {
+			continue
+		}
+		crimes = append(crimes, crime)
+	}
+	return crimes, nil
+}
+
 // Update actualiza un delito existente
 func (r *MemoryCrimeRepository) Update(ctx context.Context, crime *entities.Crime) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
-	if _, exists := r.crimes[crime.ID]; exists {
+	if previous, exists := r.crimes[crime.ID]; exists {
 		r.crimes[crime.ID] = crime
+		r.recordRevision(newRevision(ctx, entities.RevisionUpdated, crime.ID, previous, snapshot(crime)))
+		r.appendOutbox(ctx)
 		return nil
 	}
 	return nil
 }
 
+// UpdateStatus cambia el estado de un delito y registra el cambio en el historial
+func (r *MemoryCrimeRepository) UpdateStatus(ctx context.Context, change *entities.CrimeStatusChange) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	crime, exists := r.crimes[change.CrimeID]
+	if !exists || crime.Status != change.From {
+		return repositories.ErrStatusConflict
+	}
+	updated := *crime
+	updated.Status = change.To
+	updated.UpdatedAt = change.ChangedAt
+	r.crimes[crime.ID] = &updated
+	r.history[crime.ID] = append(r.history[crime.ID], change)
+	r.recordRevision(newRevision(ctx, entities.RevisionStatusChanged, crime.ID, crime, snapshot(&updated)))
+	r.appendOutbox(ctx)
+	// El cambio de estado cierra la revisión y libera la reserva de moderación
+	delete(r.claims, crime.ID)
+	return nil
+}
+
+// GetStatusHistory obtiene el historial de estados de un delito en orden cronológico
+func (r *MemoryCrimeRepository) GetStatusHistory(ctx context.Context, crimeID string) ([]*entities.CrimeStatusChange, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	history := make([]*entities.CrimeStatusChange, len(r.history[crimeID]))
+	copy(history, r.history[crimeID])
+	return history, nil
+}
+
 // Delete elimina un delito por su ID
 func (r *MemoryCrimeRepository) Delete(ctx context.Context, id string) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
+	if previous, exists := r.crimes[id]; exists {
+		r.recordRevision(newRevision(ctx, entities.RevisionDeleted, id, previous, nil))
+		r.appendOutbox(ctx)
+	}
 	delete(r.crimes, id)
+	delete(r.history, id)
+	delete(r.claims, id)
+	return nil
+}
+
+// AssignZones cambia la zona de los delitos indicados sin registrar revisiones
+func (r *MemoryCrimeRepository) AssignZones(ctx context.Context, zoneIDs map[string]string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for crimeID, zoneID := range zoneIDs {
+		if crime, exists := r.crimes[crimeID]; exists {
+			updated := *crime
+			updated.ZoneID = zoneID
+			r.crimes[crimeID] = &updated
+		}
+	}
 	return nil
 }
+
+// matchesFilter indica si un delito cumple los criterios del filtro
+func matchesFilter(crime *entities.Crime, filter repositories.CrimeFilter) bool {
+	if len(filter.Statuses) > 0 && !containsStatus(filter.Statuses, crime.Status) {
+		return false
+	}
+	if len(filter.Types) > 0 && !containsString(filter.Types, crime.Type) {
+		return false
+	}
+	if len(filter.ZoneIDs) > 0 && !containsString(filter.ZoneIDs, crime.ZoneID) {
+		return false
+	}
+	return true
+}
+
+// containsStatus indica si el estado está en la lista
+func containsStatus(statuses []entities.CrimeStatus, status entities.CrimeStatus) bool {
+	for _, s := range statuses {
+		if s == status {
+			return true
+		}
+	}
+	return false
+}
+
+// containsString indica si el valor está en la lista
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
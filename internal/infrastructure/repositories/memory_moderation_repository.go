@@ -0,0 +1,108 @@
+package repositories
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"go-crime_map_backend/internal/domain/entities"
+	"go-crime_map_backend/internal/domain/repositories"
+)
+
+// ListPending obtiene los delitos reportados con su reserva vigente, del más antiguo al más reciente
+// o por prioridad si el filtro la indica
+func (r *MemoryCrimeRepository) ListPending(ctx context.Context, filter repositories.ModerationQueueFilter) ([]*entities.ModerationItem, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	items := []*entities.ModerationItem{}
+	for _, crime := range r.crimes {
+		if crime.Status != entities.CrimeStatusReported {
+			continue
+		}
+		claim := r.activeClaim(crime.ID, filter.Now)
+		if filter.AvailableTo != "" && claim != nil && claim.ModeratorID != filter.AvailableTo {
+			continue
+		}
+		items = append(items, &entities.ModerationItem{Crime: crime, Claim: claim})
+	}
+	priority := func(item *entities.ModerationItem) int {
+		if p, ok := filter.Priorities[item.Crime.Type]; ok {
+			return p
+		}
+		return filter.DefaultPriority
+	}
+	sort.Slice(items, func(i, j int) bool {
+		if filter.Priorities != nil {
+			if pi, pj := priority(items[i]), priority(items[j]); pi != pj {
+				return pi > pj
+			}
+		}
+		if !items[i].Crime.CreatedAt.Equal(items[j].Crime.CreatedAt) {
+			return items[i].Crime.CreatedAt.Before(items[j].Crime.CreatedAt)
+		}
+		return items[i].Crime.ID < items[j].Crime.ID
+	})
+	if len(items) > filter.Limit {
+		items = items[:filter.Limit]
+	}
+	return items, nil
+}
+
+// GetClaim obtiene la reserva vigente de un delito, nil si no tiene
+func (r *MemoryCrimeRepository) GetClaim(ctx context.Context, crimeID string, now time.Time) (*entities.ModerationClaim, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.activeClaim(crimeID, now), nil
+}
+
+// Claim reserva un delito pendiente si está libre, vencido o ya era del mismo moderador
+func (r *MemoryCrimeRepository) Claim(ctx context.Context, claim *entities.ModerationClaim) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	crime, exists := r.crimes[claim.CrimeID]
+	if !exists || crime.Status != entities.CrimeStatusReported {
+		return repositories.ErrClaimConflict
+	}
+	if current := r.activeClaim(claim.CrimeID, claim.ClaimedAt); current != nil && current.ModeratorID != claim.ModeratorID {
+		return repositories.ErrClaimConflict
+	}
+	stored := *claim
+	r.claims[claim.CrimeID] = &stored
+	return nil
+}
+
+// ReleaseClaim libera la reserva del moderador
+func (r *MemoryCrimeRepository) ReleaseClaim(ctx context.Context, crimeID, moderatorID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	claim, exists := r.claims[crimeID]
+	if !exists || claim.ModeratorID != moderatorID {
+		return repositories.ErrClaimConflict
+	}
+	delete(r.claims, crimeID)
+	return nil
+}
+
+// ReleaseExpiredClaims libera las reservas vencidas y retorna cuántas liberó
+func (r *MemoryCrimeRepository) ReleaseExpiredClaims(ctx context.Context, now time.Time) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var released int64
+	for crimeID, claim := range r.claims {
+		if claim.IsExpired(now) {
+			delete(r.claims, crimeID)
+			released++
+		}
+	}
+	return released, nil
+}
+
+// activeClaim retorna una copia de la reserva vigente del delito; requiere tener el lock tomado
+func (r *MemoryCrimeRepository) activeClaim(crimeID string, now time.Time) *entities.ModerationClaim {
+	claim, exists := r.claims[crimeID]
+	if !exists || claim.IsExpired(now) {
+		return nil
+	}
+	copied := *claim
+	return &copied
+}
@@ -0,0 +1,108 @@
+package repositories
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"go-crime_map_backend/internal/domain/events"
+	"go-crime_map_backend/internal/domain/repositories"
+)
+
+// outboxRecord es un evento del outbox en memoria con su estado de entrega
+type outboxRecord struct {
+	event         events.Event
+	attempts      int
+	nextAttemptAt *time.Time // nil cuando el evento se abandonó
+	lockedUntil   time.Time
+	dispatchedAt  *time.Time
+	lastError     string
+}
+
+// ClaimPending reserva hasta limit eventos listos para entregar en now, en orden de ocurrencia
+func (r *MemoryCrimeRepository) ClaimPending(ctx context.Context, now time.Time, limit int, lease time.Duration) ([]*repositories.OutboxEntry, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	entries := make([]*repositories.OutboxEntry, 0, limit)
+	for _, record := range r.outbox {
+		if len(entries) == limit {
+			break
+		}
+		if record.dispatchedAt != nil || record.nextAttemptAt == nil ||
+			record.nextAttemptAt.After(now) || record.lockedUntil.After(now) {
+			continue
+		}
+		record.lockedUntil = now.Add(lease)
+		entries = append(entries, &repositories.OutboxEntry{Event: record.event, Attempts: record.attempts})
+	}
+	return entries, nil
+}
+
+// MarkDispatched registra que el evento se entregó a todos los handlers
+func (r *MemoryCrimeRepository) MarkDispatched(ctx context.Context, eventID string, at time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if record := r.findOutboxRecord(eventID); record != nil {
+		record.dispatchedAt = &at
+		record.lockedUntil = time.Time{}
+	}
+	return nil
+}
+
+// MarkFailed registra una entrega fallida. Si nextAttemptAt es nil el evento no se reintenta
+func (r *MemoryCrimeRepository) MarkFailed(ctx context.Context, eventID string, attempts int, nextAttemptAt *time.Time, lastError string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if record := r.findOutboxRecord(eventID); record != nil {
+		record.attempts = attempts
+		record.nextAttemptAt = nextAttemptAt
+		record.lastError = lastError
+		record.lockedUntil = time.Time{}
+	}
+	return nil
+}
+
+// ListAfter obtiene hasta limit eventos posteriores al cursor en orden de ocurrencia
+func (r *MemoryCrimeRepository) ListAfter(ctx context.Context, after repositories.OutboxCursor, limit int) ([]events.Event, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	list := make([]events.Event, 0, len(r.outbox))
+	for _, record := range r.outbox {
+		if outboxBefore(after, record.event) {
+			list = append(list, record.event)
+		}
+	}
+	sort.Slice(list, func(i, j int) bool {
+		return outboxBefore(repositories.OutboxCursor{OccurredAt: list[i].OccurredAt, EventID: list[i].ID}, list[j])
+	})
+	if len(list) > limit {
+		list = list[:limit]
+	}
+	return list, nil
+}
+
+// outboxBefore indica si el cursor está antes del evento en el orden del outbox
+func outboxBefore(cursor repositories.OutboxCursor, event events.Event) bool {
+	if !event.OccurredAt.Equal(cursor.OccurredAt) {
+		return event.OccurredAt.After(cursor.OccurredAt)
+	}
+	return event.ID > cursor.EventID
+}
+
+// appendOutbox guarda los eventos del contexto en el outbox; requiere tener el lock tomado
+func (r *MemoryCrimeRepository) appendOutbox(ctx context.Context) {
+	for _, event := range events.FromContext(ctx) {
+		occurredAt := event.OccurredAt
+		r.outbox = append(r.outbox, &outboxRecord{event: event, nextAttemptAt: &occurredAt})
+	}
+}
+
+// findOutboxRecord busca un evento del outbox por su ID; requiere tener el lock tomado
+func (r *MemoryCrimeRepository) findOutboxRecord(eventID string) *outboxRecord {
+	for _, record := range r.outbox {
+		if record.event.ID == eventID {
+			return record
+		}
+	}
+	return nil
+}
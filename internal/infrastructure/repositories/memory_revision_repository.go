@@ -0,0 +1,43 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"go-crime_map_backend/internal/domain/entities"
+)
+
+// GetRevisions obtiene las revisiones de un delito ordenadas por versión
+func (r *MemoryCrimeRepository) GetRevisions(ctx context.Context, crimeID string) ([]*entities.CrimeRevision, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	revisions := make([]*entities.CrimeRevision, len(r.revisions[crimeID]))
+	copy(revisions, r.revisions[crimeID])
+	return revisions, nil
+}
+
+// GetRevisionAt obtiene la última revisión de un delito registrada hasta at, nil si no existe
+func (r *MemoryCrimeRepository) GetRevisionAt(ctx context.Context, crimeID string, at time.Time) (*entities.CrimeRevision, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	var found *entities.CrimeRevision
+	for _, revision := range r.revisions[crimeID] {
+		if revision.RecordedAt.After(at) {
+			break
+		}
+		found = revision
+	}
+	return found, nil
+}
+
+// recordRevision agrega la revisión con la versión siguiente; requiere tener el lock tomado
+func (r *MemoryCrimeRepository) recordRevision(revision *entities.CrimeRevision) {
+	revision.Version = len(r.revisions[revision.CrimeID]) + 1
+	r.revisions[revision.CrimeID] = append(r.revisions[revision.CrimeID], revision)
+}
+
+// snapshot copia el delito para que la revisión no cambie si se modifica el original
+func snapshot(crime *entities.Crime) *entities.Crime {
+	copied := *crime
+	return &copied
+}
@@ -0,0 +1,190 @@
+package repositories
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"go-crime_map_backend/internal/domain/entities"
+	"go-crime_map_backend/internal/domain/repositories"
+)
+
+// CountByZone cuenta los delitos del filtro por zona y tipo
+func (r *MemoryCrimeRepository) CountByZone(ctx context.Context, filter repositories.StatsFilter) ([]repositories.ZoneTypeCount, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	type key struct{ zoneID, crimeType string }
+	counts := make(map[key]*repositories.ZoneTypeCount)
+	for _, crime := range r.crimes {
+		if crime.ZoneID == "" || !matchesStatsFilter(crime, filter) {
+			continue
+		}
+		k := key{crime.ZoneID, crime.Type}
+		count, found := counts[k]
+		if !found {
+			count = &repositories.ZoneTypeCount{ZoneID: crime.ZoneID, Type: crime.Type}
+			counts[k] = count
+		}
+		count.Count++
+		count.Weight += crime.Severity.Weight()
+	}
+
+	result := make([]repositories.ZoneTypeCount, 0, len(counts))
+	for _, count := range counts {
+		result = append(result, *count)
+	}
+	return result, nil
+}
+
+// CountByBucket cuenta los delitos del filtro por intervalo en la zona horaria indicada
+func (r *MemoryCrimeRepository) CountByBucket(ctx context.Context, filter repositories.StatsFilter, bucket entities.TimeBucket, location *time.Location) ([]repositories.BucketCount, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	counts := make(map[int64]*repositories.BucketCount)
+	for _, crime := range r.crimes {
+		if !matchesStatsFilter(crime, filter) {
+			continue
+		}
+		start := bucket.Truncate(crime.Date.In(location))
+		count, found := counts[start.Unix()]
+		if !found {
+			count = &repositories.BucketCount{Start: start}
+			counts[start.Unix()] = count
+		}
+		count.Count++
+		count.Weight += crime.Severity.Weight()
+	}
+
+	result := make([]repositories.BucketCount, 0, len(counts))
+	for _, count := range counts {
+		result = append(result, *count)
+	}
+	return result, nil
+}
+
+// CountByWeekdayHour cuenta los delitos del filtro por día de la semana y hora en la zona horaria indicada
+func (r *MemoryCrimeRepository) CountByWeekdayHour(ctx context.Context, filter repositories.StatsFilter, location *time.Location) ([]repositories.WeekdayHourCount, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var counts [7][24]repositories.WeekdayHourCount
+	for _, crime := range r.crimes {
+		if !matchesStatsFilter(crime, filter) {
+			continue
+		}
+		local := crime.Date.In(location)
+		count := &counts[local.Weekday()][local.Hour()]
+		count.Count++
+		count.Weight += crime.Severity.Weight()
+	}
+
+	result := []repositories.WeekdayHourCount{}
+	for weekday, hours := range counts {
+		for hour, count := range hours {
+			if count.Count > 0 {
+				count.Weekday, count.Hour = time.Weekday(weekday), hour
+				result = append(result, count)
+			}
+		}
+	}
+	return result, nil
+}
+
+// ListPoints obtiene la ubicación de los delitos del filtro, de los más recientes a los más antiguos
+func (r *MemoryCrimeRepository) ListPoints(ctx context.Context, filter repositories.StatsFilter, limit int) ([]repositories.CrimePoint, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	crimes := make([]*entities.Crime, 0, len(r.crimes))
+	for _, crime := range r.crimes {
+		if matchesStatsFilter(crime, filter) {
+			crimes = append(crimes, crime)
+		}
+	}
+	sort.Slice(crimes, func(i, j int) bool {
+		if !crimes[i].Date.Equal(crimes[j].Date) {
+			return crimes[i].Date.After(crimes[j].Date)
+		}
+		return crimes[i].ID < crimes[j].ID
+	})
+	if len(crimes) > limit {
+		crimes = crimes[:limit]
+	}
+
+	points := make([]repositories.CrimePoint, len(crimes))
+	for i, crime := range crimes {
+		points[i] = repositories.CrimePoint{
+			ID:       crime.ID,
+			Type:     crime.Type,
+			Severity: crime.Severity,
+			Location: entities.Coordinate{Latitude: crime.Location.Latitude, Longitude: crime.Location.Longitude},
+			Date:     crime.Date,
+		}
+	}
+	return points, nil
+}
+
+// CountByWindow cuenta los delitos del filtro por zona, tipo y ventana desde el inicio del período
+func (r *MemoryCrimeRepository) CountByWindow(ctx context.Context, filter repositories.StatsFilter, window time.Duration) ([]repositories.WindowCount, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	type key struct {
+		window            int
+		zoneID, crimeType string
+	}
+	counts := make(map[key]int)
+	for _, crime := range r.crimes {
+		if !matchesStatsFilter(crime, filter) {
+			continue
+		}
+		counts[key{int(crime.Date.Sub(filter.From) / window), crime.ZoneID, crime.Type}]++
+	}
+
+	result := make([]repositories.WindowCount, 0, len(counts))
+	for k, count := range counts {
+		result = append(result, repositories.WindowCount{Window: k.window, ZoneID: k.zoneID, Type: k.crimeType, Count: count})
+	}
+	return result, nil
+}
+
+// ListPage obtiene una página de los delitos del filtro ordenados por fecha e ID
+func (r *MemoryCrimeRepository) ListPage(ctx context.Context, filter repositories.StatsFilter, after *repositories.CrimeCursor, limit int) ([]*entities.Crime, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	crimes := []*entities.Crime{}
+	for _, crime := range r.crimes {
+		if !matchesStatsFilter(crime, filter) {
+			continue
+		}
+		if after != nil && (crime.Date.Before(after.Date) || crime.Date.Equal(after.Date) && crime.ID <= after.ID) {
+			continue
+		}
+		copied := *crime
+		crimes = append(crimes, &copied)
+	}
+	sort.Slice(crimes, func(i, j int) bool {
+		if !crimes[i].Date.Equal(crimes[j].Date) {
+			return crimes[i].Date.Before(crimes[j].Date)
+		}
+		return crimes[i].ID < crimes[j].ID
+	})
+	if len(crimes) > limit {
+		crimes = crimes[:limit]
+	}
+	return crimes, nil
+}
+
+// matchesStatsFilter indica si el delito cumple el filtro y su fecha está dentro del período
+func matchesStatsFilter(crime *entities.Crime, filter repositories.StatsFilter) bool {
+	if crime.Date.Before(filter.From) || !crime.Date.Before(filter.To) {
+		return false
+	}
+	if filter.BoundingBox != nil && !filter.BoundingBox.Contains(crime.Location) {
+		return false
+	}
+	return matchesFilter(crime, filter.CrimeFilter)
+}
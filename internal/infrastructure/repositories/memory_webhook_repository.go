@@ -0,0 +1,169 @@
+package repositories
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"go-crime_map_backend/internal/domain/entities"
+	"go-crime_map_backend/internal/domain/repositories"
+)
+
+// MemoryWebhookRepository implementa el repositorio de webhooks en memoria
+type MemoryWebhookRepository struct {
+	mu            sync.Mutex
+	subscriptions map[string]*entities.WebhookSubscription
+	deliveries    []*memoryDelivery
+}
+
+// memoryDelivery es una entrega en memoria con su reserva
+type memoryDelivery struct {
+	delivery    entities.WebhookDelivery
+	lockedUntil time.Time
+}
+
+// NewMemoryWebhookRepository crea una nueva instancia del repositorio en memoria
+func NewMemoryWebhookRepository() *MemoryWebhookRepository {
+	return &MemoryWebhookRepository{
+		subscriptions: make(map[string]*entities.WebhookSubscription),
+	}
+}
+
+// CreateSubscription guarda una nueva suscripción
+func (r *MemoryWebhookRepository) CreateSubscription(ctx context.Context, subscription *entities.WebhookSubscription) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	copied := *subscription
+	r.subscriptions[subscription.ID] = &copied
+	return nil
+}
+
+// GetSubscription obtiene una suscripción por su ID, nil si no existe
+func (r *MemoryWebhookRepository) GetSubscription(ctx context.Context, id string) (*entities.WebhookSubscription, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if subscription, exists := r.subscriptions[id]; exists {
+		copied := *subscription
+		return &copied, nil
+	}
+	return nil, nil
+}
+
+// ListSubscriptions obtiene todas las suscripciones ordenadas por fecha de creación
+func (r *MemoryWebhookRepository) ListSubscriptions(ctx context.Context) ([]*entities.WebhookSubscription, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	subscriptions := make([]*entities.WebhookSubscription, 0, len(r.subscriptions))
+	for _, subscription := range r.subscriptions {
+		copied := *subscription
+		subscriptions = append(subscriptions, &copied)
+	}
+	sort.Slice(subscriptions, func(i, j int) bool {
+		return subscriptions[i].CreatedAt.Before(subscriptions[j].CreatedAt)
+	})
+	return subscriptions, nil
+}
+
+// DeleteSubscription elimina la suscripción y sus entregas. Retorna false si no existía
+func (r *MemoryWebhookRepository) DeleteSubscription(ctx context.Context, id string) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, exists := r.subscriptions[id]; !exists {
+		return false, nil
+	}
+	delete(r.subscriptions, id)
+	kept := r.deliveries[:0]
+	for _, stored := range r.deliveries {
+		if stored.delivery.SubscriptionID != id {
+			kept = append(kept, stored)
+		}
+	}
+	r.deliveries = kept
+	return true, nil
+}
+
+// EnqueueDeliveries agrega las entregas pendientes ignorando las repetidas
+func (r *MemoryWebhookRepository) EnqueueDeliveries(ctx context.Context, deliveries []*entities.WebhookDelivery) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, delivery := range deliveries {
+		if r.hasDelivery(delivery.SubscriptionID, delivery.EventID) {
+			continue
+		}
+		r.deliveries = append(r.deliveries, &memoryDelivery{delivery: *delivery})
+	}
+	return nil
+}
+
+// ClaimDueDeliveries reserva hasta limit entregas pendientes cuyo intento vence en now
+func (r *MemoryWebhookRepository) ClaimDueDeliveries(ctx context.Context, now time.Time, limit int, lease time.Duration) ([]*entities.WebhookDelivery, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	deliveries := make([]*entities.WebhookDelivery, 0, limit)
+	for _, stored := range r.deliveries {
+		if len(deliveries) == limit {
+			break
+		}
+		delivery := stored.delivery
+		if delivery.Status != entities.WebhookDeliveryPending || delivery.NextAttemptAt == nil ||
+			delivery.NextAttemptAt.After(now) || stored.lockedUntil.After(now) {
+			continue
+		}
+		stored.lockedUntil = now.Add(lease)
+		deliveries = append(deliveries, &delivery)
+	}
+	return deliveries, nil
+}
+
+// UpdateDelivery guarda el resultado de un intento y libera la reserva
+func (r *MemoryWebhookRepository) UpdateDelivery(ctx context.Context, delivery *entities.WebhookDelivery) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, stored := range r.deliveries {
+		if stored.delivery.ID == delivery.ID {
+			stored.delivery = *delivery
+			stored.lockedUntil = time.Time{}
+			return nil
+		}
+	}
+	return nil
+}
+
+// GetDelivery obtiene una entrega de la suscripción por su ID, nil si no existe
+func (r *MemoryWebhookRepository) GetDelivery(ctx context.Context, subscriptionID, deliveryID string) (*entities.WebhookDelivery, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, stored := range r.deliveries {
+		if stored.delivery.ID == deliveryID && stored.delivery.SubscriptionID == subscriptionID {
+			delivery := stored.delivery
+			return &delivery, nil
+		}
+	}
+	return nil, nil
+}
+
+// ListDeliveries obtiene las entregas de la suscripción, de la más reciente a la más antigua
+func (r *MemoryWebhookRepository) ListDeliveries(ctx context.Context, subscriptionID string, filter repositories.WebhookDeliveryFilter) ([]*entities.WebhookDelivery, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	deliveries := []*entities.WebhookDelivery{}
+	for i := len(r.deliveries) - 1; i >= 0 && (filter.Limit <= 0 || len(deliveries) < filter.Limit); i-- {
+		delivery := r.deliveries[i].delivery
+		if delivery.SubscriptionID != subscriptionID || (filter.Status != "" && delivery.Status != filter.Status) {
+			continue
+		}
+		deliveries = append(deliveries, &delivery)
+	}
+	return deliveries, nil
+}
+
+// hasDelivery indica si ya existe la entrega del evento a la suscripción; requiere tener el lock tomado
+func (r *MemoryWebhookRepository) hasDelivery(subscriptionID, eventID string) bool {
+	for _, stored := range r.deliveries {
+		if stored.delivery.SubscriptionID == subscriptionID && stored.delivery.EventID == eventID {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,114 @@
+package repositories
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"go-crime_map_backend/internal/domain/entities"
+	"go-crime_map_backend/pkg/spatial"
+)
+
+// zoneCellDegrees es el tamaño de las celdas del índice espacial de zonas, unos 2 km de lado
+const zoneCellDegrees = 0.02
+
+// MemoryZoneRepository implementa el repositorio de zonas en memoria
+type MemoryZoneRepository struct {
+	mu    sync.Mutex
+	zones map[string]*entities.Zone
+	index *spatial.Grid
+}
+
+// NewMemoryZoneRepository crea una nueva instancia del repositorio en memoria
+func NewMemoryZoneRepository() *MemoryZoneRepository {
+	return &MemoryZoneRepository{
+		zones: make(map[string]*entities.Zone),
+		index: spatial.NewGrid(zoneCellDegrees),
+	}
+}
+
+// CreateZone guarda una nueva zona
+func (r *MemoryZoneRepository) CreateZone(ctx context.Context, zone *entities.Zone) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.storeZone(zone)
+	return nil
+}
+
+// GetZone obtiene una zona por su ID, nil si no existe
+func (r *MemoryZoneRepository) GetZone(ctx context.Context, id string) (*entities.Zone, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if zone, exists := r.zones[id]; exists {
+		copied := *zone
+		return &copied, nil
+	}
+	return nil, nil
+}
+
+// ListZones obtiene las zonas del tipo indicado, o todas si kind está vacío, ordenadas por tipo y nombre
+func (r *MemoryZoneRepository) ListZones(ctx context.Context, kind string) ([]*entities.Zone, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	zones := []*entities.Zone{}
+	for _, zone := range r.zones {
+		if kind == "" || zone.Kind == kind {
+			copied := *zone
+			zones = append(zones, &copied)
+		}
+	}
+	sort.Slice(zones, func(i, j int) bool {
+		if zones[i].Kind != zones[j].Kind {
+			return zones[i].Kind < zones[j].Kind
+		}
+		return zones[i].Name < zones[j].Name
+	})
+	return zones, nil
+}
+
+// UpdateZone reemplaza los datos de la zona
+func (r *MemoryZoneRepository) UpdateZone(ctx context.Context, zone *entities.Zone) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, exists := r.zones[zone.ID]; exists {
+		r.storeZone(zone)
+	}
+	return nil
+}
+
+// DeleteZone elimina la zona. Retorna false si no existía
+func (r *MemoryZoneRepository) DeleteZone(ctx context.Context, id string) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, exists := r.zones[id]; !exists {
+		return false, nil
+	}
+	delete(r.zones, id)
+	r.index.Remove(id)
+	return true, nil
+}
+
+// FindZones obtiene las zonas cuyo rectángulo contiene la ubicación
+func (r *MemoryZoneRepository) FindZones(ctx context.Context, location entities.Location) ([]*entities.Zone, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	zones := []*entities.Zone{}
+	for _, id := range r.index.Query(location.Longitude, location.Latitude) {
+		copied := *r.zones[id]
+		zones = append(zones, &copied)
+	}
+	return zones, nil
+}
+
+// storeZone guarda una copia de la zona y actualiza el índice espacial; requiere tener el lock tomado
+func (r *MemoryZoneRepository) storeZone(zone *entities.Zone) {
+	copied := *zone
+	r.zones[zone.ID] = &copied
+	bounds := zone.Geometry.Bounds()
+	r.index.Insert(zone.ID, spatial.Rect{
+		MinX: bounds.MinLongitude,
+		MinY: bounds.MinLatitude,
+		MaxX: bounds.MaxLongitude,
+		MaxY: bounds.MaxLatitude,
+	})
+}
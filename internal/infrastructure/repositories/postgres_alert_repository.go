@@ -0,0 +1,481 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"go-crime_map_backend/internal/domain/entities"
+	"go-crime_map_backend/internal/domain/repositories"
+
+	"github.com/lib/pq"
+)
+
+const (
+	// insertWatchAreaQuery guarda el rectángulo de la zona en la columna bounds, indexada con GiST
+	insertWatchAreaQuery = `
+		INSERT INTO watch_areas (id, owner_id, name, geometry, bounds, crime_types, quiet_hours, channels, email, webhook_url, active, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, box(point($5, $6), point($7, $8)), $9, $10, $11, $12, $13, $14, $15, $16)`
+
+	updateWatchAreaQuery = `
+		UPDATE watch_areas
+		 SET name = $2, geometry = $3, bounds = box(point($4, $5), point($6, $7)), crime_types = $8,
+			 quiet_hours = $9, channels = $10, email = $11, webhook_url = $12, active = $13, updated_at = $14
+		 WHERE id = $1`
+
+	// selectWatchAreasQuery selecciona las columnas que lee scanWatchArea
+	selectWatchAreasQuery = `
+		SELECT id, owner_id, name, geometry, crime_types, quiet_hours, channels, email, webhook_url, active, created_at, updated_at
+		 FROM watch_areas`
+
+	selectWatchAreaByIDQuery = selectWatchAreasQuery + `
+		 WHERE id = $1`
+
+	listWatchAreasQuery = selectWatchAreasQuery + `
+		 WHERE owner_id = $1
+		 ORDER BY created_at, id`
+
+	// findWatchAreasQuery usa el índice GiST de bounds para descartar las zonas lejanas
+	findWatchAreasQuery = selectWatchAreasQuery + `
+		 WHERE active AND bounds && box(point($1, $2), point($1, $2))
+		 ORDER BY id`
+
+	deleteWatchAreaQuery = `DELETE FROM watch_areas WHERE id = $1`
+
+	// insertNotificationQuery ignora el aviso si ya se generó para la zona, el delito y el canal
+	insertNotificationQuery = `
+		INSERT INTO notifications (id, user_id, area_id, crime_id, channel, target, title, body, crime, status, attempts, next_attempt_at, created_at, sent_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
+		ON CONFLICT (area_id, crime_id, channel) DO NOTHING`
+
+	// notificationColumns son las columnas que lee scanNotification
+	notificationColumns = `id, user_id, area_id, crime_id, channel, target, title, body, crime, status, attempts, last_error, next_attempt_at, created_at, sent_at, read_at`
+
+	claimNotificationsQuery = `
+		UPDATE notifications
+		 SET locked_until = $3
+		 WHERE id IN (
+			SELECT id FROM notifications
+			 WHERE status = 'pending'
+			   AND next_attempt_at <= $1
+			   AND (locked_until IS NULL OR locked_until <= $1)
+			 ORDER BY next_attempt_at
+			 LIMIT $2
+			 FOR UPDATE SKIP LOCKED
+		 )
+		RETURNING ` + notificationColumns
+
+	updateNotificationQuery = `
+		UPDATE notifications
+		 SET status = $2, attempts = $3, last_error = $4, next_attempt_at = $5, sent_at = $6, locked_until = NULL
+		 WHERE id = $1`
+
+	listNotificationsQuery = `
+		SELECT ` + notificationColumns + `
+		 FROM notifications
+		 WHERE user_id = $1 AND ($2 = '' OR channel = $2) AND (NOT $3 OR read_at IS NULL)
+		 ORDER BY created_at DESC, id
+		 LIMIT $4`
+
+	markNotificationReadQuery = `
+		UPDATE notifications
+		 SET read_at = COALESCE(read_at, $3)
+		 WHERE user_id = $1 AND id = $2`
+)
+
+// watchAreaGeometry es la forma en que se guarda la geometría de una zona en la columna geometry
+type watchAreaGeometry struct {
+	Circle  *entities.Circle `json:"circle,omitempty"`
+	Polygon entities.Polygon `json:"polygon,omitempty"`
+}
+
+// PostgresAlertRepository implementa el repositorio de zonas vigiladas y avisos usando PostgreSQL
+type PostgresAlertRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresAlertRepository crea una nueva instancia del repositorio
+func NewPostgresAlertRepository(db *sql.DB) *PostgresAlertRepository {
+	return &PostgresAlertRepository{
+		db: db,
+	}
+}
+
+// CreateWatchArea guarda una nueva zona
+func (r *PostgresAlertRepository) CreateWatchArea(ctx context.Context, area *entities.WatchArea) error {
+	geometry, quietHours, err := encodeWatchArea(area)
+	if err != nil {
+		return err
+	}
+	bounds := area.Bounds()
+
+	queryCtx, span := startQuerySpan(ctx, "INSERT", "watch_areas", insertWatchAreaQuery)
+	_, err = r.db.ExecContext(queryCtx, insertWatchAreaQuery,
+		area.ID,
+		area.OwnerID,
+		area.Name,
+		geometry,
+		bounds.MinLongitude, bounds.MinLatitude, bounds.MaxLongitude, bounds.MaxLatitude,
+		pq.Array(area.CrimeTypes),
+		quietHours,
+		pq.Array(channelNames(area.Channels)),
+		area.Email,
+		area.WebhookURL,
+		area.Active,
+		area.CreatedAt,
+		area.UpdatedAt,
+	)
+	endSpan(span, err)
+	if err != nil {
+		return fmt.Errorf("error al insertar la zona: %w", err)
+	}
+	return nil
+}
+
+// GetWatchArea obtiene una zona por su ID, nil si no existe
+func (r *PostgresAlertRepository) GetWatchArea(ctx context.Context, id string) (*entities.WatchArea, error) {
+	queryCtx, span := startQuerySpan(ctx, "SELECT", "watch_areas", selectWatchAreaByIDQuery)
+	area, err := scanWatchArea(r.db.QueryRowContext(queryCtx, selectWatchAreaByIDQuery, id))
+	if err == sql.ErrNoRows {
+		endSpan(span, nil)
+		return nil, nil
+	}
+	endSpan(span, err)
+	if err != nil {
+		return nil, fmt.Errorf("error al obtener la zona: %w", err)
+	}
+	return area, nil
+}
+
+// ListWatchAreas obtiene las zonas del usuario ordenadas por fecha de creación
+func (r *PostgresAlertRepository) ListWatchAreas(ctx context.Context, ownerID string) (_ []*entities.WatchArea, err error) {
+	queryCtx, span := startQuerySpan(ctx, "SELECT", "watch_areas", listWatchAreasQuery)
+	defer func() { endSpan(span, err) }()
+
+	rows, err := r.db.QueryContext(queryCtx, listWatchAreasQuery, ownerID)
+	if err != nil {
+		return nil, fmt.Errorf("error al obtener las zonas: %w", err)
+	}
+	defer rows.Close()
+
+	return scanWatchAreas(rows)
+}
+
+// UpdateWatchArea reemplaza los datos de la zona
+func (r *PostgresAlertRepository) UpdateWatchArea(ctx context.Context, area *entities.WatchArea) error {
+	geometry, quietHours, err := encodeWatchArea(area)
+	if err != nil {
+		return err
+	}
+	bounds := area.Bounds()
+
+	queryCtx, span := startQuerySpan(ctx, "UPDATE", "watch_areas", updateWatchAreaQuery)
+	_, err = r.db.ExecContext(queryCtx, updateWatchAreaQuery,
+		area.ID,
+		area.Name,
+		geometry,
+		bounds.MinLongitude, bounds.MinLatitude, bounds.MaxLongitude, bounds.MaxLatitude,
+		pq.Array(area.CrimeTypes),
+		quietHours,
+		pq.Array(channelNames(area.Channels)),
+		area.Email,
+		area.WebhookURL,
+		area.Active,
+		area.UpdatedAt,
+	)
+	endSpan(span, err)
+	if err != nil {
+		return fmt.Errorf("error al actualizar la zona: %w", err)
+	}
+	return nil
+}
+
+// DeleteWatchArea elimina la zona; sus avisos se eliminan en cascada
+func (r *PostgresAlertRepository) DeleteWatchArea(ctx context.Context, id string) (bool, error) {
+	queryCtx, span := startQuerySpan(ctx, "DELETE", "watch_areas", deleteWatchAreaQuery)
+	result, err := r.db.ExecContext(queryCtx, deleteWatchAreaQuery, id)
+	endSpan(span, err)
+	if err != nil {
+		return false, fmt.Errorf("error al eliminar la zona: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("error al eliminar la zona: %w", err)
+	}
+	return affected > 0, nil
+}
+
+// FindWatchAreas obtiene las zonas activas cuyo rectángulo contiene la ubicación
+func (r *PostgresAlertRepository) FindWatchAreas(ctx context.Context, location entities.Location) (_ []*entities.WatchArea, err error) {
+	queryCtx, span := startQuerySpan(ctx, "SELECT", "watch_areas", findWatchAreasQuery)
+	defer func() { endSpan(span, err) }()
+
+	rows, err := r.db.QueryContext(queryCtx, findWatchAreasQuery, location.Longitude, location.Latitude)
+	if err != nil {
+		return nil, fmt.Errorf("error al buscar las zonas: %w", err)
+	}
+	defer rows.Close()
+
+	return scanWatchAreas(rows)
+}
+
+// EnqueueNotifications guarda los avisos ignorando los repetidos
+func (r *PostgresAlertRepository) EnqueueNotifications(ctx context.Context, notifications []*entities.Notification) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("error al iniciar la transacción: %w", err)
+	}
+	defer tx.Rollback()
+
+	for _, notification := range notifications {
+		crime, err := json.Marshal(notification.Crime)
+		if err != nil {
+			return fmt.Errorf("error al serializar el delito del aviso: %w", err)
+		}
+
+		queryCtx, span := startQuerySpan(ctx, "INSERT", "notifications", insertNotificationQuery)
+		_, err = tx.ExecContext(queryCtx, insertNotificationQuery,
+			notification.ID,
+			notification.UserID,
+			notification.AreaID,
+			notification.CrimeID,
+			notification.Channel,
+			notification.Target,
+			notification.Title,
+			notification.Body,
+			crime,
+			notification.Status,
+			notification.Attempts,
+			notification.NextAttemptAt,
+			notification.CreatedAt,
+			notification.SentAt,
+		)
+		endSpan(span, err)
+		if err != nil {
+			return fmt.Errorf("error al guardar el aviso: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("error al confirmar la transacción: %w", err)
+	}
+	return nil
+}
+
+// ClaimDueNotifications reserva hasta limit avisos pendientes cuyo intento vence en now
+func (r *PostgresAlertRepository) ClaimDueNotifications(ctx context.Context, now time.Time, limit int, lease time.Duration) (_ []*entities.Notification, err error) {
+	queryCtx, span := startQuerySpan(ctx, "UPDATE", "notifications", claimNotificationsQuery)
+	defer func() { endSpan(span, err) }()
+
+	rows, err := r.db.QueryContext(queryCtx, claimNotificationsQuery, now, limit, now.Add(lease))
+	if err != nil {
+		return nil, fmt.Errorf("error al reservar los avisos: %w", err)
+	}
+	defer rows.Close()
+
+	notifications, err := scanNotifications(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	// UPDATE ... RETURNING no garantiza el orden de la subconsulta
+	sort.SliceStable(notifications, func(i, j int) bool {
+		return notifications[i].NextAttemptAt.Before(*notifications[j].NextAttemptAt)
+	})
+	return notifications, nil
+}
+
+// UpdateNotification guarda el resultado de un intento y libera la reserva
+func (r *PostgresAlertRepository) UpdateNotification(ctx context.Context, notification *entities.Notification) error {
+	queryCtx, span := startQuerySpan(ctx, "UPDATE", "notifications", updateNotificationQuery)
+	_, err := r.db.ExecContext(queryCtx, updateNotificationQuery,
+		notification.ID,
+		notification.Status,
+		notification.Attempts,
+		notification.LastError,
+		notification.NextAttemptAt,
+		notification.SentAt,
+	)
+	endSpan(span, err)
+	if err != nil {
+		return fmt.Errorf("error al actualizar el aviso: %w", err)
+	}
+	return nil
+}
+
+// ListNotifications obtiene los avisos del usuario, del más reciente al más antiguo
+func (r *PostgresAlertRepository) ListNotifications(ctx context.Context, userID string, filter repositories.NotificationFilter) (_ []*entities.Notification, err error) {
+	queryCtx, span := startQuerySpan(ctx, "SELECT", "notifications", listNotificationsQuery)
+	defer func() { endSpan(span, err) }()
+
+	limit := sql.NullInt64{Int64: int64(filter.Limit), Valid: filter.Limit > 0}
+	rows, err := r.db.QueryContext(queryCtx, listNotificationsQuery, userID, string(filter.Channel), filter.UnreadOnly, limit)
+	if err != nil {
+		return nil, fmt.Errorf("error al obtener los avisos: %w", err)
+	}
+	defer rows.Close()
+
+	return scanNotifications(rows)
+}
+
+// MarkNotificationRead marca el aviso del usuario como leído. Retorna false si no existe
+func (r *PostgresAlertRepository) MarkNotificationRead(ctx context.Context, userID, id string, at time.Time) (bool, error) {
+	queryCtx, span := startQuerySpan(ctx, "UPDATE", "notifications", markNotificationReadQuery)
+	result, err := r.db.ExecContext(queryCtx, markNotificationReadQuery, userID, id, at)
+	endSpan(span, err)
+	if err != nil {
+		return false, fmt.Errorf("error al marcar el aviso como leído: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("error al marcar el aviso como leído: %w", err)
+	}
+	return affected > 0, nil
+}
+
+// encodeWatchArea serializa la geometría y la franja silenciosa de la zona
+func encodeWatchArea(area *entities.WatchArea) ([]byte, []byte, error) {
+	geometry, err := json.Marshal(watchAreaGeometry{Circle: area.Circle, Polygon: area.Polygon})
+	if err != nil {
+		return nil, nil, fmt.Errorf("error al serializar la geometría de la zona: %w", err)
+	}
+	var quietHours []byte
+	if area.QuietHours != nil {
+		if quietHours, err = json.Marshal(area.QuietHours); err != nil {
+			return nil, nil, fmt.Errorf("error al serializar la franja silenciosa: %w", err)
+		}
+	}
+	return geometry, quietHours, nil
+}
+
+// channelNames convierte los canales a cadenas para guardarlos como arreglo
+func channelNames(channels []entities.NotificationChannel) []string {
+	names := make([]string, len(channels))
+	for i, channel := range channels {
+		names[i] = string(channel)
+	}
+	return names
+}
+
+// scanWatchArea lee una zona con las columnas de selectWatchAreasQuery
+func scanWatchArea(row rowScanner) (*entities.WatchArea, error) {
+	var area entities.WatchArea
+	var geometry, quietHours []byte
+	var channels []string
+
+	err := row.Scan(
+		&area.ID,
+		&area.OwnerID,
+		&area.Name,
+		&geometry,
+		pq.Array(&area.CrimeTypes),
+		&quietHours,
+		pq.Array(&channels),
+		&area.Email,
+		&area.WebhookURL,
+		&area.Active,
+		&area.CreatedAt,
+		&area.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	var shape watchAreaGeometry
+	if err := json.Unmarshal(geometry, &shape); err != nil {
+		return nil, fmt.Errorf("error al leer la geometría de la zona: %w", err)
+	}
+	area.Circle, area.Polygon = shape.Circle, shape.Polygon
+	if quietHours != nil {
+		area.QuietHours = &entities.QuietHours{}
+		if err := json.Unmarshal(quietHours, area.QuietHours); err != nil {
+			return nil, fmt.Errorf("error al leer la franja silenciosa: %w", err)
+		}
+	}
+	for _, channel := range channels {
+		area.Channels = append(area.Channels, entities.NotificationChannel(channel))
+	}
+	return &area, nil
+}
+
+// scanWatchAreas lee todas las zonas de rows
+func scanWatchAreas(rows *sql.Rows) ([]*entities.WatchArea, error) {
+	areas := []*entities.WatchArea{}
+	for rows.Next() {
+		area, err := scanWatchArea(rows)
+		if err != nil {
+			return nil, fmt.Errorf("error al escanear la zona: %w", err)
+		}
+		areas = append(areas, area)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error al iterar las zonas: %w", err)
+	}
+	return areas, nil
+}
+
+// scanNotification lee un aviso con las columnas de notificationColumns
+func scanNotification(row rowScanner) (*entities.Notification, error) {
+	var notification entities.Notification
+	var crime []byte
+	var nextAttemptAt, sentAt, readAt sql.NullTime
+
+	err := row.Scan(
+		&notification.ID,
+		&notification.UserID,
+		&notification.AreaID,
+		&notification.CrimeID,
+		&notification.Channel,
+		&notification.Target,
+		&notification.Title,
+		&notification.Body,
+		&crime,
+		&notification.Status,
+		&notification.Attempts,
+		&notification.LastError,
+		&nextAttemptAt,
+		&notification.CreatedAt,
+		&sentAt,
+		&readAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(crime, &notification.Crime); err != nil {
+		return nil, fmt.Errorf("error al leer el delito del aviso: %w", err)
+	}
+	if nextAttemptAt.Valid {
+		notification.NextAttemptAt = &nextAttemptAt.Time
+	}
+	if sentAt.Valid {
+		notification.SentAt = &sentAt.Time
+	}
+	if readAt.Valid {
+		notification.ReadAt = &readAt.Time
+	}
+	return &notification, nil
+}
+
+// scanNotifications lee todos los avisos de rows
+func scanNotifications(rows *sql.Rows) ([]*entities.Notification, error) {
+	notifications := []*entities.Notification{}
+	for rows.Next() {
+		notification, err := scanNotification(rows)
+		if err != nil {
+			return nil, fmt.Errorf("error al escanear el aviso: %w", err)
+		}
+		notifications = append(notifications, notification)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error al iterar los avisos: %w", err)
+	}
+	return notifications, nil
+}
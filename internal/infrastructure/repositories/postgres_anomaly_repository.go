@@ -0,0 +1,123 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"go-crime_map_backend/internal/domain/entities"
+	"go-crime_map_backend/internal/domain/repositories"
+)
+
+const (
+	// insertAnomalyQuery no hace nada si ya existe una anomalía para la misma serie y ventana
+	insertAnomalyQuery = `
+		INSERT INTO anomalies (id, zone_id, crime_type, window_start, window_end, count, baseline, std_dev, z_score, detected_at)
+		VALUES ($1, NULLIF($2, '')::uuid, $3, $4, $5, $6, $7, $8, $9, $10)
+		ON CONFLICT DO NOTHING
+		RETURNING id`
+
+	listAnomaliesQuery = `
+		SELECT id, COALESCE(zone_id::text, ''), crime_type, window_start, window_end, count, baseline, std_dev, z_score, detected_at
+		 FROM anomalies
+		 WHERE ($1 = '' OR zone_id::text = $1)
+		   AND ($2 = '' OR crime_type = $2)
+		   AND ($3::timestamptz IS NULL OR window_start >= $3)
+		   AND ($4::timestamptz IS NULL OR window_start < $4)
+		 ORDER BY window_start DESC, z_score DESC, id
+		 LIMIT $5`
+)
+
+// SaveAnomaly guarda la anomalía y los eventos del contexto en una transacción. Retorna
+// false si ya existía una anomalía para la misma serie y ventana
+func (r *PostgresCrimeRepository) SaveAnomaly(ctx context.Context, anomaly *entities.Anomaly) (bool, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return false, fmt.Errorf("error al iniciar la transacción: %w", err)
+	}
+	defer tx.Rollback()
+
+	var id string
+	queryCtx, span := startQuerySpan(ctx, "INSERT", "anomalies", insertAnomalyQuery)
+	err = tx.QueryRowContext(queryCtx, insertAnomalyQuery,
+		anomaly.ID,
+		anomaly.ZoneID,
+		anomaly.Type,
+		anomaly.WindowStart,
+		anomaly.WindowEnd,
+		anomaly.Count,
+		anomaly.Baseline,
+		anomaly.StdDev,
+		anomaly.ZScore,
+		anomaly.DetectedAt,
+	).Scan(&id)
+	if errors.Is(err, sql.ErrNoRows) {
+		endSpan(span, nil)
+		return false, nil
+	}
+	endSpan(span, err)
+	if err != nil {
+		return false, fmt.Errorf("error al insertar la anomalía: %w", err)
+	}
+
+	if err := insertOutboxEvents(ctx, tx); err != nil {
+		return false, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return false, fmt.Errorf("error al confirmar la transacción: %w", err)
+	}
+
+	slog.InfoContext(ctx, "anomalía guardada",
+		slog.String("repository", "PostgresCrimeRepository"),
+		slog.String("anomaly_id", anomaly.ID),
+	)
+	return true, nil
+}
+
+// ListAnomalies obtiene las anomalías del filtro, de la ventana más reciente a la más antigua
+func (r *PostgresCrimeRepository) ListAnomalies(ctx context.Context, filter repositories.AnomalyFilter) (_ []*entities.Anomaly, err error) {
+	queryCtx, span := startQuerySpan(ctx, "SELECT", "anomalies", listAnomaliesQuery)
+	defer func() { endSpan(span, err) }()
+
+	var from, to *time.Time
+	if !filter.From.IsZero() {
+		from = &filter.From
+	}
+	if !filter.To.IsZero() {
+		to = &filter.To
+	}
+	rows, err := r.db.QueryContext(queryCtx, listAnomaliesQuery, filter.ZoneID, filter.Type, from, to, filter.Limit)
+	if err != nil {
+		return nil, fmt.Errorf("error al obtener las anomalías: %w", err)
+	}
+	defer rows.Close()
+
+	anomalies := []*entities.Anomaly{}
+	for rows.Next() {
+		anomaly := &entities.Anomaly{}
+		if err := rows.Scan(
+			&anomaly.ID,
+			&anomaly.ZoneID,
+			&anomaly.Type,
+			&anomaly.WindowStart,
+			&anomaly.WindowEnd,
+			&anomaly.Count,
+			&anomaly.Baseline,
+			&anomaly.StdDev,
+			&anomaly.ZScore,
+			&anomaly.DetectedAt,
+		); err != nil {
+			return nil, fmt.Errorf("error al escanear la anomalía: %w", err)
+		}
+		anomalies = append(anomalies, anomaly)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error al iterar las anomalías: %w", err)
+	}
+	return anomalies, nil
+}
@@ -4,11 +4,13 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
-	"log"
+	"log/slog"
+	"time"
 
 	"go-crime_map_backend/internal/domain/entities"
+	"go-crime_map_backend/internal/domain/repositories"
 
-	_ "github.com/lib/pq"
+	"github.com/lib/pq"
 )
 
 const (
@@ -18,8 +20,72 @@ const (
 		RETURNING id`
 
 	insertCrimeQuery = `
-		INSERT INTO crimes (id, type, description, location_id, date, created_at, updated_at) 
+		INSERT INTO crimes (id, type, description, location_id, date, status, zone_id, severity, weapon, violent, victim_count, address_mismatch, created_at, updated_at) 
+		VALUES ($1, $2, $3, $4, $5, $6, NULLIF($7, '')::uuid, $8, $9, $10, $11, $12, $13, $14)`
+
+	// selectCrimesQuery selecciona las columnas que lee scanCrime
+	selectCrimesQuery = `
+		SELECT ` + crimeColumns + `
+		 FROM crimes c
+		 JOIN locations l ON c.location_id = l.id`
+
+	// crimeColumns son las columnas de crimes c y locations l que lee scanCrime
+	crimeColumns = `c.id, c.type, c.description, c.date, c.status, c.zone_id,
+				c.severity, c.weapon, c.violent, c.victim_count, c.address_mismatch, c.created_at, c.updated_at,
+				l.id, l.latitude, l.longitude, l.address`
+
+	selectCrimeByIDQuery = selectCrimesQuery + `
+		 WHERE c.id = $1`
+
+	// lockCrimeQuery bloquea el delito hasta el fin de la transacción para auditar el cambio
+	lockCrimeQuery = selectCrimeByIDQuery + `
+		 FOR UPDATE OF c`
+
+	listCrimesQuery = selectCrimesQuery + `
+		 WHERE (cardinality($1::text[]) = 0 OR c.status = ANY($1))
+		   AND (cardinality($2::text[]) = 0 OR c.type = ANY($2))
+		   AND (cardinality($3::text[]) = 0 OR c.zone_id::text = ANY($3))
+		 ORDER BY c.date DESC`
+
+	// findNearCrimesQuery usa los índices de la fecha y de las coordenadas
+	findNearCrimesQuery = selectCrimesQuery + `
+		 WHERE c.date BETWEEN $1 AND $2
+		   AND l.latitude BETWEEN $3 AND $4
+		   AND l.longitude BETWEEN $5 AND $6`
+
+	updateCrimeStatusQuery = `
+		UPDATE crimes SET status = $1 WHERE id = $2 AND status = $3`
+
+	deleteClaimQuery = `DELETE FROM moderation_claims WHERE crime_id = $1`
+
+	insertStatusChangeQuery = `
+		INSERT INTO crime_status_history (id, crime_id, from_status, to_status, reason, actor_id, changed_at)
 		VALUES ($1, $2, $3, $4, $5, $6, $7)`
+
+	selectStatusHistoryQuery = `
+		SELECT id, crime_id, from_status, to_status, reason, actor_id, changed_at
+		 FROM crime_status_history
+		 WHERE crime_id = $1
+		 ORDER BY changed_at, id`
+
+	selectLocationIDQuery = `SELECT location_id FROM crimes WHERE id = $1`
+
+	updateLocationQuery = `
+		UPDATE locations 
+		 SET latitude = $1, longitude = $2, address = $3
+		 WHERE id = $4`
+
+	updateCrimeQuery = `
+		UPDATE crimes 
+		 SET type = $1, description = $2, date = $3, zone_id = NULLIF($4, '')::uuid,
+		     severity = $5, weapon = $6, violent = $7, victim_count = $8, address_mismatch = $9
+		 WHERE id = $10`
+
+	assignZoneQuery = `UPDATE crimes SET zone_id = NULLIF($2, '')::uuid WHERE id = $1`
+
+	deleteCrimeQuery = `DELETE FROM crimes WHERE id = $1`
+
+	deleteLocationQuery = `DELETE FROM locations WHERE id = $1`
 )
 
 // PostgresCrimeRepository implementa la interfaz CrimeRepository usando PostgreSQL
@@ -43,84 +109,102 @@ func (r *PostgresCrimeRepository) Create(ctx context.Context, crime *entities.Cr
 	defer tx.Rollback()
 
 	var locationID int64
-	err = tx.QueryRowContext(ctx, insertLocationQuery,
+	queryCtx, span := startQuerySpan(ctx, "INSERT", "locations", insertLocationQuery)
+	err = tx.QueryRowContext(queryCtx, insertLocationQuery,
 		crime.Location.Latitude,
 		crime.Location.Longitude,
 		crime.Location.Address,
 		crime.CreatedAt,
 		crime.UpdatedAt,
 	).Scan(&locationID)
+	endSpan(span, err)
 	if err != nil {
 		return fmt.Errorf("error al insertar la ubicación: %w", err)
 	}
 
-	_, err = tx.ExecContext(ctx, insertCrimeQuery,
+	queryCtx, span = startQuerySpan(ctx, "INSERT", "crimes", insertCrimeQuery)
+	_, err = tx.ExecContext(queryCtx, insertCrimeQuery,
 		crime.ID,
 		crime.Type,
 		crime.Description,
 		locationID,
 		crime.Date,
+		crime.Status,
+		crime.ZoneID,
+		crime.Severity,
+		crime.Weapon,
+		crime.Violent,
+		crime.VictimCount,
+		crime.AddressMismatch,
 		crime.CreatedAt,
 		crime.UpdatedAt,
 	)
+	endSpan(span, err)
 	if err != nil {
 		return fmt.Errorf("error al insertar el delito: %w", err)
 	}
 
+	if err := insertRevision(ctx, tx, newRevision(ctx, entities.RevisionCreated, crime.ID, nil, crime)); err != nil {
+		return err
+	}
+
+	if err := insertOutboxEvents(ctx, tx); err != nil {
+		return err
+	}
+
 	if err := tx.Commit(); err != nil {
 		return fmt.Errorf("error al confirmar la transacción: %w", err)
 	}
 
-	log.Printf("[PostgresCrimeRepository] Delito creado exitosamente - ID: %s, Tipo: %s", crime.ID, crime.Type)
+	slog.InfoContext(ctx, "delito creado",
+		slog.String("repository", "PostgresCrimeRepository"),
+		slog.String("crime_id", crime.ID),
+		slog.String("type", crime.Type),
+	)
 
 	return nil
 }
 
 // GetByID obtiene un delito por su ID
 func (r *PostgresCrimeRepository) GetByID(ctx context.Context, id string) (*entities.Crime, error) {
-	var crime entities.Crime
-	var location entities.Location
-	var locationID int64
-
-	err := r.db.QueryRowContext(ctx,
-		`SELECT c.id, c.type, c.description, c.date, c.created_at, c.updated_at,
-				l.id, l.latitude, l.longitude, l.address
-		 FROM crimes c
-		 JOIN locations l ON c.location_id = l.id
-		 WHERE c.id = $1`,
-		id,
-	).Scan(
-		&crime.ID,
-		&crime.Type,
-		&crime.Description,
-		&crime.Date,
-		&crime.CreatedAt,
-		&crime.UpdatedAt,
-		&locationID,
-		&location.Latitude,
-		&location.Longitude,
-		&location.Address,
-	)
+	queryCtx, span := startQuerySpan(ctx, "SELECT", "crimes", selectCrimeByIDQuery)
+	crime, err := scanCrime(r.db.QueryRowContext(queryCtx, selectCrimeByIDQuery, id))
 	if err == sql.ErrNoRows {
+		endSpan(span, nil)
 		return nil, nil
 	}
+	endSpan(span, err)
 	if err != nil {
 		return nil, fmt.Errorf("error al obtener el delito: %w", err)
 	}
 
-	crime.Location = location
-	return &crime, nil
+	return crime, nil
 }
 
 // GetAll obtiene todos los delitos
 func (r *PostgresCrimeRepository) GetAll(ctx context.Context) ([]*entities.Crime, error) {
-	rows, err := r.db.QueryContext(ctx,
-		`SELECT c.id, c.type, c.description, c.date, c.created_at, c.updated_at,
-				l.id, l.latitude, l.longitude, l.address
-		 FROM crimes c
-		 JOIN locations l ON c.location_id = l.id
-		 ORDER BY c.date DESC`,
-	)
+	return r.List(ctx, repositories.CrimeFilter{})
+}
+
+// List obtiene los delitos que cumplen el filtro, ordenados por fecha descendente
+func (r *PostgresCrimeRepository) List(ctx context.Context, filter repositories.CrimeFilter) (_ []*entities.Crime, err error) {
+	queryCtx, span := startQuerySpan(ctx, "SELECT", "crimes", listCrimesQuery)
+	defer func() { endSpan(span, err) }()
+
+	statuses := make([]string, len(filter.Statuses))
+	for i, status := range filter.Statuses {
+		statuses[i] = string(status)
+	}
+	types := filter.Types
+	if types == nil {
+		types = []string{}
+	}
+	zoneIDs := filter.ZoneIDs
+	if zoneIDs == nil {
+		zoneIDs = []string{}
+	}
+
+	rows, err := r.db.QueryContext(queryCtx, listCrimesQuery, pq.Array(statuses), pq.Array(types), pq.Array(zoneIDs))
 	if err != nil {
 		return nil, fmt.Errorf("error al obtener los delitos: %w", err)
 	}
@@ -128,28 +212,11 @@ func (r *PostgresCrimeRepository) GetAll(ctx context.Context) ([]*entities.Crime
 
 	var crimes []*entities.Crime
 	for rows.Next() {
-		var crime entities.Crime
-		var location entities.Location
-		var locationID int64
-
-		err := rows.Scan(
-			&crime.ID,
-			&crime.Type,
-			&crime.Description,
-			&crime.Date,
-			&crime.CreatedAt,
-			&crime.UpdatedAt,
-			&locationID,
-			&location.Latitude,
-			&location.Longitude,
-			&location.Address,
-		)
+		crime, err := scanCrime(rows)
 		if err != nil {
 			return nil, fmt.Errorf("error al escanear el delito: %w", err)
 		}
-
-		crime.Location = location
-		crimes = append(crimes, &crime)
+		crimes = append(crimes, crime)
 	}
 
 	if err = rows.Err(); err != nil {
@@ -159,6 +226,36 @@ func (r *PostgresCrimeRepository) GetAll(ctx context.Context) ([]*entities.Crime
 	return crimes, nil
 }
 
+// FindNear obtiene los delitos cuya fecha y coordenadas están dentro de las tolerancias
+// de la consulta
+func (r *PostgresCrimeRepository) FindNear(ctx context.Context, query repositories.NearbyQuery) (_ []*entities.Crime, err error) {
+	queryCtx, span := startQuerySpan(ctx, "SELECT", "crimes", findNearCrimesQuery)
+	defer func() { endSpan(span, err) }()
+
+	rows, err := r.db.QueryContext(queryCtx, findNearCrimesQuery,
+		query.Date.Add(-query.Window), query.Date.Add(query.Window),
+		query.Latitude-query.Tolerance, query.Latitude+query.Tolerance,
+		query.Longitude-query.Tolerance, query.Longitude+query.Tolerance,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error al buscar delitos cercanos: %w", err)
+	}
+	defer rows.Close()
+
+	var crimes []*entities.Crime
+	for rows.Next() {
+		crime, err := scanCrime(rows)
+		if err != nil {
+			return nil, fmt.Errorf("error al escanear el delito: %w", err)
+		}
+		crimes = append(crimes, crime)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error al iterar los delitos: %w", err)
+	}
+	return crimes, nil
+}
+
 // Update actualiza un delito existente
 func (r *PostgresCrimeRepository) Update(ctx context.Context, crime *entities.Crime) error {
 	tx, err := r.db.BeginTx(ctx, nil)
@@ -167,44 +264,149 @@ func (r *PostgresCrimeRepository) Update(ctx context.Context, crime *entities.Cr
 	}
 	defer tx.Rollback()
 
+	// Obtener la versión actual para la auditoría
+	before, err := lockCrime(ctx, tx, crime.ID)
+	if err != nil {
+		return err
+	}
+	if before == nil {
+		return fmt.Errorf("error al obtener el delito: %w", sql.ErrNoRows)
+	}
+
 	// Obtener el ID de la ubicación actual
 	var locationID int64
-	err = tx.QueryRowContext(ctx,
-		`SELECT location_id FROM crimes WHERE id = $1`,
-		crime.ID,
-	).Scan(&locationID)
+	queryCtx, span := startQuerySpan(ctx, "SELECT", "crimes", selectLocationIDQuery)
+	err = tx.QueryRowContext(queryCtx, selectLocationIDQuery, crime.ID).Scan(&locationID)
+	endSpan(span, err)
 	if err != nil {
 		return fmt.Errorf("error al obtener el ID de la ubicación: %w", err)
 	}
 
 	// Actualizar la ubicación
-	_, err = tx.ExecContext(ctx,
-		`UPDATE locations 
-		 SET latitude = $1, longitude = $2, address = $3
-		 WHERE id = $4`,
+	queryCtx, span = startQuerySpan(ctx, "UPDATE", "locations", updateLocationQuery)
+	_, err = tx.ExecContext(queryCtx, updateLocationQuery,
 		crime.Location.Latitude,
 		crime.Location.Longitude,
 		crime.Location.Address,
 		locationID,
 	)
+	endSpan(span, err)
 	if err != nil {
 		return fmt.Errorf("error al actualizar la ubicación: %w", err)
 	}
 
 	// Actualizar el delito
-	_, err = tx.ExecContext(ctx,
-		`UPDATE crimes 
-		 SET type = $1, description = $2, date = $3
-		 WHERE id = $4`,
+	queryCtx, span = startQuerySpan(ctx, "UPDATE", "crimes", updateCrimeQuery)
+	_, err = tx.ExecContext(queryCtx, updateCrimeQuery,
 		crime.Type,
 		crime.Description,
 		crime.Date,
+		crime.ZoneID,
+		crime.Severity,
+		crime.Weapon,
+		crime.Violent,
+		crime.VictimCount,
+		crime.AddressMismatch,
 		crime.ID,
 	)
+	endSpan(span, err)
 	if err != nil {
 		return fmt.Errorf("error al actualizar el delito: %w", err)
 	}
 
+	after := *before
+	after.Type = crime.Type
+	after.Description = crime.Description
+	after.Location = crime.Location
+	after.Date = crime.Date
+	after.ZoneID = crime.ZoneID
+	after.Severity = crime.Severity
+	after.Weapon = crime.Weapon
+	after.Violent = crime.Violent
+	after.VictimCount = crime.VictimCount
+	after.AddressMismatch = crime.AddressMismatch
+	after.UpdatedAt = time.Now()
+	if err := insertRevision(ctx, tx, newRevision(ctx, entities.RevisionUpdated, crime.ID, before, &after)); err != nil {
+		return err
+	}
+
+	if err := insertOutboxEvents(ctx, tx); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("error al confirmar la transacción: %w", err)
+	}
+
+	return nil
+}
+
+// UpdateStatus cambia el estado de un delito y registra el cambio en el historial
+func (r *PostgresCrimeRepository) UpdateStatus(ctx context.Context, change *entities.CrimeStatusChange) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("error al iniciar la transacción: %w", err)
+	}
+	defer tx.Rollback()
+
+	before, err := lockCrime(ctx, tx, change.CrimeID)
+	if err != nil {
+		return err
+	}
+	if before == nil || before.Status != change.From {
+		return repositories.ErrStatusConflict
+	}
+
+	// Actualizar el estado solo si no cambió desde que se leyó
+	queryCtx, span := startQuerySpan(ctx, "UPDATE", "crimes", updateCrimeStatusQuery)
+	result, err := tx.ExecContext(queryCtx, updateCrimeStatusQuery, change.To, change.CrimeID, change.From)
+	endSpan(span, err)
+	if err != nil {
+		return fmt.Errorf("error al actualizar el estado del delito: %w", err)
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("error al actualizar el estado del delito: %w", err)
+	}
+	if affected == 0 {
+		return repositories.ErrStatusConflict
+	}
+
+	// El cambio de estado cierra la revisión y libera la reserva de moderación
+	queryCtx, span = startQuerySpan(ctx, "DELETE", "moderation_claims", deleteClaimQuery)
+	_, err = tx.ExecContext(queryCtx, deleteClaimQuery, change.CrimeID)
+	endSpan(span, err)
+	if err != nil {
+		return fmt.Errorf("error al liberar la reserva de moderación: %w", err)
+	}
+
+	// Registrar el cambio en el historial
+	queryCtx, span = startQuerySpan(ctx, "INSERT", "crime_status_history", insertStatusChangeQuery)
+	_, err = tx.ExecContext(queryCtx, insertStatusChangeQuery,
+		change.ID,
+		change.CrimeID,
+		change.From,
+		change.To,
+		change.Reason,
+		change.ActorID,
+		change.ChangedAt,
+	)
+	endSpan(span, err)
+	if err != nil {
+		return fmt.Errorf("error al registrar el cambio de estado: %w", err)
+	}
+
+	after := *before
+	after.Status = change.To
+	after.UpdatedAt = change.ChangedAt
+	if err := insertRevision(ctx, tx, newRevision(ctx, entities.RevisionStatusChanged, change.CrimeID, before, &after)); err != nil {
+		return err
+	}
+
+	if err := insertOutboxEvents(ctx, tx); err != nil {
+		return err
+	}
+
 	if err := tx.Commit(); err != nil {
 		return fmt.Errorf("error al confirmar la transacción: %w", err)
 	}
@@ -212,6 +414,42 @@ func (r *PostgresCrimeRepository) Update(ctx context.Context, crime *entities.Cr
 	return nil
 }
 
+// GetStatusHistory obtiene el historial de estados de un delito en orden cronológico
+func (r *PostgresCrimeRepository) GetStatusHistory(ctx context.Context, crimeID string) (_ []*entities.CrimeStatusChange, err error) {
+	queryCtx, span := startQuerySpan(ctx, "SELECT", "crime_status_history", selectStatusHistoryQuery)
+	defer func() { endSpan(span, err) }()
+
+	rows, err := r.db.QueryContext(queryCtx, selectStatusHistoryQuery, crimeID)
+	if err != nil {
+		return nil, fmt.Errorf("error al obtener el historial de estados: %w", err)
+	}
+	defer rows.Close()
+
+	history := []*entities.CrimeStatusChange{}
+	for rows.Next() {
+		var change entities.CrimeStatusChange
+		err := rows.Scan(
+			&change.ID,
+			&change.CrimeID,
+			&change.From,
+			&change.To,
+			&change.Reason,
+			&change.ActorID,
+			&change.ChangedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("error al escanear el cambio de estado: %w", err)
+		}
+		history = append(history, &change)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error al iterar el historial de estados: %w", err)
+	}
+
+	return history, nil
+}
+
 // Delete elimina un delito por su ID
 func (r *PostgresCrimeRepository) Delete(ctx context.Context, id string) error {
 	tx, err := r.db.BeginTx(ctx, nil)
@@ -220,34 +458,48 @@ func (r *PostgresCrimeRepository) Delete(ctx context.Context, id string) error {
 	}
 	defer tx.Rollback()
 
+	// Obtener la última versión para la auditoría
+	before, err := lockCrime(ctx, tx, id)
+	if err != nil {
+		return err
+	}
+	if before == nil {
+		return fmt.Errorf("error al obtener el delito: %w", sql.ErrNoRows)
+	}
+
 	// Obtener el ID de la ubicación
 	var locationID int64
-	err = tx.QueryRowContext(ctx,
-		`SELECT location_id FROM crimes WHERE id = $1`,
-		id,
-	).Scan(&locationID)
+	queryCtx, span := startQuerySpan(ctx, "SELECT", "crimes", selectLocationIDQuery)
+	err = tx.QueryRowContext(queryCtx, selectLocationIDQuery, id).Scan(&locationID)
+	endSpan(span, err)
 	if err != nil {
 		return fmt.Errorf("error al obtener el ID de la ubicación: %w", err)
 	}
 
 	// Eliminar el delito
-	_, err = tx.ExecContext(ctx,
-		`DELETE FROM crimes WHERE id = $1`,
-		id,
-	)
+	queryCtx, span = startQuerySpan(ctx, "DELETE", "crimes", deleteCrimeQuery)
+	_, err = tx.ExecContext(queryCtx, deleteCrimeQuery, id)
+	endSpan(span, err)
 	if err != nil {
 		return fmt.Errorf("error al eliminar el delito: %w", err)
 	}
 
 	// Eliminar la ubicación
-	_, err = tx.ExecContext(ctx,
-		`DELETE FROM locations WHERE id = $1`,
-		locationID,
-	)
+	queryCtx, span = startQuerySpan(ctx, "DELETE", "locations", deleteLocationQuery)
+	_, err = tx.ExecContext(queryCtx, deleteLocationQuery, locationID)
+	endSpan(span, err)
 	if err != nil {
 		return fmt.Errorf("error al eliminar la ubicación: %w", err)
 	}
 
+	if err := insertRevision(ctx, tx, newRevision(ctx, entities.RevisionDeleted, id, before, nil)); err != nil {
+		return err
+	}
+
+	if err := insertOutboxEvents(ctx, tx); err != nil {
+		return err
+	}
+
 	if err := tx.Commit(); err != nil {
 		return fmt.Errorf("error al confirmar la transacción: %w", err)
 	}
@@ -255,6 +507,35 @@ func (r *PostgresCrimeRepository) Delete(ctx context.Context, id string) error {
 	return nil
 }
 
+// AssignZones cambia la zona de los delitos indicados sin registrar revisiones
+func (r *PostgresCrimeRepository) AssignZones(ctx context.Context, zoneIDs map[string]string) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("error al iniciar la transacción: %w", err)
+	}
+	defer tx.Rollback()
+
+	stmt, err := tx.PrepareContext(ctx, assignZoneQuery)
+	if err != nil {
+		return fmt.Errorf("error al preparar la asignación de zonas: %w", err)
+	}
+	defer stmt.Close()
+
+	for crimeID, zoneID := range zoneIDs {
+		queryCtx, span := startQuerySpan(ctx, "UPDATE", "crimes", assignZoneQuery)
+		_, err = stmt.ExecContext(queryCtx, crimeID, zoneID)
+		endSpan(span, err)
+		if err != nil {
+			return fmt.Errorf("error al asignar la zona del delito: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("error al confirmar la transacción: %w", err)
+	}
+	return nil
+}
+
 // DeleteAll elimina todos los registros de la base de datos
 func (r *PostgresCrimeRepository) DeleteAll() error {
 	query := `
@@ -265,6 +546,45 @@ func (r *PostgresCrimeRepository) DeleteAll() error {
 	return err
 }
 
+// rowScanner abstrae *sql.Row y *sql.Rows para compartir el escaneo de delitos
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+// scanCrime lee un delito con las columnas de crimeColumns; extra recibe
+// las columnas adicionales que la consulta seleccione a continuación
+func scanCrime(row rowScanner, extra ...any) (*entities.Crime, error) {
+	var crime entities.Crime
+	var locationID int64
+	var zoneID sql.NullString
+
+	dest := []any{
+		&crime.ID,
+		&crime.Type,
+		&crime.Description,
+		&crime.Date,
+		&crime.Status,
+		&zoneID,
+		&crime.Severity,
+		&crime.Weapon,
+		&crime.Violent,
+		&crime.VictimCount,
+		&crime.AddressMismatch,
+		&crime.CreatedAt,
+		&crime.UpdatedAt,
+		&locationID,
+		&crime.Location.Latitude,
+		&crime.Location.Longitude,
+		&crime.Location.Address,
+	}
+	if err := row.Scan(append(dest, extra...)...); err != nil {
+		return nil, err
+	}
+	crime.ZoneID = zoneID.String
+
+	return &crime, nil
+}
+
 // Close cierra la conexión a la base de datos
 func (r *PostgresCrimeRepository) Close() error {
 	return r.db.Close()
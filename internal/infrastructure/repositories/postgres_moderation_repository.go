@@ -0,0 +1,198 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"go-crime_map_backend/internal/domain/entities"
+	"go-crime_map_backend/internal/domain/repositories"
+
+	"github.com/lib/pq"
+)
+
+const (
+	// listPendingFrom selecciona los delitos reportados con su reserva vigente en $1; si $2 no
+	// es vacío excluye los reservados por otros moderadores
+	listPendingFrom = `
+		SELECT ` + crimeColumns + `,
+				mc.moderator_id, mc.claimed_at, mc.expires_at
+		 FROM crimes c
+		 JOIN locations l ON c.location_id = l.id
+		 LEFT JOIN moderation_claims mc ON mc.crime_id = c.id AND mc.expires_at > $1`
+
+	listPendingWhere = `
+		 WHERE c.status = 'reported'
+		   AND ($2::text = '' OR mc.moderator_id IS NULL OR mc.moderator_id = $2::text)`
+
+	// listPendingByAgeQuery recorre idx_crimes_moderation_queue y corta en $3
+	listPendingByAgeQuery = listPendingFrom + listPendingWhere + `
+		 ORDER BY c.created_at, c.id
+		 LIMIT $3`
+
+	// listPendingByPriorityQuery toma la prioridad de cada tipo de los arreglos $4 y $5,
+	// con $6 para los tipos no listados
+	listPendingByPriorityQuery = listPendingFrom + `
+		 LEFT JOIN unnest($4::text[], $5::int[]) AS p(type, priority) ON p.type = c.type` + listPendingWhere + `
+		 ORDER BY COALESCE(p.priority, $6) DESC, c.created_at, c.id
+		 LIMIT $3`
+
+	selectClaimQuery = `
+		SELECT crime_id, moderator_id, claimed_at, expires_at
+		 FROM moderation_claims
+		 WHERE crime_id = $1 AND expires_at > $2`
+
+	// upsertClaimQuery solo reserva delitos reportados y solo reemplaza reservas
+	// vencidas o del mismo moderador
+	upsertClaimQuery = `
+		INSERT INTO moderation_claims (crime_id, moderator_id, claimed_at, expires_at)
+		SELECT id, $2, $3, $4 FROM crimes WHERE id = $1 AND status = 'reported'
+		ON CONFLICT (crime_id) DO UPDATE
+		 SET moderator_id = EXCLUDED.moderator_id,
+		     claimed_at = EXCLUDED.claimed_at,
+		     expires_at = EXCLUDED.expires_at
+		 WHERE moderation_claims.moderator_id = EXCLUDED.moderator_id
+		    OR moderation_claims.expires_at <= EXCLUDED.claimed_at`
+
+	releaseClaimQuery = `DELETE FROM moderation_claims WHERE crime_id = $1 AND moderator_id = $2`
+
+	releaseExpiredClaimsQuery = `DELETE FROM moderation_claims WHERE expires_at <= $1`
+)
+
+// ListPending obtiene los delitos reportados con su reserva vigente, del más antiguo al más reciente
+// o por prioridad si el filtro la indica. El orden y el límite se aplican en la consulta
+func (r *PostgresCrimeRepository) ListPending(ctx context.Context, filter repositories.ModerationQueueFilter) (_ []*entities.ModerationItem, err error) {
+	query := listPendingByAgeQuery
+	args := []any{filter.Now, filter.AvailableTo, filter.Limit}
+	if filter.Priorities != nil {
+		types := make([]string, 0, len(filter.Priorities))
+		priorities := make([]int64, 0, len(filter.Priorities))
+		for crimeType, priority := range filter.Priorities {
+			types = append(types, crimeType)
+			priorities = append(priorities, int64(priority))
+		}
+		query = listPendingByPriorityQuery
+		args = append(args, pq.Array(types), pq.Array(priorities), filter.DefaultPriority)
+	}
+
+	queryCtx, span := startQuerySpan(ctx, "SELECT", "crimes", query)
+	defer func() { endSpan(span, err) }()
+
+	rows, err := r.db.QueryContext(queryCtx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("error al obtener la cola de moderación: %w", err)
+	}
+	defer rows.Close()
+
+	items := []*entities.ModerationItem{}
+	for rows.Next() {
+		var moderatorID sql.NullString
+		var claimedAt, expiresAt sql.NullTime
+		crime, err := scanCrime(rows, &moderatorID, &claimedAt, &expiresAt)
+		if err != nil {
+			return nil, fmt.Errorf("error al escanear el delito: %w", err)
+		}
+
+		item := &entities.ModerationItem{Crime: crime}
+		if moderatorID.Valid {
+			item.Claim = &entities.ModerationClaim{
+				CrimeID:     crime.ID,
+				ModeratorID: moderatorID.String,
+				ClaimedAt:   claimedAt.Time,
+				ExpiresAt:   expiresAt.Time,
+			}
+		}
+		items = append(items, item)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error al iterar la cola de moderación: %w", err)
+	}
+
+	return items, nil
+}
+
+// GetClaim obtiene la reserva vigente de un delito, nil si no tiene
+func (r *PostgresCrimeRepository) GetClaim(ctx context.Context, crimeID string, now time.Time) (*entities.ModerationClaim, error) {
+	queryCtx, span := startQuerySpan(ctx, "SELECT", "moderation_claims", selectClaimQuery)
+	var claim entities.ModerationClaim
+	err := r.db.QueryRowContext(queryCtx, selectClaimQuery, crimeID, now).Scan(
+		&claim.CrimeID,
+		&claim.ModeratorID,
+		&claim.ClaimedAt,
+		&claim.ExpiresAt,
+	)
+	if err == sql.ErrNoRows {
+		endSpan(span, nil)
+		return nil, nil
+	}
+	endSpan(span, err)
+	if err != nil {
+		return nil, fmt.Errorf("error al obtener la reserva de moderación: %w", err)
+	}
+
+	return &claim, nil
+}
+
+// Claim reserva un delito pendiente si está libre, vencido o ya era del mismo moderador
+func (r *PostgresCrimeRepository) Claim(ctx context.Context, claim *entities.ModerationClaim) error {
+	queryCtx, span := startQuerySpan(ctx, "INSERT", "moderation_claims", upsertClaimQuery)
+	result, err := r.db.ExecContext(queryCtx, upsertClaimQuery,
+		claim.CrimeID,
+		claim.ModeratorID,
+		claim.ClaimedAt,
+		claim.ExpiresAt,
+	)
+	endSpan(span, err)
+	if err != nil {
+		return fmt.Errorf("error al reservar el delito: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("error al reservar el delito: %w", err)
+	}
+	if affected == 0 {
+		return repositories.ErrClaimConflict
+	}
+
+	return nil
+}
+
+// ReleaseClaim libera la reserva del moderador
+func (r *PostgresCrimeRepository) ReleaseClaim(ctx context.Context, crimeID, moderatorID string) error {
+	queryCtx, span := startQuerySpan(ctx, "DELETE", "moderation_claims", releaseClaimQuery)
+	result, err := r.db.ExecContext(queryCtx, releaseClaimQuery, crimeID, moderatorID)
+	endSpan(span, err)
+	if err != nil {
+		return fmt.Errorf("error al liberar la reserva: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("error al liberar la reserva: %w", err)
+	}
+	if affected == 0 {
+		return repositories.ErrClaimConflict
+	}
+
+	return nil
+}
+
+// ReleaseExpiredClaims libera las reservas vencidas y retorna cuántas liberó
+func (r *PostgresCrimeRepository) ReleaseExpiredClaims(ctx context.Context, now time.Time) (int64, error) {
+	queryCtx, span := startQuerySpan(ctx, "DELETE", "moderation_claims", releaseExpiredClaimsQuery)
+	result, err := r.db.ExecContext(queryCtx, releaseExpiredClaimsQuery, now)
+	endSpan(span, err)
+	if err != nil {
+		return 0, fmt.Errorf("error al liberar las reservas vencidas: %w", err)
+	}
+
+	released, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("error al liberar las reservas vencidas: %w", err)
+	}
+
+	return released, nil
+}
@@ -0,0 +1,187 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"time"
+
+	"go-crime_map_backend/internal/domain/events"
+	"go-crime_map_backend/internal/domain/repositories"
+)
+
+const (
+	insertOutboxEventQuery = `
+		INSERT INTO outbox_events (id, event_type, aggregate_id, actor_id, request_id, payload, occurred_at, next_attempt_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $7)`
+
+	// claimOutboxEventsQuery reserva los eventos listos; SKIP LOCKED permite que varias
+	// instancias del despachador trabajen en paralelo sin tomar los mismos eventos
+	claimOutboxEventsQuery = `
+		UPDATE outbox_events
+		 SET locked_until = $3
+		 WHERE id IN (
+			SELECT id FROM outbox_events
+			 WHERE dispatched_at IS NULL
+			   AND next_attempt_at <= $1
+			   AND (locked_until IS NULL OR locked_until <= $1)
+			 ORDER BY occurred_at
+			 LIMIT $2
+			 FOR UPDATE SKIP LOCKED
+		 )
+		RETURNING id, event_type, aggregate_id, actor_id, request_id, payload, occurred_at, attempts`
+
+	// listOutboxEventsQuery lee los eventos posteriores al cursor, entregados o no; el ID
+	// se compara como texto para admitir el cursor vacío
+	listOutboxEventsQuery = `
+		SELECT id, event_type, aggregate_id, actor_id, request_id, payload, occurred_at
+		 FROM outbox_events
+		 WHERE occurred_at >= $1
+		   AND (occurred_at, id::text) > ($1, $2)
+		 ORDER BY occurred_at, id::text
+		 LIMIT $3`
+
+	markOutboxEventDispatchedQuery = `
+		UPDATE outbox_events
+		 SET dispatched_at = $2, locked_until = NULL
+		 WHERE id = $1`
+
+	markOutboxEventFailedQuery = `
+		UPDATE outbox_events
+		 SET attempts = $2, next_attempt_at = $3, last_error = $4, locked_until = NULL
+		 WHERE id = $1`
+)
+
+// ClaimPending reserva hasta limit eventos listos para entregar en now, en orden de ocurrencia
+func (r *PostgresCrimeRepository) ClaimPending(ctx context.Context, now time.Time, limit int, lease time.Duration) (_ []*repositories.OutboxEntry, err error) {
+	queryCtx, span := startQuerySpan(ctx, "UPDATE", "outbox_events", claimOutboxEventsQuery)
+	defer func() { endSpan(span, err) }()
+
+	rows, err := r.db.QueryContext(queryCtx, claimOutboxEventsQuery, now, limit, now.Add(lease))
+	if err != nil {
+		return nil, fmt.Errorf("error al reservar los eventos del outbox: %w", err)
+	}
+	defer rows.Close()
+
+	entries := []*repositories.OutboxEntry{}
+	for rows.Next() {
+		var entry repositories.OutboxEntry
+		var payload []byte
+		err := rows.Scan(
+			&entry.Event.ID,
+			&entry.Event.Type,
+			&entry.Event.AggregateID,
+			&entry.Event.ActorID,
+			&entry.Event.RequestID,
+			&payload,
+			&entry.Event.OccurredAt,
+			&entry.Attempts,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("error al escanear el evento del outbox: %w", err)
+		}
+		entry.Event.Payload = payload
+		entries = append(entries, &entry)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error al iterar los eventos del outbox: %w", err)
+	}
+
+	// UPDATE ... RETURNING no garantiza el orden de la subconsulta
+	sortOutboxEntries(entries)
+	return entries, nil
+}
+
+// MarkDispatched registra que el evento se entregó a todos los handlers
+func (r *PostgresCrimeRepository) MarkDispatched(ctx context.Context, eventID string, at time.Time) error {
+	queryCtx, span := startQuerySpan(ctx, "UPDATE", "outbox_events", markOutboxEventDispatchedQuery)
+	_, err := r.db.ExecContext(queryCtx, markOutboxEventDispatchedQuery, eventID, at)
+	endSpan(span, err)
+	if err != nil {
+		return fmt.Errorf("error al marcar el evento como entregado: %w", err)
+	}
+	return nil
+}
+
+// MarkFailed registra una entrega fallida. Si nextAttemptAt es nil el evento no se reintenta
+func (r *PostgresCrimeRepository) MarkFailed(ctx context.Context, eventID string, attempts int, nextAttemptAt *time.Time, lastError string) error {
+	var next sql.NullTime
+	if nextAttemptAt != nil {
+		next = sql.NullTime{Time: *nextAttemptAt, Valid: true}
+	}
+
+	queryCtx, span := startQuerySpan(ctx, "UPDATE", "outbox_events", markOutboxEventFailedQuery)
+	_, err := r.db.ExecContext(queryCtx, markOutboxEventFailedQuery, eventID, attempts, next, lastError)
+	endSpan(span, err)
+	if err != nil {
+		return fmt.Errorf("error al registrar la entrega fallida: %w", err)
+	}
+	return nil
+}
+
+// ListAfter obtiene hasta limit eventos posteriores al cursor en orden de ocurrencia
+func (r *PostgresCrimeRepository) ListAfter(ctx context.Context, after repositories.OutboxCursor, limit int) (_ []events.Event, err error) {
+	queryCtx, span := startQuerySpan(ctx, "SELECT", "outbox_events", listOutboxEventsQuery)
+	defer func() { endSpan(span, err) }()
+
+	rows, err := r.db.QueryContext(queryCtx, listOutboxEventsQuery, after.OccurredAt, after.EventID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("error al leer los eventos del outbox: %w", err)
+	}
+	defer rows.Close()
+
+	list := []events.Event{}
+	for rows.Next() {
+		var event events.Event
+		var payload []byte
+		err := rows.Scan(
+			&event.ID,
+			&event.Type,
+			&event.AggregateID,
+			&event.ActorID,
+			&event.RequestID,
+			&payload,
+			&event.OccurredAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("error al escanear el evento del outbox: %w", err)
+		}
+		event.Payload = payload
+		list = append(list, event)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error al iterar los eventos del outbox: %w", err)
+	}
+	return list, nil
+}
+
+// insertOutboxEvents guarda los eventos del contexto dentro de la transacción de la escritura
+func insertOutboxEvents(ctx context.Context, tx *sql.Tx) error {
+	for _, event := range events.FromContext(ctx) {
+		queryCtx, span := startQuerySpan(ctx, "INSERT", "outbox_events", insertOutboxEventQuery)
+		_, err := tx.ExecContext(queryCtx, insertOutboxEventQuery,
+			event.ID,
+			event.Type,
+			event.AggregateID,
+			event.ActorID,
+			event.RequestID,
+			[]byte(event.Payload),
+			event.OccurredAt,
+		)
+		endSpan(span, err)
+		if err != nil {
+			return fmt.Errorf("error al registrar el evento %s: %w", event.Type, err)
+		}
+	}
+	return nil
+}
+
+// sortOutboxEntries ordena los eventos por fecha de ocurrencia
+func sortOutboxEntries(entries []*repositories.OutboxEntry) {
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].Event.OccurredAt.Before(entries[j].Event.OccurredAt)
+	})
+}
@@ -0,0 +1,161 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"go-crime_map_backend/internal/domain/entities"
+)
+
+const (
+	// insertRevisionQuery asigna la versión siguiente; el delito está bloqueado por la
+	// transacción, por lo que no hay dos revisiones concurrentes del mismo delito
+	insertRevisionQuery = `
+		INSERT INTO crime_revisions (id, crime_id, version, action, actor_id, actor_role, request_id, changes, snapshot, recorded_at)
+		SELECT $1, $2, COALESCE(MAX(version), 0) + 1, $3, $4, $5, $6, $7, $8, $9
+		 FROM crime_revisions
+		 WHERE crime_id = $2
+		RETURNING version`
+
+	selectRevisionsQuery = `
+		SELECT id, crime_id, version, action, actor_id, actor_role, request_id, changes, snapshot, recorded_at
+		 FROM crime_revisions`
+
+	selectRevisionsByCrimeQuery = selectRevisionsQuery + `
+		 WHERE crime_id = $1
+		 ORDER BY version`
+
+	selectRevisionAtQuery = selectRevisionsQuery + `
+		 WHERE crime_id = $1 AND recorded_at <= $2
+		 ORDER BY version DESC
+		 LIMIT 1`
+)
+
+// GetRevisions obtiene las revisiones de un delito ordenadas por versión
+func (r *PostgresCrimeRepository) GetRevisions(ctx context.Context, crimeID string) (_ []*entities.CrimeRevision, err error) {
+	queryCtx, span := startQuerySpan(ctx, "SELECT", "crime_revisions", selectRevisionsByCrimeQuery)
+	defer func() { endSpan(span, err) }()
+
+	rows, err := r.db.QueryContext(queryCtx, selectRevisionsByCrimeQuery, crimeID)
+	if err != nil {
+		return nil, fmt.Errorf("error al obtener las revisiones: %w", err)
+	}
+	defer rows.Close()
+
+	revisions := []*entities.CrimeRevision{}
+	for rows.Next() {
+		revision, err := scanRevision(rows)
+		if err != nil {
+			return nil, fmt.Errorf("error al escanear la revisión: %w", err)
+		}
+		revisions = append(revisions, revision)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error al iterar las revisiones: %w", err)
+	}
+
+	return revisions, nil
+}
+
+// GetRevisionAt obtiene la última revisión de un delito registrada hasta at, nil si no existe
+func (r *PostgresCrimeRepository) GetRevisionAt(ctx context.Context, crimeID string, at time.Time) (*entities.CrimeRevision, error) {
+	queryCtx, span := startQuerySpan(ctx, "SELECT", "crime_revisions", selectRevisionAtQuery)
+	revision, err := scanRevision(r.db.QueryRowContext(queryCtx, selectRevisionAtQuery, crimeID, at))
+	if err == sql.ErrNoRows {
+		endSpan(span, nil)
+		return nil, nil
+	}
+	endSpan(span, err)
+	if err != nil {
+		return nil, fmt.Errorf("error al obtener la revisión: %w", err)
+	}
+
+	return revision, nil
+}
+
+// lockCrime obtiene el delito bloqueándolo hasta el fin de la transacción, nil si no existe
+func lockCrime(ctx context.Context, tx *sql.Tx, id string) (*entities.Crime, error) {
+	queryCtx, span := startQuerySpan(ctx, "SELECT", "crimes", lockCrimeQuery)
+	crime, err := scanCrime(tx.QueryRowContext(queryCtx, lockCrimeQuery, id))
+	if err == sql.ErrNoRows {
+		endSpan(span, nil)
+		return nil, nil
+	}
+	endSpan(span, err)
+	if err != nil {
+		return nil, fmt.Errorf("error al obtener el delito: %w", err)
+	}
+
+	return crime, nil
+}
+
+// insertRevision registra la revisión dentro de la transacción del cambio que audita
+func insertRevision(ctx context.Context, tx *sql.Tx, revision *entities.CrimeRevision) error {
+	changes, err := json.Marshal(revision.Changes)
+	if err != nil {
+		return fmt.Errorf("error al serializar los cambios: %w", err)
+	}
+	var snapshot []byte
+	if revision.Snapshot != nil {
+		if snapshot, err = json.Marshal(revision.Snapshot); err != nil {
+			return fmt.Errorf("error al serializar el delito: %w", err)
+		}
+	}
+
+	queryCtx, span := startQuerySpan(ctx, "INSERT", "crime_revisions", insertRevisionQuery)
+	err = tx.QueryRowContext(queryCtx, insertRevisionQuery,
+		revision.ID,
+		revision.CrimeID,
+		revision.Action,
+		revision.ActorID,
+		revision.ActorRole,
+		revision.RequestID,
+		changes,
+		snapshot,
+		revision.RecordedAt,
+	).Scan(&revision.Version)
+	endSpan(span, err)
+	if err != nil {
+		return fmt.Errorf("error al registrar la revisión: %w", err)
+	}
+
+	return nil
+}
+
+// scanRevision lee una revisión con las columnas de selectRevisionsQuery
+func scanRevision(row rowScanner) (*entities.CrimeRevision, error) {
+	var revision entities.CrimeRevision
+	var changes, snapshot []byte
+
+	err := row.Scan(
+		&revision.ID,
+		&revision.CrimeID,
+		&revision.Version,
+		&revision.Action,
+		&revision.ActorID,
+		&revision.ActorRole,
+		&revision.RequestID,
+		&changes,
+		&snapshot,
+		&revision.RecordedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(changes, &revision.Changes); err != nil {
+		return nil, fmt.Errorf("error al leer los cambios: %w", err)
+	}
+	if snapshot != nil {
+		revision.Snapshot = &entities.Crime{}
+		if err := json.Unmarshal(snapshot, revision.Snapshot); err != nil {
+			return nil, fmt.Errorf("error al leer el delito: %w", err)
+		}
+	}
+
+	return &revision, nil
+}
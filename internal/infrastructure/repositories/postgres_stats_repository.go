@@ -0,0 +1,258 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go-crime_map_backend/internal/domain/entities"
+	"go-crime_map_backend/internal/domain/repositories"
+
+	"github.com/lib/pq"
+)
+
+const (
+	// statsFromClause filtra los delitos de una estadística usando los parámetros $1 a $9.
+	// El período usa el índice idx_crimes_date
+	statsFromClause = `
+		 FROM crimes c
+		 JOIN locations l ON c.location_id = l.id
+		 WHERE c.date >= $1 AND c.date < $2
+		   AND (cardinality($3::text[]) = 0 OR c.status = ANY($3))
+		   AND (cardinality($4::text[]) = 0 OR c.type = ANY($4))
+		   AND (cardinality($5::text[]) = 0 OR c.zone_id::text = ANY($5))
+		   AND ($6::float8 IS NULL OR (l.latitude BETWEEN $6 AND $8 AND l.longitude BETWEEN $7 AND $9))`
+
+	// severityWeightSum suma los pesos de gravedad; debe coincidir con entities.Severity.Weight
+	severityWeightSum = `
+				SUM(CASE c.severity WHEN 'critical' THEN 8 WHEN 'high' THEN 4 WHEN 'medium' THEN 2 ELSE 1 END)`
+
+	countByZoneQuery = `
+		SELECT c.zone_id, c.type, COUNT(*),` + severityWeightSum + statsFromClause + `
+		   AND c.zone_id IS NOT NULL
+		 GROUP BY c.zone_id, c.type`
+
+	// countByBucketQuery agrupa por la hora local de la zona horaria $11; date_trunc
+	// empieza las semanas el lunes
+	countByBucketQuery = `
+		SELECT date_trunc($10, c.date AT TIME ZONE $11) AS bucket, COUNT(*),` + severityWeightSum + statsFromClause + `
+		 GROUP BY bucket`
+
+	countByWeekdayHourQuery = `
+		SELECT EXTRACT(DOW FROM c.date AT TIME ZONE $10)::int AS weekday,
+				EXTRACT(HOUR FROM c.date AT TIME ZONE $10)::int AS hour,
+				COUNT(*),` + severityWeightSum + statsFromClause + `
+		 GROUP BY weekday, hour`
+
+	// countByWindowQuery numera las ventanas de $10 segundos desde el inicio del período
+	countByWindowQuery = `
+		SELECT floor(extract(epoch FROM c.date - $1) / $10)::int AS window_index,
+				COALESCE(c.zone_id::text, ''), c.type, COUNT(*)` + statsFromClause + `
+		 GROUP BY window_index, c.zone_id, c.type`
+
+	listPointsQuery = `
+		SELECT c.id, c.type, c.severity, l.latitude, l.longitude, c.date` + statsFromClause + `
+		 ORDER BY c.date DESC, c.id
+		 LIMIT $10`
+
+	// listPageQuery continúa el recorrido después del delito ($10, $11); con $10 NULL empieza
+	// por el primero
+	listPageQuery = `
+		SELECT ` + crimeColumns + statsFromClause + `
+		   AND ($10::timestamptz IS NULL OR (c.date, c.id) > ($10, $11::uuid))
+		 ORDER BY c.date, c.id
+		 LIMIT $12`
+)
+
+// CountByZone cuenta los delitos del filtro por zona y tipo
+func (r *PostgresCrimeRepository) CountByZone(ctx context.Context, filter repositories.StatsFilter) (_ []repositories.ZoneTypeCount, err error) {
+	queryCtx, span := startQuerySpan(ctx, "SELECT", "crimes", countByZoneQuery)
+	defer func() { endSpan(span, err) }()
+
+	rows, err := r.db.QueryContext(queryCtx, countByZoneQuery, statsFilterArgs(filter)...)
+	if err != nil {
+		return nil, fmt.Errorf("error al contar los delitos por zona: %w", err)
+	}
+	defer rows.Close()
+
+	counts := []repositories.ZoneTypeCount{}
+	for rows.Next() {
+		var count repositories.ZoneTypeCount
+		if err := rows.Scan(&count.ZoneID, &count.Type, &count.Count, &count.Weight); err != nil {
+			return nil, fmt.Errorf("error al escanear el conteo de delitos: %w", err)
+		}
+		counts = append(counts, count)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error al iterar los conteos de delitos: %w", err)
+	}
+	return counts, nil
+}
+
+// CountByBucket cuenta los delitos del filtro por intervalo en la zona horaria indicada
+func (r *PostgresCrimeRepository) CountByBucket(ctx context.Context, filter repositories.StatsFilter, bucket entities.TimeBucket, location *time.Location) (_ []repositories.BucketCount, err error) {
+	queryCtx, span := startQuerySpan(ctx, "SELECT", "crimes", countByBucketQuery)
+	defer func() { endSpan(span, err) }()
+
+	args := append(statsFilterArgs(filter), string(bucket), location.String())
+	rows, err := r.db.QueryContext(queryCtx, countByBucketQuery, args...)
+	if err != nil {
+		return nil, fmt.Errorf("error al contar los delitos por intervalo: %w", err)
+	}
+	defer rows.Close()
+
+	counts := []repositories.BucketCount{}
+	for rows.Next() {
+		var start time.Time
+		var count int
+		var weight float64
+		if err := rows.Scan(&start, &count, &weight); err != nil {
+			return nil, fmt.Errorf("error al escanear el conteo de delitos: %w", err)
+		}
+		// date_trunc retorna la hora local sin zona horaria; se interpreta en location
+		start = time.Date(start.Year(), start.Month(), start.Day(), start.Hour(), 0, 0, 0, location)
+		counts = append(counts, repositories.BucketCount{Start: start, Count: count, Weight: weight})
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error al iterar los conteos de delitos: %w", err)
+	}
+	return counts, nil
+}
+
+// CountByWeekdayHour cuenta los delitos del filtro por día de la semana y hora en la zona horaria indicada
+func (r *PostgresCrimeRepository) CountByWeekdayHour(ctx context.Context, filter repositories.StatsFilter, location *time.Location) (_ []repositories.WeekdayHourCount, err error) {
+	queryCtx, span := startQuerySpan(ctx, "SELECT", "crimes", countByWeekdayHourQuery)
+	defer func() { endSpan(span, err) }()
+
+	args := append(statsFilterArgs(filter), location.String())
+	rows, err := r.db.QueryContext(queryCtx, countByWeekdayHourQuery, args...)
+	if err != nil {
+		return nil, fmt.Errorf("error al contar los delitos por día y hora: %w", err)
+	}
+	defer rows.Close()
+
+	counts := []repositories.WeekdayHourCount{}
+	for rows.Next() {
+		var count repositories.WeekdayHourCount
+		if err := rows.Scan(&count.Weekday, &count.Hour, &count.Count, &count.Weight); err != nil {
+			return nil, fmt.Errorf("error al escanear el conteo de delitos: %w", err)
+		}
+		counts = append(counts, count)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error al iterar los conteos de delitos: %w", err)
+	}
+	return counts, nil
+}
+
+// ListPoints obtiene la ubicación de los delitos del filtro, de los más recientes a los más antiguos
+func (r *PostgresCrimeRepository) ListPoints(ctx context.Context, filter repositories.StatsFilter, limit int) (_ []repositories.CrimePoint, err error) {
+	queryCtx, span := startQuerySpan(ctx, "SELECT", "crimes", listPointsQuery)
+	defer func() { endSpan(span, err) }()
+
+	args := append(statsFilterArgs(filter), limit)
+	rows, err := r.db.QueryContext(queryCtx, listPointsQuery, args...)
+	if err != nil {
+		return nil, fmt.Errorf("error al obtener las ubicaciones de los delitos: %w", err)
+	}
+	defer rows.Close()
+
+	points := []repositories.CrimePoint{}
+	for rows.Next() {
+		var point repositories.CrimePoint
+		if err := rows.Scan(&point.ID, &point.Type, &point.Severity, &point.Location.Latitude, &point.Location.Longitude, &point.Date); err != nil {
+			return nil, fmt.Errorf("error al escanear la ubicación del delito: %w", err)
+		}
+		points = append(points, point)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error al iterar las ubicaciones de los delitos: %w", err)
+	}
+	return points, nil
+}
+
+// ListPage obtiene una página de los delitos del filtro ordenados por fecha e ID
+func (r *PostgresCrimeRepository) ListPage(ctx context.Context, filter repositories.StatsFilter, after *repositories.CrimeCursor, limit int) (_ []*entities.Crime, err error) {
+	queryCtx, span := startQuerySpan(ctx, "SELECT", "crimes", listPageQuery)
+	defer func() { endSpan(span, err) }()
+
+	var afterDate *time.Time
+	var afterID *string
+	if after != nil {
+		afterDate, afterID = &after.Date, &after.ID
+	}
+	args := append(statsFilterArgs(filter), afterDate, afterID, limit)
+	rows, err := r.db.QueryContext(queryCtx, listPageQuery, args...)
+	if err != nil {
+		return nil, fmt.Errorf("error al obtener la página de delitos: %w", err)
+	}
+	defer rows.Close()
+
+	crimes := []*entities.Crime{}
+	for rows.Next() {
+		crime, err := scanCrime(rows)
+		if err != nil {
+			return nil, err
+		}
+		crimes = append(crimes, crime)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error al iterar los delitos: %w", err)
+	}
+	return crimes, nil
+}
+
+// CountByWindow cuenta los delitos del filtro por zona, tipo y ventana desde el inicio del período
+func (r *PostgresCrimeRepository) CountByWindow(ctx context.Context, filter repositories.StatsFilter, window time.Duration) (_ []repositories.WindowCount, err error) {
+	queryCtx, span := startQuerySpan(ctx, "SELECT", "crimes", countByWindowQuery)
+	defer func() { endSpan(span, err) }()
+
+	args := append(statsFilterArgs(filter), window.Seconds())
+	rows, err := r.db.QueryContext(queryCtx, countByWindowQuery, args...)
+	if err != nil {
+		return nil, fmt.Errorf("error al contar los delitos por ventana: %w", err)
+	}
+	defer rows.Close()
+
+	counts := []repositories.WindowCount{}
+	for rows.Next() {
+		var count repositories.WindowCount
+		if err := rows.Scan(&count.Window, &count.ZoneID, &count.Type, &count.Count); err != nil {
+			return nil, fmt.Errorf("error al escanear el conteo de delitos: %w", err)
+		}
+		counts = append(counts, count)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error al iterar los conteos de delitos: %w", err)
+	}
+	return counts, nil
+}
+
+// statsFilterArgs retorna los parámetros de statsFromClause
+func statsFilterArgs(filter repositories.StatsFilter) []any {
+	statuses := make([]string, len(filter.Statuses))
+	for i, status := range filter.Statuses {
+		statuses[i] = string(status)
+	}
+	types := filter.Types
+	if types == nil {
+		types = []string{}
+	}
+	zoneIDs := filter.ZoneIDs
+	if zoneIDs == nil {
+		zoneIDs = []string{}
+	}
+
+	// Sin rectángulo los límites van como NULL y la condición no filtra
+	var minLat, minLon, maxLat, maxLon *float64
+	if box := filter.BoundingBox; box != nil {
+		minLat, minLon, maxLat, maxLon = &box.MinLatitude, &box.MinLongitude, &box.MaxLatitude, &box.MaxLongitude
+	}
+	return []any{filter.From, filter.To, pq.Array(statuses), pq.Array(types), pq.Array(zoneIDs), minLat, minLon, maxLat, maxLon}
+}
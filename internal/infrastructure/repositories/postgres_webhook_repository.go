@@ -0,0 +1,357 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"time"
+
+	"go-crime_map_backend/internal/domain/entities"
+	"go-crime_map_backend/internal/domain/repositories"
+
+	"github.com/lib/pq"
+)
+
+const (
+	insertWebhookSubscriptionQuery = `
+		INSERT INTO webhook_subscriptions (id, url, event_types, min_latitude, min_longitude, max_latitude, max_longitude, crime_types, secret, created_by, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)`
+
+	// selectWebhookSubscriptionsQuery selecciona las columnas que lee scanWebhookSubscription
+	selectWebhookSubscriptionsQuery = `
+		SELECT id, url, event_types, min_latitude, min_longitude, max_latitude, max_longitude, crime_types, secret, created_by, created_at
+		 FROM webhook_subscriptions`
+
+	selectWebhookSubscriptionByIDQuery = selectWebhookSubscriptionsQuery + `
+		 WHERE id = $1`
+
+	listWebhookSubscriptionsQuery = selectWebhookSubscriptionsQuery + `
+		 ORDER BY created_at, id`
+
+	deleteWebhookSubscriptionQuery = `DELETE FROM webhook_subscriptions WHERE id = $1`
+
+	// insertWebhookDeliveryQuery ignora la entrega si el evento ya se encoló para la suscripción
+	insertWebhookDeliveryQuery = `
+		INSERT INTO webhook_deliveries (id, subscription_id, event_id, event_type, payload, status, attempts, next_attempt_at, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		ON CONFLICT (subscription_id, event_id) DO NOTHING`
+
+	// webhookDeliveryColumns son las columnas que lee scanWebhookDelivery
+	webhookDeliveryColumns = `id, subscription_id, event_id, event_type, payload, status, attempts, next_attempt_at, last_status_code, last_error, created_at, delivered_at`
+
+	claimWebhookDeliveriesQuery = `
+		UPDATE webhook_deliveries
+		 SET locked_until = $3
+		 WHERE id IN (
+			SELECT id FROM webhook_deliveries
+			 WHERE status = 'pending'
+			   AND next_attempt_at <= $1
+			   AND (locked_until IS NULL OR locked_until <= $1)
+			 ORDER BY next_attempt_at
+			 LIMIT $2
+			 FOR UPDATE SKIP LOCKED
+		 )
+		RETURNING ` + webhookDeliveryColumns
+
+	updateWebhookDeliveryQuery = `
+		UPDATE webhook_deliveries
+		 SET status = $2, attempts = $3, next_attempt_at = $4, last_status_code = $5, last_error = $6,
+			 delivered_at = $7, locked_until = NULL
+		 WHERE id = $1`
+
+	selectWebhookDeliveryQuery = `
+		SELECT ` + webhookDeliveryColumns + `
+		 FROM webhook_deliveries
+		 WHERE subscription_id = $1 AND id = $2`
+
+	listWebhookDeliveriesQuery = `
+		SELECT ` + webhookDeliveryColumns + `
+		 FROM webhook_deliveries
+		 WHERE subscription_id = $1 AND ($2 = '' OR status = $2)
+		 ORDER BY created_at DESC, id
+		 LIMIT $3`
+)
+
+// PostgresWebhookRepository implementa el repositorio de webhooks usando PostgreSQL
+type PostgresWebhookRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresWebhookRepository crea una nueva instancia del repositorio
+func NewPostgresWebhookRepository(db *sql.DB) *PostgresWebhookRepository {
+	return &PostgresWebhookRepository{
+		db: db,
+	}
+}
+
+// CreateSubscription guarda una nueva suscripción
+func (r *PostgresWebhookRepository) CreateSubscription(ctx context.Context, subscription *entities.WebhookSubscription) error {
+	var minLat, minLon, maxLat, maxLon sql.NullFloat64
+	if box := subscription.BoundingBox; box != nil {
+		minLat = sql.NullFloat64{Float64: box.MinLatitude, Valid: true}
+		minLon = sql.NullFloat64{Float64: box.MinLongitude, Valid: true}
+		maxLat = sql.NullFloat64{Float64: box.MaxLatitude, Valid: true}
+		maxLon = sql.NullFloat64{Float64: box.MaxLongitude, Valid: true}
+	}
+
+	queryCtx, span := startQuerySpan(ctx, "INSERT", "webhook_subscriptions", insertWebhookSubscriptionQuery)
+	_, err := r.db.ExecContext(queryCtx, insertWebhookSubscriptionQuery,
+		subscription.ID,
+		subscription.URL,
+		pq.Array(subscription.EventTypes),
+		minLat, minLon, maxLat, maxLon,
+		pq.Array(subscription.CrimeTypes),
+		subscription.Secret,
+		subscription.CreatedBy,
+		subscription.CreatedAt,
+	)
+	endSpan(span, err)
+	if err != nil {
+		return fmt.Errorf("error al insertar la suscripción: %w", err)
+	}
+	return nil
+}
+
+// GetSubscription obtiene una suscripción por su ID, nil si no existe
+func (r *PostgresWebhookRepository) GetSubscription(ctx context.Context, id string) (*entities.WebhookSubscription, error) {
+	queryCtx, span := startQuerySpan(ctx, "SELECT", "webhook_subscriptions", selectWebhookSubscriptionByIDQuery)
+	subscription, err := scanWebhookSubscription(r.db.QueryRowContext(queryCtx, selectWebhookSubscriptionByIDQuery, id))
+	if err == sql.ErrNoRows {
+		endSpan(span, nil)
+		return nil, nil
+	}
+	endSpan(span, err)
+	if err != nil {
+		return nil, fmt.Errorf("error al obtener la suscripción: %w", err)
+	}
+	return subscription, nil
+}
+
+// ListSubscriptions obtiene todas las suscripciones ordenadas por fecha de creación
+func (r *PostgresWebhookRepository) ListSubscriptions(ctx context.Context) (_ []*entities.WebhookSubscription, err error) {
+	queryCtx, span := startQuerySpan(ctx, "SELECT", "webhook_subscriptions", listWebhookSubscriptionsQuery)
+	defer func() { endSpan(span, err) }()
+
+	rows, err := r.db.QueryContext(queryCtx, listWebhookSubscriptionsQuery)
+	if err != nil {
+		return nil, fmt.Errorf("error al obtener las suscripciones: %w", err)
+	}
+	defer rows.Close()
+
+	subscriptions := []*entities.WebhookSubscription{}
+	for rows.Next() {
+		subscription, err := scanWebhookSubscription(rows)
+		if err != nil {
+			return nil, fmt.Errorf("error al escanear la suscripción: %w", err)
+		}
+		subscriptions = append(subscriptions, subscription)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error al iterar las suscripciones: %w", err)
+	}
+	return subscriptions, nil
+}
+
+// DeleteSubscription elimina la suscripción; sus entregas se eliminan en cascada
+func (r *PostgresWebhookRepository) DeleteSubscription(ctx context.Context, id string) (bool, error) {
+	queryCtx, span := startQuerySpan(ctx, "DELETE", "webhook_subscriptions", deleteWebhookSubscriptionQuery)
+	result, err := r.db.ExecContext(queryCtx, deleteWebhookSubscriptionQuery, id)
+	endSpan(span, err)
+	if err != nil {
+		return false, fmt.Errorf("error al eliminar la suscripción: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("error al eliminar la suscripción: %w", err)
+	}
+	return affected > 0, nil
+}
+
+// EnqueueDeliveries agrega las entregas pendientes ignorando las repetidas
+func (r *PostgresWebhookRepository) EnqueueDeliveries(ctx context.Context, deliveries []*entities.WebhookDelivery) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("error al iniciar la transacción: %w", err)
+	}
+	defer tx.Rollback()
+
+	for _, delivery := range deliveries {
+		queryCtx, span := startQuerySpan(ctx, "INSERT", "webhook_deliveries", insertWebhookDeliveryQuery)
+		_, err := tx.ExecContext(queryCtx, insertWebhookDeliveryQuery,
+			delivery.ID,
+			delivery.SubscriptionID,
+			delivery.EventID,
+			delivery.EventType,
+			[]byte(delivery.Payload),
+			delivery.Status,
+			delivery.Attempts,
+			delivery.NextAttemptAt,
+			delivery.CreatedAt,
+		)
+		endSpan(span, err)
+		if err != nil {
+			return fmt.Errorf("error al encolar la entrega: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("error al confirmar la transacción: %w", err)
+	}
+	return nil
+}
+
+// ClaimDueDeliveries reserva hasta limit entregas pendientes cuyo intento vence en now
+func (r *PostgresWebhookRepository) ClaimDueDeliveries(ctx context.Context, now time.Time, limit int, lease time.Duration) (_ []*entities.WebhookDelivery, err error) {
+	queryCtx, span := startQuerySpan(ctx, "UPDATE", "webhook_deliveries", claimWebhookDeliveriesQuery)
+	defer func() { endSpan(span, err) }()
+
+	rows, err := r.db.QueryContext(queryCtx, claimWebhookDeliveriesQuery, now, limit, now.Add(lease))
+	if err != nil {
+		return nil, fmt.Errorf("error al reservar las entregas: %w", err)
+	}
+	defer rows.Close()
+
+	deliveries, err := scanWebhookDeliveries(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	// UPDATE ... RETURNING no garantiza el orden de la subconsulta
+	sort.SliceStable(deliveries, func(i, j int) bool {
+		return deliveries[i].NextAttemptAt.Before(*deliveries[j].NextAttemptAt)
+	})
+	return deliveries, nil
+}
+
+// UpdateDelivery guarda el resultado de un intento y libera la reserva
+func (r *PostgresWebhookRepository) UpdateDelivery(ctx context.Context, delivery *entities.WebhookDelivery) error {
+	queryCtx, span := startQuerySpan(ctx, "UPDATE", "webhook_deliveries", updateWebhookDeliveryQuery)
+	_, err := r.db.ExecContext(queryCtx, updateWebhookDeliveryQuery,
+		delivery.ID,
+		delivery.Status,
+		delivery.Attempts,
+		delivery.NextAttemptAt,
+		delivery.LastStatusCode,
+		delivery.LastError,
+		delivery.DeliveredAt,
+	)
+	endSpan(span, err)
+	if err != nil {
+		return fmt.Errorf("error al actualizar la entrega: %w", err)
+	}
+	return nil
+}
+
+// GetDelivery obtiene una entrega de la suscripción por su ID, nil si no existe
+func (r *PostgresWebhookRepository) GetDelivery(ctx context.Context, subscriptionID, deliveryID string) (*entities.WebhookDelivery, error) {
+	queryCtx, span := startQuerySpan(ctx, "SELECT", "webhook_deliveries", selectWebhookDeliveryQuery)
+	delivery, err := scanWebhookDelivery(r.db.QueryRowContext(queryCtx, selectWebhookDeliveryQuery, subscriptionID, deliveryID))
+	if err == sql.ErrNoRows {
+		endSpan(span, nil)
+		return nil, nil
+	}
+	endSpan(span, err)
+	if err != nil {
+		return nil, fmt.Errorf("error al obtener la entrega: %w", err)
+	}
+	return delivery, nil
+}
+
+// ListDeliveries obtiene las entregas de la suscripción, de la más reciente a la más antigua
+func (r *PostgresWebhookRepository) ListDeliveries(ctx context.Context, subscriptionID string, filter repositories.WebhookDeliveryFilter) (_ []*entities.WebhookDelivery, err error) {
+	queryCtx, span := startQuerySpan(ctx, "SELECT", "webhook_deliveries", listWebhookDeliveriesQuery)
+	defer func() { endSpan(span, err) }()
+
+	limit := sql.NullInt64{Int64: int64(filter.Limit), Valid: filter.Limit > 0}
+	rows, err := r.db.QueryContext(queryCtx, listWebhookDeliveriesQuery, subscriptionID, string(filter.Status), limit)
+	if err != nil {
+		return nil, fmt.Errorf("error al obtener las entregas: %w", err)
+	}
+	defer rows.Close()
+
+	return scanWebhookDeliveries(rows)
+}
+
+// scanWebhookSubscription lee una suscripción con las columnas de selectWebhookSubscriptionsQuery
+func scanWebhookSubscription(row rowScanner) (*entities.WebhookSubscription, error) {
+	var subscription entities.WebhookSubscription
+	var minLat, minLon, maxLat, maxLon sql.NullFloat64
+
+	err := row.Scan(
+		&subscription.ID,
+		&subscription.URL,
+		pq.Array(&subscription.EventTypes),
+		&minLat, &minLon, &maxLat, &maxLon,
+		pq.Array(&subscription.CrimeTypes),
+		&subscription.Secret,
+		&subscription.CreatedBy,
+		&subscription.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if minLat.Valid {
+		subscription.BoundingBox = &entities.BoundingBox{
+			MinLatitude:  minLat.Float64,
+			MinLongitude: minLon.Float64,
+			MaxLatitude:  maxLat.Float64,
+			MaxLongitude: maxLon.Float64,
+		}
+	}
+	return &subscription, nil
+}
+
+// scanWebhookDelivery lee una entrega con las columnas de webhookDeliveryColumns
+func scanWebhookDelivery(row rowScanner) (*entities.WebhookDelivery, error) {
+	var delivery entities.WebhookDelivery
+	var payload []byte
+	var nextAttemptAt, deliveredAt sql.NullTime
+
+	err := row.Scan(
+		&delivery.ID,
+		&delivery.SubscriptionID,
+		&delivery.EventID,
+		&delivery.EventType,
+		&payload,
+		&delivery.Status,
+		&delivery.Attempts,
+		&nextAttemptAt,
+		&delivery.LastStatusCode,
+		&delivery.LastError,
+		&delivery.CreatedAt,
+		&deliveredAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	delivery.Payload = payload
+	if nextAttemptAt.Valid {
+		delivery.NextAttemptAt = &nextAttemptAt.Time
+	}
+	if deliveredAt.Valid {
+		delivery.DeliveredAt = &deliveredAt.Time
+	}
+	return &delivery, nil
+}
+
+// scanWebhookDeliveries lee todas las entregas de rows
+func scanWebhookDeliveries(rows *sql.Rows) ([]*entities.WebhookDelivery, error) {
+	deliveries := []*entities.WebhookDelivery{}
+	for rows.Next() {
+		delivery, err := scanWebhookDelivery(rows)
+		if err != nil {
+			return nil, fmt.Errorf("error al escanear la entrega: %w", err)
+		}
+		deliveries = append(deliveries, delivery)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error al iterar las entregas: %w", err)
+	}
+	return deliveries, nil
+}
@@ -0,0 +1,206 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+
+	"go-crime_map_backend/internal/domain/entities"
+)
+
+const (
+	// insertZoneQuery guarda el rectángulo de la zona en la columna bounds, indexada con GiST
+	insertZoneQuery = `
+		INSERT INTO zones (id, name, kind, population, geometry, bounds, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, box(point($6, $7), point($8, $9)), $10, $11)`
+
+	updateZoneQuery = `
+		UPDATE zones
+		 SET name = $2, kind = $3, population = $4, geometry = $5,
+		     bounds = box(point($6, $7), point($8, $9)), updated_at = $10
+		 WHERE id = $1`
+
+	// selectZonesQuery selecciona las columnas que lee scanZone
+	selectZonesQuery = `
+		SELECT id, name, kind, population, geometry, created_at, updated_at
+		 FROM zones`
+
+	selectZoneByIDQuery = selectZonesQuery + `
+		 WHERE id = $1`
+
+	listZonesQuery = selectZonesQuery + `
+		 WHERE ($1 = '' OR kind = $1)
+		 ORDER BY kind, name`
+
+	// findZonesQuery usa el índice GiST de bounds para descartar las zonas lejanas
+	findZonesQuery = selectZonesQuery + `
+		 WHERE bounds && box(point($1, $2), point($1, $2))
+		 ORDER BY id`
+
+	// deleteZoneQuery deja sin zona a los delitos de la zona mediante ON DELETE SET NULL
+	deleteZoneQuery = `DELETE FROM zones WHERE id = $1`
+)
+
+// PostgresZoneRepository implementa el repositorio de zonas usando PostgreSQL
+type PostgresZoneRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresZoneRepository crea una nueva instancia del repositorio
+func NewPostgresZoneRepository(db *sql.DB) *PostgresZoneRepository {
+	return &PostgresZoneRepository{
+		db: db,
+	}
+}
+
+// CreateZone guarda una nueva zona
+func (r *PostgresZoneRepository) CreateZone(ctx context.Context, zone *entities.Zone) error {
+	geometry, err := json.Marshal(zone.Geometry)
+	if err != nil {
+		return fmt.Errorf("error al serializar la geometría de la zona: %w", err)
+	}
+	bounds := zone.Geometry.Bounds()
+
+	queryCtx, span := startQuerySpan(ctx, "INSERT", "zones", insertZoneQuery)
+	_, err = r.db.ExecContext(queryCtx, insertZoneQuery,
+		zone.ID,
+		zone.Name,
+		zone.Kind,
+		zone.Population,
+		geometry,
+		bounds.MinLongitude, bounds.MinLatitude, bounds.MaxLongitude, bounds.MaxLatitude,
+		zone.CreatedAt,
+		zone.UpdatedAt,
+	)
+	endSpan(span, err)
+	if err != nil {
+		return fmt.Errorf("error al insertar la zona: %w", err)
+	}
+	return nil
+}
+
+// GetZone obtiene una zona por su ID, nil si no existe
+func (r *PostgresZoneRepository) GetZone(ctx context.Context, id string) (*entities.Zone, error) {
+	queryCtx, span := startQuerySpan(ctx, "SELECT", "zones", selectZoneByIDQuery)
+	zone, err := scanZone(r.db.QueryRowContext(queryCtx, selectZoneByIDQuery, id))
+	if err == sql.ErrNoRows {
+		endSpan(span, nil)
+		return nil, nil
+	}
+	endSpan(span, err)
+	if err != nil {
+		return nil, fmt.Errorf("error al obtener la zona: %w", err)
+	}
+	return zone, nil
+}
+
+// ListZones obtiene las zonas del tipo indicado, o todas si kind está vacío, ordenadas por tipo y nombre
+func (r *PostgresZoneRepository) ListZones(ctx context.Context, kind string) (_ []*entities.Zone, err error) {
+	queryCtx, span := startQuerySpan(ctx, "SELECT", "zones", listZonesQuery)
+	defer func() { endSpan(span, err) }()
+
+	rows, err := r.db.QueryContext(queryCtx, listZonesQuery, kind)
+	if err != nil {
+		return nil, fmt.Errorf("error al obtener las zonas: %w", err)
+	}
+	defer rows.Close()
+
+	return scanZones(rows)
+}
+
+// UpdateZone reemplaza los datos de la zona
+func (r *PostgresZoneRepository) UpdateZone(ctx context.Context, zone *entities.Zone) error {
+	geometry, err := json.Marshal(zone.Geometry)
+	if err != nil {
+		return fmt.Errorf("error al serializar la geometría de la zona: %w", err)
+	}
+	bounds := zone.Geometry.Bounds()
+
+	queryCtx, span := startQuerySpan(ctx, "UPDATE", "zones", updateZoneQuery)
+	_, err = r.db.ExecContext(queryCtx, updateZoneQuery,
+		zone.ID,
+		zone.Name,
+		zone.Kind,
+		zone.Population,
+		geometry,
+		bounds.MinLongitude, bounds.MinLatitude, bounds.MaxLongitude, bounds.MaxLatitude,
+		zone.UpdatedAt,
+	)
+	endSpan(span, err)
+	if err != nil {
+		return fmt.Errorf("error al actualizar la zona: %w", err)
+	}
+	return nil
+}
+
+// DeleteZone elimina la zona. Retorna false si no existía
+func (r *PostgresZoneRepository) DeleteZone(ctx context.Context, id string) (bool, error) {
+	queryCtx, span := startQuerySpan(ctx, "DELETE", "zones", deleteZoneQuery)
+	result, err := r.db.ExecContext(queryCtx, deleteZoneQuery, id)
+	endSpan(span, err)
+	if err != nil {
+		return false, fmt.Errorf("error al eliminar la zona: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("error al eliminar la zona: %w", err)
+	}
+	return affected > 0, nil
+}
+
+// FindZones obtiene las zonas cuyo rectángulo contiene la ubicación
+func (r *PostgresZoneRepository) FindZones(ctx context.Context, location entities.Location) (_ []*entities.Zone, err error) {
+	queryCtx, span := startQuerySpan(ctx, "SELECT", "zones", findZonesQuery)
+	defer func() { endSpan(span, err) }()
+
+	rows, err := r.db.QueryContext(queryCtx, findZonesQuery, location.Longitude, location.Latitude)
+	if err != nil {
+		return nil, fmt.Errorf("error al buscar las zonas: %w", err)
+	}
+	defer rows.Close()
+
+	return scanZones(rows)
+}
+
+// scanZone lee una zona con las columnas de selectZonesQuery
+func scanZone(row rowScanner) (*entities.Zone, error) {
+	var zone entities.Zone
+	var geometry []byte
+
+	err := row.Scan(
+		&zone.ID,
+		&zone.Name,
+		&zone.Kind,
+		&zone.Population,
+		&geometry,
+		&zone.CreatedAt,
+		&zone.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(geometry, &zone.Geometry); err != nil {
+		return nil, fmt.Errorf("error al leer la geometría de la zona: %w", err)
+	}
+	return &zone, nil
+}
+
+// scanZones lee todas las zonas de rows
+func scanZones(rows *sql.Rows) ([]*entities.Zone, error) {
+	zones := []*entities.Zone{}
+	for rows.Next() {
+		zone, err := scanZone(rows)
+		if err != nil {
+			return nil, fmt.Errorf("error al escanear la zona: %w", err)
+		}
+		zones = append(zones, zone)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error al iterar las zonas: %w", err)
+	}
+	return zones, nil
+}
@@ -0,0 +1,28 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"go-crime_map_backend/internal/domain/entities"
+	"go-crime_map_backend/pkg/requestid"
+
+	"github.com/google/uuid"
+)
+
+// newRevision construye la revisión de un cambio con el actor y la solicitud del contexto.
+// La versión la asigna cada repositorio al guardarla.
+func newRevision(ctx context.Context, action entities.RevisionAction, crimeID string, before, after *entities.Crime) *entities.CrimeRevision {
+	actor := entities.ActorFromContext(ctx)
+	return &entities.CrimeRevision{
+		ID:         uuid.New().String(),
+		CrimeID:    crimeID,
+		Action:     action,
+		ActorID:    actor.ID,
+		ActorRole:  actor.Role,
+		RequestID:  requestid.FromContext(ctx),
+		Changes:    entities.DiffCrimes(before, after),
+		Snapshot:   after,
+		RecordedAt: time.Now(),
+	}
+}
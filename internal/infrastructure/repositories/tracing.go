@@ -0,0 +1,35 @@
+package repositories
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// tracer crea los spans de las sentencias SQL usando el TracerProvider global
+var tracer = otel.Tracer("go-crime_map_backend/internal/infrastructure/repositories")
+
+// startQuerySpan inicia un span para una sentencia SQL siguiendo las convenciones de OpenTelemetry
+func startQuerySpan(ctx context.Context, operation, table, query string) (context.Context, trace.Span) {
+	return tracer.Start(ctx, operation+" "+table,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("db.system", "postgresql"),
+			attribute.String("db.operation.name", operation),
+			attribute.String("db.collection.name", table),
+			attribute.String("db.query.text", query),
+		),
+	)
+}
+
+// endSpan registra el error en el span, si existe, y lo finaliza
+func endSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
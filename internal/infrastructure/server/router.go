@@ -0,0 +1,193 @@
+package server
+
+import (
+	"log/slog"
+	"net/http"
+	"time"
+
+	"go-crime_map_backend/internal/infrastructure/config"
+	"go-crime_map_backend/internal/infrastructure/metrics"
+	crimeHttp "go-crime_map_backend/internal/interfaces/http"
+	"go-crime_map_backend/internal/interfaces/http/middleware"
+	"go-crime_map_backend/internal/interfaces/http/openapi"
+
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin"
+)
+
+// Dependencies agrupa los componentes que necesita el router
+type Dependencies struct {
+	Logger                 *slog.Logger
+	Metrics                *metrics.Metrics
+	RateLimitStore         middleware.RateLimitStore
+	Authenticator          middleware.APIKeyAuthenticator
+	CrimeController        *crimeHttp.CrimeController
+	CrimeQueryController   *crimeHttp.CrimeQueryController
+	CrimeStatusController  *crimeHttp.CrimeStatusController
+	CrimeHistoryController *crimeHttp.CrimeHistoryController
+	CrimeEditController    *crimeHttp.CrimeEditController
+	ModerationController   *crimeHttp.ModerationController
+	WebhookController      *crimeHttp.WebhookController
+	CrimeStreamController  *crimeHttp.CrimeStreamController
+	AlertController        *crimeHttp.AlertController
+	ZoneController         *crimeHttp.ZoneController
+	StatsController        *crimeHttp.StatsController
+	AnomalyController      *crimeHttp.AnomalyController
+	SafetyController       *crimeHttp.SafetyController
+	TileController         *crimeHttp.TileController
+	ExportController       *crimeHttp.ExportController
+	CrimeImportController  *crimeHttp.CrimeImportController
+}
+
+// NewRouter crea el router HTTP con los middlewares y las rutas de la aplicación
+func NewRouter(cfg *config.Config, deps Dependencies) (*gin.Engine, error) {
+	router := gin.New()
+
+	// Solo se confía en las cabeceras X-Forwarded-For de los proxies configurados
+	if err := router.SetTrustedProxies(cfg.TrustedProxies); err != nil {
+		return nil, err
+	}
+
+	// Middlewares globales, CORS responde las solicitudes preflight antes del limitador de solicitudes
+	router.Use(
+		otelgin.Middleware(cfg.Tracing.ServiceName),
+		middleware.RequestID(),
+		middleware.Logger(deps.Logger),
+		middleware.Recovery(deps.Logger),
+		middleware.SecurityHeaders(middleware.SecurityOptions{
+			HSTSMaxAge:            cfg.Security.HSTSMaxAge,
+			HSTSIncludeSubdomains: cfg.Security.HSTSIncludeSubdomains,
+			FrameOptions:          cfg.Security.FrameOptions,
+			ContentSecurityPolicy: cfg.Security.ContentSecurityPolicy,
+		}),
+		middleware.CORS(middleware.CORSOptions{
+			AllowedOrigins:   cfg.CORS.AllowedOrigins,
+			AllowedMethods:   cfg.CORS.AllowedMethods,
+			AllowedHeaders:   cfg.CORS.AllowedHeaders,
+			ExposedHeaders:   cfg.CORS.ExposedHeaders,
+			AllowCredentials: cfg.CORS.AllowCredentials,
+			MaxAge:           cfg.CORS.MaxAge,
+		}),
+		deps.Metrics.GinMiddleware(),
+	)
+
+	// Configurar rutas
+	router.GET("/health", func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{
+			"status": "ok",
+			"time":   time.Now(),
+		})
+	})
+
+	router.GET("/metrics", gin.WrapH(deps.Metrics.Handler()))
+
+	// Documentación de la API
+	router.GET("/openapi.json", openapi.SpecHandler)
+	router.GET("/docs", openapi.DocsHandler)
+
+	// Grupo de rutas para la API v1
+	v1 := router.Group("/api/v1")
+	v1.Use(middleware.Authenticate(deps.Authenticator))
+	if cfg.RateLimit.Enabled {
+		v1.Use(middleware.RateLimit(deps.RateLimitStore, middleware.RateLimitPolicies{
+			Read:  middleware.RateLimitPolicy{Name: "v1-read", Rate: cfg.RateLimit.ReadRate, Burst: cfg.RateLimit.ReadBurst},
+			Write: middleware.RateLimitPolicy{Name: "v1-write", Rate: cfg.RateLimit.WriteRate, Burst: cfg.RateLimit.WriteBurst},
+		}))
+	}
+	{
+		crimes := v1.Group("/crimes")
+		{
+			crimes.GET("/", deps.CrimeQueryController.List)
+			crimes.POST("/", deps.CrimeController.Create)
+			crimes.GET("/stream", deps.CrimeStreamController.Stream)
+			crimes.GET("/ws", deps.CrimeStreamController.WebSocket)
+			crimes.GET("/export", deps.ExportController.Crimes)
+			crimes.POST("/import", deps.CrimeImportController.Shapefile)
+			crimes.GET("/:id", deps.CrimeQueryController.Get)
+			crimes.PUT("/:id", deps.CrimeEditController.Update)
+			crimes.DELETE("/:id", deps.CrimeEditController.Delete)
+			crimes.POST("/:id/status", deps.CrimeStatusController.Transition)
+			crimes.GET("/:id/status-history", deps.CrimeStatusController.History)
+			crimes.GET("/:id/history", deps.CrimeHistoryController.History)
+		}
+
+		moderation := v1.Group("/moderation")
+		{
+			moderation.GET("/queue", deps.ModerationController.Queue)
+			moderation.POST("/queue/:id/claim", deps.ModerationController.Claim)
+			moderation.DELETE("/queue/:id/claim", deps.ModerationController.Release)
+			moderation.POST("/queue/:id/approve", deps.ModerationController.Approve)
+			moderation.POST("/queue/:id/reject", deps.ModerationController.Reject)
+		}
+
+		webhooks := v1.Group("/webhooks")
+		{
+			webhooks.GET("/", deps.WebhookController.List)
+			webhooks.POST("/", deps.WebhookController.Create)
+			webhooks.DELETE("/:id", deps.WebhookController.Delete)
+			webhooks.GET("/:id/deliveries", deps.WebhookController.Deliveries)
+			webhooks.POST("/:id/deliveries/:delivery_id/replay", deps.WebhookController.Replay)
+		}
+
+		watchAreas := v1.Group("/watch-areas")
+		{
+			watchAreas.GET("/", deps.AlertController.ListAreas)
+			watchAreas.POST("/", deps.AlertController.CreateArea)
+			watchAreas.PUT("/:id", deps.AlertController.UpdateArea)
+			watchAreas.DELETE("/:id", deps.AlertController.DeleteArea)
+		}
+
+		notifications := v1.Group("/notifications")
+		{
+			notifications.GET("/", deps.AlertController.Notifications)
+			notifications.POST("/:id/read", deps.AlertController.MarkRead)
+		}
+
+		zones := v1.Group("/zones")
+		{
+			zones.GET("/", deps.ZoneController.List)
+			zones.POST("/", deps.ZoneController.Create)
+			zones.POST("/import", deps.ZoneController.Import)
+			zones.POST("/import/shapefile", deps.ZoneController.ImportShapefile)
+			zones.GET("/:id", deps.ZoneController.Get)
+			zones.PUT("/:id", deps.ZoneController.Update)
+			zones.DELETE("/:id", deps.ZoneController.Delete)
+		}
+
+		stats := v1.Group("/stats")
+		{
+			stats.GET("/zones", deps.StatsController.Zones)
+			stats.GET("/timeseries", deps.StatsController.TimeSeries)
+			stats.GET("/punchcard", deps.StatsController.Punchcard)
+			stats.GET("/hotspots", deps.StatsController.Hotspots)
+			stats.GET("/hotspots/significance", deps.StatsController.HotspotSignificance)
+		}
+
+		anomalies := v1.Group("/anomalies")
+		{
+			anomalies.GET("/", deps.AnomalyController.List)
+		}
+
+		safety := v1.Group("/safety")
+		{
+			safety.GET("/", deps.SafetyController.Point)
+			safety.POST("/route", deps.SafetyController.Route)
+		}
+	}
+
+	// Tiles vectoriales del mapa. Un mapa pide muchos tiles a la vez, por lo que tienen su
+	// propio límite de solicitudes para no consumir el de la API
+	tiles := router.Group("/tiles")
+	tiles.Use(middleware.Authenticate(deps.Authenticator))
+	if cfg.RateLimit.Enabled {
+		tiles.Use(middleware.RateLimit(deps.RateLimitStore, middleware.RateLimitPolicies{
+			Read:  middleware.RateLimitPolicy{Name: "tiles-read", Rate: cfg.RateLimit.TileRate, Burst: cfg.RateLimit.TileBurst},
+			Write: middleware.RateLimitPolicy{Name: "tiles-write", Rate: cfg.RateLimit.WriteRate, Burst: cfg.RateLimit.WriteBurst},
+		}))
+	}
+	{
+		tiles.GET("/crimes/:z/:x/:y", deps.TileController.Crimes)
+	}
+
+	return router, nil
+}
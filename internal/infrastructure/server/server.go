@@ -4,35 +4,65 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"log/slog"
 	"net/http"
 	"os"
 	"time"
 
+	"go-crime_map_backend/internal/domain/entities"
+	"go-crime_map_backend/internal/domain/events"
+	"go-crime_map_backend/internal/infrastructure/config"
 	"go-crime_map_backend/internal/infrastructure/database"
+	"go-crime_map_backend/internal/infrastructure/geocoding"
+	"go-crime_map_backend/internal/infrastructure/jobs"
+	"go-crime_map_backend/internal/infrastructure/logging"
+	"go-crime_map_backend/internal/infrastructure/metrics"
+	"go-crime_map_backend/internal/infrastructure/notifications"
 	"go-crime_map_backend/internal/infrastructure/repositories"
+	"go-crime_map_backend/internal/infrastructure/tracing"
+	"go-crime_map_backend/internal/infrastructure/webhooks"
 	crimeHttp "go-crime_map_backend/internal/interfaces/http"
+	"go-crime_map_backend/internal/interfaces/http/middleware"
 	"go-crime_map_backend/internal/usecases"
 
 	"github.com/gin-gonic/gin"
 )
 
 type Server struct {
-	httpServer *http.Server
-	router     *gin.Engine
-	db         *sql.DB
+	httpServer      *http.Server
+	router          *gin.Engine
+	db              *sql.DB
+	scheduler       *jobs.Scheduler
+	shutdownTracing tracing.ShutdownFunc
 }
 
 // NewServer crea una nueva instancia del servidor HTTP
 func NewServer() *Server {
-	router := gin.Default()
+	cfg := config.Load()
+
+	// Configurar el logger estructurado para toda la aplicación
+	logger := logging.New(os.Stdout, cfg.LogLevel, cfg.LogFormat)
+	slog.SetDefault(logger)
+
+	// Configurar la trazabilidad distribuida
+	shutdownTracing, err := tracing.Setup(context.Background(), tracing.Options{
+		ServiceName:  cfg.Tracing.ServiceName,
+		Exporter:     cfg.Tracing.Exporter,
+		OTLPEndpoint: cfg.Tracing.OTLPEndpoint,
+		OTLPInsecure: cfg.Tracing.OTLPInsecure,
+		SampleRatio:  cfg.Tracing.SampleRatio,
+	})
+	if err != nil {
+		panic(fmt.Sprintf("Error al configurar la trazabilidad: %v", err))
+	}
 
 	// Inicializar la conexión a la base de datos
 	var dbConfig *database.Config
 	if os.Getenv("TEST_MODE") == "true" {
-		fmt.Println("Usando configuración de base de datos de test")
+		logger.Info("usando configuración de base de datos de test")
 		dbConfig = database.NewTestConfig()
 	} else {
-		fmt.Println("Usando configuración de base de datos de producción")
+		logger.Info("usando configuración de base de datos de producción")
 		dbConfig = database.NewConfig()
 	}
 
@@ -41,30 +71,234 @@ func NewServer() *Server {
 		panic(fmt.Sprintf("Error al conectar con la base de datos: %v", err))
 	}
 
+	// Inicializar las métricas de la aplicación
+	appMetrics := metrics.New()
+	appMetrics.RegisterDB(db, dbConfig.DBName)
+
 	// Inicializar el repositorio de PostgreSQL
-	crimeRepo := repositories.NewPostgresCrimeRepository(db)
+	postgresRepo := repositories.NewPostgresCrimeRepository(db)
+	crimeRepo := metrics.NewInstrumentedCrimeRepository(postgresRepo, "PostgresCrimeRepository", appMetrics)
+	moderationRepo := metrics.NewInstrumentedModerationRepository(postgresRepo, "PostgresCrimeRepository", appMetrics)
+	revisionRepo := metrics.NewInstrumentedRevisionRepository(postgresRepo, "PostgresCrimeRepository", appMetrics)
+	outboxRepo := metrics.NewInstrumentedOutboxRepository(postgresRepo, "PostgresCrimeRepository", appMetrics)
+	statsRepo := metrics.NewInstrumentedStatsRepository(postgresRepo, "PostgresCrimeRepository", appMetrics)
+	anomalyRepo := metrics.NewInstrumentedAnomalyRepository(postgresRepo, "PostgresCrimeRepository", appMetrics)
+	webhookRepo := metrics.NewInstrumentedWebhookRepository(
+		repositories.NewPostgresWebhookRepository(db), "PostgresWebhookRepository", appMetrics)
+	alertRepo := metrics.NewInstrumentedAlertRepository(
+		repositories.NewPostgresAlertRepository(db), "PostgresAlertRepository", appMetrics)
+	zoneRepo := metrics.NewInstrumentedZoneRepository(
+		repositories.NewPostgresZoneRepository(db), "PostgresZoneRepository", appMetrics)
+	zoneLocator := usecases.NewZoneLocator(zoneRepo)
+
+	// Cargar el nomenclador con el que se ubican y verifican las direcciones
+	var locationResolver *usecases.LocationResolver
+	if cfg.Geocoding.GazetteerPath != "" {
+		gazetteer, err := geocoding.LoadGazetteer(cfg.Geocoding.GazetteerPath, cfg.Geocoding.ReverseMeters)
+		if err != nil {
+			panic(fmt.Sprintf("Error al cargar el nomenclador de direcciones: %v", err))
+		}
+		logger.Info("nomenclador de direcciones cargado",
+			slog.String("path", cfg.Geocoding.GazetteerPath),
+			slog.Int("addresses", gazetteer.Len()),
+		)
+		locationResolver = usecases.NewLocationResolver(gazetteer, cfg.Geocoding.MismatchMeters)
+	}
 
 	// Inicializar el caso de uso
-	createCrimeUseCase := usecases.NewCreateCrimeUseCase(crimeRepo)
+	createCrimeUseCase := metrics.NewInstrumentedCreateCrime(
+		usecases.NewCreateCrimeUseCaseWithGeocoding(crimeRepo, zoneLocator, locationResolver), appMetrics)
 
-	// Inicializar el controlador
+	// Inicializar los controladores
 	crimeController := crimeHttp.NewCrimeController(createCrimeUseCase)
+	crimeImportController := crimeHttp.NewCrimeImportController(usecases.NewImportCrimesUseCase(createCrimeUseCase))
+	crimeQueryController := crimeHttp.NewCrimeQueryController(
+		usecases.NewListCrimesUseCase(crimeRepo),
+		usecases.NewGetCrimeUseCase(crimeRepo),
+		usecases.NewGetCrimeAsOfUseCase(revisionRepo),
+	)
+	crimeHistoryController := crimeHttp.NewCrimeHistoryController(usecases.NewGetCrimeHistoryUseCase(revisionRepo))
+	crimeEditController := crimeHttp.NewCrimeEditController(
+		usecases.NewUpdateCrimeUseCaseWithGeocoding(crimeRepo, zoneLocator, locationResolver),
+		usecases.NewDeleteCrimeUseCase(crimeRepo),
+	)
+	crimeStatusController := crimeHttp.NewCrimeStatusController(
+		usecases.NewTransitionCrimeStatusUseCase(crimeRepo),
+		usecases.NewGetCrimeStatusHistoryUseCase(crimeRepo),
+	)
+	moderationController := crimeHttp.NewModerationController(
+		usecases.NewListModerationQueueUseCase(moderationRepo),
+		usecases.NewClaimCrimeUseCase(crimeRepo, moderationRepo, cfg.Moderation.ClaimTTL),
+		usecases.NewReleaseCrimeClaimUseCase(moderationRepo),
+		usecases.NewModerateCrimeUseCase(crimeRepo, moderationRepo),
+	)
+
+	webhookController := crimeHttp.NewWebhookController(
+		usecases.NewCreateWebhookSubscriptionUseCase(webhookRepo),
+		usecases.NewListWebhookSubscriptionsUseCase(webhookRepo),
+		usecases.NewDeleteWebhookSubscriptionUseCase(webhookRepo),
+		usecases.NewListWebhookDeliveriesUseCase(webhookRepo),
+		usecases.NewReplayWebhookDeliveryUseCase(webhookRepo),
+	)
+
+	alertController := crimeHttp.NewAlertController(
+		usecases.NewCreateWatchAreaUseCase(alertRepo, cfg.Alerts.MaxWatchAreas),
+		usecases.NewListWatchAreasUseCase(alertRepo),
+		usecases.NewUpdateWatchAreaUseCase(alertRepo),
+		usecases.NewDeleteWatchAreaUseCase(alertRepo),
+		usecases.NewListNotificationsUseCase(alertRepo),
+		usecases.NewMarkNotificationReadUseCase(alertRepo),
+	)
+
+	zoneController := crimeHttp.NewZoneController(
+		usecases.NewCreateZoneUseCase(zoneRepo, crimeRepo),
+		usecases.NewListZonesUseCase(zoneRepo),
+		usecases.NewGetZoneUseCase(zoneRepo),
+		usecases.NewUpdateZoneUseCase(zoneRepo, crimeRepo),
+		usecases.NewDeleteZoneUseCase(zoneRepo, crimeRepo),
+		usecases.NewImportZonesUseCase(zoneRepo, crimeRepo),
+	)
+	statsController := crimeHttp.NewStatsController(
+		usecases.NewGetZoneStatsUseCase(zoneRepo, statsRepo),
+		usecases.NewGetCrimeTimeSeriesUseCase(statsRepo),
+		usecases.NewGetCrimePunchcardUseCase(statsRepo),
+		usecases.NewDetectHotspotsUseCase(statsRepo, usecases.HotspotOptions{
+			CacheTTL:  cfg.Hotspots.CacheTTL,
+			CacheSize: cfg.Hotspots.CacheSize,
+			MaxCrimes: cfg.Hotspots.MaxCrimes,
+		}),
+		usecases.NewGetHotspotSignificanceUseCase(statsRepo, zoneRepo, cfg.Hotspots.MaxCrimes),
+	)
+	crimeTiles := usecases.NewGetCrimeTileUseCase(statsRepo, usecases.CrimeTileOptions{
+		CacheTTL:       cfg.Tiles.CacheTTL,
+		CacheSize:      cfg.Tiles.CacheSize,
+		MaxCrimes:      cfg.Tiles.MaxCrimes,
+		ClusterMaxZoom: cfg.Tiles.ClusterMaxZoom,
+	})
+	tileController := crimeHttp.NewTileController(crimeTiles)
+	exportController := crimeHttp.NewExportController(usecases.NewExportCrimesUseCase(statsRepo))
+	anomalyController := crimeHttp.NewAnomalyController(usecases.NewListAnomaliesUseCase(anomalyRepo))
+	safetyController := crimeHttp.NewSafetyController(usecases.NewGetSafetyScoreUseCase(statsRepo, usecases.SafetyOptions{
+		DensityScale: cfg.Safety.DensityScale,
+		Lookback:     cfg.Safety.Lookback,
+		MaxCrimes:    cfg.Safety.MaxCrimes,
+	}))
+
+	// Feed en tiempo real, alimentado por el despachador de eventos de dominio
+	crimeFeed := usecases.NewCrimeFeed(usecases.CrimeFeedOptions{
+		BufferSize:  cfg.Stream.BufferSize,
+		HistorySize: cfg.Stream.HistorySize,
+	})
+	crimeStreamController := crimeHttp.NewCrimeStreamController(crimeFeed, crimeHttp.StreamOptions{
+		HeartbeatInterval: cfg.Stream.HeartbeatInterval,
+		WriteTimeout:      cfg.Stream.WriteTimeout,
+		AllowedOrigins:    cfg.CORS.AllowedOrigins,
+	})
+
+	// Tareas periódicas en segundo plano
+	scheduler := jobs.NewScheduler(logger)
+	releaseExpiredClaims := usecases.NewReleaseExpiredClaimsUseCase(moderationRepo)
+	scheduler.Every("release_expired_claims", cfg.Moderation.ReaperInterval, func(ctx context.Context) error {
+		_, err := releaseExpiredClaims.Execute(ctx)
+		return err
+	})
+
+	// Despachador de eventos de dominio; los handlers se registran antes de iniciarlo
+	dispatcherOpts := usecases.DefaultOutboxDispatcherOptions()
+	dispatcherOpts.BatchSize = cfg.Outbox.BatchSize
+	dispatcherOpts.MaxAttempts = cfg.Outbox.MaxAttempts
+	dispatcher := usecases.NewOutboxDispatcher(outboxRepo, dispatcherOpts)
+	dispatcher.SubscribeAll(appMetrics.EventCounter())
+	dispatcher.SubscribeAll(usecases.NewWebhookFanout(webhookRepo))
+	watchAreaMatcher := usecases.NewWatchAreaMatcher(alertRepo)
+	dispatcher.Subscribe(events.CrimeReported, watchAreaMatcher)
+	dispatcher.Subscribe(events.CrimeStatusChanged, watchAreaMatcher)
+	scheduler.Every("dispatch_outbox", cfg.Outbox.PollInterval, func(ctx context.Context) error {
+		_, err := dispatcher.DispatchPending(ctx)
+		return err
+	})
+
+	// El estado en memoria de cada instancia recibe todos los eventos, no solo los que
+	// despacha esta instancia
+	broadcaster := usecases.NewOutboxBroadcaster(outboxRepo, usecases.OutboxBroadcasterOptions{
+		BatchSize: cfg.Outbox.BatchSize,
+		Overlap:   cfg.Outbox.BroadcastOverlap,
+	})
+	broadcaster.SubscribeAll(crimeFeed)
+	broadcaster.SubscribeAll(crimeTiles)
+	scheduler.Every("broadcast_outbox", cfg.Outbox.PollInterval, func(ctx context.Context) error {
+		_, err := broadcaster.Poll(ctx)
+		return err
+	})
+
+	webhookOpts := usecases.DefaultDeliverWebhooksOptions()
+	webhookOpts.BatchSize = cfg.Webhooks.BatchSize
+	webhookOpts.MaxAttempts = cfg.Webhooks.MaxAttempts
+	deliverWebhooks := usecases.NewDeliverWebhooksUseCase(webhookRepo, webhooks.NewHTTPSender(cfg.Webhooks.Timeout), webhookOpts)
+	scheduler.Every("deliver_webhooks", cfg.Webhooks.DeliveryInterval, func(ctx context.Context) error {
+		_, err := deliverWebhooks.Execute(ctx)
+		return err
+	})
 
-	// Configurar rutas
-	router.GET("/health", func(c *gin.Context) {
-		c.JSON(http.StatusOK, gin.H{
-			"status": "ok",
-			"time":   time.Now(),
+	// Canales de los avisos de las zonas vigiladas; la bandeja no necesita emisor
+	senders := map[entities.NotificationChannel]usecases.NotificationSender{
+		entities.NotificationWebhook: notifications.NewHTTPSender(cfg.Alerts.Timeout),
+	}
+	if cfg.SMTP.Host != "" {
+		senders[entities.NotificationEmail] = notifications.NewSMTPSender(notifications.SMTPOptions{
+			Host:     cfg.SMTP.Host,
+			Port:     cfg.SMTP.Port,
+			Username: cfg.SMTP.Username,
+			Password: cfg.SMTP.Password,
+			From:     cfg.SMTP.From,
 		})
+	} else {
+		logger.Warn("SMTP_HOST no configurado, los avisos por email no se enviarán")
+	}
+	alertOpts := usecases.DefaultDeliverNotificationsOptions()
+	alertOpts.BatchSize = cfg.Alerts.BatchSize
+	alertOpts.MaxAttempts = cfg.Alerts.MaxAttempts
+	deliverNotifications := usecases.NewDeliverNotificationsUseCase(alertRepo, senders, alertOpts)
+	scheduler.Every("deliver_notifications", cfg.Alerts.DeliveryInterval, func(ctx context.Context) error {
+		_, err := deliverNotifications.Execute(ctx)
+		return err
 	})
 
-	// Grupo de rutas para la API v1
-	v1 := router.Group("/api/v1")
-	{
-		crimes := v1.Group("/crimes")
-		{
-			crimes.POST("/", crimeController.Create)
-		}
+	detectAnomalies := usecases.NewDetectAnomaliesUseCase(statsRepo, anomalyRepo, usecases.DetectAnomaliesOptions{
+		Window:          cfg.Anomalies.Window,
+		BaselineWindows: cfg.Anomalies.BaselineWindows,
+		Threshold:       cfg.Anomalies.Threshold,
+		MinCount:        cfg.Anomalies.MinCount,
+	})
+	scheduler.Every("detect_anomalies", cfg.Anomalies.Interval, func(ctx context.Context) error {
+		_, err := detectAnomalies.Execute(ctx)
+		return err
+	})
+
+	router, err := NewRouter(cfg, Dependencies{
+		Logger:                 logger,
+		Metrics:                appMetrics,
+		RateLimitStore:         middleware.NewMemoryRateLimitStore(),
+		Authenticator:          newAuthenticator(cfg.APIKeys),
+		CrimeController:        crimeController,
+		CrimeQueryController:   crimeQueryController,
+		CrimeStatusController:  crimeStatusController,
+		CrimeHistoryController: crimeHistoryController,
+		CrimeEditController:    crimeEditController,
+		ModerationController:   moderationController,
+		WebhookController:      webhookController,
+		CrimeStreamController:  crimeStreamController,
+		AlertController:        alertController,
+		ZoneController:         zoneController,
+		StatsController:        statsController,
+		AnomalyController:      anomalyController,
+		SafetyController:       safetyController,
+		TileController:         tileController,
+		ExportController:       exportController,
+		CrimeImportController:  crimeImportController,
+	})
+	if err != nil {
+		panic(fmt.Sprintf("Error al configurar el router: %v", err))
 	}
 
 	return &Server{
@@ -73,12 +307,28 @@ func NewServer() *Server {
 			Addr:    ":8080",
 			Handler: router,
 		},
-		db: db,
+		db:              db,
+		scheduler:       scheduler,
+		shutdownTracing: shutdownTracing,
+	}
+}
+
+// newAuthenticator crea el autenticador con las claves de API configuradas
+func newAuthenticator(keys []config.APIKeyConfig) *middleware.StaticAPIKeys {
+	actors := make(map[string]entities.Actor, len(keys))
+	for _, key := range keys {
+		role := entities.Role(key.Role)
+		if !role.IsValid() || role == entities.RoleAnonymous {
+			slog.Warn("clave de API con rol inválido ignorada", slog.String("user_id", key.UserID))
+			continue
+		}
+		actors[key.Key] = entities.Actor{ID: key.UserID, Role: role}
 	}
+	return middleware.NewStaticAPIKeys(actors)
 }
 
 func (s *Server) Start() error {
-	fmt.Println("Servidor iniciado en el puerto 8080")
+	slog.Info("servidor iniciado", slog.String("addr", s.httpServer.Addr))
 	return s.httpServer.ListenAndServe()
 }
 
@@ -86,10 +336,20 @@ func (s *Server) Shutdown() error {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
+	// Detener las tareas periódicas antes de cerrar la base de datos
+	s.scheduler.Stop()
+
 	// Cerrar la conexión a la base de datos
 	if err := s.db.Close(); err != nil {
-		fmt.Printf("Error al cerrar la conexión a la base de datos: %v\n", err)
+		slog.Error("error al cerrar la conexión a la base de datos", slog.Any("error", err))
+	}
+
+	err := s.httpServer.Shutdown(ctx)
+
+	// Enviar los spans pendientes antes de salir
+	if err := s.shutdownTracing(ctx); err != nil {
+		slog.Error("error al cerrar el exportador de trazas", slog.Any("error", err))
 	}
 
-	return s.httpServer.Shutdown(ctx)
+	return err
 }
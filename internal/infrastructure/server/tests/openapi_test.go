@@ -0,0 +1,147 @@
+package tests
+
+import (
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go-crime_map_backend/internal/infrastructure/config"
+	"go-crime_map_backend/internal/infrastructure/metrics"
+	"go-crime_map_backend/internal/infrastructure/repositories"
+	"go-crime_map_backend/internal/infrastructure/server"
+	crimeHttp "go-crime_map_backend/internal/interfaces/http"
+	"go-crime_map_backend/internal/interfaces/http/middleware"
+	"go-crime_map_backend/internal/interfaces/http/openapi"
+	"go-crime_map_backend/internal/usecases"
+)
+
+func setupRouter(t *testing.T) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	repo := repositories.NewMemoryCrimeRepository()
+	webhookRepo := repositories.NewMemoryWebhookRepository()
+	alertRepo := repositories.NewMemoryAlertRepository()
+	zoneRepo := repositories.NewMemoryZoneRepository()
+	router, err := server.NewRouter(config.Load(), server.Dependencies{
+		Logger:          slog.New(slog.NewTextHandler(io.Discard, nil)),
+		Metrics:         metrics.New(),
+		RateLimitStore:  middleware.NewMemoryRateLimitStore(),
+		Authenticator:   middleware.NewStaticAPIKeys(nil),
+		CrimeController: crimeHttp.NewCrimeController(usecases.NewCreateCrimeUseCase(repo)),
+		CrimeQueryController: crimeHttp.NewCrimeQueryController(
+			usecases.NewListCrimesUseCase(repo), usecases.NewGetCrimeUseCase(repo), usecases.NewGetCrimeAsOfUseCase(repo)),
+		CrimeStatusController: crimeHttp.NewCrimeStatusController(
+			usecases.NewTransitionCrimeStatusUseCase(repo), usecases.NewGetCrimeStatusHistoryUseCase(repo)),
+		CrimeHistoryController: crimeHttp.NewCrimeHistoryController(usecases.NewGetCrimeHistoryUseCase(repo)),
+		CrimeEditController:    crimeHttp.NewCrimeEditController(usecases.NewUpdateCrimeUseCase(repo), usecases.NewDeleteCrimeUseCase(repo)),
+		ModerationController: crimeHttp.NewModerationController(
+			usecases.NewListModerationQueueUseCase(repo),
+			usecases.NewClaimCrimeUseCase(repo, repo, time.Minute),
+			usecases.NewReleaseCrimeClaimUseCase(repo),
+			usecases.NewModerateCrimeUseCase(repo, repo)),
+		WebhookController: crimeHttp.NewWebhookController(
+			usecases.NewCreateWebhookSubscriptionUseCase(webhookRepo),
+			usecases.NewListWebhookSubscriptionsUseCase(webhookRepo),
+			usecases.NewDeleteWebhookSubscriptionUseCase(webhookRepo),
+			usecases.NewListWebhookDeliveriesUseCase(webhookRepo),
+			usecases.NewReplayWebhookDeliveryUseCase(webhookRepo)),
+		CrimeStreamController: crimeHttp.NewCrimeStreamController(
+			usecases.NewCrimeFeed(usecases.CrimeFeedOptions{BufferSize: 8, HistorySize: 16}),
+			crimeHttp.StreamOptions{HeartbeatInterval: time.Second, WriteTimeout: time.Second}),
+		AlertController: crimeHttp.NewAlertController(
+			usecases.NewCreateWatchAreaUseCase(alertRepo, 5),
+			usecases.NewListWatchAreasUseCase(alertRepo),
+			usecases.NewUpdateWatchAreaUseCase(alertRepo),
+			usecases.NewDeleteWatchAreaUseCase(alertRepo),
+			usecases.NewListNotificationsUseCase(alertRepo),
+			usecases.NewMarkNotificationReadUseCase(alertRepo)),
+		ZoneController: crimeHttp.NewZoneController(
+			usecases.NewCreateZoneUseCase(zoneRepo, repo),
+			usecases.NewListZonesUseCase(zoneRepo),
+			usecases.NewGetZoneUseCase(zoneRepo),
+			usecases.NewUpdateZoneUseCase(zoneRepo, repo),
+			usecases.NewDeleteZoneUseCase(zoneRepo, repo),
+			usecases.NewImportZonesUseCase(zoneRepo, repo)),
+		StatsController: crimeHttp.NewStatsController(
+			usecases.NewGetZoneStatsUseCase(zoneRepo, repo),
+			usecases.NewGetCrimeTimeSeriesUseCase(repo),
+			usecases.NewGetCrimePunchcardUseCase(repo),
+			usecases.NewDetectHotspotsUseCase(repo, usecases.HotspotOptions{}),
+			usecases.NewGetHotspotSignificanceUseCase(repo, zoneRepo, 0)),
+		AnomalyController:     crimeHttp.NewAnomalyController(usecases.NewListAnomaliesUseCase(repo)),
+		SafetyController:      crimeHttp.NewSafetyController(usecases.NewGetSafetyScoreUseCase(repo, usecases.SafetyOptions{})),
+		TileController:        crimeHttp.NewTileController(usecases.NewGetCrimeTileUseCase(repo, usecases.CrimeTileOptions{})),
+		ExportController:      crimeHttp.NewExportController(usecases.NewExportCrimesUseCase(repo)),
+		CrimeImportController: crimeHttp.NewCrimeImportController(usecases.NewImportCrimesUseCase(usecases.NewCreateCrimeUseCase(repo))),
+	})
+	require.NoError(t, err)
+	return router
+}
+
+func TestOpenAPIMatchesRoutes(t *testing.T) {
+	router := setupRouter(t)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/openapi.json", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var doc struct {
+		OpenAPI string                                `json:"openapi"`
+		Paths   map[string]map[string]json.RawMessage `json:"paths"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &doc))
+	assert.True(t, strings.HasPrefix(doc.OpenAPI, "3.1"))
+
+	var documented []string
+	for path, item := range doc.Paths {
+		for method := range item {
+			documented = append(documented, strings.ToUpper(method)+" "+path)
+		}
+	}
+
+	var registered []string
+	for _, route := range router.Routes() {
+		registered = append(registered, route.Method+" "+openapi.GinPathToOpenAPI(route.Path))
+	}
+
+	sort.Strings(documented)
+	sort.Strings(registered)
+	assert.Equal(t, registered, documented, "las rutas registradas en Gin y el documento OpenAPI no coinciden")
+}
+
+func TestOpenAPISchemas(t *testing.T) {
+	doc := openapi.Document()
+	schemas := doc["components"].(map[string]any)["schemas"].(map[string]any)
+
+	request := schemas["CreateCrimeRequest"].(map[string]any)
+	assert.ElementsMatch(t, []string{"type", "description", "location", "date"}, request["required"])
+	properties := request["properties"].(map[string]any)
+	assert.Contains(t, properties["type"].(map[string]any)["enum"], "ROBO")
+	assert.Equal(t, map[string]any{"type": "string", "format": "date-time"}, properties["date"])
+
+	crime := schemas["Crime"].(map[string]any)
+	assert.Contains(t, crime["properties"], "created_at")
+
+	errorSchema := schemas["Error"].(map[string]any)
+	assert.Equal(t, []string{"error"}, errorSchema["required"])
+}
+
+func TestDocsPage(t *testing.T) {
+	router := setupRouter(t)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/docs", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Header().Get("Content-Type"), "text/html")
+	assert.Contains(t, w.Body.String(), "/openapi.json")
+	assert.Contains(t, w.Header().Get("Content-Security-Policy"), "script-src https://cdn.jsdelivr.net 'nonce-")
+}
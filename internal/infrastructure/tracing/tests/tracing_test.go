@@ -0,0 +1,81 @@
+package tests
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin"
+	"go.opentelemetry.io/otel/trace"
+
+	"go-crime_map_backend/internal/infrastructure/tracing"
+)
+
+// fakeCollector simula un colector OTLP/HTTP y guarda los cuerpos recibidos
+type fakeCollector struct {
+	mu       sync.Mutex
+	paths    []string
+	payloads [][]byte
+}
+
+func (f *fakeCollector) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+	f.mu.Lock()
+	f.paths = append(f.paths, r.URL.Path)
+	f.payloads = append(f.payloads, body)
+	f.mu.Unlock()
+	w.WriteHeader(http.StatusOK)
+}
+
+func TestTracingOTLPExporter(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	collector := &fakeCollector{}
+	server := httptest.NewServer(collector)
+	defer server.Close()
+
+	shutdown, err := tracing.Setup(context.Background(), tracing.Options{
+		ServiceName:  "crime-map-test",
+		Exporter:     tracing.ExporterOTLP,
+		OTLPEndpoint: strings.TrimPrefix(server.URL, "http://"),
+		OTLPInsecure: true,
+		SampleRatio:  1,
+	})
+	require.NoError(t, err)
+
+	var serverSpan trace.SpanContext
+	router := gin.New()
+	router.Use(otelgin.Middleware("crime-map-test"))
+	router.GET("/api/v1/crimes", func(c *gin.Context) {
+		serverSpan = trace.SpanContextFromContext(c.Request.Context())
+		c.Status(http.StatusOK)
+	})
+
+	// El span del servidor continúa la traza recibida en traceparent
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/crimes", nil)
+	req.Header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	router.ServeHTTP(httptest.NewRecorder(), req)
+
+	assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", serverSpan.TraceID().String())
+	assert.NotEqual(t, "00f067aa0ba902b7", serverSpan.SpanID().String())
+
+	// Al cerrar se vacían los spans pendientes hacia el colector
+	require.NoError(t, shutdown(context.Background()))
+
+	collector.mu.Lock()
+	defer collector.mu.Unlock()
+	require.NotEmpty(t, collector.payloads)
+	assert.Equal(t, "/v1/traces", collector.paths[0])
+	assert.Contains(t, string(collector.payloads[0]), "crime-map-test")
+}
+
+func TestTracingUnknownExporter(t *testing.T) {
+	_, err := tracing.Setup(context.Background(), tracing.Options{Exporter: "zipkin"})
+	assert.Error(t, err)
+}
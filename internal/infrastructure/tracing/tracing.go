@@ -0,0 +1,93 @@
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+)
+
+const (
+	// ExporterNone deshabilita la exportación de spans
+	ExporterNone = "none"
+
+	// ExporterStdout escribe los spans como JSON en la salida estándar
+	ExporterStdout = "stdout"
+
+	// ExporterOTLP envía los spans a un colector OTLP por HTTP
+	ExporterOTLP = "otlp"
+)
+
+// Options representa la configuración de la trazabilidad distribuida
+type Options struct {
+	ServiceName  string
+	Exporter     string  // none, stdout u otlp
+	OTLPEndpoint string  // host:puerto del colector OTLP/HTTP
+	OTLPInsecure bool    // Usa HTTP sin TLS para el colector
+	SampleRatio  float64 // Proporción de trazas raíz muestreadas, entre 0 y 1
+	Writer       io.Writer
+}
+
+// ShutdownFunc vacía los spans pendientes y libera el exportador
+type ShutdownFunc func(ctx context.Context) error
+
+// Setup configura el TracerProvider global y la propagación W3C traceparent
+func Setup(ctx context.Context, opts Options) (ShutdownFunc, error) {
+	// La propagación se configura siempre para respetar el traceparent de los clientes
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{},
+		propagation.Baggage{},
+	))
+
+	var exporter sdktrace.SpanExporter
+	var err error
+	switch opts.Exporter {
+	case "", ExporterNone:
+		return func(context.Context) error { return nil }, nil
+	case ExporterStdout:
+		writer := opts.Writer
+		if writer == nil {
+			writer = os.Stdout
+		}
+		exporter, err = stdouttrace.New(stdouttrace.WithWriter(writer))
+	case ExporterOTLP:
+		clientOpts := []otlptracehttp.Option{}
+		if opts.OTLPEndpoint != "" {
+			clientOpts = append(clientOpts, otlptracehttp.WithEndpoint(opts.OTLPEndpoint))
+		}
+		if opts.OTLPInsecure {
+			clientOpts = append(clientOpts, otlptracehttp.WithInsecure())
+		}
+		exporter, err = otlptracehttp.New(ctx, clientOpts...)
+	default:
+		return nil, fmt.Errorf("exportador de trazas desconocido: %s", opts.Exporter)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error al crear el exportador de trazas: %w", err)
+	}
+
+	res, err := resource.Merge(resource.Default(), resource.NewWithAttributes(
+		semconv.SchemaURL,
+		semconv.ServiceName(opts.ServiceName),
+	))
+	if err != nil {
+		return nil, fmt.Errorf("error al crear el recurso de trazas: %w", err)
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(opts.SampleRatio))),
+	)
+	otel.SetTracerProvider(provider)
+
+	return provider.Shutdown, nil
+}
@@ -0,0 +1,94 @@
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"go-crime_map_backend/internal/usecases"
+)
+
+const (
+	// HeaderEvent contiene el tipo de evento entregado
+	HeaderEvent = "X-Webhook-Event"
+
+	// HeaderDelivery contiene el ID de la entrega, igual en cada reintento
+	HeaderDelivery = "X-Webhook-Delivery"
+
+	// HeaderTimestamp contiene el instante de envío en segundos Unix, incluido en la firma
+	HeaderTimestamp = "X-Webhook-Timestamp"
+
+	// HeaderSignature contiene la firma sha256=<hex> del cuerpo
+	HeaderSignature = "X-Webhook-Signature"
+
+	// maxResponseBody limita lo que se lee de la respuesta del receptor
+	maxResponseBody = 64 << 10
+)
+
+// HTTPSender envía las entregas de webhooks por HTTP firmándolas con HMAC-SHA256
+type HTTPSender struct {
+	client *http.Client
+	now    func() time.Time
+}
+
+// NewHTTPSender crea un emisor con el tiempo de espera indicado por envío
+func NewHTTPSender(timeout time.Duration) *HTTPSender {
+	return &HTTPSender{
+		client: &http.Client{
+			Timeout: timeout,
+			// Las redirecciones no se siguen para que la URL suscrita sea la única destinataria
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+		now: time.Now,
+	}
+}
+
+// Send envía el cuerpo con un POST firmado y retorna el código HTTP de la respuesta
+func (s *HTTPSender) Send(ctx context.Context, request usecases.WebhookRequest) (int, error) {
+	timestamp := strconv.FormatInt(s.now().Unix(), 10)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, request.URL, bytes.NewReader(request.Body))
+	if err != nil {
+		return 0, fmt.Errorf("error al crear la solicitud del webhook: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "crime-map-webhooks/1.0")
+	req.Header.Set(HeaderEvent, request.EventType)
+	req.Header.Set(HeaderDelivery, request.DeliveryID)
+	req.Header.Set(HeaderTimestamp, timestamp)
+	req.Header.Set(HeaderSignature, Sign(request.Secret, timestamp, request.Body))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("error al enviar el webhook: %w", err)
+	}
+	defer resp.Body.Close()
+	// Leer la respuesta permite reutilizar la conexión
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, maxResponseBody))
+
+	return resp.StatusCode, nil
+}
+
+// Sign calcula la firma de una entrega: HMAC-SHA256 con el secreto de la suscripción
+// sobre "<timestamp>.<cuerpo>", en hexadecimal y con el prefijo sha256=
+func Sign(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify indica si la firma corresponde al cuerpo y al instante, comparando en tiempo constante
+func Verify(secret, timestamp string, body []byte, signature string) bool {
+	return hmac.Equal([]byte(Sign(secret, timestamp, body)), []byte(signature))
+}
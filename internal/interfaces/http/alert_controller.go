@@ -0,0 +1,183 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+
+	"go-crime_map_backend/internal/domain/entities"
+	"go-crime_map_backend/internal/interfaces/http/middleware"
+	"go-crime_map_backend/internal/usecases"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AlertController maneja las peticiones HTTP de las zonas vigiladas y la bandeja de avisos
+type AlertController struct {
+	createAreaUseCase        *usecases.CreateWatchAreaUseCase
+	listAreasUseCase         *usecases.ListWatchAreasUseCase
+	updateAreaUseCase        *usecases.UpdateWatchAreaUseCase
+	deleteAreaUseCase        *usecases.DeleteWatchAreaUseCase
+	listNotificationsUseCase *usecases.ListNotificationsUseCase
+	markReadUseCase          *usecases.MarkNotificationReadUseCase
+}
+
+// NewAlertController crea una nueva instancia del controlador
+func NewAlertController(
+	createAreaUseCase *usecases.CreateWatchAreaUseCase,
+	listAreasUseCase *usecases.ListWatchAreasUseCase,
+	updateAreaUseCase *usecases.UpdateWatchAreaUseCase,
+	deleteAreaUseCase *usecases.DeleteWatchAreaUseCase,
+	listNotificationsUseCase *usecases.ListNotificationsUseCase,
+	markReadUseCase *usecases.MarkNotificationReadUseCase,
+) *AlertController {
+	return &AlertController{
+		createAreaUseCase:        createAreaUseCase,
+		listAreasUseCase:         listAreasUseCase,
+		updateAreaUseCase:        updateAreaUseCase,
+		deleteAreaUseCase:        deleteAreaUseCase,
+		listNotificationsUseCase: listNotificationsUseCase,
+		markReadUseCase:          markReadUseCase,
+	}
+}
+
+// WatchAreaRequest representa la petición para crear o reemplazar una zona vigilada.
+// La zona es un círculo o un polígono, nunca ambos
+type WatchAreaRequest struct {
+	Name       string                         `json:"name"`
+	Circle     *entities.Circle               `json:"circle"`
+	Polygon    entities.Polygon               `json:"polygon"`
+	CrimeTypes []string                       `json:"crime_types"`
+	QuietHours *entities.QuietHours           `json:"quiet_hours"`
+	Channels   []entities.NotificationChannel `json:"channels" binding:"required,min=1"`
+	Email      string                         `json:"email"`       // Requerido con el canal email
+	WebhookURL string                         `json:"webhook_url"` // Requerido con el canal webhook
+	Active     *bool                          `json:"active"`      // Por defecto true
+}
+
+// WatchAreaListResponse representa la respuesta del listado de zonas vigiladas
+type WatchAreaListResponse struct {
+	Areas []*entities.WatchArea `json:"areas"`
+}
+
+// NotificationListResponse representa la respuesta de la bandeja de avisos
+type NotificationListResponse struct {
+	Notifications []*entities.Notification `json:"notifications"`
+	Count         int                      `json:"count"`
+}
+
+// CreateArea maneja la petición POST para crear una zona vigilada
+func (c *AlertController) CreateArea(ctx *gin.Context) {
+	input, ok := bindWatchArea(ctx)
+	if !ok {
+		return
+	}
+
+	area, err := c.createAreaUseCase.Execute(ctx.Request.Context(), input, middleware.ActorFromContext(ctx))
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, area)
+}
+
+// ListAreas maneja la petición GET para listar las zonas vigiladas del usuario
+func (c *AlertController) ListAreas(ctx *gin.Context) {
+	areas, err := c.listAreasUseCase.Execute(ctx.Request.Context(), middleware.ActorFromContext(ctx))
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, WatchAreaListResponse{Areas: areas})
+}
+
+// UpdateArea maneja la petición PUT para reemplazar una zona vigilada
+func (c *AlertController) UpdateArea(ctx *gin.Context) {
+	id, ok := idParam(ctx, "id", usecases.ErrWatchAreaNotFound)
+	if !ok {
+		return
+	}
+	input, ok := bindWatchArea(ctx)
+	if !ok {
+		return
+	}
+
+	area, err := c.updateAreaUseCase.Execute(ctx.Request.Context(), id, input, middleware.ActorFromContext(ctx))
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, area)
+}
+
+// DeleteArea maneja la petición DELETE para eliminar una zona vigilada
+func (c *AlertController) DeleteArea(ctx *gin.Context) {
+	id, ok := idParam(ctx, "id", usecases.ErrWatchAreaNotFound)
+	if !ok {
+		return
+	}
+	if err := c.deleteAreaUseCase.Execute(ctx.Request.Context(), id, middleware.ActorFromContext(ctx)); err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// Notifications maneja la petición GET para consultar la bandeja de avisos del usuario
+func (c *AlertController) Notifications(ctx *gin.Context) {
+	limit, err := strconv.Atoi(ctx.DefaultQuery("limit", "0"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, middleware.ErrorBody(ctx, "el límite debe ser un número entero"))
+		return
+	}
+
+	notifications, err := c.listNotificationsUseCase.Execute(ctx.Request.Context(), usecases.ListNotificationsInput{
+		UnreadOnly: ctx.Query("unread") == "true",
+		Limit:      limit,
+		Actor:      middleware.ActorFromContext(ctx),
+	})
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, NotificationListResponse{Notifications: notifications, Count: len(notifications)})
+}
+
+// MarkRead maneja la petición POST para marcar un aviso como leído
+func (c *AlertController) MarkRead(ctx *gin.Context) {
+	id, ok := idParam(ctx, "id", usecases.ErrNotificationNotFound)
+	if !ok {
+		return
+	}
+	if err := c.markReadUseCase.Execute(ctx.Request.Context(), id, middleware.ActorFromContext(ctx)); err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// bindWatchArea interpreta el cuerpo de la petición de una zona vigilada
+func bindWatchArea(ctx *gin.Context) (usecases.WatchAreaInput, bool) {
+	var req WatchAreaRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, middleware.ErrorBody(ctx, err.Error()))
+		return usecases.WatchAreaInput{}, false
+	}
+
+	return usecases.WatchAreaInput{
+		Name:       req.Name,
+		Circle:     req.Circle,
+		Polygon:    req.Polygon,
+		CrimeTypes: req.CrimeTypes,
+		QuietHours: req.QuietHours,
+		Channels:   req.Channels,
+		Email:      req.Email,
+		WebhookURL: req.WebhookURL,
+		Active:     req.Active,
+	}, true
+}
@@ -0,0 +1,56 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+
+	"go-crime_map_backend/internal/domain/entities"
+	"go-crime_map_backend/internal/interfaces/http/middleware"
+	"go-crime_map_backend/internal/usecases"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AnomalyController maneja las peticiones HTTP de las anomalías detectadas
+type AnomalyController struct {
+	listUseCase *usecases.ListAnomaliesUseCase
+}
+
+// NewAnomalyController crea una nueva instancia del controlador
+func NewAnomalyController(listUseCase *usecases.ListAnomaliesUseCase) *AnomalyController {
+	return &AnomalyController{listUseCase: listUseCase}
+}
+
+// AnomalyListResponse representa la respuesta del listado de anomalías
+type AnomalyListResponse struct {
+	Anomalies []*entities.Anomaly `json:"anomalies"`
+	Count     int                 `json:"count"`
+}
+
+// List maneja la petición GET para listar las anomalías, opcionalmente de una zona (zone) o un
+// tipo (type) y con inicio de ventana entre from y to
+func (c *AnomalyController) List(ctx *gin.Context) {
+	from, to, ok := queryPeriod(ctx)
+	if !ok {
+		return
+	}
+	limit, err := strconv.Atoi(ctx.DefaultQuery("limit", "0"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, middleware.ErrorBody(ctx, "el límite debe ser un número entero"))
+		return
+	}
+
+	anomalies, err := c.listUseCase.Execute(ctx.Request.Context(), usecases.ListAnomaliesInput{
+		ZoneID: ctx.Query("zone"),
+		Type:   ctx.Query("type"),
+		From:   from,
+		To:     to,
+		Limit:  limit,
+	})
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, AnomalyListResponse{Anomalies: anomalies, Count: len(anomalies)})
+}
@@ -2,9 +2,13 @@ package http
 
 import (
 	"errors"
+	"log/slog"
 	"net/http"
+	"strings"
 	"time"
 
+	"go-crime_map_backend/internal/domain/entities"
+	"go-crime_map_backend/internal/interfaces/http/middleware"
 	"go-crime_map_backend/internal/usecases"
 
 	"github.com/gin-gonic/gin"
@@ -12,11 +16,11 @@ import (
 
 // CrimeController maneja las peticiones HTTP relacionadas con los delitos
 type CrimeController struct {
-	createCrimeUseCase *usecases.CreateCrimeUseCase
+	createCrimeUseCase usecases.CreateCrimeExecutor
 }
 
 // NewCrimeController crea una nueva instancia del controlador
-func NewCrimeController(createCrimeUseCase *usecases.CreateCrimeUseCase) *CrimeController {
+func NewCrimeController(createCrimeUseCase usecases.CreateCrimeExecutor) *CrimeController {
 	return &CrimeController{
 		createCrimeUseCase: createCrimeUseCase,
 	}
@@ -24,24 +28,42 @@ func NewCrimeController(createCrimeUseCase *usecases.CreateCrimeUseCase) *CrimeC
 
 // CreateCrimeRequest representa la estructura de la petición HTTP
 type CreateCrimeRequest struct {
-	Type        string    `json:"type" binding:"required"`
-	Description string    `json:"description" binding:"required"`
-	Location    Location  `json:"location" binding:"required"`
-	Date        time.Time `json:"date" binding:"required"`
+	Type        string            `json:"type" binding:"required"`
+	Description string            `json:"description" binding:"required"`
+	Location    Location          `json:"location" binding:"required"`
+	Date        time.Time         `json:"date" binding:"required"`
+	Severity    entities.Severity `json:"severity"`     // Por defecto se deriva del tipo, el arma y la violencia
+	Weapon      entities.Weapon   `json:"weapon"`       // none por defecto
+	Violent     *bool             `json:"violent"`      // Por defecto se deriva del tipo
+	VictimCount int               `json:"victim_count"` // 0 si se desconoce
 }
 
-// Location representa la ubicación en la petición HTTP
+// Location representa la ubicación en la petición HTTP. Si el servidor tiene un nomenclador
+// basta con las coordenadas o con la dirección y se completa la que falte; si no, se
+// requieren ambas
 type Location struct {
-	Latitude  float64 `json:"latitude" binding:"required"`
-	Longitude float64 `json:"longitude" binding:"required"`
-	Address   string  `json:"address" binding:"required"`
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+	Address   string  `json:"address"`
+}
+
+// errEmptyLocation es el mensaje de las peticiones sin coordenadas ni dirección
+const errEmptyLocation = "la ubicación requiere coordenadas o una dirección"
+
+// isEmpty indica si la ubicación no tiene coordenadas ni dirección
+func (l Location) isEmpty() bool {
+	return l.Latitude == 0 && l.Longitude == 0 && strings.TrimSpace(l.Address) == ""
 }
 
 // Create maneja la petición POST para crear un nuevo delito
 func (c *CrimeController) Create(ctx *gin.Context) {
 	var req CreateCrimeRequest
 	if err := ctx.ShouldBindJSON(&req); err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		ctx.JSON(http.StatusBadRequest, middleware.ErrorBody(ctx, err.Error()))
+		return
+	}
+	if req.Location.isEmpty() {
+		ctx.JSON(http.StatusBadRequest, middleware.ErrorBody(ctx, errEmptyLocation))
 		return
 	}
 
@@ -53,7 +75,11 @@ func (c *CrimeController) Create(ctx *gin.Context) {
 			Longitude: req.Location.Longitude,
 			Address:   req.Location.Address,
 		},
-		Date: req.Date,
+		Date:        req.Date,
+		Severity:    req.Severity,
+		Weapon:      req.Weapon,
+		Violent:     req.Violent,
+		VictimCount: req.VictimCount,
 	}
 
 	crime, err := c.createCrimeUseCase.Execute(ctx.Request.Context(), input)
@@ -72,12 +98,23 @@ func (c *CrimeController) Create(ctx *gin.Context) {
 			statusCode = http.StatusBadRequest
 		case errors.Is(err, usecases.ErrInvalidLongitude):
 			statusCode = http.StatusBadRequest
+		case errors.Is(err, usecases.ErrMissingCoordinates),
+			errors.Is(err, usecases.ErrMissingAddress),
+			errors.Is(err, usecases.ErrAddressNotFound):
+			statusCode = http.StatusBadRequest
+		case errors.Is(err, usecases.ErrInvalidSeverity),
+			errors.Is(err, usecases.ErrInvalidWeapon),
+			errors.Is(err, usecases.ErrInvalidVictimCount):
+			statusCode = http.StatusBadRequest
 		case errors.Is(err, usecases.ErrDuplicateCrime):
 			statusCode = http.StatusConflict
 		default:
 			statusCode = http.StatusInternalServerError
 		}
-		ctx.JSON(statusCode, gin.H{"error": err.Error()})
+		if statusCode == http.StatusInternalServerError {
+			slog.ErrorContext(ctx.Request.Context(), "error al crear el delito", slog.Any("error", err))
+		}
+		ctx.JSON(statusCode, middleware.ErrorBody(ctx, err.Error()))
 		return
 	}
 
@@ -0,0 +1,80 @@
+package http
+
+import (
+	"net/http"
+
+	"go-crime_map_backend/internal/interfaces/http/middleware"
+	"go-crime_map_backend/internal/usecases"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CrimeEditController maneja las peticiones HTTP de corrección y eliminación de delitos
+type CrimeEditController struct {
+	updateUseCase *usecases.UpdateCrimeUseCase
+	deleteUseCase *usecases.DeleteCrimeUseCase
+}
+
+// NewCrimeEditController crea una nueva instancia del controlador
+func NewCrimeEditController(updateUseCase *usecases.UpdateCrimeUseCase, deleteUseCase *usecases.DeleteCrimeUseCase) *CrimeEditController {
+	return &CrimeEditController{
+		updateUseCase: updateUseCase,
+		deleteUseCase: deleteUseCase,
+	}
+}
+
+// Update maneja la petición PUT para corregir los datos de un delito
+func (c *CrimeEditController) Update(ctx *gin.Context) {
+	id, ok := idParam(ctx, "id", usecases.ErrCrimeNotFound)
+	if !ok {
+		return
+	}
+	var req CreateCrimeRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, middleware.ErrorBody(ctx, err.Error()))
+		return
+	}
+	if req.Location.isEmpty() {
+		ctx.JSON(http.StatusBadRequest, middleware.ErrorBody(ctx, errEmptyLocation))
+		return
+	}
+
+	crime, err := c.updateUseCase.Execute(ctx.Request.Context(), usecases.UpdateCrimeInput{
+		CrimeID: id,
+		Data: usecases.CreateCrimeInput{
+			Type:        req.Type,
+			Description: req.Description,
+			Location: usecases.Location{
+				Latitude:  req.Location.Latitude,
+				Longitude: req.Location.Longitude,
+				Address:   req.Location.Address,
+			},
+			Date:        req.Date,
+			Severity:    req.Severity,
+			Weapon:      req.Weapon,
+			Violent:     req.Violent,
+			VictimCount: req.VictimCount,
+		},
+		Actor: middleware.ActorFromContext(ctx),
+	})
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, crime)
+}
+
+// Delete maneja la petición DELETE para eliminar un delito
+func (c *CrimeEditController) Delete(ctx *gin.Context) {
+	id, ok := idParam(ctx, "id", usecases.ErrCrimeNotFound)
+	if !ok {
+		return
+	}
+	if err := c.deleteUseCase.Execute(ctx.Request.Context(), id, middleware.ActorFromContext(ctx)); err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
@@ -0,0 +1,43 @@
+package http
+
+import (
+	"net/http"
+
+	"go-crime_map_backend/internal/domain/entities"
+	"go-crime_map_backend/internal/interfaces/http/middleware"
+	"go-crime_map_backend/internal/usecases"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CrimeHistoryController maneja las peticiones HTTP del historial de versiones de los delitos
+type CrimeHistoryController struct {
+	historyUseCase *usecases.GetCrimeHistoryUseCase
+}
+
+// NewCrimeHistoryController crea una nueva instancia del controlador
+func NewCrimeHistoryController(historyUseCase *usecases.GetCrimeHistoryUseCase) *CrimeHistoryController {
+	return &CrimeHistoryController{
+		historyUseCase: historyUseCase,
+	}
+}
+
+// CrimeHistoryResponse representa la respuesta del historial de versiones
+type CrimeHistoryResponse struct {
+	Revisions []*entities.CrimeRevision `json:"revisions"`
+}
+
+// History maneja la petición GET para obtener las revisiones de un delito
+func (c *CrimeHistoryController) History(ctx *gin.Context) {
+	id, ok := idParam(ctx, "id", usecases.ErrCrimeNotFound)
+	if !ok {
+		return
+	}
+	revisions, err := c.historyUseCase.Execute(ctx.Request.Context(), id, middleware.ActorFromContext(ctx))
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, CrimeHistoryResponse{Revisions: revisions})
+}
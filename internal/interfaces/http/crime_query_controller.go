@@ -0,0 +1,82 @@
+package http
+
+import (
+	"net/http"
+	"time"
+
+	"go-crime_map_backend/internal/domain/entities"
+	"go-crime_map_backend/internal/interfaces/http/middleware"
+	"go-crime_map_backend/internal/usecases"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CrimeQueryController maneja las peticiones HTTP de consulta de delitos
+type CrimeQueryController struct {
+	listCrimesUseCase   *usecases.ListCrimesUseCase
+	getCrimeUseCase     *usecases.GetCrimeUseCase
+	getCrimeAsOfUseCase *usecases.GetCrimeAsOfUseCase
+}
+
+// NewCrimeQueryController crea una nueva instancia del controlador
+func NewCrimeQueryController(listCrimesUseCase *usecases.ListCrimesUseCase, getCrimeUseCase *usecases.GetCrimeUseCase, getCrimeAsOfUseCase *usecases.GetCrimeAsOfUseCase) *CrimeQueryController {
+	return &CrimeQueryController{
+		listCrimesUseCase:   listCrimesUseCase,
+		getCrimeUseCase:     getCrimeUseCase,
+		getCrimeAsOfUseCase: getCrimeAsOfUseCase,
+	}
+}
+
+// ListCrimesResponse representa la respuesta del listado de delitos
+type ListCrimesResponse struct {
+	Crimes []*entities.Crime `json:"crimes"`
+	Count  int               `json:"count"`
+}
+
+// List maneja la petición GET para listar delitos, filtrando por estado, tipo y zona
+func (c *CrimeQueryController) List(ctx *gin.Context) {
+	var statuses []entities.CrimeStatus
+	for _, status := range queryList(ctx, "status") {
+		statuses = append(statuses, entities.CrimeStatus(status))
+	}
+
+	crimes, err := c.listCrimesUseCase.Execute(ctx.Request.Context(), usecases.ListCrimesInput{
+		Statuses: statuses,
+		Types:    queryList(ctx, "type"),
+		ZoneIDs:  queryList(ctx, "zone"),
+		Actor:    middleware.ActorFromContext(ctx),
+	})
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, ListCrimesResponse{Crimes: crimes, Count: len(crimes)})
+}
+
+// Get maneja la petición GET para obtener un delito por su ID.
+// Con el parámetro as_of retorna el delito tal como estaba en ese instante.
+func (c *CrimeQueryController) Get(ctx *gin.Context) {
+	id, ok := idParam(ctx, "id", usecases.ErrCrimeNotFound)
+	if !ok {
+		return
+	}
+	var crime *entities.Crime
+	var err error
+	if asOf := ctx.Query("as_of"); asOf != "" {
+		at, parseErr := time.Parse(time.RFC3339, asOf)
+		if parseErr != nil {
+			ctx.JSON(http.StatusBadRequest, middleware.ErrorBody(ctx, "as_of debe tener formato RFC 3339"))
+			return
+		}
+		crime, err = c.getCrimeAsOfUseCase.Execute(ctx.Request.Context(), id, at, middleware.ActorFromContext(ctx))
+	} else {
+		crime, err = c.getCrimeUseCase.Execute(ctx.Request.Context(), id, middleware.ActorFromContext(ctx))
+	}
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, crime)
+}
@@ -0,0 +1,77 @@
+package http
+
+import (
+	"net/http"
+
+	"go-crime_map_backend/internal/domain/entities"
+	"go-crime_map_backend/internal/interfaces/http/middleware"
+	"go-crime_map_backend/internal/usecases"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CrimeStatusController maneja las peticiones HTTP del flujo de estados de los delitos
+type CrimeStatusController struct {
+	transitionUseCase *usecases.TransitionCrimeStatusUseCase
+	historyUseCase    *usecases.GetCrimeStatusHistoryUseCase
+}
+
+// NewCrimeStatusController crea una nueva instancia del controlador
+func NewCrimeStatusController(transitionUseCase *usecases.TransitionCrimeStatusUseCase, historyUseCase *usecases.GetCrimeStatusHistoryUseCase) *CrimeStatusController {
+	return &CrimeStatusController{
+		transitionUseCase: transitionUseCase,
+		historyUseCase:    historyUseCase,
+	}
+}
+
+// TransitionStatusRequest representa la petición para cambiar el estado de un delito
+type TransitionStatusRequest struct {
+	Status string `json:"status" binding:"required"`
+	Reason string `json:"reason"`
+}
+
+// StatusHistoryResponse representa la respuesta del historial de estados
+type StatusHistoryResponse struct {
+	History []*entities.CrimeStatusChange `json:"history"`
+}
+
+// Transition maneja la petición POST para cambiar el estado de un delito
+func (c *CrimeStatusController) Transition(ctx *gin.Context) {
+	id, ok := idParam(ctx, "id", usecases.ErrCrimeNotFound)
+	if !ok {
+		return
+	}
+	var req TransitionStatusRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, middleware.ErrorBody(ctx, err.Error()))
+		return
+	}
+
+	crime, err := c.transitionUseCase.Execute(ctx.Request.Context(), usecases.TransitionCrimeStatusInput{
+		CrimeID: id,
+		Status:  entities.CrimeStatus(req.Status),
+		Reason:  req.Reason,
+		Actor:   middleware.ActorFromContext(ctx),
+	})
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, crime)
+}
+
+// History maneja la petición GET para obtener el historial de estados de un delito
+func (c *CrimeStatusController) History(ctx *gin.Context) {
+	id, ok := idParam(ctx, "id", usecases.ErrCrimeNotFound)
+	if !ok {
+		return
+	}
+	history, err := c.historyUseCase.Execute(ctx.Request.Context(), id, middleware.ActorFromContext(ctx))
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, StatusHistoryResponse{History: history})
+}
@@ -0,0 +1,242 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"go-crime_map_backend/internal/domain/entities"
+	"go-crime_map_backend/internal/interfaces/http/middleware"
+	"go-crime_map_backend/internal/usecases"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+)
+
+// sseRetry es la espera en milisegundos que el navegador aplica antes de reconectarse
+const sseRetry = 3000
+
+// StreamOptions configura las conexiones del feed en tiempo real
+type StreamOptions struct {
+	HeartbeatInterval time.Duration // Frecuencia de los pings que mantienen viva la conexión
+	WriteTimeout      time.Duration // Tiempo máximo de cada escritura antes de cerrar la conexión
+	AllowedOrigins    []string      // Orígenes aceptados para WebSocket, * para cualquiera
+}
+
+// CrimeStreamController maneja las conexiones del feed de delitos en tiempo real
+type CrimeStreamController struct {
+	feed     *usecases.CrimeFeed
+	opts     StreamOptions
+	upgrader websocket.Upgrader
+}
+
+// NewCrimeStreamController crea una nueva instancia del controlador
+func NewCrimeStreamController(feed *usecases.CrimeFeed, opts StreamOptions) *CrimeStreamController {
+	c := &CrimeStreamController{feed: feed, opts: opts}
+	c.upgrader = websocket.Upgrader{CheckOrigin: c.checkOrigin}
+	return c
+}
+
+// Stream maneja la petición GET que envía los cambios de los delitos como Server-Sent Events
+func (c *CrimeStreamController) Stream(ctx *gin.Context) {
+	lastEventID := ctx.GetHeader("Last-Event-ID")
+	if lastEventID == "" {
+		lastEventID = ctx.Query("last_event_id")
+	}
+	subscription, ok := c.subscribe(ctx, lastEventID)
+	if !ok {
+		return
+	}
+	defer subscription.Close()
+
+	header := ctx.Writer.Header()
+	header.Set("Content-Type", "text/event-stream")
+	header.Set("Cache-Control", "no-cache")
+	header.Set("Connection", "keep-alive")
+	header.Set("X-Accel-Buffering", "no") // Evita que nginx acumule la respuesta
+	ctx.Status(http.StatusOK)
+
+	controller := http.NewResponseController(ctx.Writer)
+	write := func(frame string) bool {
+		// El plazo acota el tiempo que una conexión lenta retiene este handler
+		_ = controller.SetWriteDeadline(time.Now().Add(c.opts.WriteTimeout))
+		if _, err := ctx.Writer.WriteString(frame); err != nil {
+			return false
+		}
+		ctx.Writer.Flush()
+		return true
+	}
+
+	if !write(fmt.Sprintf("retry: %d\n\n", sseRetry)) {
+		return
+	}
+	if subscription.Reset && !write("event: reset\ndata: {}\n\n") {
+		return
+	}
+	for _, event := range subscription.Backlog {
+		if !write(sseFrame(event)) {
+			return
+		}
+	}
+
+	heartbeat := time.NewTicker(c.opts.HeartbeatInterval)
+	defer heartbeat.Stop()
+	for {
+		select {
+		case <-ctx.Request.Context().Done():
+			return
+		case <-heartbeat.C:
+			if !write(": ping\n\n") {
+				return
+			}
+		case event, open := <-subscription.Events():
+			// Un canal cerrado indica que el cliente se retrasó; EventSource se reconecta
+			// con Last-Event-ID y recibe lo que perdió
+			if !open || !write(sseFrame(event)) {
+				return
+			}
+		}
+	}
+}
+
+// WebSocket maneja la petición GET que envía los cambios de los delitos por WebSocket
+func (c *CrimeStreamController) WebSocket(ctx *gin.Context) {
+	subscription, ok := c.subscribe(ctx, ctx.Query("last_event_id"))
+	if !ok {
+		return
+	}
+	defer subscription.Close()
+
+	conn, err := c.upgrader.Upgrade(ctx.Writer, ctx.Request, nil)
+	if err != nil {
+		// El upgrader ya respondió con el error
+		return
+	}
+	defer conn.Close()
+
+	// Lectura de los pongs y del cierre del cliente; los mensajes recibidos se descartan
+	readCtx, cancel := context.WithCancel(ctx.Request.Context())
+	defer cancel()
+	readTimeout := 2 * c.opts.HeartbeatInterval
+	_ = conn.SetReadDeadline(time.Now().Add(readTimeout))
+	conn.SetPongHandler(func(string) error {
+		return conn.SetReadDeadline(time.Now().Add(readTimeout))
+	})
+	go func() {
+		defer cancel()
+		for {
+			if _, _, err := conn.NextReader(); err != nil {
+				return
+			}
+		}
+	}()
+
+	write := func(message any) bool {
+		_ = conn.SetWriteDeadline(time.Now().Add(c.opts.WriteTimeout))
+		return conn.WriteJSON(message) == nil
+	}
+
+	if subscription.Reset && !write(gin.H{"action": "reset"}) {
+		return
+	}
+	for _, event := range subscription.Backlog {
+		if !write(event) {
+			return
+		}
+	}
+
+	heartbeat := time.NewTicker(c.opts.HeartbeatInterval)
+	defer heartbeat.Stop()
+	for {
+		select {
+		case <-readCtx.Done():
+			return
+		case <-heartbeat.C:
+			if err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(c.opts.WriteTimeout)); err != nil {
+				return
+			}
+		case event, open := <-subscription.Events():
+			if !open {
+				// El cliente se retrasó: se cierra con 1013 para que se reconecte con last_event_id
+				closeMessage := websocket.FormatCloseMessage(websocket.CloseTryAgainLater, "cliente demasiado lento")
+				_ = conn.WriteControl(websocket.CloseMessage, closeMessage, time.Now().Add(c.opts.WriteTimeout))
+				return
+			}
+			if !write(event) {
+				return
+			}
+		}
+	}
+}
+
+// subscribe interpreta los filtros de la petición y suscribe la conexión al feed
+func (c *CrimeStreamController) subscribe(ctx *gin.Context, lastEventID string) (*usecases.CrimeFeedSubscription, bool) {
+	filter := usecases.CrimeFeedFilter{
+		Types: queryList(ctx, "type"),
+		Actor: middleware.ActorFromContext(ctx),
+	}
+	if raw := ctx.Query("bbox"); raw != "" {
+		box, err := parseBoundingBox(raw)
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, middleware.ErrorBody(ctx, err.Error()))
+			return nil, false
+		}
+		filter.BoundingBox = box
+	}
+
+	subscription, err := c.feed.Subscribe(filter, lastEventID)
+	if err != nil {
+		respondError(ctx, err)
+		return nil, false
+	}
+	return subscription, true
+}
+
+// checkOrigin acepta las conexiones WebSocket sin Origin o de los orígenes permitidos por CORS
+func (c *CrimeStreamController) checkOrigin(r *http.Request) bool {
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		return true
+	}
+	for _, allowed := range c.opts.AllowedOrigins {
+		if allowed == "*" || strings.EqualFold(allowed, origin) {
+			return true
+		}
+	}
+	return false
+}
+
+// sseFrame serializa un evento del feed como un mensaje de Server-Sent Events
+func sseFrame(event usecases.CrimeFeedEvent) string {
+	data, err := json.Marshal(event)
+	if err != nil {
+		data = []byte("{}")
+	}
+	return fmt.Sprintf("id: %s\nevent: %s\ndata: %s\n\n", event.ID, event.Action, data)
+}
+
+// parseBoundingBox interpreta una zona con el formato oeste,sur,este,norte
+func parseBoundingBox(raw string) (*entities.BoundingBox, error) {
+	parts := strings.Split(raw, ",")
+	if len(parts) != 4 {
+		return nil, fmt.Errorf("bbox debe tener el formato oeste,sur,este,norte")
+	}
+	values := make([]float64, len(parts))
+	for i, part := range parts {
+		value, err := strconv.ParseFloat(strings.TrimSpace(part), 64)
+		if err != nil {
+			return nil, fmt.Errorf("bbox debe tener el formato oeste,sur,este,norte")
+		}
+		values[i] = value
+	}
+	return &entities.BoundingBox{
+		MinLongitude: values[0],
+		MinLatitude:  values[1],
+		MaxLongitude: values[2],
+		MaxLatitude:  values[3],
+	}, nil
+}
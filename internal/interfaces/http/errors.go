@@ -0,0 +1,108 @@
+package http
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"go-crime_map_backend/internal/domain/repositories"
+	"go-crime_map_backend/internal/interfaces/http/middleware"
+	"go-crime_map_backend/internal/usecases"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// respondError responde con el código HTTP que corresponde al error de los casos de uso
+func respondError(ctx *gin.Context, err error) {
+	var statusCode int
+	_, invalidInput := usecases.ValidationErrorCode(err)
+	switch {
+	case invalidInput:
+		statusCode = http.StatusBadRequest
+	case errors.Is(err, usecases.ErrCrimeNotFound),
+		errors.Is(err, usecases.ErrWebhookNotFound),
+		errors.Is(err, usecases.ErrWebhookDeliveryNotFound),
+		errors.Is(err, usecases.ErrWatchAreaNotFound),
+		errors.Is(err, usecases.ErrNotificationNotFound),
+		errors.Is(err, usecases.ErrZoneNotFound):
+		statusCode = http.StatusNotFound
+	case errors.Is(err, usecases.ErrForbidden):
+		statusCode = http.StatusForbidden
+	case errors.Is(err, usecases.ErrInvalidStatus),
+		errors.Is(err, usecases.ErrReasonRequired),
+		errors.Is(err, usecases.ErrReasonTooLong),
+		errors.Is(err, usecases.ErrInvalidQueueSort),
+		errors.Is(err, usecases.ErrInvalidDecision),
+		errors.Is(err, usecases.ErrInvalidWebhookURL),
+		errors.Is(err, usecases.ErrInvalidEventType),
+		errors.Is(err, usecases.ErrInvalidBoundingBox),
+		errors.Is(err, usecases.ErrWebhookSecretTooShort),
+		errors.Is(err, usecases.ErrInvalidDeliveryStatus),
+		errors.Is(err, usecases.ErrInvalidWatchAreaGeometry),
+		errors.Is(err, usecases.ErrWatchAreaNameTooLong),
+		errors.Is(err, usecases.ErrInvalidQuietHours),
+		errors.Is(err, usecases.ErrInvalidNotificationChannel),
+		errors.Is(err, usecases.ErrInvalidEmail),
+		errors.Is(err, usecases.ErrInvalidZoneName),
+		errors.Is(err, usecases.ErrInvalidZoneKind),
+		errors.Is(err, usecases.ErrInvalidZonePopulation),
+		errors.Is(err, usecases.ErrInvalidZoneGeometry),
+		errors.Is(err, usecases.ErrInvalidZoneImport),
+		errors.Is(err, usecases.ErrInvalidCrimeImport),
+		errors.Is(err, usecases.ErrInvalidStatsPeriod),
+		errors.Is(err, usecases.ErrInvalidStatsSort),
+		errors.Is(err, usecases.ErrInvalidTimeBucket),
+		errors.Is(err, usecases.ErrInvalidTimezone),
+		errors.Is(err, usecases.ErrInvalidHotspotMethod),
+		errors.Is(err, usecases.ErrInvalidHotspotParameters),
+		errors.Is(err, usecases.ErrTooManyHotspotCrimes),
+		errors.Is(err, usecases.ErrInvalidAggregation),
+		errors.Is(err, usecases.ErrInvalidSignificanceParameters),
+		errors.Is(err, usecases.ErrInvalidAnomalyLimit),
+		errors.Is(err, usecases.ErrInvalidAnomalyPeriod),
+		errors.Is(err, usecases.ErrInvalidSafetyLocation),
+		errors.Is(err, usecases.ErrInvalidSafetyRoute),
+		errors.Is(err, usecases.ErrInvalidSafetyParameters),
+		errors.Is(err, usecases.ErrInvalidTile),
+		errors.Is(err, usecases.ErrInvalidExportFormat):
+		statusCode = http.StatusBadRequest
+	case errors.Is(err, usecases.ErrInvalidTransition),
+		errors.Is(err, repositories.ErrStatusConflict),
+		errors.Is(err, repositories.ErrClaimConflict),
+		errors.Is(err, usecases.ErrNotPending),
+		errors.Is(err, usecases.ErrClaimNotHeld),
+		errors.Is(err, usecases.ErrTooManyWatchAreas),
+		errors.Is(err, usecases.ErrDuplicateZone):
+		statusCode = http.StatusConflict
+	default:
+		statusCode = http.StatusInternalServerError
+		slog.ErrorContext(ctx.Request.Context(), "error al procesar la solicitud", slog.Any("error", err))
+	}
+	ctx.JSON(statusCode, middleware.ErrorBody(ctx, err.Error()))
+}
+
+// idParam retorna el ID del parámetro de ruta. Los IDs son UUID, así que si no lo es ningún
+// recurso puede tenerlo: responde 404 con notFound sin consultar el repositorio
+func idParam(ctx *gin.Context, name string, notFound error) (string, bool) {
+	id := ctx.Param(name)
+	if _, err := uuid.Parse(id); err != nil {
+		ctx.JSON(http.StatusNotFound, middleware.ErrorBody(ctx, notFound.Error()))
+		return "", false
+	}
+	return id, true
+}
+
+// queryList obtiene un parámetro de consulta con valores separados por coma
+func queryList(ctx *gin.Context, name string) []string {
+	var values []string
+	for _, raw := range ctx.QueryArray(name) {
+		for _, value := range strings.Split(raw, ",") {
+			if value = strings.TrimSpace(value); value != "" {
+				values = append(values, value)
+			}
+		}
+	}
+	return values
+}
@@ -0,0 +1,48 @@
+package http
+
+import (
+	"log/slog"
+	"net/http"
+
+	"go-crime_map_backend/internal/usecases"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ExportController maneja las peticiones HTTP de exportación de delitos
+type ExportController struct {
+	exportUseCase *usecases.ExportCrimesUseCase
+}
+
+// NewExportController crea una nueva instancia del controlador
+func NewExportController(exportUseCase *usecases.ExportCrimesUseCase) *ExportController {
+	return &ExportController{exportUseCase: exportUseCase}
+}
+
+// Crimes maneja la petición GET que exporta los delitos en el formato format (kml o gpx), con
+// los mismos filtros que las estadísticas. El archivo se envía a medida que se lee de la base
+func (c *ExportController) Crimes(ctx *gin.Context) {
+	criteria, ok := queryStatsCriteria(ctx)
+	if !ok {
+		return
+	}
+	export, err := c.exportUseCase.Execute(ctx.Request.Context(), usecases.ExportCrimesInput{
+		StatsCriteria: criteria,
+		Format:        ctx.Query("format"),
+	})
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.Header("Content-Type", export.ContentType)
+	ctx.Header("Content-Disposition", `attachment; filename="`+export.Filename+`"`)
+	ctx.Header("X-Accel-Buffering", "no") // Evita que nginx acumule el archivo
+	ctx.Status(http.StatusOK)
+	// Una vez enviado el encabezado ya no se puede responder el error; el archivo queda
+	// incompleto y el cliente lo detecta al no poder leerlo
+	if written, err := export.WriteTo(ctx.Request.Context(), ctx.Writer); err != nil {
+		slog.ErrorContext(ctx.Request.Context(), "error al exportar los delitos",
+			slog.String("format", export.Format), slog.Int("written", written), slog.Any("error", err))
+	}
+}
@@ -0,0 +1,92 @@
+package http
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+
+	"go-crime_map_backend/internal/interfaces/http/middleware"
+	"go-crime_map_backend/internal/usecases"
+
+	"github.com/gin-gonic/gin"
+)
+
+// maxShapefileImportBytes limita el tamaño del zip de una importación de shapefiles
+const maxShapefileImportBytes = 50 << 20
+
+// CrimeImportController maneja las peticiones HTTP de importación de delitos
+type CrimeImportController struct {
+	importUseCase *usecases.ImportCrimesUseCase
+}
+
+// NewCrimeImportController crea una nueva instancia del controlador
+func NewCrimeImportController(importUseCase *usecases.ImportCrimesUseCase) *CrimeImportController {
+	return &CrimeImportController{importUseCase: importUseCase}
+}
+
+// Shapefile maneja la petición POST para importar delitos desde un shapefile de puntos en un
+// zip. Las columnas del .dbf se indican con los parámetros *_field, el sistema de coordenadas
+// con srid si el zip no tiene .prj y la zona horaria de las fechas con timezone
+func (c *CrimeImportController) Shapefile(ctx *gin.Context) {
+	data, ok := readShapefileBody(ctx)
+	if !ok {
+		return
+	}
+	srid, ok := querySRID(ctx)
+	if !ok {
+		return
+	}
+
+	result, err := c.importUseCase.Execute(ctx.Request.Context(), usecases.ImportCrimesInput{
+		Data: data,
+		Fields: usecases.CrimeFieldMapping{
+			Type:        ctx.Query("type_field"),
+			Description: ctx.Query("description_field"),
+			Date:        ctx.Query("date_field"),
+			Time:        ctx.Query("time_field"),
+			Address:     ctx.Query("address_field"),
+			Severity:    ctx.Query("severity_field"),
+			Weapon:      ctx.Query("weapon_field"),
+			VictimCount: ctx.Query("victims_field"),
+		},
+		SRID:     srid,
+		Timezone: ctx.Query("timezone"),
+	}, middleware.ActorFromContext(ctx))
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, result)
+}
+
+// readShapefileBody lee el zip del cuerpo de la petición. Si no se puede leer o excede el
+// límite responde el error y retorna false
+func readShapefileBody(ctx *gin.Context) ([]byte, bool) {
+	data, err := io.ReadAll(http.MaxBytesReader(ctx.Writer, ctx.Request.Body, maxShapefileImportBytes))
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			ctx.JSON(http.StatusRequestEntityTooLarge, middleware.ErrorBody(ctx, "el shapefile no puede exceder los 50 MB"))
+			return nil, false
+		}
+		ctx.JSON(http.StatusBadRequest, middleware.ErrorBody(ctx, "no se pudo leer el shapefile"))
+		return nil, false
+	}
+	return data, true
+}
+
+// querySRID lee el código EPSG del parámetro srid, 0 si no se indica
+func querySRID(ctx *gin.Context) (int, bool) {
+	raw := ctx.Query("srid")
+	if raw == "" {
+		return 0, true
+	}
+	srid, err := strconv.Atoi(raw)
+	if err != nil || srid <= 0 {
+		ctx.JSON(http.StatusBadRequest, middleware.ErrorBody(ctx, "srid debe ser un código EPSG"))
+		return 0, false
+	}
+	return srid, true
+}
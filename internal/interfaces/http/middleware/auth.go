@@ -0,0 +1,84 @@
+package middleware
+
+import (
+	"context"
+	"crypto/sha256"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"go-crime_map_backend/internal/domain/entities"
+)
+
+// ActorKey es la clave del contexto de Gin donde se guarda el actor de la solicitud
+const ActorKey = "actor"
+
+// APIKeyAuthenticator resuelve el actor asociado a una clave de API
+type APIKeyAuthenticator interface {
+	// Authenticate retorna el actor de la clave y false si la clave no existe
+	Authenticate(ctx context.Context, apiKey string) (entities.Actor, bool, error)
+}
+
+// StaticAPIKeys implementa APIKeyAuthenticator con un conjunto fijo de claves configuradas
+type StaticAPIKeys struct {
+	actors map[[sha256.Size]byte]entities.Actor
+}
+
+// NewStaticAPIKeys crea el autenticador a partir de un mapa de clave de API a actor
+func NewStaticAPIKeys(keys map[string]entities.Actor) *StaticAPIKeys {
+	actors := make(map[[sha256.Size]byte]entities.Actor, len(keys))
+	for key, actor := range keys {
+		actors[sha256.Sum256([]byte(key))] = actor
+	}
+	return &StaticAPIKeys{actors: actors}
+}
+
+// Authenticate busca el actor por el hash de la clave para no comparar claves en texto plano
+func (k *StaticAPIKeys) Authenticate(ctx context.Context, apiKey string) (entities.Actor, bool, error) {
+	actor, ok := k.actors[sha256.Sum256([]byte(apiKey))]
+	return actor, ok, nil
+}
+
+// Authenticate crea un middleware que identifica al actor por su clave de API.
+// Las solicitudes sin clave continúan como anónimas; las claves inválidas se rechazan.
+func Authenticate(authenticator APIKeyAuthenticator) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		apiKey := c.GetHeader(APIKeyHeader)
+		if apiKey == "" {
+			setActor(c, entities.AnonymousActor)
+			c.Next()
+			return
+		}
+
+		actor, ok, err := authenticator.Authenticate(c.Request.Context(), apiKey)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusInternalServerError, ErrorBody(c, "error al validar la clave de API"))
+			return
+		}
+		if !ok {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, ErrorBody(c, "clave de API inválida"))
+			return
+		}
+
+		c.Set(UserIDKey, actor.ID)
+		setActor(c, actor)
+		c.Next()
+	}
+}
+
+// setActor guarda el actor en el contexto de Gin y en el de la solicitud, donde lo leen
+// los repositorios para registrar la auditoría
+func setActor(c *gin.Context, actor entities.Actor) {
+	c.Set(ActorKey, actor)
+	c.Request = c.Request.WithContext(entities.ContextWithActor(c.Request.Context(), actor))
+}
+
+// ActorFromContext obtiene el actor de la solicitud, anónimo si no se autenticó
+func ActorFromContext(c *gin.Context) entities.Actor {
+	if value, exists := c.Get(ActorKey); exists {
+		if actor, ok := value.(entities.Actor); ok {
+			return actor
+		}
+	}
+	return entities.AnonymousActor
+}
@@ -0,0 +1,93 @@
+package middleware
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+)
+
+// bucketIdleTimeout define cuánto tiempo se conserva un bucket sin uso
+const bucketIdleTimeout = 10 * time.Minute
+
+// tokenBucket representa el estado de un bucket de tokens
+type tokenBucket struct {
+	tokens   float64
+	lastSeen time.Time
+}
+
+// MemoryRateLimitStore implementa RateLimitStore en memoria para una sola instancia
+type MemoryRateLimitStore struct {
+	mu        sync.Mutex
+	buckets   map[string]*tokenBucket
+	lastSweep time.Time
+	now       func() time.Time
+}
+
+// NewMemoryRateLimitStore crea una nueva instancia del almacenamiento en memoria
+func NewMemoryRateLimitStore() *MemoryRateLimitStore {
+	return NewMemoryRateLimitStoreWithClock(time.Now)
+}
+
+// NewMemoryRateLimitStoreWithClock crea el almacenamiento con un reloj propio, útil en pruebas
+func NewMemoryRateLimitStoreWithClock(now func() time.Time) *MemoryRateLimitStore {
+	return &MemoryRateLimitStore{
+		buckets:   make(map[string]*tokenBucket),
+		lastSweep: now(),
+		now:       now,
+	}
+}
+
+// Take intenta consumir un token del bucket identificado por key
+func (s *MemoryRateLimitStore) Take(ctx context.Context, key string, policy RateLimitPolicy) (RateLimitResult, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	s.sweep(now)
+
+	burst := float64(policy.Burst)
+	bucket, exists := s.buckets[key]
+	if !exists {
+		bucket = &tokenBucket{tokens: burst, lastSeen: now}
+		s.buckets[key] = bucket
+	}
+
+	// Reponer los tokens acumulados desde el último acceso
+	elapsed := now.Sub(bucket.lastSeen).Seconds()
+	bucket.tokens = math.Min(burst, bucket.tokens+elapsed*policy.Rate)
+	bucket.lastSeen = now
+
+	result := RateLimitResult{Limit: policy.Burst}
+	if bucket.tokens >= 1 {
+		bucket.tokens--
+		result.Allowed = true
+	} else {
+		result.RetryAfter = durationForTokens(1-bucket.tokens, policy.Rate)
+	}
+	result.Remaining = int(bucket.tokens)
+	result.ResetAfter = durationForTokens(burst-bucket.tokens, policy.Rate)
+
+	return result, nil
+}
+
+// sweep elimina los buckets que no se usan hace tiempo para acotar la memoria
+func (s *MemoryRateLimitStore) sweep(now time.Time) {
+	if now.Sub(s.lastSweep) < bucketIdleTimeout {
+		return
+	}
+	for key, bucket := range s.buckets {
+		if now.Sub(bucket.lastSeen) > bucketIdleTimeout {
+			delete(s.buckets, key)
+		}
+	}
+	s.lastSweep = now
+}
+
+// durationForTokens calcula el tiempo necesario para reponer la cantidad de tokens indicada
+func durationForTokens(tokens, rate float64) time.Duration {
+	if rate <= 0 {
+		return bucketIdleTimeout
+	}
+	return time.Duration(tokens / rate * float64(time.Second))
+}
@@ -0,0 +1,101 @@
+package middleware
+
+import (
+	"context"
+	"log/slog"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// UserIDKey es la clave del contexto de Gin donde se guarda el usuario autenticado
+	UserIDKey = "user_id"
+
+	// APIKeyHeader es la cabecera con la que los clientes envían su clave de API
+	APIKeyHeader = "X-API-Key"
+)
+
+// RateLimitPolicy define la capacidad de un bucket de tokens
+type RateLimitPolicy struct {
+	Name  string  // Nombre de la política, separa los buckets de distintas políticas
+	Rate  float64 // Tokens que se reponen por segundo
+	Burst int     // Capacidad máxima del bucket
+}
+
+// RateLimitResult representa el resultado de consumir un token del bucket
+type RateLimitResult struct {
+	Allowed    bool
+	Limit      int
+	Remaining  int
+	RetryAfter time.Duration // Tiempo hasta que haya un token disponible
+	ResetAfter time.Duration // Tiempo hasta que el bucket vuelva a estar lleno
+}
+
+// RateLimitStore define el almacenamiento de los buckets de tokens.
+// Permite reemplazar el almacenamiento en memoria por uno compartido entre instancias.
+type RateLimitStore interface {
+	// Take intenta consumir un token del bucket identificado por key
+	Take(ctx context.Context, key string, policy RateLimitPolicy) (RateLimitResult, error)
+}
+
+// RateLimitPolicies agrupa las políticas aplicadas a un grupo de rutas
+type RateLimitPolicies struct {
+	Read  RateLimitPolicy // Política para GET, HEAD y OPTIONS
+	Write RateLimitPolicy // Política para el resto de los métodos
+}
+
+// RateLimit crea un middleware que limita las solicitudes por identidad del cliente.
+// Debe ir después de Authenticate para agrupar por usuario autenticado
+func RateLimit(store RateLimitStore, policies RateLimitPolicies) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		policy := policies.Write
+		switch c.Request.Method {
+		case http.MethodGet, http.MethodHead, http.MethodOptions:
+			policy = policies.Read
+		}
+
+		result, err := store.Take(c.Request.Context(), policy.Name+":"+ClientIdentity(c), policy)
+		if err != nil {
+			// Si el almacenamiento falla se deja pasar la solicitud para no cortar el servicio
+			slog.WarnContext(c.Request.Context(), "error al consultar el límite de solicitudes", slog.Any("error", err))
+			c.Next()
+			return
+		}
+
+		c.Header("X-RateLimit-Limit", strconv.Itoa(result.Limit))
+		c.Header("X-RateLimit-Remaining", strconv.Itoa(result.Remaining))
+		c.Header("X-RateLimit-Reset", strconv.Itoa(ceilSeconds(result.ResetAfter)))
+
+		if !result.Allowed {
+			c.Header("Retry-After", strconv.Itoa(ceilSeconds(result.RetryAfter)))
+			c.AbortWithStatusJSON(http.StatusTooManyRequests,
+				ErrorBody(c, "demasiadas solicitudes, intente nuevamente más tarde"))
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// ClientIdentity obtiene la identidad con la que se agrupan las solicitudes de un cliente:
+// el usuario autenticado o, si no hay, la IP del cliente. La cabecera X-API-Key no cuenta
+// hasta que Authenticate la valida; si no, una clave nueva en cada solicitud evitaría el límite
+func ClientIdentity(c *gin.Context) string {
+	if userID := c.GetString(UserIDKey); userID != "" {
+		return "user:" + userID
+	}
+	// ClientIP solo considera X-Forwarded-For y X-Real-IP si provienen de un proxy confiable
+	return "ip:" + c.ClientIP()
+}
+
+// ceilSeconds redondea una duración hacia arriba a segundos enteros
+func ceilSeconds(d time.Duration) int {
+	if d <= 0 {
+		return 0
+	}
+	return int(math.Ceil(d.Seconds()))
+}
@@ -0,0 +1,57 @@
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"go-crime_map_backend/pkg/requestid"
+)
+
+const (
+	// RequestIDKey es la clave del contexto de Gin donde se guarda el identificador de la solicitud
+	RequestIDKey = "request_id"
+
+	// maxRequestIDLength define la longitud máxima aceptada para un X-Request-ID entrante
+	maxRequestIDLength = 128
+)
+
+// RequestID crea un middleware que acepta o genera el X-Request-ID de cada solicitud
+// y lo propaga en el contexto hacia los casos de uso y repositorios
+func RequestID() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id := c.GetHeader(requestid.Header)
+		if !validRequestID(id) {
+			id = uuid.New().String()
+		}
+
+		c.Set(RequestIDKey, id)
+		c.Request = c.Request.WithContext(requestid.WithRequestID(c.Request.Context(), id))
+		c.Header(requestid.Header, id)
+		c.Next()
+	}
+}
+
+// ErrorBody construye el cuerpo de una respuesta de error con el identificador de la solicitud
+func ErrorBody(c *gin.Context, message string) gin.H {
+	body := gin.H{"error": message}
+	if id := c.GetString(RequestIDKey); id != "" {
+		body["request_id"] = id
+	}
+	return body
+}
+
+// validRequestID verifica que el identificador recibido sea seguro para registrar y reenviar
+func validRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLength {
+		return false
+	}
+	for _, r := range id {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+		case r == '-', r == '_', r == '.', r == ':':
+		default:
+			return false
+		}
+	}
+	return true
+}
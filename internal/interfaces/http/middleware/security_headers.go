@@ -0,0 +1,43 @@
+package middleware
+
+import (
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SecurityOptions define las cabeceras de seguridad que se agregan a cada respuesta
+type SecurityOptions struct {
+	HSTSMaxAge            int // Segundos de Strict-Transport-Security, 0 la deshabilita
+	HSTSIncludeSubdomains bool
+	FrameOptions          string
+	// ContentSecurityPolicy se aplica por defecto; los endpoints HTML pueden reemplazarla
+	ContentSecurityPolicy string
+}
+
+// SecurityHeaders crea un middleware que agrega las cabeceras de seguridad a las respuestas
+func SecurityHeaders(opts SecurityOptions) gin.HandlerFunc {
+	hsts := ""
+	if opts.HSTSMaxAge > 0 {
+		hsts = "max-age=" + strconv.Itoa(opts.HSTSMaxAge)
+		if opts.HSTSIncludeSubdomains {
+			hsts += "; includeSubDomains"
+		}
+	}
+
+	return func(c *gin.Context) {
+		header := c.Writer.Header()
+		header.Set("X-Content-Type-Options", "nosniff")
+		header.Set("Referrer-Policy", "no-referrer")
+		if hsts != "" {
+			header.Set("Strict-Transport-Security", hsts)
+		}
+		if opts.FrameOptions != "" {
+			header.Set("X-Frame-Options", opts.FrameOptions)
+		}
+		if opts.ContentSecurityPolicy != "" {
+			header.Set("Content-Security-Policy", opts.ContentSecurityPolicy)
+		}
+		c.Next()
+	}
+}
@@ -0,0 +1,63 @@
+package tests
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go-crime_map_backend/internal/domain/entities"
+	"go-crime_map_backend/internal/interfaces/http/middleware"
+)
+
+func TestAuthenticate(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	authenticator := middleware.NewStaticAPIKeys(map[string]entities.Actor{
+		"mod-key": {ID: "mod-1", Role: entities.RoleModerator},
+	})
+
+	router := gin.New()
+	router.Use(middleware.RequestID(), middleware.Authenticate(authenticator))
+	router.GET("/whoami", func(c *gin.Context) {
+		c.JSON(http.StatusOK, middleware.ActorFromContext(c))
+	})
+
+	tests := []struct {
+		name           string
+		apiKey         string
+		expectedStatus int
+		expectedActor  entities.Actor
+	}{
+		{name: "Sin clave es anónimo", expectedStatus: http.StatusOK, expectedActor: entities.AnonymousActor},
+		{name: "Clave válida", apiKey: "mod-key", expectedStatus: http.StatusOK, expectedActor: entities.Actor{ID: "mod-1", Role: entities.RoleModerator}},
+		{name: "Clave inválida", apiKey: "otra", expectedStatus: http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/whoami", nil)
+			if tt.apiKey != "" {
+				req.Header.Set(middleware.APIKeyHeader, tt.apiKey)
+			}
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			require.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedStatus != http.StatusOK {
+				var body map[string]string
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+				assert.Equal(t, "clave de API inválida", body["error"])
+				assert.NotEmpty(t, body["request_id"])
+				return
+			}
+
+			var actor entities.Actor
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &actor))
+			assert.Equal(t, tt.expectedActor, actor)
+		})
+	}
+}
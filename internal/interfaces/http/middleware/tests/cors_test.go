@@ -0,0 +1,95 @@
+package tests
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"go-crime_map_backend/internal/interfaces/http/middleware"
+)
+
+func setupCORSRouter(opts middleware.CORSOptions) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(
+		middleware.SecurityHeaders(middleware.SecurityOptions{
+			HSTSMaxAge:            3600,
+			HSTSIncludeSubdomains: true,
+			FrameOptions:          "DENY",
+			ContentSecurityPolicy: "default-src 'none'",
+		}),
+		middleware.CORS(opts),
+	)
+	router.POST("/api/v1/crimes", func(c *gin.Context) { c.Status(http.StatusCreated) })
+	return router
+}
+
+func TestCORS(t *testing.T) {
+	opts := middleware.CORSOptions{
+		AllowedOrigins:   []string{"https://mapa.example.com"},
+		AllowedMethods:   []string{"GET", "POST"},
+		AllowedHeaders:   []string{"Content-Type", "X-API-Key"},
+		ExposedHeaders:   []string{"Retry-After"},
+		AllowCredentials: true,
+		MaxAge:           600,
+	}
+
+	t.Run("preflight desde un origen permitido", func(t *testing.T) {
+		router := setupCORSRouter(opts)
+		req := httptest.NewRequest(http.MethodOptions, "/api/v1/crimes", nil)
+		req.Header.Set("Origin", "https://mapa.example.com")
+		req.Header.Set("Access-Control-Request-Method", "POST")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusNoContent, w.Code)
+		assert.Equal(t, "https://mapa.example.com", w.Header().Get("Access-Control-Allow-Origin"))
+		assert.Equal(t, "true", w.Header().Get("Access-Control-Allow-Credentials"))
+		assert.Equal(t, "GET, POST", w.Header().Get("Access-Control-Allow-Methods"))
+		assert.Equal(t, "Content-Type, X-API-Key", w.Header().Get("Access-Control-Allow-Headers"))
+		assert.Equal(t, "600", w.Header().Get("Access-Control-Max-Age"))
+	})
+
+	t.Run("preflight desde un origen no permitido", func(t *testing.T) {
+		router := setupCORSRouter(opts)
+		req := httptest.NewRequest(http.MethodOptions, "/api/v1/crimes", nil)
+		req.Header.Set("Origin", "https://otro.example.com")
+		req.Header.Set("Access-Control-Request-Method", "POST")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusForbidden, w.Code)
+		assert.Empty(t, w.Header().Get("Access-Control-Allow-Origin"))
+	})
+
+	t.Run("solicitud simple con cabeceras de seguridad", func(t *testing.T) {
+		router := setupCORSRouter(opts)
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/crimes", nil)
+		req.Header.Set("Origin", "https://mapa.example.com")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+		assert.Equal(t, "https://mapa.example.com", w.Header().Get("Access-Control-Allow-Origin"))
+		assert.Equal(t, "Retry-After", w.Header().Get("Access-Control-Expose-Headers"))
+		assert.Equal(t, "Origin", w.Header().Get("Vary"))
+		assert.Equal(t, "nosniff", w.Header().Get("X-Content-Type-Options"))
+		assert.Equal(t, "DENY", w.Header().Get("X-Frame-Options"))
+		assert.Equal(t, "max-age=3600; includeSubDomains", w.Header().Get("Strict-Transport-Security"))
+		assert.Equal(t, "default-src 'none'", w.Header().Get("Content-Security-Policy"))
+	})
+
+	t.Run("comodín sin credenciales", func(t *testing.T) {
+		router := setupCORSRouter(middleware.CORSOptions{AllowedOrigins: []string{"*"}})
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/crimes", nil)
+		req.Header.Set("Origin", "https://cualquiera.example.com")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, "*", w.Header().Get("Access-Control-Allow-Origin"))
+		assert.Empty(t, w.Header().Get("Access-Control-Allow-Credentials"))
+	})
+}
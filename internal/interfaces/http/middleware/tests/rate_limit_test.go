@@ -0,0 +1,129 @@
+package tests
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go-crime_map_backend/internal/domain/entities"
+	"go-crime_map_backend/internal/interfaces/http/middleware"
+)
+
+// fakeClock es un reloj controlable para las pruebas
+type fakeClock struct {
+	now time.Time
+}
+
+func (f *fakeClock) Now() time.Time {
+	return f.now
+}
+
+func setupRateLimitRouter(t *testing.T, clock *fakeClock, trustedProxies []string, handlers ...gin.HandlerFunc) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	require.NoError(t, router.SetTrustedProxies(trustedProxies))
+
+	store := middleware.NewMemoryRateLimitStoreWithClock(clock.Now)
+	group := router.Group("/api", handlers...)
+	group.Use(middleware.RateLimit(store, middleware.RateLimitPolicies{
+		Read:  middleware.RateLimitPolicy{Name: "read", Rate: 1, Burst: 3},
+		Write: middleware.RateLimitPolicy{Name: "write", Rate: 0.5, Burst: 1},
+	}))
+	group.GET("/crimes", func(c *gin.Context) { c.Status(http.StatusOK) })
+	group.POST("/crimes", func(c *gin.Context) { c.Status(http.StatusCreated) })
+	return router
+}
+
+func doRequest(router *gin.Engine, method, remoteAddr string, headers map[string]string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, "/api/crimes", nil)
+	req.RemoteAddr = remoteAddr
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestRateLimit(t *testing.T) {
+	t.Run("escrituras más estrictas que lecturas", func(t *testing.T) {
+		clock := &fakeClock{now: time.Now()}
+		router := setupRateLimitRouter(t, clock, nil)
+
+		w := doRequest(router, http.MethodPost, "10.0.0.1:1234", nil)
+		assert.Equal(t, http.StatusCreated, w.Code)
+		assert.Equal(t, "1", w.Header().Get("X-RateLimit-Limit"))
+		assert.Equal(t, "0", w.Header().Get("X-RateLimit-Remaining"))
+
+		w = doRequest(router, http.MethodPost, "10.0.0.1:1234", nil)
+		assert.Equal(t, http.StatusTooManyRequests, w.Code)
+		assert.Equal(t, "2", w.Header().Get("Retry-After"))
+
+		// Las lecturas tienen su propio bucket
+		for i := 0; i < 3; i++ {
+			w = doRequest(router, http.MethodGet, "10.0.0.1:1234", nil)
+			assert.Equal(t, http.StatusOK, w.Code)
+		}
+		w = doRequest(router, http.MethodGet, "10.0.0.1:1234", nil)
+		assert.Equal(t, http.StatusTooManyRequests, w.Code)
+	})
+
+	t.Run("los tokens se reponen con el tiempo", func(t *testing.T) {
+		clock := &fakeClock{now: time.Now()}
+		router := setupRateLimitRouter(t, clock, nil)
+
+		assert.Equal(t, http.StatusCreated, doRequest(router, http.MethodPost, "10.0.0.1:1234", nil).Code)
+		assert.Equal(t, http.StatusTooManyRequests, doRequest(router, http.MethodPost, "10.0.0.1:1234", nil).Code)
+
+		clock.now = clock.now.Add(2 * time.Second)
+		assert.Equal(t, http.StatusCreated, doRequest(router, http.MethodPost, "10.0.0.1:1234", nil).Code)
+	})
+
+	t.Run("buckets separados por usuario autenticado", func(t *testing.T) {
+		clock := &fakeClock{now: time.Now()}
+		authenticator := middleware.NewStaticAPIKeys(map[string]entities.Actor{
+			"clave-1": {ID: "user-1", Role: entities.RoleCitizen},
+			"clave-2": {ID: "user-2", Role: entities.RoleCitizen},
+		})
+		router := setupRateLimitRouter(t, clock, nil, middleware.Authenticate(authenticator))
+
+		first := map[string]string{middleware.APIKeyHeader: "clave-1"}
+		second := map[string]string{middleware.APIKeyHeader: "clave-2"}
+		assert.Equal(t, http.StatusCreated, doRequest(router, http.MethodPost, "10.0.0.1:1234", first).Code)
+		assert.Equal(t, http.StatusTooManyRequests, doRequest(router, http.MethodPost, "10.0.0.1:1234", first).Code)
+		assert.Equal(t, http.StatusCreated, doRequest(router, http.MethodPost, "10.0.0.1:1234", second).Code)
+	})
+
+	t.Run("claves sin autenticar comparten el bucket de la IP", func(t *testing.T) {
+		clock := &fakeClock{now: time.Now()}
+		router := setupRateLimitRouter(t, clock, nil)
+
+		assert.Equal(t, http.StatusCreated, doRequest(router, http.MethodPost, "10.0.0.1:1234",
+			map[string]string{middleware.APIKeyHeader: "aleatoria-1"}).Code)
+		assert.Equal(t, http.StatusTooManyRequests, doRequest(router, http.MethodPost, "10.0.0.1:1234",
+			map[string]string{middleware.APIKeyHeader: "aleatoria-2"}).Code)
+	})
+
+	t.Run("X-Forwarded-For solo desde proxies confiables", func(t *testing.T) {
+		clock := &fakeClock{now: time.Now()}
+
+		// Sin proxies confiables la cabecera se ignora y se usa la IP de conexión
+		router := setupRateLimitRouter(t, clock, nil)
+		assert.Equal(t, http.StatusCreated, doRequest(router, http.MethodPost, "10.0.0.1:1234",
+			map[string]string{"X-Forwarded-For": "203.0.113.1"}).Code)
+		assert.Equal(t, http.StatusTooManyRequests, doRequest(router, http.MethodPost, "10.0.0.1:1234",
+			map[string]string{"X-Forwarded-For": "203.0.113.2"}).Code)
+
+		// Con el proxy configurado como confiable cada cliente tiene su bucket
+		router = setupRateLimitRouter(t, clock, []string{"10.0.0.1"})
+		assert.Equal(t, http.StatusCreated, doRequest(router, http.MethodPost, "10.0.0.1:1234",
+			map[string]string{"X-Forwarded-For": "203.0.113.1"}).Code)
+		assert.Equal(t, http.StatusCreated, doRequest(router, http.MethodPost, "10.0.0.1:1234",
+			map[string]string{"X-Forwarded-For": "203.0.113.2"}).Code)
+	})
+}
@@ -0,0 +1,124 @@
+package tests
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go-crime_map_backend/internal/infrastructure/logging"
+	"go-crime_map_backend/internal/interfaces/http/middleware"
+	"go-crime_map_backend/pkg/requestid"
+)
+
+func setupRequestIDRouter(buf *bytes.Buffer) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	logger := logging.New(buf, "debug", "json")
+
+	router := gin.New()
+	router.Use(middleware.RequestID(), middleware.Logger(logger), middleware.Recovery(logger))
+	router.GET("/ok", func(c *gin.Context) {
+		// Simula el log de un caso de uso que solo recibe el contexto
+		logger.InfoContext(c.Request.Context(), "caso de uso ejecutado")
+		c.JSON(http.StatusOK, gin.H{"request_id": requestid.FromContext(c.Request.Context())})
+	})
+	router.GET("/error", func(c *gin.Context) {
+		c.JSON(http.StatusBadRequest, middleware.ErrorBody(c, "solicitud inválida"))
+	})
+	router.GET("/panic", func(c *gin.Context) {
+		panic("falla inesperada")
+	})
+	return router
+}
+
+func readLogLines(t *testing.T, buf *bytes.Buffer) []map[string]interface{} {
+	var lines []map[string]interface{}
+	scanner := bufio.NewScanner(buf)
+	for scanner.Scan() {
+		var line map[string]interface{}
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &line))
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+func TestRequestID(t *testing.T) {
+	t.Run("genera un identificador y lo propaga al contexto y los logs", func(t *testing.T) {
+		var buf bytes.Buffer
+		router := setupRequestIDRouter(&buf)
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/ok", nil))
+
+		id := w.Header().Get(requestid.Header)
+		require.NotEmpty(t, id)
+
+		var body map[string]string
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+		assert.Equal(t, id, body["request_id"])
+
+		lines := readLogLines(t, &buf)
+		require.Len(t, lines, 2)
+		for _, line := range lines {
+			assert.Equal(t, id, line["request_id"])
+		}
+		assert.Equal(t, "solicitud HTTP", lines[1]["msg"])
+		assert.Equal(t, float64(http.StatusOK), lines[1]["status"])
+	})
+
+	t.Run("acepta el identificador recibido", func(t *testing.T) {
+		var buf bytes.Buffer
+		router := setupRequestIDRouter(&buf)
+
+		req := httptest.NewRequest(http.MethodGet, "/error", nil)
+		req.Header.Set(requestid.Header, "abc-123")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, "abc-123", w.Header().Get(requestid.Header))
+		var body map[string]string
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+		assert.Equal(t, "solicitud inválida", body["error"])
+		assert.Equal(t, "abc-123", body["request_id"])
+	})
+
+	t.Run("reemplaza identificadores inválidos", func(t *testing.T) {
+		var buf bytes.Buffer
+		router := setupRequestIDRouter(&buf)
+
+		req := httptest.NewRequest(http.MethodGet, "/ok", nil)
+		req.Header.Set(requestid.Header, "inválido con espacios\n")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		id := w.Header().Get(requestid.Header)
+		assert.NotEmpty(t, id)
+		assert.NotEqual(t, "inválido con espacios\n", id)
+	})
+
+	t.Run("los pánicos responden 500 con el identificador", func(t *testing.T) {
+		var buf bytes.Buffer
+		router := setupRequestIDRouter(&buf)
+
+		req := httptest.NewRequest(http.MethodGet, "/panic", nil)
+		req.Header.Set(requestid.Header, "req-panic")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+		var body map[string]string
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+		assert.Equal(t, "req-panic", body["request_id"])
+
+		lines := readLogLines(t, &buf)
+		require.NotEmpty(t, lines)
+		assert.Equal(t, "pánico recuperado en la solicitud", lines[0]["msg"])
+		assert.Equal(t, "req-panic", lines[0]["request_id"])
+	})
+}
@@ -0,0 +1,135 @@
+package http
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+
+	"go-crime_map_backend/internal/domain/entities"
+	"go-crime_map_backend/internal/interfaces/http/middleware"
+	"go-crime_map_backend/internal/usecases"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ModerationController maneja las peticiones HTTP de la cola de moderación
+type ModerationController struct {
+	listQueueUseCase    *usecases.ListModerationQueueUseCase
+	claimUseCase        *usecases.ClaimCrimeUseCase
+	releaseClaimUseCase *usecases.ReleaseCrimeClaimUseCase
+	moderateUseCase     *usecases.ModerateCrimeUseCase
+}
+
+// NewModerationController crea una nueva instancia del controlador
+func NewModerationController(
+	listQueueUseCase *usecases.ListModerationQueueUseCase,
+	claimUseCase *usecases.ClaimCrimeUseCase,
+	releaseClaimUseCase *usecases.ReleaseCrimeClaimUseCase,
+	moderateUseCase *usecases.ModerateCrimeUseCase,
+) *ModerationController {
+	return &ModerationController{
+		listQueueUseCase:    listQueueUseCase,
+		claimUseCase:        claimUseCase,
+		releaseClaimUseCase: releaseClaimUseCase,
+		moderateUseCase:     moderateUseCase,
+	}
+}
+
+// ModerationQueueResponse representa la respuesta de la cola de moderación
+type ModerationQueueResponse struct {
+	Items []*entities.ModerationItem `json:"items"`
+	Count int                        `json:"count"`
+}
+
+// ModerationDecisionRequest representa la nota del moderador al aprobar o rechazar un delito
+type ModerationDecisionRequest struct {
+	Note string `json:"note" binding:"max=500"`
+}
+
+// Queue maneja la petición GET para consultar la cola de moderación
+func (c *ModerationController) Queue(ctx *gin.Context) {
+	limit, err := strconv.Atoi(ctx.DefaultQuery("limit", "0"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, middleware.ErrorBody(ctx, "el límite debe ser un número entero"))
+		return
+	}
+
+	items, err := c.listQueueUseCase.Execute(ctx.Request.Context(), usecases.ListModerationQueueInput{
+		Sort:          ctx.Query("sort"),
+		Limit:         limit,
+		OnlyAvailable: ctx.Query("available") == "true",
+		Actor:         middleware.ActorFromContext(ctx),
+	})
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, ModerationQueueResponse{Items: items, Count: len(items)})
+}
+
+// Claim maneja la petición POST para reservar un delito de la cola
+func (c *ModerationController) Claim(ctx *gin.Context) {
+	id, ok := idParam(ctx, "id", usecases.ErrCrimeNotFound)
+	if !ok {
+		return
+	}
+	claim, err := c.claimUseCase.Execute(ctx.Request.Context(), id, middleware.ActorFromContext(ctx))
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, claim)
+}
+
+// Release maneja la petición DELETE para liberar la reserva de un delito
+func (c *ModerationController) Release(ctx *gin.Context) {
+	id, ok := idParam(ctx, "id", usecases.ErrCrimeNotFound)
+	if !ok {
+		return
+	}
+	if err := c.releaseClaimUseCase.Execute(ctx.Request.Context(), id, middleware.ActorFromContext(ctx)); err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// Approve maneja la petición POST para verificar un delito reservado
+func (c *ModerationController) Approve(ctx *gin.Context) {
+	c.decide(ctx, usecases.ModerationApprove)
+}
+
+// Reject maneja la petición POST para rechazar un delito reservado
+func (c *ModerationController) Reject(ctx *gin.Context) {
+	c.decide(ctx, usecases.ModerationReject)
+}
+
+// decide aplica la decisión de moderación; el cuerpo con la nota es opcional
+func (c *ModerationController) decide(ctx *gin.Context, decision string) {
+	id, ok := idParam(ctx, "id", usecases.ErrCrimeNotFound)
+	if !ok {
+		return
+	}
+	var req ModerationDecisionRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		ctx.JSON(http.StatusBadRequest, middleware.ErrorBody(ctx, err.Error()))
+		return
+	}
+
+	crime, err := c.moderateUseCase.Execute(ctx.Request.Context(), usecases.ModerateCrimeInput{
+		CrimeID:  id,
+		Decision: decision,
+		Note:     req.Note,
+		Actor:    middleware.ActorFromContext(ctx),
+	})
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, crime)
+}
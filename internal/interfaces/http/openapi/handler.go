@@ -0,0 +1,79 @@
+package openapi
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+
+	"go-crime_map_backend/internal/interfaces/http/middleware"
+)
+
+// swaggerUIVersion es la versión de Swagger UI que se carga desde el CDN
+const swaggerUIVersion = "5.17.14"
+
+var (
+	specOnce sync.Once
+	specJSON []byte
+	specErr  error
+)
+
+// SpecHandler sirve el documento OpenAPI en formato JSON
+func SpecHandler(c *gin.Context) {
+	specOnce.Do(func() {
+		specJSON, specErr = json.MarshalIndent(Document(), "", "  ")
+	})
+	if specErr != nil {
+		c.JSON(http.StatusInternalServerError, middleware.ErrorBody(c, "error al generar el documento OpenAPI"))
+		return
+	}
+	c.Data(http.StatusOK, "application/json; charset=utf-8", specJSON)
+}
+
+// DocsHandler sirve la documentación interactiva con Swagger UI
+func DocsHandler(c *gin.Context) {
+	nonce, err := newNonce()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, middleware.ErrorBody(c, "error al generar la documentación"))
+		return
+	}
+
+	// La página necesita cargar Swagger UI desde el CDN, se reemplaza la CSP por defecto de la API
+	cdn := "https://cdn.jsdelivr.net"
+	c.Header("Content-Security-Policy", fmt.Sprintf(
+		"default-src 'none'; script-src %s 'nonce-%s'; style-src %s; img-src 'self' data: %s; connect-src 'self'; frame-ancestors 'none'",
+		cdn, nonce, cdn, cdn))
+	c.Data(http.StatusOK, "text/html; charset=utf-8", []byte(fmt.Sprintf(docsPage,
+		swaggerUIVersion, swaggerUIVersion, nonce)))
+}
+
+// newNonce genera un valor aleatorio para autorizar el script de inicialización en la CSP
+func newNonce() (string, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(buf), nil
+}
+
+// docsPage es la plantilla HTML de la documentación interactiva
+const docsPage = `<!DOCTYPE html>
+<html lang="es">
+<head>
+  <meta charset="utf-8">
+  <title>Crime Map API</title>
+  <link rel="stylesheet" href="https://cdn.jsdelivr.net/npm/swagger-ui-dist@%s/swagger-ui.css">
+</head>
+<body>
+  <div id="swagger-ui"></div>
+  <script src="https://cdn.jsdelivr.net/npm/swagger-ui-dist@%s/swagger-ui-bundle.js"></script>
+  <script nonce="%s">
+    window.ui = SwaggerUIBundle({ url: "/openapi.json", dom_id: "#swagger-ui" });
+  </script>
+</body>
+</html>
+`
@@ -0,0 +1,121 @@
+package openapi
+
+import (
+	"reflect"
+	"strings"
+	"time"
+)
+
+// timeType se documenta como cadena con formato date-time
+var timeType = reflect.TypeOf(time.Time{})
+
+// schemaGenerator genera esquemas JSON a partir de los tipos de Go usando sus tags json y binding
+type schemaGenerator struct {
+	// names asocia los tipos registrados como componentes con su nombre, se referencian con $ref
+	names map[reflect.Type]string
+}
+
+// schemaFor genera el esquema de un tipo, referenciando los componentes registrados
+func (g *schemaGenerator) schemaFor(t reflect.Type) map[string]any {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if name, ok := g.names[t]; ok {
+		return ref(name)
+	}
+	return g.inline(t)
+}
+
+// inline genera el esquema de un tipo sin usar referencias para el tipo raíz
+func (g *schemaGenerator) inline(t reflect.Type) map[string]any {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t == timeType {
+		return map[string]any{"type": "string", "format": "date-time"}
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		return map[string]any{"type": "string"}
+	case reflect.Bool:
+		return map[string]any{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return map[string]any{"type": "integer", "format": "int32"}
+	case reflect.Int64, reflect.Uint64:
+		return map[string]any{"type": "integer", "format": "int64"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]any{"type": "number", "format": "double"}
+	case reflect.Slice, reflect.Array:
+		return map[string]any{"type": "array", "items": g.schemaFor(t.Elem())}
+	case reflect.Map:
+		return map[string]any{"type": "object", "additionalProperties": g.schemaFor(t.Elem())}
+	case reflect.Struct:
+		return g.object(t)
+	default:
+		return map[string]any{}
+	}
+}
+
+// object genera el esquema de un struct a partir de sus campos exportados.
+// En las peticiones son requeridos los campos con binding:"required"; en las
+// respuestas, que no usan binding, los campos que no se omiten nunca.
+func (g *schemaGenerator) object(t reflect.Type) map[string]any {
+	properties := map[string]any{}
+	var required []string
+
+	isRequest := false
+	for i := 0; i < t.NumField(); i++ {
+		if _, ok := t.Field(i).Tag.Lookup("binding"); ok {
+			isRequest = true
+			break
+		}
+	}
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		name, omitEmpty := jsonName(field)
+		if name == "-" {
+			continue
+		}
+
+		properties[name] = g.schemaFor(field.Type)
+		if isRequest && strings.Contains(field.Tag.Get("binding"), "required") ||
+			!isRequest && !omitEmpty && field.Type.Kind() != reflect.Pointer {
+			required = append(required, name)
+		}
+	}
+
+	schema := map[string]any{"type": "object", "properties": properties}
+	if len(required) > 0 {
+		schema["required"] = required
+	}
+	return schema
+}
+
+// jsonName obtiene el nombre JSON de un campo y si se omite cuando está vacío
+func jsonName(field reflect.StructField) (string, bool) {
+	tag := field.Tag.Get("json")
+	if tag == "" {
+		return field.Name, false
+	}
+	parts := strings.Split(tag, ",")
+	name := parts[0]
+	if name == "" {
+		name = field.Name
+	}
+	for _, option := range parts[1:] {
+		if option == "omitempty" {
+			return name, true
+		}
+	}
+	return name, false
+}
+
+// ref crea una referencia a un componente de esquema
+func ref(name string) map[string]any {
+	return map[string]any{"$ref": "#/components/schemas/" + name}
+}
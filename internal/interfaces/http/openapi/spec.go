@@ -0,0 +1,1169 @@
+package openapi
+
+import (
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"go-crime_map_backend/internal/domain/entities"
+	crimeHttp "go-crime_map_backend/internal/interfaces/http"
+	"go-crime_map_backend/internal/usecases"
+	"go-crime_map_backend/pkg/geojson"
+)
+
+// Operation describe una operación expuesta por la API
+type Operation struct {
+	Method       string
+	Path         string // Ruta en formato Gin, por ejemplo /api/v1/crimes/:id
+	Tag          string
+	Summary      string
+	Description  string
+	Parameters   []Parameter
+	RequestBody  reflect.Type // Tipo del cuerpo de la petición, nil si no tiene
+	BodyOptional bool         // El cuerpo de la petición se puede omitir
+	RequestFile  string       // Tipo de contenido del cuerpo binario, como un zip; sin RequestBody
+	Responses    []Response
+	Secured      bool // Acepta la clave de API para autenticar al actor
+}
+
+// Parameter describe un parámetro de ruta, de consulta o de cabecera
+type Parameter struct {
+	Name        string
+	In          string // path, query o header
+	Description string
+	Required    bool
+	Schema      map[string]any
+}
+
+// Response describe una respuesta posible de una operación
+type Response struct {
+	Status      int
+	Description string
+	Body        reflect.Type // Tipo del cuerpo JSON, nil si no tiene
+	ContentType string       // Tipo de contenido; con Body reemplaza a application/json
+	RateLimited bool         // Incluye las cabeceras del limitador de solicitudes
+}
+
+// ErrorResponse representa el cuerpo de las respuestas de error
+type ErrorResponse struct {
+	Error     string `json:"error"`
+	RequestID string `json:"request_id,omitempty"`
+}
+
+// HealthResponse representa la respuesta del chequeo de salud
+type HealthResponse struct {
+	Status string    `json:"status"`
+	Time   time.Time `json:"time"`
+}
+
+// components define los tipos publicados como esquemas reutilizables
+var components = map[string]reflect.Type{
+	"BoundingBox":             reflect.TypeOf(entities.BoundingBox{}),
+	"CreateCrimeRequest":      reflect.TypeOf(crimeHttp.CreateCrimeRequest{}),
+	"CreateWebhookRequest":    reflect.TypeOf(crimeHttp.CreateWebhookRequest{}),
+	"CreateWebhookResponse":   reflect.TypeOf(crimeHttp.CreateWebhookResponse{}),
+	"Crime":                   reflect.TypeOf(entities.Crime{}),
+	"CrimeFeedEvent":          reflect.TypeOf(usecases.CrimeFeedEvent{}),
+	"CrimeHistoryResponse":    reflect.TypeOf(crimeHttp.CrimeHistoryResponse{}),
+	"CrimeRevision":           reflect.TypeOf(entities.CrimeRevision{}),
+	"CrimeStatusChange":       reflect.TypeOf(entities.CrimeStatusChange{}),
+	"Error":                   reflect.TypeOf(ErrorResponse{}),
+	"FeatureCollection":       reflect.TypeOf(geojson.FeatureCollection{}),
+	"Geometry":                reflect.TypeOf(geojson.Geometry{}),
+	"Health":                  reflect.TypeOf(HealthResponse{}),
+	"ListCrimesResponse":      reflect.TypeOf(crimeHttp.ListCrimesResponse{}),
+	"ImportZonesResult":       reflect.TypeOf(usecases.ImportZonesResult{}),
+	"ImportCrimesResult":      reflect.TypeOf(usecases.ImportCrimesResult{}),
+	"ModerationClaim":         reflect.TypeOf(entities.ModerationClaim{}),
+	"ModerationDecision":      reflect.TypeOf(crimeHttp.ModerationDecisionRequest{}),
+	"ModerationItem":          reflect.TypeOf(entities.ModerationItem{}),
+	"ModerationQueueResponse": reflect.TypeOf(crimeHttp.ModerationQueueResponse{}),
+	"MultiPolygon":            reflect.TypeOf(entities.MultiPolygon{}),
+	"Notification":            reflect.TypeOf(entities.Notification{}),
+	"NotificationList":        reflect.TypeOf(crimeHttp.NotificationListResponse{}),
+	"StatusHistoryResponse":   reflect.TypeOf(crimeHttp.StatusHistoryResponse{}),
+	"TransitionStatusRequest": reflect.TypeOf(crimeHttp.TransitionStatusRequest{}),
+	"WatchArea":               reflect.TypeOf(entities.WatchArea{}),
+	"WatchAreaList":           reflect.TypeOf(crimeHttp.WatchAreaListResponse{}),
+	"WatchAreaRequest":        reflect.TypeOf(crimeHttp.WatchAreaRequest{}),
+	"WebhookDeliveries":       reflect.TypeOf(crimeHttp.WebhookDeliveriesResponse{}),
+	"WebhookDelivery":         reflect.TypeOf(entities.WebhookDelivery{}),
+	"WebhookList":             reflect.TypeOf(crimeHttp.WebhookListResponse{}),
+	"WebhookSubscription":     reflect.TypeOf(entities.WebhookSubscription{}),
+	"Zone":                    reflect.TypeOf(entities.Zone{}),
+	"ZoneList":                reflect.TypeOf(crimeHttp.ZoneListResponse{}),
+	"ZoneRequest":             reflect.TypeOf(crimeHttp.ZoneRequest{}),
+	"ZoneStats":               reflect.TypeOf(entities.ZoneStats{}),
+	"ZoneStatsResult":         reflect.TypeOf(usecases.ZoneStatsResult{}),
+	"TimeSeriesResult":        reflect.TypeOf(usecases.TimeSeriesResult{}),
+	"TimeSeriesPoint":         reflect.TypeOf(entities.TimeSeriesPoint{}),
+	"PunchcardResult":         reflect.TypeOf(usecases.PunchcardResult{}),
+	"HotspotResult":           reflect.TypeOf(usecases.HotspotResult{}),
+	"Hotspot":                 reflect.TypeOf(entities.Hotspot{}),
+	"HotspotSignificance":     reflect.TypeOf(usecases.HotspotSignificanceResult{}),
+	"Anomaly":                 reflect.TypeOf(entities.Anomaly{}),
+	"AnomalyList":             reflect.TypeOf(crimeHttp.AnomalyListResponse{}),
+	"SafetyScore":             reflect.TypeOf(usecases.SafetyScoreResult{}),
+	"SafetyFactor":            reflect.TypeOf(entities.SafetyFactor{}),
+	"SafetyRouteRequest":      reflect.TypeOf(crimeHttp.SafetyRouteRequest{}),
+}
+
+// standardErrors agrega las respuestas de error comunes a las operaciones de la API v1
+func standardErrors(responses ...Response) []Response {
+	return append(responses,
+		Response{Status: http.StatusUnauthorized, Description: "Clave de API inválida", Body: components["Error"]},
+		Response{Status: http.StatusTooManyRequests, Description: "Se superó el límite de solicitudes", Body: components["Error"], RateLimited: true},
+		Response{Status: http.StatusInternalServerError, Description: "Error interno", Body: components["Error"]},
+	)
+}
+
+// Operations retorna todas las operaciones documentadas de la API
+func Operations() []Operation {
+	return []Operation{
+		{
+			Method:  http.MethodGet,
+			Path:    "/health",
+			Tag:     "sistema",
+			Summary: "Verificar el estado del servidor",
+			Responses: []Response{
+				{Status: http.StatusOK, Description: "El servidor está operativo", Body: components["Health"]},
+			},
+		},
+		{
+			Method:  http.MethodGet,
+			Path:    "/metrics",
+			Tag:     "sistema",
+			Summary: "Métricas en formato de texto de Prometheus",
+			Responses: []Response{
+				{Status: http.StatusOK, Description: "Métricas de la aplicación", ContentType: "text/plain"},
+			},
+		},
+		{
+			Method:  http.MethodGet,
+			Path:    "/openapi.json",
+			Tag:     "sistema",
+			Summary: "Documento OpenAPI de la API",
+			Responses: []Response{
+				{Status: http.StatusOK, Description: "Documento OpenAPI 3.1", ContentType: "application/json"},
+			},
+		},
+		{
+			Method:  http.MethodGet,
+			Path:    "/docs",
+			Tag:     "sistema",
+			Summary: "Documentación interactiva de la API",
+			Responses: []Response{
+				{Status: http.StatusOK, Description: "Página HTML con la documentación", ContentType: "text/html"},
+			},
+		},
+		{
+			Method:      http.MethodPost,
+			Path:        "/api/v1/crimes/",
+			Tag:         "delitos",
+			Summary:     "Reportar un delito",
+			Description: "Crea un delito tras validar sus datos y verificar que no exista un duplicado.",
+			RequestBody: components["CreateCrimeRequest"],
+			Secured:     true,
+			Responses: standardErrors(
+				Response{Status: http.StatusCreated, Description: "Delito creado", Body: components["Crime"], RateLimited: true},
+				Response{Status: http.StatusBadRequest, Description: "Datos inválidos", Body: components["Error"]},
+				Response{Status: http.StatusConflict, Description: "Ya existe un delito con los mismos datos", Body: components["Error"]},
+			),
+		},
+		{
+			Method:      http.MethodGet,
+			Path:        "/api/v1/crimes/",
+			Tag:         "delitos",
+			Summary:     "Listar delitos",
+			Description: "Lista los delitos ordenados por fecha. Sin rol de moderación solo se incluyen los estados públicos.",
+			Parameters: []Parameter{
+				{Name: "status", In: "query", Description: "Estados a incluir, separados por coma", Schema: map[string]any{"type": "string"}},
+				{Name: "type", In: "query", Description: "Tipos de delito a incluir, separados por coma", Schema: map[string]any{"type": "string"}},
+				{Name: "zone", In: "query", Description: "IDs de las zonas a incluir, separados por coma", Schema: map[string]any{"type": "string"}},
+			},
+			Secured: true,
+			Responses: standardErrors(
+				Response{Status: http.StatusOK, Description: "Delitos encontrados", Body: components["ListCrimesResponse"], RateLimited: true},
+				Response{Status: http.StatusBadRequest, Description: "Estado inválido", Body: components["Error"]},
+				Response{Status: http.StatusForbidden, Description: "Estado no visible para el actor", Body: components["Error"]},
+			),
+		},
+		{
+			Method:  http.MethodGet,
+			Path:    "/api/v1/crimes/stream",
+			Tag:     "delitos",
+			Summary: "Feed de delitos en tiempo real (Server-Sent Events)",
+			Description: "Envía un evento created, updated o deleted (datos CrimeFeedEvent) por cada cambio de un delito visible para el actor, " +
+				"con comentarios ping periódicos. Con Last-Event-ID se reanuda tras el último evento recibido; si ya no está en el historial " +
+				"se envía un evento reset y el cliente debe recargar los delitos. Los clientes lentos se desconectan para que reanuden.",
+			Parameters: streamParameters(
+				Parameter{Name: "Last-Event-ID", In: "header", Description: "ID del último evento recibido", Schema: map[string]any{"type": "string"}},
+			),
+			Secured: true,
+			Responses: standardErrors(
+				Response{Status: http.StatusOK, Description: "Flujo de eventos", ContentType: "text/event-stream", RateLimited: true},
+				Response{Status: http.StatusBadRequest, Description: "Filtros inválidos", Body: components["Error"]},
+			),
+		},
+		{
+			Method:  http.MethodGet,
+			Path:    "/api/v1/crimes/ws",
+			Tag:     "delitos",
+			Summary: "Feed de delitos en tiempo real (WebSocket)",
+			Description: "Igual que /api/v1/crimes/stream, pero cada evento se envía como un mensaje de texto con un CrimeFeedEvent. " +
+				"El servidor envía pings de control y cierra con el código 1013 a los clientes lentos.",
+			Parameters: streamParameters(),
+			Secured:    true,
+			Responses: standardErrors(
+				Response{Status: http.StatusSwitchingProtocols, Description: "Conexión WebSocket establecida"},
+				Response{Status: http.StatusBadRequest, Description: "Filtros inválidos o solicitud sin upgrade", Body: components["Error"]},
+				Response{Status: http.StatusForbidden, Description: "Origen no permitido"},
+			),
+		},
+		{
+			Method:  http.MethodGet,
+			Path:    "/api/v1/crimes/export",
+			Tag:     "delitos",
+			Summary: "Exportar delitos en KML o GPX",
+			Description: "Descarga los delitos filtrados para los equipos de calle. En KML cada delito es un placemark con el " +
+				"estilo de su tipo y su fecha como TimeStamp, para el control de tiempo de Google Earth; en GPX es un waypoint " +
+				"con bandera roja si su gravedad es alta o crítica. El archivo se envía por partes a medida que se lee, en " +
+				"orden cronológico. Sin rol de moderación solo se incluyen los estados públicos.",
+			Parameters: statsParameters(
+				Parameter{Name: "format", In: "query", Description: "Formato del archivo", Required: true, Schema: map[string]any{"type": "string", "enum": []string{usecases.ExportFormatKML, usecases.ExportFormatGPX}}},
+			),
+			Secured: true,
+			Responses: standardErrors(
+				Response{Status: http.StatusOK, Description: "Archivo KML (application/vnd.google-earth.kml+xml) o GPX (application/gpx+xml)", ContentType: "application/vnd.google-earth.kml+xml", RateLimited: true},
+				Response{Status: http.StatusBadRequest, Description: "Formato o filtros inválidos", Body: components["Error"]},
+				Response{Status: http.StatusForbidden, Description: "Estado no visible para el actor", Body: components["Error"]},
+			),
+		},
+		{
+			Method:  http.MethodPost,
+			Path:    "/api/v1/crimes/import",
+			Tag:     "delitos",
+			Summary: "Importar delitos desde un shapefile",
+			Description: "Recibe un zip de hasta 50 MB con un shapefile de puntos (.shp, .dbf y .prj), como los que publican las " +
+				"estadísticas oficiales. Las coordenadas se convierten a WGS84 desde el sistema del .prj o de srid: geográficas, " +
+				"UTM, las fajas Gauss-Krüger de POSGAR y Campo Inchauspe y Web Mercator. Cada registro se crea como un reporte " +
+				"con las mismas validaciones que POST /api/v1/crimes/; los inválidos o duplicados se informan sin detener la " +
+				"importación. Hasta 10000 registros por archivo.",
+			Parameters: []Parameter{
+				{Name: "srid", In: "query", Description: "Código EPSG del sistema de coordenadas; reemplaza al .prj", Schema: map[string]any{"type": "integer"}},
+				{Name: "timezone", In: "query", Description: "Zona horaria IANA de las fechas", Schema: map[string]any{"type": "string", "default": "UTC"}},
+				{Name: "type_field", In: "query", Description: "Columna con el tipo de delito", Schema: map[string]any{"type": "string", "default": "tipo"}},
+				{Name: "description_field", In: "query", Description: "Columna con la descripción", Schema: map[string]any{"type": "string", "default": "descripcio"}},
+				{Name: "date_field", In: "query", Description: "Columna con la fecha", Schema: map[string]any{"type": "string", "default": "fecha"}},
+				{Name: "time_field", In: "query", Description: "Columna con la hora", Schema: map[string]any{"type": "string", "default": "hora"}},
+				{Name: "address_field", In: "query", Description: "Columna con la dirección", Schema: map[string]any{"type": "string", "default": "direccion"}},
+				{Name: "severity_field", In: "query", Description: "Columna con la gravedad", Schema: map[string]any{"type": "string", "default": "gravedad"}},
+				{Name: "weapon_field", In: "query", Description: "Columna con el arma", Schema: map[string]any{"type": "string", "default": "arma"}},
+				{Name: "victims_field", In: "query", Description: "Columna con la cantidad de víctimas", Schema: map[string]any{"type": "string", "default": "victimas"}},
+			},
+			RequestFile: "application/zip",
+			Secured:     true,
+			Responses: standardErrors(
+				Response{Status: http.StatusOK, Description: "Resumen de la importación", Body: components["ImportCrimesResult"], RateLimited: true},
+				Response{Status: http.StatusBadRequest, Description: "Archivo, sistema de coordenadas o parámetros inválidos", Body: components["Error"]},
+				Response{Status: http.StatusForbidden, Description: "Solo disponible para administradores", Body: components["Error"]},
+				Response{Status: http.StatusRequestEntityTooLarge, Description: "El archivo excede los 50 MB", Body: components["Error"]},
+			),
+		},
+		{
+			Method:      http.MethodGet,
+			Path:        "/api/v1/crimes/:id",
+			Tag:         "delitos",
+			Summary:     "Obtener un delito",
+			Description: "Con as_of retorna el delito tal como estaba en ese instante, reconstruido a partir de su historial de versiones.",
+			Parameters: []Parameter{
+				{Name: "as_of", In: "query", Description: "Instante a consultar en formato RFC 3339", Schema: map[string]any{"type": "string", "format": "date-time"}},
+			},
+			Secured: true,
+			Responses: standardErrors(
+				Response{Status: http.StatusOK, Description: "Delito encontrado", Body: components["Crime"], RateLimited: true},
+				Response{Status: http.StatusBadRequest, Description: "Instante inválido", Body: components["Error"]},
+				Response{Status: http.StatusNotFound, Description: "El delito no existe o no existía en ese instante", Body: components["Error"]},
+			),
+		},
+		{
+			Method:      http.MethodPut,
+			Path:        "/api/v1/crimes/:id",
+			Tag:         "moderación",
+			Summary:     "Corregir un delito",
+			Description: "Reemplaza el tipo, la descripción, la ubicación y la fecha del delito. El estado se cambia con el flujo de estados.",
+			RequestBody: components["CreateCrimeRequest"],
+			Secured:     true,
+			Responses: standardErrors(
+				Response{Status: http.StatusOK, Description: "Delito actualizado", Body: components["Crime"], RateLimited: true},
+				Response{Status: http.StatusBadRequest, Description: "Datos inválidos", Body: components["Error"]},
+				Response{Status: http.StatusForbidden, Description: "Solo disponible para moderadores", Body: components["Error"]},
+				Response{Status: http.StatusNotFound, Description: "El delito no existe", Body: components["Error"]},
+			),
+		},
+		{
+			Method:      http.MethodDelete,
+			Path:        "/api/v1/crimes/:id",
+			Tag:         "moderación",
+			Summary:     "Eliminar un delito",
+			Description: "Elimina el delito. Su historial de versiones se conserva.",
+			Secured:     true,
+			Responses: standardErrors(
+				Response{Status: http.StatusNoContent, Description: "Delito eliminado", RateLimited: true},
+				Response{Status: http.StatusForbidden, Description: "Solo disponible para administradores", Body: components["Error"]},
+				Response{Status: http.StatusNotFound, Description: "El delito no existe", Body: components["Error"]},
+			),
+		},
+		{
+			Method:      http.MethodGet,
+			Path:        "/api/v1/crimes/:id/history",
+			Tag:         "auditoría",
+			Summary:     "Historial de versiones de un delito",
+			Description: "Lista cada alta, modificación, cambio de estado y baja del delito con su autor, la solicitud que lo originó y los campos modificados.",
+			Secured:     true,
+			Responses: standardErrors(
+				Response{Status: http.StatusOK, Description: "Revisiones del delito", Body: components["CrimeHistoryResponse"], RateLimited: true},
+				Response{Status: http.StatusForbidden, Description: "Solo disponible para moderadores", Body: components["Error"]},
+				Response{Status: http.StatusNotFound, Description: "El delito no tiene revisiones", Body: components["Error"]},
+			),
+		},
+		{
+			Method:      http.MethodPost,
+			Path:        "/api/v1/crimes/:id/status",
+			Tag:         "moderación",
+			Summary:     "Cambiar el estado de un delito",
+			Description: "Aplica una transición del flujo reported → verified → resolved. Rechazar un delito o reabrirlo requiere un motivo.",
+			RequestBody: components["TransitionStatusRequest"],
+			Secured:     true,
+			Responses: standardErrors(
+				Response{Status: http.StatusOK, Description: "Estado actualizado", Body: components["Crime"], RateLimited: true},
+				Response{Status: http.StatusBadRequest, Description: "Estado o motivo inválido", Body: components["Error"]},
+				Response{Status: http.StatusForbidden, Description: "El rol no permite la transición", Body: components["Error"]},
+				Response{Status: http.StatusNotFound, Description: "El delito no existe", Body: components["Error"]},
+				Response{Status: http.StatusConflict, Description: "La transición no es válida desde el estado actual", Body: components["Error"]},
+			),
+		},
+		{
+			Method:  http.MethodGet,
+			Path:    "/api/v1/crimes/:id/status-history",
+			Tag:     "moderación",
+			Summary: "Historial de estados de un delito",
+			Secured: true,
+			Responses: standardErrors(
+				Response{Status: http.StatusOK, Description: "Cambios de estado del delito", Body: components["StatusHistoryResponse"], RateLimited: true},
+				Response{Status: http.StatusForbidden, Description: "Solo disponible para moderadores", Body: components["Error"]},
+				Response{Status: http.StatusNotFound, Description: "El delito no existe", Body: components["Error"]},
+			),
+		},
+		{
+			Method:      http.MethodGet,
+			Path:        "/api/v1/moderation/queue",
+			Tag:         "moderación",
+			Summary:     "Cola de moderación",
+			Description: "Lista los delitos en estado reported con su reserva vigente, del más antiguo al más reciente o por prioridad.",
+			Parameters: []Parameter{
+				{Name: "sort", In: "query", Description: "Orden de la cola", Schema: map[string]any{"type": "string", "enum": []string{usecases.ModerationSortAge, usecases.ModerationSortPriority}, "default": usecases.ModerationSortAge}},
+				{Name: "limit", In: "query", Description: "Cantidad máxima de elementos (máximo 200)", Schema: map[string]any{"type": "integer", "default": 50}},
+				{Name: "available", In: "query", Description: "Excluye los delitos reservados por otros moderadores", Schema: map[string]any{"type": "boolean"}},
+			},
+			Secured: true,
+			Responses: standardErrors(
+				Response{Status: http.StatusOK, Description: "Delitos pendientes de moderación", Body: components["ModerationQueueResponse"], RateLimited: true},
+				Response{Status: http.StatusBadRequest, Description: "Parámetros inválidos", Body: components["Error"]},
+				Response{Status: http.StatusForbidden, Description: "Solo disponible para moderadores", Body: components["Error"]},
+			),
+		},
+		{
+			Method:      http.MethodPost,
+			Path:        "/api/v1/moderation/queue/:id/claim",
+			Tag:         "moderación",
+			Summary:     "Reservar un delito para revisarlo",
+			Description: "Reserva el delito durante el tiempo configurado para que ningún otro moderador lo revise. Reservarlo de nuevo renueva la reserva.",
+			Secured:     true,
+			Responses: standardErrors(
+				Response{Status: http.StatusOK, Description: "Delito reservado", Body: components["ModerationClaim"], RateLimited: true},
+				Response{Status: http.StatusForbidden, Description: "Solo disponible para moderadores", Body: components["Error"]},
+				Response{Status: http.StatusNotFound, Description: "El delito no existe", Body: components["Error"]},
+				Response{Status: http.StatusConflict, Description: "Reservado por otro moderador o ya moderado", Body: components["Error"]},
+			),
+		},
+		{
+			Method:  http.MethodDelete,
+			Path:    "/api/v1/moderation/queue/:id/claim",
+			Tag:     "moderación",
+			Summary: "Liberar la reserva de un delito",
+			Secured: true,
+			Responses: standardErrors(
+				Response{Status: http.StatusNoContent, Description: "Reserva liberada", RateLimited: true},
+				Response{Status: http.StatusForbidden, Description: "Solo disponible para moderadores", Body: components["Error"]},
+				Response{Status: http.StatusConflict, Description: "El moderador no tiene reservado el delito", Body: components["Error"]},
+			),
+		},
+		moderationDecision(http.MethodPost, "/api/v1/moderation/queue/:id/approve", "Aprobar un delito reservado",
+			"Verifica el delito. La nota es opcional y se guarda en el historial de estados."),
+		moderationDecision(http.MethodPost, "/api/v1/moderation/queue/:id/reject", "Rechazar un delito reservado",
+			"Rechaza el delito. La nota es obligatoria y se guarda como motivo en el historial de estados."),
+		{
+			Method:  http.MethodGet,
+			Path:    "/api/v1/webhooks/",
+			Tag:     "webhooks",
+			Summary: "Listar los webhooks suscritos",
+			Secured: true,
+			Responses: standardErrors(
+				Response{Status: http.StatusOK, Description: "Suscripciones", Body: components["WebhookList"], RateLimited: true},
+				Response{Status: http.StatusForbidden, Description: "Solo disponible para administradores", Body: components["Error"]},
+			),
+		},
+		{
+			Method:  http.MethodPost,
+			Path:    "/api/v1/webhooks/",
+			Tag:     "webhooks",
+			Summary: "Suscribir un webhook",
+			Description: "Registra una URL que recibe por POST los eventos indicados, opcionalmente filtrados por zona y tipo de delito. " +
+				"Cada entrega se firma con HMAC-SHA256 sobre \"<X-Webhook-Timestamp>.<cuerpo>\" y se envía en la cabecera X-Webhook-Signature (sha256=<hex>). " +
+				"El secreto se retorna solo en esta respuesta.",
+			RequestBody: components["CreateWebhookRequest"],
+			Secured:     true,
+			Responses: standardErrors(
+				Response{Status: http.StatusCreated, Description: "Webhook suscrito", Body: components["CreateWebhookResponse"], RateLimited: true},
+				Response{Status: http.StatusBadRequest, Description: "URL, eventos o filtros inválidos", Body: components["Error"]},
+				Response{Status: http.StatusForbidden, Description: "Solo disponible para administradores", Body: components["Error"]},
+			),
+		},
+		{
+			Method:  http.MethodDelete,
+			Path:    "/api/v1/webhooks/:id",
+			Tag:     "webhooks",
+			Summary: "Dar de baja un webhook",
+			Secured: true,
+			Responses: standardErrors(
+				Response{Status: http.StatusNoContent, Description: "Webhook dado de baja", RateLimited: true},
+				Response{Status: http.StatusForbidden, Description: "Solo disponible para administradores", Body: components["Error"]},
+				Response{Status: http.StatusNotFound, Description: "El webhook no existe", Body: components["Error"]},
+			),
+		},
+		{
+			Method:      http.MethodGet,
+			Path:        "/api/v1/webhooks/:id/deliveries",
+			Tag:         "webhooks",
+			Summary:     "Registro de entregas de un webhook",
+			Description: "Lista las entregas de la más reciente a la más antigua con el resultado del último intento. Las entregas dead agotaron los reintentos.",
+			Parameters: []Parameter{
+				{Name: "status", In: "query", Description: "Estado de las entregas", Schema: map[string]any{"type": "string", "enum": []entities.WebhookDeliveryStatus{entities.WebhookDeliveryPending, entities.WebhookDeliverySucceeded, entities.WebhookDeliveryDead}}},
+				{Name: "limit", In: "query", Description: "Cantidad máxima de entregas (máximo 200)", Schema: map[string]any{"type": "integer", "default": 50}},
+			},
+			Secured: true,
+			Responses: standardErrors(
+				Response{Status: http.StatusOK, Description: "Entregas del webhook", Body: components["WebhookDeliveries"], RateLimited: true},
+				Response{Status: http.StatusBadRequest, Description: "Parámetros inválidos", Body: components["Error"]},
+				Response{Status: http.StatusForbidden, Description: "Solo disponible para administradores", Body: components["Error"]},
+				Response{Status: http.StatusNotFound, Description: "El webhook no existe", Body: components["Error"]},
+			),
+		},
+		{
+			Method:      http.MethodPost,
+			Path:        "/api/v1/webhooks/:id/deliveries/:delivery_id/replay",
+			Tag:         "webhooks",
+			Summary:     "Reenviar una entrega",
+			Description: "Vuelve a encolar la entrega con los reintentos reiniciados. El cuerpo es el mismo, por lo que el receptor puede descartarlo por el ID del evento.",
+			Secured:     true,
+			Responses: standardErrors(
+				Response{Status: http.StatusAccepted, Description: "Entrega encolada", Body: components["WebhookDelivery"], RateLimited: true},
+				Response{Status: http.StatusForbidden, Description: "Solo disponible para administradores", Body: components["Error"]},
+				Response{Status: http.StatusNotFound, Description: "La entrega no existe", Body: components["Error"]},
+			),
+		},
+		{
+			Method:  http.MethodGet,
+			Path:    "/api/v1/watch-areas/",
+			Tag:     "alertas",
+			Summary: "Listar las zonas vigiladas del usuario",
+			Secured: true,
+			Responses: standardErrors(
+				Response{Status: http.StatusOK, Description: "Zonas del usuario", Body: components["WatchAreaList"], RateLimited: true},
+				Response{Status: http.StatusForbidden, Description: "Requiere un usuario autenticado", Body: components["Error"]},
+			),
+		},
+		{
+			Method:  http.MethodPost,
+			Path:    "/api/v1/watch-areas/",
+			Tag:     "alertas",
+			Summary: "Crear una zona vigilada",
+			Description: "La zona es un círculo (50 m a 50 km de radio) o un polígono. Cuando un delito de los tipos indicados se verifica " +
+				"dentro de la zona se avisa por los canales elegidos; durante la franja silenciosa los avisos por email y webhook se postergan.",
+			RequestBody: components["WatchAreaRequest"],
+			Secured:     true,
+			Responses: standardErrors(
+				Response{Status: http.StatusCreated, Description: "Zona creada", Body: components["WatchArea"], RateLimited: true},
+				Response{Status: http.StatusBadRequest, Description: "Datos inválidos", Body: components["Error"]},
+				Response{Status: http.StatusForbidden, Description: "Requiere un usuario autenticado", Body: components["Error"]},
+				Response{Status: http.StatusConflict, Description: "Se alcanzó el máximo de zonas", Body: components["Error"]},
+			),
+		},
+		{
+			Method:      http.MethodPut,
+			Path:        "/api/v1/watch-areas/:id",
+			Tag:         "alertas",
+			Summary:     "Reemplazar una zona vigilada",
+			RequestBody: components["WatchAreaRequest"],
+			Secured:     true,
+			Responses: standardErrors(
+				Response{Status: http.StatusOK, Description: "Zona actualizada", Body: components["WatchArea"], RateLimited: true},
+				Response{Status: http.StatusBadRequest, Description: "Datos inválidos", Body: components["Error"]},
+				Response{Status: http.StatusForbidden, Description: "Requiere un usuario autenticado", Body: components["Error"]},
+				Response{Status: http.StatusNotFound, Description: "La zona no existe", Body: components["Error"]},
+			),
+		},
+		{
+			Method:  http.MethodDelete,
+			Path:    "/api/v1/watch-areas/:id",
+			Tag:     "alertas",
+			Summary: "Eliminar una zona vigilada y sus avisos",
+			Secured: true,
+			Responses: standardErrors(
+				Response{Status: http.StatusNoContent, Description: "Zona eliminada", RateLimited: true},
+				Response{Status: http.StatusForbidden, Description: "Requiere un usuario autenticado", Body: components["Error"]},
+				Response{Status: http.StatusNotFound, Description: "La zona no existe", Body: components["Error"]},
+			),
+		},
+		{
+			Method:  http.MethodGet,
+			Path:    "/api/v1/notifications/",
+			Tag:     "alertas",
+			Summary: "Bandeja de avisos del usuario",
+			Parameters: []Parameter{
+				{Name: "unread", In: "query", Description: "Solo los avisos sin leer", Schema: map[string]any{"type": "boolean"}},
+				{Name: "limit", In: "query", Description: "Cantidad máxima de avisos (máximo 200)", Schema: map[string]any{"type": "integer", "default": 200}},
+			},
+			Secured: true,
+			Responses: standardErrors(
+				Response{Status: http.StatusOK, Description: "Avisos de la bandeja", Body: components["NotificationList"], RateLimited: true},
+				Response{Status: http.StatusBadRequest, Description: "Parámetros inválidos", Body: components["Error"]},
+				Response{Status: http.StatusForbidden, Description: "Requiere un usuario autenticado", Body: components["Error"]},
+			),
+		},
+		{
+			Method:  http.MethodPost,
+			Path:    "/api/v1/notifications/:id/read",
+			Tag:     "alertas",
+			Summary: "Marcar un aviso como leído",
+			Secured: true,
+			Responses: standardErrors(
+				Response{Status: http.StatusNoContent, Description: "Aviso marcado como leído", RateLimited: true},
+				Response{Status: http.StatusForbidden, Description: "Requiere un usuario autenticado", Body: components["Error"]},
+				Response{Status: http.StatusNotFound, Description: "El aviso no existe", Body: components["Error"]},
+			),
+		},
+		{
+			Method:  http.MethodGet,
+			Path:    "/api/v1/zones/",
+			Tag:     "zonas",
+			Summary: "Listar las zonas administrativas",
+			Parameters: []Parameter{
+				{Name: "kind", In: "query", Description: "Tipo de zona, por ejemplo barrio o comuna", Schema: map[string]any{"type": "string"}},
+			},
+			Secured: true,
+			Responses: standardErrors(
+				Response{Status: http.StatusOK, Description: "Zonas ordenadas por tipo y nombre", Body: components["ZoneList"], RateLimited: true},
+			),
+		},
+		{
+			Method:  http.MethodPost,
+			Path:    "/api/v1/zones/",
+			Tag:     "zonas",
+			Summary: "Crear una zona administrativa",
+			Description: "La geometría es un GeoJSON Polygon o MultiPolygon. Al crear, modificar o eliminar zonas se recalcula la zona de " +
+				"todos los delitos; si varias zonas contienen un delito se le asigna la más pequeña.",
+			RequestBody: components["ZoneRequest"],
+			Secured:     true,
+			Responses: standardErrors(
+				Response{Status: http.StatusCreated, Description: "Zona creada", Body: components["Zone"], RateLimited: true},
+				Response{Status: http.StatusBadRequest, Description: "Datos inválidos", Body: components["Error"]},
+				Response{Status: http.StatusForbidden, Description: "Solo disponible para administradores", Body: components["Error"]},
+				Response{Status: http.StatusConflict, Description: "Ya existe una zona con el mismo nombre y tipo", Body: components["Error"]},
+			),
+		},
+		{
+			Method:  http.MethodPost,
+			Path:    "/api/v1/zones/import",
+			Tag:     "zonas",
+			Summary: "Importar zonas desde GeoJSON",
+			Description: "Recibe un FeatureCollection de hasta 20 MB con geometrías Polygon o MultiPolygon. Crea las zonas nuevas y " +
+				"reemplaza la geometría de las que ya existen con el mismo nombre y tipo.",
+			Parameters: []Parameter{
+				{Name: "kind", In: "query", Description: "Tipo de las zonas importadas", Required: true, Schema: map[string]any{"type": "string"}},
+				{Name: "name_property", In: "query", Description: "Propiedad de cada feature con el nombre de la zona", Schema: map[string]any{"type": "string", "default": "name"}},
+				{Name: "population_property", In: "query", Description: "Propiedad de cada feature con la población de la zona", Schema: map[string]any{"type": "string", "default": "population"}},
+			},
+			RequestBody: components["FeatureCollection"],
+			Secured:     true,
+			Responses: standardErrors(
+				Response{Status: http.StatusOK, Description: "Resumen de la importación", Body: components["ImportZonesResult"], RateLimited: true},
+				Response{Status: http.StatusBadRequest, Description: "Archivo inválido", Body: components["Error"]},
+				Response{Status: http.StatusForbidden, Description: "Solo disponible para administradores", Body: components["Error"]},
+				Response{Status: http.StatusRequestEntityTooLarge, Description: "El archivo excede los 20 MB", Body: components["Error"]},
+			),
+		},
+		{
+			Method:  http.MethodPost,
+			Path:    "/api/v1/zones/import/shapefile",
+			Tag:     "zonas",
+			Summary: "Importar zonas desde un shapefile",
+			Description: "Recibe un zip de hasta 50 MB con un shapefile de polígonos (.shp, .dbf y .prj), como los límites de barrios " +
+				"que publican los organismos oficiales. Las coordenadas se convierten a WGS84 como en la importación de delitos. " +
+				"Crea las zonas nuevas y reemplaza la geometría de las que ya existen con el mismo nombre y tipo.",
+			Parameters: []Parameter{
+				{Name: "kind", In: "query", Description: "Tipo de las zonas importadas", Required: true, Schema: map[string]any{"type": "string"}},
+				{Name: "name_field", In: "query", Description: "Columna con el nombre de la zona", Schema: map[string]any{"type": "string", "default": "nombre"}},
+				{Name: "population_field", In: "query", Description: "Columna con la población de la zona", Schema: map[string]any{"type": "string", "default": "poblacion"}},
+				{Name: "srid", In: "query", Description: "Código EPSG del sistema de coordenadas; reemplaza al .prj", Schema: map[string]any{"type": "integer"}},
+			},
+			RequestFile: "application/zip",
+			Secured:     true,
+			Responses: standardErrors(
+				Response{Status: http.StatusOK, Description: "Resumen de la importación", Body: components["ImportZonesResult"], RateLimited: true},
+				Response{Status: http.StatusBadRequest, Description: "Archivo o sistema de coordenadas inválido", Body: components["Error"]},
+				Response{Status: http.StatusForbidden, Description: "Solo disponible para administradores", Body: components["Error"]},
+				Response{Status: http.StatusRequestEntityTooLarge, Description: "El archivo excede los 50 MB", Body: components["Error"]},
+			),
+		},
+		{
+			Method:  http.MethodGet,
+			Path:    "/api/v1/zones/:id",
+			Tag:     "zonas",
+			Summary: "Obtener una zona administrativa",
+			Secured: true,
+			Responses: standardErrors(
+				Response{Status: http.StatusOK, Description: "Zona", Body: components["Zone"], RateLimited: true},
+				Response{Status: http.StatusNotFound, Description: "La zona no existe", Body: components["Error"]},
+			),
+		},
+		{
+			Method:      http.MethodPut,
+			Path:        "/api/v1/zones/:id",
+			Tag:         "zonas",
+			Summary:     "Reemplazar una zona administrativa",
+			RequestBody: components["ZoneRequest"],
+			Secured:     true,
+			Responses: standardErrors(
+				Response{Status: http.StatusOK, Description: "Zona actualizada", Body: components["Zone"], RateLimited: true},
+				Response{Status: http.StatusBadRequest, Description: "Datos inválidos", Body: components["Error"]},
+				Response{Status: http.StatusForbidden, Description: "Solo disponible para administradores", Body: components["Error"]},
+				Response{Status: http.StatusNotFound, Description: "La zona no existe", Body: components["Error"]},
+				Response{Status: http.StatusConflict, Description: "Ya existe una zona con el mismo nombre y tipo", Body: components["Error"]},
+			),
+		},
+		{
+			Method:  http.MethodDelete,
+			Path:    "/api/v1/zones/:id",
+			Tag:     "zonas",
+			Summary: "Eliminar una zona administrativa",
+			Secured: true,
+			Responses: standardErrors(
+				Response{Status: http.StatusNoContent, Description: "Zona eliminada", RateLimited: true},
+				Response{Status: http.StatusForbidden, Description: "Solo disponible para administradores", Body: components["Error"]},
+				Response{Status: http.StatusNotFound, Description: "La zona no existe", Body: components["Error"]},
+			),
+		},
+		{
+			Method:  http.MethodGet,
+			Path:    "/api/v1/stats/zones",
+			Tag:     "estadísticas",
+			Summary: "Estadísticas de delitos por zona",
+			Description: "Cuenta los delitos de cada zona por fecha del delito, con la tasa cada 1000 habitantes, el desglose por tipo " +
+				"y la variación respecto del período anterior de la misma duración. Cada delito se cuenta en la zona que tiene " +
+				"asignada. Sin rol de moderación solo se cuentan los estados públicos.",
+			Parameters: []Parameter{
+				{Name: "from", In: "query", Description: "Inicio del período en formato RFC 3339, por defecto 30 días antes del fin", Schema: map[string]any{"type": "string", "format": "date-time"}},
+				{Name: "to", In: "query", Description: "Fin del período (excluido) en formato RFC 3339, por defecto el instante actual", Schema: map[string]any{"type": "string", "format": "date-time"}},
+				{Name: "kind", In: "query", Description: "Tipo de zona", Schema: map[string]any{"type": "string"}},
+				{Name: "type", In: "query", Description: "Tipos de delito a incluir, separados por coma", Schema: map[string]any{"type": "string"}},
+				{Name: "status", In: "query", Description: "Estados a incluir, separados por coma", Schema: map[string]any{"type": "string"}},
+				{Name: "sort", In: "query", Description: "Orden del ranking", Schema: map[string]any{"type": "string", "enum": []string{"count", "rate", "change", "change_percent", "severity", "name"}, "default": "count"}},
+				{Name: "limit", In: "query", Description: "Cantidad máxima de zonas, 0 para todas", Schema: map[string]any{"type": "integer", "default": 0}},
+			},
+			Secured: true,
+			Responses: standardErrors(
+				Response{Status: http.StatusOK, Description: "Estadísticas por zona", Body: components["ZoneStatsResult"], RateLimited: true},
+				Response{Status: http.StatusBadRequest, Description: "Parámetros inválidos", Body: components["Error"]},
+				Response{Status: http.StatusForbidden, Description: "Estado no visible para el actor", Body: components["Error"]},
+			),
+		},
+		{
+			Method:  http.MethodGet,
+			Path:    "/api/v1/stats/timeseries",
+			Tag:     "estadísticas",
+			Summary: "Serie temporal de delitos",
+			Description: "Cuenta los delitos por intervalo según la fecha del delito en la zona horaria indicada. Incluye todos los " +
+				"intervalos del período, también los que no tienen delitos. Sin rol de moderación solo se cuentan los estados públicos.",
+			Parameters: statsParameters(
+				Parameter{Name: "bucket", In: "query", Description: "Intervalo de agrupación; las semanas empiezan el lunes", Schema: map[string]any{"type": "string", "enum": []string{"hour", "day", "week", "month"}, "default": "day"}},
+			),
+			Secured: true,
+			Responses: standardErrors(
+				Response{Status: http.StatusOK, Description: "Serie temporal", Body: components["TimeSeriesResult"], RateLimited: true},
+				Response{Status: http.StatusBadRequest, Description: "Parámetros inválidos", Body: components["Error"]},
+				Response{Status: http.StatusForbidden, Description: "Estado no visible para el actor", Body: components["Error"]},
+			),
+		},
+		{
+			Method:  http.MethodGet,
+			Path:    "/api/v1/stats/punchcard",
+			Tag:     "estadísticas",
+			Summary: "Delitos por día de la semana y hora",
+			Description: "Matriz de 7 filas, de lunes a domingo, por 24 columnas con la cantidad de delitos de cada hora local " +
+				"en la zona horaria indicada. Sin rol de moderación solo se cuentan los estados públicos.",
+			Parameters: statsParameters(),
+			Secured:    true,
+			Responses: standardErrors(
+				Response{Status: http.StatusOK, Description: "Matriz de día de la semana y hora", Body: components["PunchcardResult"], RateLimited: true},
+				Response{Status: http.StatusBadRequest, Description: "Parámetros inválidos", Body: components["Error"]},
+				Response{Status: http.StatusForbidden, Description: "Estado no visible para el actor", Body: components["Error"]},
+			),
+		},
+		{
+			Method:  http.MethodGet,
+			Path:    "/api/v1/stats/hotspots",
+			Tag:     "estadísticas",
+			Summary: "Zonas calientes",
+			Description: "Detecta concentraciones de delitos con DBSCAN o con estimación de densidad de kernel y retorna el " +
+				"centroide, la envolvente convexa y la cantidad de delitos de cada una. Los resultados se guardan en caché por " +
+				"conjunto de parámetros. Sin rol de moderación solo se analizan los estados públicos.",
+			Parameters: statsParameters(
+				Parameter{Name: "method", In: "query", Description: "Método de detección", Schema: map[string]any{"type": "string", "enum": []string{"dbscan", "kde"}, "default": "dbscan"}},
+				Parameter{Name: "eps_meters", In: "query", Description: "DBSCAN: distancia máxima en metros entre delitos vecinos", Schema: map[string]any{"type": "number", "minimum": 10, "maximum": 5000, "default": 200}},
+				Parameter{Name: "min_points", In: "query", Description: "DBSCAN: delitos vecinos necesarios para formar un núcleo, incluido el propio", Schema: map[string]any{"type": "integer", "minimum": 2, "maximum": 1000, "default": 5}},
+				Parameter{Name: "bandwidth_meters", In: "query", Description: "KDE: desviación en metros del kernel gaussiano", Schema: map[string]any{"type": "number", "minimum": 25, "maximum": 5000, "default": 250}},
+				Parameter{Name: "threshold", In: "query", Description: "KDE: fracción de la densidad máxima que delimita las zonas", Schema: map[string]any{"type": "number", "exclusiveMinimum": 0, "maximum": 1, "default": 0.5}},
+			),
+			Secured: true,
+			Responses: standardErrors(
+				Response{Status: http.StatusOK, Description: "Zonas calientes", Body: components["HotspotResult"], RateLimited: true},
+				Response{Status: http.StatusBadRequest, Description: "Parámetros inválidos o demasiados delitos para analizar", Body: components["Error"]},
+				Response{Status: http.StatusForbidden, Description: "Estado no visible para el actor", Body: components["Error"]},
+			),
+		},
+		{
+			Method:  http.MethodGet,
+			Path:    "/api/v1/stats/hotspots/significance",
+			Tag:     "estadísticas",
+			Summary: "Puntos calientes y fríos significativos (Gi*)",
+			Description: "Agrega los delitos por celda de una grilla o por zona y calcula el estadístico Gi* de Getis-Ord con pesos " +
+				"binarios por distancia entre centros. Responde un GeoJSON FeatureCollection cuyas features tienen las propiedades " +
+				"count, z_score, p_value, confidence (de -3, punto frío al 99 %, a 3, punto caliente al 99 %) y class (hot, cold o " +
+				"not_significant). En la grilla se omiten las celdas sin delitos que no son significativas. Sin rol de moderación " +
+				"solo se analizan los estados públicos.",
+			Parameters: statsParameters(
+				Parameter{Name: "aggregation", In: "query", Description: "Áreas en que se agregan los delitos", Schema: map[string]any{"type": "string", "enum": []string{"grid", "zone"}, "default": "grid"}},
+				Parameter{Name: "cell_meters", In: "query", Description: "grid: lado de las celdas en metros", Schema: map[string]any{"type": "number", "minimum": 50, "maximum": 5000, "default": 250}},
+				Parameter{Name: "zone_kind", In: "query", Description: "zone: tipo de las zonas analizadas, obligatorio", Schema: map[string]any{"type": "string"}},
+				Parameter{Name: "distance_meters", In: "query", Description: "Distancia máxima entre los centros de dos vecinos; por defecto 1,5 celdas o la menor que da un vecino a cada zona", Schema: map[string]any{"type": "number", "minimum": 0, "maximum": 50000}},
+				Parameter{Name: "significant_only", In: "query", Description: "Retorna solo las áreas con al menos 90 % de confianza", Schema: map[string]any{"type": "boolean", "default": false}},
+			),
+			Secured: true,
+			Responses: standardErrors(
+				Response{Status: http.StatusOK, Description: "Áreas con su significancia", Body: components["HotspotSignificance"], ContentType: "application/geo+json", RateLimited: true},
+				Response{Status: http.StatusBadRequest, Description: "Parámetros inválidos o demasiados delitos para analizar", Body: components["Error"]},
+				Response{Status: http.StatusForbidden, Description: "Estado no visible para el actor", Body: components["Error"]},
+			),
+		},
+		{
+			Method:  http.MethodGet,
+			Path:    "/api/v1/anomalies/",
+			Tag:     "estadísticas",
+			Summary: "Listar los aumentos inusuales de delitos",
+			Description: "Una tarea periódica compara los delitos públicos de la última ventana con el promedio y la desviación " +
+				"estándar de las ventanas anteriores de cada serie (zona y tipo, zona, tipo y total). Una zona o un tipo vacío " +
+				"indican que la serie agrega todas las zonas o todos los tipos. Se ordenan de la ventana más reciente a la más antigua.",
+			Parameters: []Parameter{
+				{Name: "zone", In: "query", Description: "ID de la zona de la serie", Schema: map[string]any{"type": "string"}},
+				{Name: "type", In: "query", Description: "Tipo de delito de la serie", Schema: map[string]any{"type": "string"}},
+				{Name: "from", In: "query", Description: "Inicio mínimo de la ventana (RFC 3339)", Schema: map[string]any{"type": "string", "format": "date-time"}},
+				{Name: "to", In: "query", Description: "Inicio máximo de la ventana, excluido (RFC 3339)", Schema: map[string]any{"type": "string", "format": "date-time"}},
+				{Name: "limit", In: "query", Description: "Cantidad máxima de anomalías", Schema: map[string]any{"type": "integer", "minimum": 1, "maximum": 500, "default": 50}},
+			},
+			Secured: true,
+			Responses: standardErrors(
+				Response{Status: http.StatusOK, Description: "Anomalías detectadas", Body: components["AnomalyList"], RateLimited: true},
+				Response{Status: http.StatusBadRequest, Description: "Parámetros inválidos", Body: components["Error"]},
+			),
+		},
+		{
+			Method:  http.MethodGet,
+			Path:    "/api/v1/safety/",
+			Tag:     "estadísticas",
+			Summary: "Puntaje de riesgo de un punto",
+			Description: "Calcula la densidad de delitos públicos dentro del radio, donde cada delito pesa según su gravedad " +
+				"con un decaimiento exponencial según su antigüedad. El puntaje va de 0 a 100 e incluye el aporte de cada tipo de delito.",
+			Parameters: []Parameter{
+				{Name: "lat", In: "query", Description: "Latitud del punto", Required: true, Schema: map[string]any{"type": "number", "minimum": -90, "maximum": 90}},
+				{Name: "lon", In: "query", Description: "Longitud del punto", Required: true, Schema: map[string]any{"type": "number", "minimum": -180, "maximum": 180}},
+				{Name: "radius_meters", In: "query", Description: "Radio en metros alrededor del punto", Schema: map[string]any{"type": "number", "minimum": 50, "maximum": 5000, "default": 500}},
+				{Name: "half_life_days", In: "query", Description: "Días en que el peso de un delito se reduce a la mitad", Schema: map[string]any{"type": "number", "minimum": 1, "maximum": 365, "default": 90}},
+			},
+			Secured: true,
+			Responses: standardErrors(
+				Response{Status: http.StatusOK, Description: "Puntaje de riesgo", Body: components["SafetyScore"], RateLimited: true},
+				Response{Status: http.StatusBadRequest, Description: "Parámetros inválidos", Body: components["Error"]},
+			),
+		},
+		{
+			Method:  http.MethodPost,
+			Path:    "/api/v1/safety/route",
+			Tag:     "estadísticas",
+			Summary: "Puntaje de riesgo de una ruta",
+			Description: "Igual que el puntaje de un punto, sobre la franja de radius_meters a cada lado de la ruta. La ruta se " +
+				"indica con sus vértices (path) o como polilínea codificada (polyline), de 2 a 1000 vértices y hasta 100 km.",
+			RequestBody: components["SafetyRouteRequest"],
+			Secured:     true,
+			Responses: standardErrors(
+				Response{Status: http.StatusOK, Description: "Puntaje de riesgo", Body: components["SafetyScore"], RateLimited: true},
+				Response{Status: http.StatusBadRequest, Description: "Ruta o parámetros inválidos", Body: components["Error"]},
+			),
+		},
+		{
+			Method:  http.MethodGet,
+			Path:    "/tiles/crimes/:z/:x/:y",
+			Tag:     "mapa",
+			Summary: "Tile vectorial de delitos",
+			Description: "Tile en formato Mapbox Vector Tile de la grilla XYZ de Web Mercator; y lleva la extensión .mvt " +
+				"(por ejemplo /tiles/crimes/14/5534/9872.mvt). Hasta el zoom TILE_CLUSTER_MAX_ZOOM los delitos cercanos se " +
+				"agrupan en la capa clusters (count y severity_score) y los aislados van a la capa crimes; en los zooms mayores " +
+				"cada delito es un punto de la capa crimes con id, type, severity y date (segundos Unix). La respuesta incluye " +
+				"un ETag; con If-None-Match se responde 304 si el tile no cambió. Sin rol de moderación solo se incluyen los " +
+				"estados públicos.",
+			Parameters: []Parameter{
+				{Name: "from", In: "query", Description: "Inicio del período en formato RFC 3339, por defecto 30 días antes del fin", Schema: map[string]any{"type": "string", "format": "date-time"}},
+				{Name: "to", In: "query", Description: "Fin del período (excluido) en formato RFC 3339, por defecto el fin del día actual en UTC", Schema: map[string]any{"type": "string", "format": "date-time"}},
+				{Name: "type", In: "query", Description: "Tipos de delito a incluir, separados por coma", Schema: map[string]any{"type": "string"}},
+				{Name: "status", In: "query", Description: "Estados a incluir, separados por coma", Schema: map[string]any{"type": "string"}},
+				{Name: "If-None-Match", In: "header", Description: "ETag de la copia que tiene el cliente", Schema: map[string]any{"type": "string"}},
+			},
+			Secured: true,
+			Responses: standardErrors(
+				Response{Status: http.StatusOK, Description: "Tile de delitos, vacío si no hay ninguno", ContentType: "application/vnd.mapbox-vector-tile", RateLimited: true},
+				Response{Status: http.StatusNotModified, Description: "El tile no cambió desde el ETag indicado"},
+				Response{Status: http.StatusBadRequest, Description: "Tile o filtros inválidos", Body: components["Error"]},
+				Response{Status: http.StatusForbidden, Description: "Estado no visible para el actor", Body: components["Error"]},
+				Response{Status: http.StatusNotFound, Description: "La fila no tiene la extensión .mvt", Body: components["Error"]},
+			),
+		},
+	}
+}
+
+// streamParameters documenta los filtros comunes de las conexiones del feed en tiempo real
+func streamParameters(extra ...Parameter) []Parameter {
+	return append([]Parameter{
+		{Name: "bbox", In: "query", Description: "Zona de interés con el formato oeste,sur,este,norte", Schema: map[string]any{"type": "string"}},
+		{Name: "type", In: "query", Description: "Tipos de delito a incluir, separados por coma", Schema: map[string]any{"type": "string"}},
+		{Name: "last_event_id", In: "query", Description: "ID del último evento recibido", Schema: map[string]any{"type": "string"}},
+	}, extra...)
+}
+
+// statsParameters documenta los filtros comunes de las estadísticas temporales
+func statsParameters(extra ...Parameter) []Parameter {
+	return append([]Parameter{
+		{Name: "from", In: "query", Description: "Inicio del período en formato RFC 3339, por defecto 30 días antes del fin", Schema: map[string]any{"type": "string", "format": "date-time"}},
+		{Name: "to", In: "query", Description: "Fin del período (excluido) en formato RFC 3339, por defecto el instante actual", Schema: map[string]any{"type": "string", "format": "date-time"}},
+		{Name: "timezone", In: "query", Description: "Zona horaria IANA en que se agrupan las fechas", Schema: map[string]any{"type": "string", "default": "UTC"}},
+		{Name: "type", In: "query", Description: "Tipos de delito a incluir, separados por coma", Schema: map[string]any{"type": "string"}},
+		{Name: "zone", In: "query", Description: "IDs de las zonas a incluir, separados por coma", Schema: map[string]any{"type": "string"}},
+		{Name: "bbox", In: "query", Description: "Rectángulo con el formato oeste,sur,este,norte", Schema: map[string]any{"type": "string"}},
+		{Name: "status", In: "query", Description: "Estados a incluir, separados por coma", Schema: map[string]any{"type": "string"}},
+	}, extra...)
+}
+
+// moderationDecision documenta las operaciones de aprobación y rechazo, que comparten contrato
+func moderationDecision(method, path, summary, description string) Operation {
+	return Operation{
+		Method:       method,
+		Path:         path,
+		Tag:          "moderación",
+		Summary:      summary,
+		Description:  description + " Requiere tener la reserva vigente del delito.",
+		RequestBody:  components["ModerationDecision"],
+		BodyOptional: true,
+		Secured:      true,
+		Responses: standardErrors(
+			Response{Status: http.StatusOK, Description: "Delito moderado", Body: components["Crime"], RateLimited: true},
+			Response{Status: http.StatusBadRequest, Description: "Nota inválida o faltante", Body: components["Error"]},
+			Response{Status: http.StatusForbidden, Description: "Solo disponible para moderadores", Body: components["Error"]},
+			Response{Status: http.StatusNotFound, Description: "El delito no existe", Body: components["Error"]},
+			Response{Status: http.StatusConflict, Description: "Sin reserva vigente o el delito ya fue moderado", Body: components["Error"]},
+		),
+	}
+}
+
+// Document genera el documento OpenAPI 3.1 de la API
+func Document() map[string]any {
+	generator := &schemaGenerator{names: map[reflect.Type]string{}}
+	for name, t := range components {
+		generator.names[t] = name
+	}
+
+	schemas := map[string]any{}
+	for name, t := range components {
+		schemas[name] = generator.inline(t)
+	}
+	describeCrimeRequest(schemas["CreateCrimeRequest"].(map[string]any))
+	describeCrimeStatus(schemas)
+	describeGeoJSON(schemas)
+
+	paths := map[string]any{}
+	for _, op := range Operations() {
+		path, params := openAPIPath(op.Path)
+		item, ok := paths[path].(map[string]any)
+		if !ok {
+			item = map[string]any{}
+			paths[path] = item
+		}
+		item[strings.ToLower(op.Method)] = operationObject(generator, op, params)
+	}
+
+	return map[string]any{
+		"openapi": "3.1.0",
+		"info": map[string]any{
+			"title":       "Crime Map API",
+			"version":     "1.0.0",
+			"description": "API para reportar y consultar delitos en el mapa.",
+		},
+		"paths": paths,
+		"components": map[string]any{
+			"schemas": schemas,
+			"securitySchemes": map[string]any{
+				"ApiKeyAuth": map[string]any{
+					"type":        "apiKey",
+					"in":          "header",
+					"name":        "X-API-Key",
+					"description": "Clave de API del actor. Determina su rol (citizen, moderator o admin) y lo identifica para el límite de solicitudes.",
+				},
+			},
+			"headers": map[string]any{
+				"X-RateLimit-Limit":     header("Capacidad del bucket de solicitudes"),
+				"X-RateLimit-Remaining": header("Solicitudes disponibles en el bucket"),
+				"X-RateLimit-Reset":     header("Segundos hasta que el bucket vuelva a estar lleno"),
+				"Retry-After":           header("Segundos a esperar antes de reintentar"),
+				"X-Request-ID":          map[string]any{"description": "Identificador de correlación de la solicitud", "schema": map[string]any{"type": "string"}},
+			},
+		},
+	}
+}
+
+// operationObject construye el objeto de operación de OpenAPI
+func operationObject(generator *schemaGenerator, op Operation, pathParams []string) map[string]any {
+	object := map[string]any{
+		"summary":     op.Summary,
+		"operationId": operationID(op),
+		"tags":        []string{op.Tag},
+	}
+	if op.Description != "" {
+		object["description"] = op.Description
+	}
+
+	var parameters []any
+	for _, name := range pathParams {
+		parameters = append(parameters, map[string]any{
+			"name": name, "in": "path", "required": true, "schema": map[string]any{"type": "string"},
+		})
+	}
+	for _, param := range op.Parameters {
+		parameters = append(parameters, map[string]any{
+			"name": param.Name, "in": param.In, "required": param.Required,
+			"description": param.Description, "schema": param.Schema,
+		})
+	}
+	if len(parameters) > 0 {
+		object["parameters"] = parameters
+	}
+
+	switch {
+	case op.RequestBody != nil:
+		object["requestBody"] = map[string]any{
+			"required": !op.BodyOptional,
+			"content": map[string]any{
+				"application/json": map[string]any{"schema": generator.schemaFor(op.RequestBody)},
+			},
+		}
+	case op.RequestFile != "":
+		object["requestBody"] = map[string]any{
+			"required": !op.BodyOptional,
+			"content": map[string]any{
+				op.RequestFile: map[string]any{"schema": map[string]any{"type": "string", "contentMediaType": op.RequestFile}},
+			},
+		}
+	}
+
+	responses := map[string]any{}
+	for _, resp := range op.Responses {
+		response := map[string]any{"description": resp.Description}
+		headers := map[string]any{"X-Request-ID": map[string]any{"$ref": "#/components/headers/X-Request-ID"}}
+		if resp.RateLimited {
+			for _, name := range []string{"X-RateLimit-Limit", "X-RateLimit-Remaining", "X-RateLimit-Reset"} {
+				headers[name] = map[string]any{"$ref": "#/components/headers/" + name}
+			}
+			if resp.Status == http.StatusTooManyRequests {
+				headers["Retry-After"] = map[string]any{"$ref": "#/components/headers/Retry-After"}
+			}
+		}
+		response["headers"] = headers
+
+		switch {
+		case resp.Body != nil:
+			contentType := resp.ContentType
+			if contentType == "" {
+				contentType = "application/json"
+			}
+			response["content"] = map[string]any{
+				contentType: map[string]any{"schema": generator.schemaFor(resp.Body)},
+			}
+		case resp.ContentType != "":
+			response["content"] = map[string]any{
+				resp.ContentType: map[string]any{"schema": map[string]any{"type": "string"}},
+			}
+		}
+		responses[strconv.Itoa(resp.Status)] = response
+	}
+	object["responses"] = responses
+
+	if op.Secured {
+		// La clave de API es opcional: sin ella el actor es anónimo y se identifica por su IP
+		object["security"] = []any{map[string]any{}, map[string]any{"ApiKeyAuth": []string{}}}
+	}
+
+	return object
+}
+
+// describeCrimeRequest agrega al esquema de la petición las restricciones de los casos de uso
+func describeCrimeRequest(schema map[string]any) {
+	properties := schema["properties"].(map[string]any)
+	properties["type"].(map[string]any)["enum"] = usecases.ValidCrimeTypes()
+	properties["description"].(map[string]any)["maxLength"] = usecases.MaxDescriptionLength()
+
+	// Con nomenclador basta con las coordenadas o con la dirección; sin él se requieren ambas
+	properties["location"].(map[string]any)["anyOf"] = []any{
+		map[string]any{"required": []string{"latitude", "longitude"}},
+		map[string]any{"required": []string{"address"}},
+	}
+	location := properties["location"].(map[string]any)["properties"].(map[string]any)
+	location["latitude"].(map[string]any)["exclusiveMinimum"] = -90
+	location["latitude"].(map[string]any)["exclusiveMaximum"] = 90
+	location["longitude"].(map[string]any)["exclusiveMinimum"] = -180
+	location["longitude"].(map[string]any)["exclusiveMaximum"] = 180
+
+	properties["severity"].(map[string]any)["enum"] = severityValues()
+	properties["weapon"].(map[string]any)["enum"] = weaponValues()
+	properties["victim_count"].(map[string]any)["minimum"] = 0
+	properties["victim_count"].(map[string]any)["maximum"] = 1000
+}
+
+// severityValues retorna las gravedades de los delitos como texto
+func severityValues() []string {
+	values := make([]string, 0, len(entities.Severities))
+	for _, severity := range entities.Severities {
+		values = append(values, string(severity))
+	}
+	return values
+}
+
+// weaponValues retorna los tipos de arma como texto
+func weaponValues() []string {
+	values := make([]string, 0, len(entities.Weapons))
+	for _, weapon := range entities.Weapons {
+		values = append(values, string(weapon))
+	}
+	return values
+}
+
+// describeCrimeStatus agrega los valores posibles de los estados y de la clasificación de los delitos
+func describeCrimeStatus(schemas map[string]any) {
+	statuses := make([]string, 0, len(entities.CrimeStatuses))
+	for _, status := range entities.CrimeStatuses {
+		statuses = append(statuses, string(status))
+	}
+
+	property := func(schema, name string) map[string]any {
+		return schemas[schema].(map[string]any)["properties"].(map[string]any)[name].(map[string]any)
+	}
+	property("Crime", "status")["enum"] = statuses
+	property("Crime", "severity")["enum"] = severityValues()
+	property("Crime", "weapon")["enum"] = weaponValues()
+	property("TransitionStatusRequest", "status")["enum"] = statuses
+	property("CrimeStatusChange", "from")["enum"] = statuses
+	property("CrimeStatusChange", "to")["enum"] = statuses
+}
+
+// describeGeoJSON reemplaza los esquemas de las geometrías, que se serializan como GeoJSON
+// (RFC 7946) y no según sus tipos de Go
+func describeGeoJSON(schemas map[string]any) {
+	position := map[string]any{
+		"type":        "array",
+		"description": "Posición [longitud, latitud]",
+		"items":       map[string]any{"type": "number"},
+		"minItems":    2,
+	}
+	ring := map[string]any{"type": "array", "items": position, "minItems": 4}
+	polygon := map[string]any{"type": "array", "items": ring, "minItems": 1}
+
+	schemas["Geometry"] = map[string]any{
+		"type":        "object",
+		"description": "Geometría GeoJSON",
+		"properties": map[string]any{
+			"type":        map[string]any{"type": "string"},
+			"coordinates": map[string]any{"type": "array"},
+		},
+		"required": []string{"type", "coordinates"},
+	}
+	schemas["MultiPolygon"] = map[string]any{
+		"type":        "object",
+		"description": "Geometría GeoJSON MultiPolygon; al recibirla también se acepta un Polygon",
+		"properties": map[string]any{
+			"type":        map[string]any{"type": "string", "enum": []string{geojson.TypeMultiPolygon, geojson.TypePolygon}},
+			"coordinates": map[string]any{"type": "array", "items": polygon},
+		},
+		"required": []string{"type", "coordinates"},
+	}
+	feature := schemas["FeatureCollection"].(map[string]any)["properties"].(map[string]any)["features"].(map[string]any)["items"].(map[string]any)
+	feature["properties"].(map[string]any)["id"] = map[string]any{"type": []string{"string", "number"}}
+}
+
+// openAPIPath convierte una ruta de Gin al formato de OpenAPI y retorna sus parámetros
+func openAPIPath(ginPath string) (string, []string) {
+	segments := strings.Split(ginPath, "/")
+	var params []string
+	for i, segment := range segments {
+		if strings.HasPrefix(segment, ":") || strings.HasPrefix(segment, "*") {
+			name := segment[1:]
+			params = append(params, name)
+			segments[i] = "{" + name + "}"
+		}
+	}
+	return strings.Join(segments, "/"), params
+}
+
+// GinPathToOpenAPI convierte una ruta de Gin al formato de OpenAPI
+func GinPathToOpenAPI(ginPath string) string {
+	path, _ := openAPIPath(ginPath)
+	return path
+}
+
+// operationID genera un identificador estable a partir del método y la ruta
+func operationID(op Operation) string {
+	var b strings.Builder
+	b.WriteString(strings.ToLower(op.Method))
+	for _, segment := range strings.Split(op.Path, "/") {
+		segment = strings.TrimLeft(segment, ":*")
+		for _, part := range strings.FieldsFunc(segment, func(r rune) bool {
+			return r == '.' || r == '-' || r == '_'
+		}) {
+			b.WriteString(strings.ToUpper(part[:1]) + part[1:])
+		}
+	}
+	return b.String()
+}
+
+// header crea la definición de una cabecera numérica
+func header(description string) map[string]any {
+	return map[string]any{"description": description, "schema": map[string]any{"type": "integer"}}
+}
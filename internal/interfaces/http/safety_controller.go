@@ -0,0 +1,115 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+
+	"go-crime_map_backend/internal/domain/entities"
+	"go-crime_map_backend/internal/interfaces/http/middleware"
+	"go-crime_map_backend/internal/usecases"
+	"go-crime_map_backend/pkg/polyline"
+
+	"github.com/gin-gonic/gin"
+)
+
+// defaultPolylinePrecision es la cantidad de decimales habitual de las polilíneas codificadas
+const defaultPolylinePrecision = 5
+
+// SafetyController maneja las peticiones HTTP del puntaje de seguridad
+type SafetyController struct {
+	scoreUseCase *usecases.GetSafetyScoreUseCase
+}
+
+// NewSafetyController crea una nueva instancia del controlador
+func NewSafetyController(scoreUseCase *usecases.GetSafetyScoreUseCase) *SafetyController {
+	return &SafetyController{scoreUseCase: scoreUseCase}
+}
+
+// SafetyRouteRequest representa la petición del puntaje de seguridad de una ruta. La ruta se
+// indica con sus vértices en path o como polilínea codificada en polyline
+type SafetyRouteRequest struct {
+	Path         []entities.Coordinate `json:"path"`
+	Polyline     string                `json:"polyline"`
+	Precision    int                   `json:"precision"`      // Decimales de la polilínea, 5 por defecto
+	RadiusMeters float64               `json:"radius_meters"`  // Ancho a cada lado de la ruta, 500 por defecto
+	HalfLifeDays float64               `json:"half_life_days"` // Vida media del peso de un delito, 90 por defecto
+}
+
+// Point maneja la petición GET del puntaje de seguridad alrededor del punto lat, lon
+func (c *SafetyController) Point(ctx *gin.Context) {
+	var values [4]float64
+	for i, name := range []string{"lat", "lon", "radius_meters", "half_life_days"} {
+		raw := ctx.Query(name)
+		if raw == "" {
+			if i < 2 {
+				ctx.JSON(http.StatusBadRequest, middleware.ErrorBody(ctx, name+" es obligatorio"))
+				return
+			}
+			continue
+		}
+		value, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, middleware.ErrorBody(ctx, name+" debe ser un número"))
+			return
+		}
+		values[i] = value
+	}
+
+	result, err := c.scoreUseCase.Execute(ctx.Request.Context(), usecases.SafetyInput{
+		Point:        &entities.Coordinate{Latitude: values[0], Longitude: values[1]},
+		RadiusMeters: values[2],
+		HalfLifeDays: values[3],
+	})
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, result)
+}
+
+// Route maneja la petición POST del puntaje de seguridad a lo largo de una ruta
+func (c *SafetyController) Route(ctx *gin.Context) {
+	var req SafetyRouteRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, middleware.ErrorBody(ctx, err.Error()))
+		return
+	}
+	if (req.Path == nil) == (req.Polyline == "") {
+		ctx.JSON(http.StatusBadRequest, middleware.ErrorBody(ctx, "se debe indicar path o polyline, pero no ambos"))
+		return
+	}
+
+	route := req.Path
+	if req.Polyline != "" {
+		precision := req.Precision
+		if precision == 0 {
+			precision = defaultPolylinePrecision
+		}
+		if precision < 1 || precision > 7 {
+			ctx.JSON(http.StatusBadRequest, middleware.ErrorBody(ctx, "precision debe estar entre 1 y 7"))
+			return
+		}
+		points, err := polyline.Decode(req.Polyline, precision)
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, middleware.ErrorBody(ctx, err.Error()))
+			return
+		}
+		route = make([]entities.Coordinate, len(points))
+		for i, point := range points {
+			route[i] = entities.Coordinate{Latitude: point[0], Longitude: point[1]}
+		}
+	}
+
+	result, err := c.scoreUseCase.Execute(ctx.Request.Context(), usecases.SafetyInput{
+		Route:        route,
+		RadiusMeters: req.RadiusMeters,
+		HalfLifeDays: req.HalfLifeDays,
+	})
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, result)
+}
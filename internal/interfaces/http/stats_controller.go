@@ -0,0 +1,250 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"go-crime_map_backend/internal/domain/entities"
+	"go-crime_map_backend/internal/interfaces/http/middleware"
+	"go-crime_map_backend/internal/usecases"
+
+	"github.com/gin-gonic/gin"
+)
+
+// StatsController maneja las peticiones HTTP de las estadísticas de delitos
+type StatsController struct {
+	zoneStatsUseCase    *usecases.GetZoneStatsUseCase
+	timeSeriesUseCase   *usecases.GetCrimeTimeSeriesUseCase
+	punchcardUseCase    *usecases.GetCrimePunchcardUseCase
+	hotspotsUseCase     *usecases.DetectHotspotsUseCase
+	significanceUseCase *usecases.GetHotspotSignificanceUseCase
+}
+
+// NewStatsController crea una nueva instancia del controlador
+func NewStatsController(
+	zoneStatsUseCase *usecases.GetZoneStatsUseCase,
+	timeSeriesUseCase *usecases.GetCrimeTimeSeriesUseCase,
+	punchcardUseCase *usecases.GetCrimePunchcardUseCase,
+	hotspotsUseCase *usecases.DetectHotspotsUseCase,
+	significanceUseCase *usecases.GetHotspotSignificanceUseCase,
+) *StatsController {
+	return &StatsController{
+		zoneStatsUseCase:    zoneStatsUseCase,
+		timeSeriesUseCase:   timeSeriesUseCase,
+		punchcardUseCase:    punchcardUseCase,
+		hotspotsUseCase:     hotspotsUseCase,
+		significanceUseCase: significanceUseCase,
+	}
+}
+
+// Zones maneja la petición GET de las estadísticas por zona. El período se indica con from
+// y to en formato RFC 3339 y el ranking con sort y limit
+func (c *StatsController) Zones(ctx *gin.Context) {
+	from, to, ok := queryPeriod(ctx)
+	if !ok {
+		return
+	}
+	limit, err := strconv.Atoi(ctx.DefaultQuery("limit", "0"))
+	if err != nil || limit < 0 {
+		ctx.JSON(http.StatusBadRequest, middleware.ErrorBody(ctx, "el límite debe ser un número entero no negativo"))
+		return
+	}
+
+	result, err := c.zoneStatsUseCase.Execute(ctx.Request.Context(), usecases.ZoneStatsInput{
+		From:     from,
+		To:       to,
+		Kind:     ctx.Query("kind"),
+		Types:    queryList(ctx, "type"),
+		Statuses: queryStatuses(ctx),
+		Sort:     ctx.Query("sort"),
+		Limit:    limit,
+		Actor:    middleware.ActorFromContext(ctx),
+	})
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, result)
+}
+
+// TimeSeries maneja la petición GET de la serie temporal de delitos, agrupada por el
+// intervalo bucket en la zona horaria timezone
+func (c *StatsController) TimeSeries(ctx *gin.Context) {
+	criteria, ok := queryStatsCriteria(ctx)
+	if !ok {
+		return
+	}
+
+	result, err := c.timeSeriesUseCase.Execute(ctx.Request.Context(), usecases.TimeSeriesInput{
+		StatsCriteria: criteria,
+		Bucket:        entities.TimeBucket(ctx.Query("bucket")),
+	})
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, result)
+}
+
+// Punchcard maneja la petición GET de la matriz de delitos por día de la semana y hora
+func (c *StatsController) Punchcard(ctx *gin.Context) {
+	criteria, ok := queryStatsCriteria(ctx)
+	if !ok {
+		return
+	}
+
+	result, err := c.punchcardUseCase.Execute(ctx.Request.Context(), criteria)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, result)
+}
+
+// Hotspots maneja la petición GET de las zonas calientes, detectadas con DBSCAN (eps_meters y
+// min_points) o con densidad de kernel (bandwidth_meters y threshold) según method
+func (c *StatsController) Hotspots(ctx *gin.Context) {
+	criteria, ok := queryStatsCriteria(ctx)
+	if !ok {
+		return
+	}
+	input := usecases.HotspotInput{StatsCriteria: criteria, Method: ctx.Query("method")}
+	for _, param := range []struct {
+		name   string
+		target *float64
+	}{
+		{"eps_meters", &input.EpsMeters},
+		{"bandwidth_meters", &input.BandwidthMeters},
+		{"threshold", &input.Threshold},
+	} {
+		name := param.name
+		raw := ctx.Query(name)
+		if raw == "" {
+			continue
+		}
+		value, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, middleware.ErrorBody(ctx, name+" debe ser un número"))
+			return
+		}
+		*param.target = value
+	}
+	if raw := ctx.Query("min_points"); raw != "" {
+		value, err := strconv.Atoi(raw)
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, middleware.ErrorBody(ctx, "min_points debe ser un número entero"))
+			return
+		}
+		input.MinPoints = value
+	}
+
+	result, err := c.hotspotsUseCase.Execute(ctx.Request.Context(), input)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, result)
+}
+
+// HotspotSignificance maneja la petición GET del análisis Gi* de puntos calientes y fríos.
+// Responde un GeoJSON FeatureCollection con una feature por celda (aggregation=grid) o por
+// zona del tipo zone_kind (aggregation=zone)
+func (c *StatsController) HotspotSignificance(ctx *gin.Context) {
+	criteria, ok := queryStatsCriteria(ctx)
+	if !ok {
+		return
+	}
+	input := usecases.HotspotSignificanceInput{
+		StatsCriteria:   criteria,
+		Aggregation:     ctx.Query("aggregation"),
+		ZoneKind:        ctx.Query("zone_kind"),
+		SignificantOnly: ctx.Query("significant_only") == "true",
+	}
+	for _, param := range []struct {
+		name   string
+		target *float64
+	}{
+		{"cell_meters", &input.CellMeters},
+		{"distance_meters", &input.DistanceMeters},
+	} {
+		raw := ctx.Query(param.name)
+		if raw == "" {
+			continue
+		}
+		value, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, middleware.ErrorBody(ctx, param.name+" debe ser un número"))
+			return
+		}
+		*param.target = value
+	}
+
+	result, err := c.significanceUseCase.Execute(ctx.Request.Context(), input)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.Header("Content-Type", "application/geo+json")
+	ctx.JSON(http.StatusOK, result)
+}
+
+// queryStatsCriteria lee los filtros comunes de las estadísticas temporales. Si alguno es
+// inválido responde 400 y retorna false
+func queryStatsCriteria(ctx *gin.Context) (usecases.StatsCriteria, bool) {
+	from, to, ok := queryPeriod(ctx)
+	if !ok {
+		return usecases.StatsCriteria{}, false
+	}
+	criteria := usecases.StatsCriteria{
+		From:     from,
+		To:       to,
+		Timezone: ctx.Query("timezone"),
+		Types:    queryList(ctx, "type"),
+		ZoneIDs:  queryList(ctx, "zone"),
+		Statuses: queryStatuses(ctx),
+		Actor:    middleware.ActorFromContext(ctx),
+	}
+	if raw := ctx.Query("bbox"); raw != "" {
+		box, err := parseBoundingBox(raw)
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, middleware.ErrorBody(ctx, err.Error()))
+			return usecases.StatsCriteria{}, false
+		}
+		criteria.BoundingBox = box
+	}
+	return criteria, true
+}
+
+// queryStatuses lee el parámetro status con estados separados por coma
+func queryStatuses(ctx *gin.Context) []entities.CrimeStatus {
+	var statuses []entities.CrimeStatus
+	for _, status := range queryList(ctx, "status") {
+		statuses = append(statuses, entities.CrimeStatus(status))
+	}
+	return statuses
+}
+
+// queryPeriod lee los parámetros from y to en formato RFC 3339; los ausentes quedan en cero.
+// Si alguno es inválido responde 400 y retorna false
+func queryPeriod(ctx *gin.Context) (time.Time, time.Time, bool) {
+	var period [2]time.Time
+	for i, name := range []string{"from", "to"} {
+		raw := ctx.Query(name)
+		if raw == "" {
+			continue
+		}
+		at, err := time.Parse(time.RFC3339, raw)
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, middleware.ErrorBody(ctx, name+" debe tener formato RFC 3339"))
+			return time.Time{}, time.Time{}, false
+		}
+		period[i] = at
+	}
+	return period[0], period[1], true
+}
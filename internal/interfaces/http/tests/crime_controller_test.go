@@ -70,8 +70,17 @@ func setupTestDB(t *testing.T) *database.Config {
 
 	// Eliminar tablas si existen
 	_, err = db.Exec(`
+		DROP TABLE IF EXISTS test.notifications CASCADE;
+		DROP TABLE IF EXISTS test.watch_areas CASCADE;
+		DROP TABLE IF EXISTS test.webhook_deliveries CASCADE;
+		DROP TABLE IF EXISTS test.webhook_subscriptions CASCADE;
+		DROP TABLE IF EXISTS test.outbox_events CASCADE;
+		DROP TABLE IF EXISTS test.crime_revisions CASCADE;
+		DROP TABLE IF EXISTS test.moderation_claims CASCADE;
+		DROP TABLE IF EXISTS test.crime_status_history CASCADE;
 		DROP TABLE IF EXISTS test.crimes CASCADE;
 		DROP TABLE IF EXISTS test.locations CASCADE;
+		DROP TABLE IF EXISTS test.zones CASCADE;
 	`)
 	require.NoError(t, err)
 
@@ -0,0 +1,44 @@
+package tests
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"go-crime_map_backend/internal/domain/entities"
+	"go-crime_map_backend/internal/domain/repositories"
+	crimeController "go-crime_map_backend/internal/interfaces/http"
+	"go-crime_map_backend/internal/usecases"
+)
+
+// failingCrimeRepository falla la prueba si se consulta el repositorio
+type failingCrimeRepository struct {
+	repositories.CrimeRepository
+	t *testing.T
+}
+
+func (r failingCrimeRepository) GetByID(ctx context.Context, id string) (*entities.Crime, error) {
+	r.t.Errorf("no se esperaba consultar el delito %q", id)
+	return nil, nil
+}
+
+func TestGetCrimeWithMalformedID(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	repo := failingCrimeRepository{t: t}
+	controller := crimeController.NewCrimeQueryController(nil, usecases.NewGetCrimeUseCase(repo), nil)
+
+	router := gin.New()
+	router.GET("/api/v1/crimes/:id", controller.Get)
+
+	for _, id := range []string{"no-es-un-uuid", "1", "1'%20OR%20'1'='1"} {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/crimes/"+id, nil))
+
+		assert.Equal(t, http.StatusNotFound, w.Code, id)
+		assert.Contains(t, w.Body.String(), usecases.ErrCrimeNotFound.Error(), id)
+	}
+}
@@ -0,0 +1,90 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"go-crime_map_backend/internal/interfaces/http/middleware"
+	"go-crime_map_backend/internal/usecases"
+
+	"github.com/gin-gonic/gin"
+)
+
+// mvtContentType es el tipo de contenido de los tiles en formato Mapbox Vector Tile
+const mvtContentType = "application/vnd.mapbox-vector-tile"
+
+// TileController maneja las peticiones HTTP de los tiles vectoriales del mapa
+type TileController struct {
+	crimeTileUseCase *usecases.GetCrimeTileUseCase
+}
+
+// NewTileController crea una nueva instancia del controlador
+func NewTileController(crimeTileUseCase *usecases.GetCrimeTileUseCase) *TileController {
+	return &TileController{crimeTileUseCase: crimeTileUseCase}
+}
+
+// Crimes maneja la petición GET del tile de delitos z/x/y.mvt, filtrado por type, status y el
+// período from/to. Si el cliente envía en If-None-Match el ETag vigente responde 304 sin cuerpo
+func (c *TileController) Crimes(ctx *gin.Context) {
+	var coords [3]int
+	for i, name := range []string{"z", "x", "y"} {
+		raw := ctx.Param(name)
+		if name == "y" {
+			var found bool
+			if raw, found = strings.CutSuffix(raw, ".mvt"); !found {
+				ctx.JSON(http.StatusNotFound, middleware.ErrorBody(ctx, "el tile debe tener la extensión .mvt"))
+				return
+			}
+		}
+		value, err := strconv.Atoi(raw)
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, middleware.ErrorBody(ctx, name+" debe ser un número entero"))
+			return
+		}
+		coords[i] = value
+	}
+	from, to, ok := queryPeriod(ctx)
+	if !ok {
+		return
+	}
+
+	tile, err := c.crimeTileUseCase.Execute(ctx.Request.Context(), usecases.CrimeTileInput{
+		Z:        coords[0],
+		X:        coords[1],
+		Y:        coords[2],
+		From:     from,
+		To:       to,
+		Types:    queryList(ctx, "type"),
+		Statuses: queryStatuses(ctx),
+		Actor:    middleware.ActorFromContext(ctx),
+	})
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	// El contenido depende del actor, por lo que solo lo guarda el navegador y lo revalida
+	ctx.Header("ETag", tile.ETag)
+	ctx.Header("Cache-Control", "private, no-cache")
+	ctx.Writer.Header().Add("Vary", "X-API-Key")
+	if tile.Truncated {
+		ctx.Header("X-Tile-Truncated", "true")
+	}
+	if etagMatches(ctx.GetHeader("If-None-Match"), tile.ETag) {
+		ctx.Status(http.StatusNotModified)
+		return
+	}
+	ctx.Data(http.StatusOK, mvtContentType, tile.Data)
+}
+
+// etagMatches indica si la cabecera If-None-Match incluye el ETag o es *
+func etagMatches(header, etag string) bool {
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+		if candidate == etag || candidate == "*" {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,158 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+
+	"go-crime_map_backend/internal/domain/entities"
+	"go-crime_map_backend/internal/interfaces/http/middleware"
+	"go-crime_map_backend/internal/usecases"
+
+	"github.com/gin-gonic/gin"
+)
+
+// WebhookController maneja las peticiones HTTP de las suscripciones de webhooks
+type WebhookController struct {
+	createUseCase         *usecases.CreateWebhookSubscriptionUseCase
+	listUseCase           *usecases.ListWebhookSubscriptionsUseCase
+	deleteUseCase         *usecases.DeleteWebhookSubscriptionUseCase
+	listDeliveriesUseCase *usecases.ListWebhookDeliveriesUseCase
+	replayUseCase         *usecases.ReplayWebhookDeliveryUseCase
+}
+
+// NewWebhookController crea una nueva instancia del controlador
+func NewWebhookController(
+	createUseCase *usecases.CreateWebhookSubscriptionUseCase,
+	listUseCase *usecases.ListWebhookSubscriptionsUseCase,
+	deleteUseCase *usecases.DeleteWebhookSubscriptionUseCase,
+	listDeliveriesUseCase *usecases.ListWebhookDeliveriesUseCase,
+	replayUseCase *usecases.ReplayWebhookDeliveryUseCase,
+) *WebhookController {
+	return &WebhookController{
+		createUseCase:         createUseCase,
+		listUseCase:           listUseCase,
+		deleteUseCase:         deleteUseCase,
+		listDeliveriesUseCase: listDeliveriesUseCase,
+		replayUseCase:         replayUseCase,
+	}
+}
+
+// CreateWebhookRequest representa la petición para suscribir un webhook
+type CreateWebhookRequest struct {
+	URL         string                `json:"url" binding:"required"`
+	EventTypes  []string              `json:"event_types" binding:"required,min=1"`
+	BoundingBox *entities.BoundingBox `json:"bounding_box"`
+	CrimeTypes  []string              `json:"crime_types"`
+	Secret      string                `json:"secret"` // Opcional, se genera uno si se omite
+}
+
+// CreateWebhookResponse representa la suscripción creada junto con su secreto, que solo se muestra una vez
+type CreateWebhookResponse struct {
+	Subscription *entities.WebhookSubscription `json:"subscription"`
+	Secret       string                        `json:"secret"`
+}
+
+// WebhookListResponse representa la respuesta del listado de suscripciones
+type WebhookListResponse struct {
+	Subscriptions []*entities.WebhookSubscription `json:"subscriptions"`
+}
+
+// WebhookDeliveriesResponse representa la respuesta del registro de entregas
+type WebhookDeliveriesResponse struct {
+	Deliveries []*entities.WebhookDelivery `json:"deliveries"`
+	Count      int                         `json:"count"`
+}
+
+// Create maneja la petición POST para suscribir un webhook
+func (c *WebhookController) Create(ctx *gin.Context) {
+	var req CreateWebhookRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, middleware.ErrorBody(ctx, err.Error()))
+		return
+	}
+
+	subscription, err := c.createUseCase.Execute(ctx.Request.Context(), usecases.CreateWebhookSubscriptionInput{
+		URL:         req.URL,
+		EventTypes:  req.EventTypes,
+		BoundingBox: req.BoundingBox,
+		CrimeTypes:  req.CrimeTypes,
+		Secret:      req.Secret,
+		Actor:       middleware.ActorFromContext(ctx),
+	})
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, CreateWebhookResponse{Subscription: subscription, Secret: subscription.Secret})
+}
+
+// List maneja la petición GET para listar las suscripciones
+func (c *WebhookController) List(ctx *gin.Context) {
+	subscriptions, err := c.listUseCase.Execute(ctx.Request.Context(), middleware.ActorFromContext(ctx))
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, WebhookListResponse{Subscriptions: subscriptions})
+}
+
+// Delete maneja la petición DELETE para dar de baja una suscripción
+func (c *WebhookController) Delete(ctx *gin.Context) {
+	id, ok := idParam(ctx, "id", usecases.ErrWebhookNotFound)
+	if !ok {
+		return
+	}
+	if err := c.deleteUseCase.Execute(ctx.Request.Context(), id, middleware.ActorFromContext(ctx)); err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// Deliveries maneja la petición GET para consultar el registro de entregas de una suscripción
+func (c *WebhookController) Deliveries(ctx *gin.Context) {
+	id, ok := idParam(ctx, "id", usecases.ErrWebhookNotFound)
+	if !ok {
+		return
+	}
+	limit, err := strconv.Atoi(ctx.DefaultQuery("limit", "0"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, middleware.ErrorBody(ctx, "el límite debe ser un número entero"))
+		return
+	}
+
+	deliveries, err := c.listDeliveriesUseCase.Execute(ctx.Request.Context(), usecases.ListWebhookDeliveriesInput{
+		SubscriptionID: id,
+		Status:         entities.WebhookDeliveryStatus(ctx.Query("status")),
+		Limit:          limit,
+		Actor:          middleware.ActorFromContext(ctx),
+	})
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, WebhookDeliveriesResponse{Deliveries: deliveries, Count: len(deliveries)})
+}
+
+// Replay maneja la petición POST para reenviar una entrega
+func (c *WebhookController) Replay(ctx *gin.Context) {
+	id, ok := idParam(ctx, "id", usecases.ErrWebhookNotFound)
+	if !ok {
+		return
+	}
+	deliveryID, ok := idParam(ctx, "delivery_id", usecases.ErrWebhookDeliveryNotFound)
+	if !ok {
+		return
+	}
+	delivery, err := c.replayUseCase.Execute(ctx.Request.Context(), id, deliveryID, middleware.ActorFromContext(ctx))
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusAccepted, delivery)
+}
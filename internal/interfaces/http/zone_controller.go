@@ -0,0 +1,205 @@
+package http
+
+import (
+	"errors"
+	"io"
+	"net/http"
+
+	"go-crime_map_backend/internal/domain/entities"
+	"go-crime_map_backend/internal/interfaces/http/middleware"
+	"go-crime_map_backend/internal/usecases"
+
+	"github.com/gin-gonic/gin"
+)
+
+// maxZoneImportBytes limita el tamaño del archivo GeoJSON de una importación de zonas
+const maxZoneImportBytes = 20 << 20
+
+// ZoneController maneja las peticiones HTTP de las zonas administrativas
+type ZoneController struct {
+	createUseCase *usecases.CreateZoneUseCase
+	listUseCase   *usecases.ListZonesUseCase
+	getUseCase    *usecases.GetZoneUseCase
+	updateUseCase *usecases.UpdateZoneUseCase
+	deleteUseCase *usecases.DeleteZoneUseCase
+	importUseCase *usecases.ImportZonesUseCase
+}
+
+// NewZoneController crea una nueva instancia del controlador
+func NewZoneController(
+	createUseCase *usecases.CreateZoneUseCase,
+	listUseCase *usecases.ListZonesUseCase,
+	getUseCase *usecases.GetZoneUseCase,
+	updateUseCase *usecases.UpdateZoneUseCase,
+	deleteUseCase *usecases.DeleteZoneUseCase,
+	importUseCase *usecases.ImportZonesUseCase,
+) *ZoneController {
+	return &ZoneController{
+		createUseCase: createUseCase,
+		listUseCase:   listUseCase,
+		getUseCase:    getUseCase,
+		updateUseCase: updateUseCase,
+		deleteUseCase: deleteUseCase,
+		importUseCase: importUseCase,
+	}
+}
+
+// ZoneRequest representa la petición para crear o reemplazar una zona
+type ZoneRequest struct {
+	Name       string                `json:"name" binding:"required"`
+	Kind       string                `json:"kind" binding:"required"`
+	Population int                   `json:"population"`                  // Cantidad de habitantes, opcional
+	Geometry   entities.MultiPolygon `json:"geometry" binding:"required"` // GeoJSON Polygon o MultiPolygon
+}
+
+// ZoneListResponse representa la respuesta del listado de zonas
+type ZoneListResponse struct {
+	Zones []*entities.Zone `json:"zones"`
+	Count int              `json:"count"`
+}
+
+// Create maneja la petición POST para crear una zona
+func (c *ZoneController) Create(ctx *gin.Context) {
+	var req ZoneRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, middleware.ErrorBody(ctx, err.Error()))
+		return
+	}
+
+	zone, err := c.createUseCase.Execute(ctx.Request.Context(), usecases.ZoneInput{
+		Name:       req.Name,
+		Kind:       req.Kind,
+		Population: req.Population,
+		Geometry:   req.Geometry,
+	}, middleware.ActorFromContext(ctx))
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, zone)
+}
+
+// List maneja la petición GET para listar las zonas, opcionalmente de un tipo
+func (c *ZoneController) List(ctx *gin.Context) {
+	zones, err := c.listUseCase.Execute(ctx.Request.Context(), ctx.Query("kind"))
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, ZoneListResponse{Zones: zones, Count: len(zones)})
+}
+
+// Get maneja la petición GET para obtener una zona por su ID
+func (c *ZoneController) Get(ctx *gin.Context) {
+	id, ok := idParam(ctx, "id", usecases.ErrZoneNotFound)
+	if !ok {
+		return
+	}
+	zone, err := c.getUseCase.Execute(ctx.Request.Context(), id)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, zone)
+}
+
+// Update maneja la petición PUT para reemplazar una zona
+func (c *ZoneController) Update(ctx *gin.Context) {
+	id, ok := idParam(ctx, "id", usecases.ErrZoneNotFound)
+	if !ok {
+		return
+	}
+	var req ZoneRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, middleware.ErrorBody(ctx, err.Error()))
+		return
+	}
+
+	zone, err := c.updateUseCase.Execute(ctx.Request.Context(), id, usecases.ZoneInput{
+		Name:       req.Name,
+		Kind:       req.Kind,
+		Population: req.Population,
+		Geometry:   req.Geometry,
+	}, middleware.ActorFromContext(ctx))
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, zone)
+}
+
+// Delete maneja la petición DELETE para eliminar una zona
+func (c *ZoneController) Delete(ctx *gin.Context) {
+	id, ok := idParam(ctx, "id", usecases.ErrZoneNotFound)
+	if !ok {
+		return
+	}
+	if err := c.deleteUseCase.Execute(ctx.Request.Context(), id, middleware.ActorFromContext(ctx)); err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// Import maneja la petición POST para importar zonas desde un GeoJSON FeatureCollection.
+// El tipo de las zonas se indica con kind y las propiedades con el nombre y la población
+// con name_property y population_property
+func (c *ZoneController) Import(ctx *gin.Context) {
+	data, err := io.ReadAll(http.MaxBytesReader(ctx.Writer, ctx.Request.Body, maxZoneImportBytes))
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			ctx.JSON(http.StatusRequestEntityTooLarge, middleware.ErrorBody(ctx, "el archivo de zonas no puede exceder los 20 MB"))
+			return
+		}
+		ctx.JSON(http.StatusBadRequest, middleware.ErrorBody(ctx, "no se pudo leer el archivo de zonas"))
+		return
+	}
+
+	result, err := c.importUseCase.Execute(ctx.Request.Context(), usecases.ImportZonesInput{
+		Data:               data,
+		Kind:               ctx.Query("kind"),
+		NameProperty:       ctx.Query("name_property"),
+		PopulationProperty: ctx.Query("population_property"),
+	}, middleware.ActorFromContext(ctx))
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, result)
+}
+
+// ImportShapefile maneja la petición POST para importar zonas desde un shapefile de polígonos
+// en un zip. El tipo de las zonas se indica con kind, las columnas con el nombre y la
+// población con name_field y population_field y el sistema de coordenadas con srid si el zip
+// no tiene .prj
+func (c *ZoneController) ImportShapefile(ctx *gin.Context) {
+	data, ok := readShapefileBody(ctx)
+	if !ok {
+		return
+	}
+	srid, ok := querySRID(ctx)
+	if !ok {
+		return
+	}
+
+	result, err := c.importUseCase.ExecuteShapefile(ctx.Request.Context(), usecases.ImportZonesShapefileInput{
+		Data:            data,
+		Kind:            ctx.Query("kind"),
+		NameField:       ctx.Query("name_field"),
+		PopulationField: ctx.Query("population_field"),
+		SRID:            srid,
+	}, middleware.ActorFromContext(ctx))
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, result)
+}
@@ -0,0 +1,16 @@
+package usecases
+
+import "time"
+
+// backoffDelay calcula la espera antes del siguiente intento: base tras el primer fallo,
+// duplicándose en cada fallo siguiente sin superar max
+func backoffDelay(base, max time.Duration, attempts int) time.Duration {
+	delay := base
+	for i := 1; i < attempts && delay < max; i++ {
+		delay *= 2
+	}
+	if delay > max {
+		delay = max
+	}
+	return delay
+}
@@ -0,0 +1,125 @@
+package usecases
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"go-crime_map_backend/internal/domain/events"
+	"go-crime_map_backend/internal/domain/repositories"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// OutboxBroadcasterOptions configura la difusión de los eventos del outbox a una instancia
+type OutboxBroadcasterOptions struct {
+	BatchSize int           // Eventos leídos por consulta
+	Overlap   time.Duration // Margen hacia atrás con el que se releen los eventos en cada lectura
+}
+
+// DefaultOutboxBroadcasterOptions retorna la configuración por defecto de la difusión
+func DefaultOutboxBroadcasterOptions() OutboxBroadcasterOptions {
+	return OutboxBroadcasterOptions{
+		BatchSize: 500,
+		Overlap:   30 * time.Second,
+	}
+}
+
+// OutboxBroadcaster entrega todos los eventos del outbox a los handlers que mantienen estado
+// en memoria de la instancia, como el feed en tiempo real y la caché de tiles. El despachador
+// entrega cada evento a una sola instancia; el difusor, en cambio, lee el outbox con un cursor
+// propio de cada instancia, por lo que todas reciben todos los eventos. La fecha de un evento
+// se asigna antes de confirmar su transacción, así que cada lectura retrocede Overlap para
+// recuperar los que confirmaron tarde y descarta por ID los ya entregados. Las entregas no se
+// reintentan: si un handler falla solo pierde ese evento.
+type OutboxBroadcaster struct {
+	eventHandlers
+	outboxRepo repositories.OutboxRepository
+	opts       OutboxBroadcasterOptions
+
+	mu     sync.Mutex
+	cursor time.Time            // Fecha del evento más reciente entregado
+	seen   map[string]time.Time // Eventos entregados dentro del margen, con su fecha
+}
+
+// NewOutboxBroadcaster crea un difusor sin handlers que entrega los eventos ocurridos desde
+// ahora
+func NewOutboxBroadcaster(repo repositories.OutboxRepository, opts OutboxBroadcasterOptions) *OutboxBroadcaster {
+	return NewOutboxBroadcasterSince(repo, opts, time.Now())
+}
+
+// NewOutboxBroadcasterSince crea un difusor que entrega los eventos ocurridos desde since,
+// útil en pruebas
+func NewOutboxBroadcasterSince(repo repositories.OutboxRepository, opts OutboxBroadcasterOptions, since time.Time) *OutboxBroadcaster {
+	defaults := DefaultOutboxBroadcasterOptions()
+	if opts.BatchSize <= 0 {
+		opts.BatchSize = defaults.BatchSize
+	}
+	if opts.Overlap < 0 {
+		opts.Overlap = 0
+	}
+	return &OutboxBroadcaster{
+		eventHandlers: eventHandlers{handlers: make(map[events.Type][]events.Handler)},
+		outboxRepo:    repo,
+		opts:          opts,
+		cursor:        since,
+		seen:          make(map[string]time.Time),
+	}
+}
+
+// Poll entrega los eventos nuevos del outbox y retorna cuántos entregó
+func (b *OutboxBroadcaster) Poll(ctx context.Context) (_ int, err error) {
+	ctx, span := tracer.Start(ctx, "OutboxBroadcaster.Poll")
+	defer func() { endSpan(span, err) }()
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	after := repositories.OutboxCursor{OccurredAt: b.cursor.Add(-b.opts.Overlap)}
+	delivered := 0
+	for {
+		batch, err := b.outboxRepo.ListAfter(ctx, after, b.opts.BatchSize)
+		if err != nil {
+			return delivered, err
+		}
+		for _, event := range batch {
+			after = repositories.OutboxCursor{OccurredAt: event.OccurredAt, EventID: event.ID}
+			if _, seen := b.seen[event.ID]; seen {
+				continue
+			}
+			b.seen[event.ID] = event.OccurredAt
+			if event.OccurredAt.After(b.cursor) {
+				b.cursor = event.OccurredAt
+			}
+			b.deliver(ctx, event)
+			delivered++
+		}
+		if len(batch) < b.opts.BatchSize {
+			break
+		}
+	}
+
+	// Los eventos anteriores al margen no se vuelven a leer
+	horizon := b.cursor.Add(-b.opts.Overlap)
+	for id, occurredAt := range b.seen {
+		if occurredAt.Before(horizon) {
+			delete(b.seen, id)
+		}
+	}
+	span.SetAttributes(attribute.Int("outbox.broadcast", delivered))
+	return delivered, nil
+}
+
+// deliver entrega el evento a cada handler suscrito; los errores solo se registran
+func (b *OutboxBroadcaster) deliver(ctx context.Context, event events.Event) {
+	for _, handler := range b.subscribers(event.Type) {
+		if err := safeHandle(ctx, handler, event); err != nil {
+			slog.WarnContext(ctx, "error al difundir el evento de dominio",
+				slog.String("event_id", event.ID),
+				slog.String("event_type", string(event.Type)),
+				slog.Any("error", err),
+			)
+		}
+	}
+}
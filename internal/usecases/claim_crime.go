@@ -0,0 +1,72 @@
+package usecases
+
+import (
+	"context"
+	"time"
+
+	"go-crime_map_backend/internal/domain/entities"
+	"go-crime_map_backend/internal/domain/repositories"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// ClaimCrimeUseCase maneja la reserva de un delito pendiente por parte de un moderador
+type ClaimCrimeUseCase struct {
+	crimeRepo      repositories.CrimeRepository
+	moderationRepo repositories.ModerationRepository
+	ttl            time.Duration
+	now            func() time.Time
+}
+
+// NewClaimCrimeUseCase crea una nueva instancia del caso de uso; ttl es la duración de cada reserva
+func NewClaimCrimeUseCase(crimeRepo repositories.CrimeRepository, moderationRepo repositories.ModerationRepository, ttl time.Duration) *ClaimCrimeUseCase {
+	return NewClaimCrimeUseCaseWithClock(crimeRepo, moderationRepo, ttl, time.Now)
+}
+
+// NewClaimCrimeUseCaseWithClock crea el caso de uso con un reloj propio, útil en pruebas
+func NewClaimCrimeUseCaseWithClock(crimeRepo repositories.CrimeRepository, moderationRepo repositories.ModerationRepository, ttl time.Duration, now func() time.Time) *ClaimCrimeUseCase {
+	return &ClaimCrimeUseCase{
+		crimeRepo:      crimeRepo,
+		moderationRepo: moderationRepo,
+		ttl:            ttl,
+		now:            now,
+	}
+}
+
+// Execute reserva el delito para el moderador durante el ttl configurado.
+// Si el moderador ya lo tenía reservado, la reserva se renueva.
+func (uc *ClaimCrimeUseCase) Execute(ctx context.Context, crimeID string, actor entities.Actor) (_ *entities.ModerationClaim, err error) {
+	ctx, span := tracer.Start(ctx, "ClaimCrimeUseCase.Execute", trace.WithAttributes(
+		attribute.String("crime.id", crimeID),
+	))
+	defer func() { endSpan(span, err) }()
+
+	if !actor.IsStaff() {
+		return nil, ErrForbidden
+	}
+
+	crime, err := uc.crimeRepo.GetByID(ctx, crimeID)
+	if err != nil {
+		return nil, err
+	}
+	if crime == nil {
+		return nil, ErrCrimeNotFound
+	}
+	if crime.Status != entities.CrimeStatusReported {
+		return nil, ErrNotPending
+	}
+
+	now := uc.now()
+	claim := &entities.ModerationClaim{
+		CrimeID:     crime.ID,
+		ModeratorID: actor.ID,
+		ClaimedAt:   now,
+		ExpiresAt:   now.Add(uc.ttl),
+	}
+	if err := uc.moderationRepo.Claim(ctx, claim); err != nil {
+		return nil, err
+	}
+
+	return claim, nil
+}
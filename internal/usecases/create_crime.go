@@ -3,12 +3,17 @@ package usecases
 import (
 	"context"
 	"errors"
+	"log/slog"
+	"sort"
 	"time"
 
 	"go-crime_map_backend/internal/domain/entities"
+	"go-crime_map_backend/internal/domain/events"
 	"go-crime_map_backend/internal/domain/repositories"
 
 	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
 var (
@@ -30,6 +35,15 @@ var (
 	// ErrInvalidLongitude se retorna cuando la longitud es inválida
 	ErrInvalidLongitude = errors.New("longitud inválida")
 
+	// ErrInvalidSeverity se retorna cuando la gravedad no es low, medium, high ni critical
+	ErrInvalidSeverity = errors.New("la gravedad debe ser low, medium, high o critical")
+
+	// ErrInvalidWeapon se retorna cuando el tipo de arma no existe
+	ErrInvalidWeapon = errors.New("el arma debe ser none, blunt, knife, firearm u other")
+
+	// ErrInvalidVictimCount se retorna cuando la cantidad de víctimas está fuera de rango
+	ErrInvalidVictimCount = errors.New("la cantidad de víctimas debe estar entre 0 y 1000")
+
 	// ErrDuplicateCrime se retorna cuando se intenta crear un delito duplicado
 	ErrDuplicateCrime = errors.New("ya existe un delito con los mismos datos")
 
@@ -47,71 +61,210 @@ var (
 		"ESTAFA":       true,
 	}
 
+	// validationErrorCodes asocia cada error de validación con un código estable
+	validationErrorCodes = map[error]string{
+		ErrInvalidType:        "invalid_type",
+		ErrEmptyDescription:   "empty_description",
+		ErrDescriptionTooLong: "description_too_long",
+		ErrFutureDate:         "future_date",
+		ErrInvalidLatitude:    "invalid_latitude",
+		ErrInvalidLongitude:   "invalid_longitude",
+		ErrInvalidSeverity:    "invalid_severity",
+		ErrInvalidWeapon:      "invalid_weapon",
+		ErrInvalidVictimCount: "invalid_victim_count",
+		ErrMissingCoordinates: "missing_coordinates",
+		ErrMissingAddress:     "missing_address",
+		ErrAddressNotFound:    "address_not_found",
+	}
+
 	// maxDescriptionLength define la longitud máxima permitida para la descripción
 	maxDescriptionLength = 500
 )
 
-// CreateCrimeInput representa los datos necesarios para crear un delito
+// CreateCrimeInput representa los datos necesarios para crear un delito. La clasificación es
+// opcional: sin gravedad ni marca de violencia se derivan del tipo y del arma
 type CreateCrimeInput struct {
-	Type        string    `json:"type"`
-	Description string    `json:"description"`
-	Location    Location  `json:"location"`
-	Date        time.Time `json:"date"`
+	Type        string            `json:"type"`
+	Description string            `json:"description"`
+	Location    Location          `json:"location"`
+	Date        time.Time         `json:"date"`
+	Severity    entities.Severity `json:"severity"`
+	Weapon      entities.Weapon   `json:"weapon"`
+	Violent     *bool             `json:"violent"`
+	VictimCount int               `json:"victim_count"`
 }
 
-// Location representa la ubicación del delito
+// Location representa la ubicación del delito. Con un geocodificador basta con las
+// coordenadas o con la dirección
 type Location struct {
 	Latitude  float64 `json:"latitude"`
 	Longitude float64 `json:"longitude"`
 	Address   string  `json:"address"`
 }
 
+// CreateCrimeExecutor define la ejecución del caso de uso de creación de delitos,
+// permite decorar el caso de uso sin modificarlo
+type CreateCrimeExecutor interface {
+	Execute(ctx context.Context, input CreateCrimeInput) (*entities.Crime, error)
+}
+
 // CreateCrimeUseCase maneja la lógica de negocio para crear un nuevo delito
 type CreateCrimeUseCase struct {
 	crimeRepo repositories.CrimeRepository
+	zones     *ZoneLocator
+	locations *LocationResolver
 }
 
 // NewCreateCrimeUseCase crea una nueva instancia del caso de uso
 func NewCreateCrimeUseCase(repo repositories.CrimeRepository) *CreateCrimeUseCase {
+	return NewCreateCrimeUseCaseWithZones(repo, nil)
+}
+
+// NewCreateCrimeUseCaseWithZones crea el caso de uso asignando a cada delito la zona que
+// contiene su ubicación; sin localizador los delitos quedan sin zona
+func NewCreateCrimeUseCaseWithZones(repo repositories.CrimeRepository, zones *ZoneLocator) *CreateCrimeUseCase {
+	return NewCreateCrimeUseCaseWithGeocoding(repo, zones, nil)
+}
+
+// NewCreateCrimeUseCaseWithGeocoding crea el caso de uso completando la ubicación de cada
+// delito con el resolutor; sin resolutor los delitos requieren coordenadas
+func NewCreateCrimeUseCaseWithGeocoding(repo repositories.CrimeRepository, zones *ZoneLocator, locations *LocationResolver) *CreateCrimeUseCase {
 	return &CreateCrimeUseCase{
 		crimeRepo: repo,
+		zones:     zones,
+		locations: locations,
 	}
 }
 
 // Execute ejecuta el caso de uso para crear un nuevo delito
-func (uc *CreateCrimeUseCase) Execute(ctx context.Context, input CreateCrimeInput) (*entities.Crime, error) {
+func (uc *CreateCrimeUseCase) Execute(ctx context.Context, input CreateCrimeInput) (_ *entities.Crime, err error) {
+	ctx, span := tracer.Start(ctx, "CreateCrimeUseCase.Execute",
+		trace.WithAttributes(attribute.String("crime.type", input.Type)))
+	defer func() { endSpan(span, err) }()
+
+	// Validar los datos de entrada
+	_, validateSpan := tracer.Start(ctx, "CreateCrimeUseCase.validate")
+	err = validateCreateCrimeInput(input)
+	endSpan(validateSpan, err)
+	if err != nil {
+		return nil, err
+	}
+
+	// Completar las coordenadas o la dirección que falten
+	var addressMismatch bool
+	if input.Location, addressMismatch, err = resolveLocation(ctx, uc.locations, input.Location); err != nil {
+		return nil, err
+	}
+
+	// Verificar si existe un delito duplicado
+	dupCtx, duplicateSpan := tracer.Start(ctx, "CreateCrimeUseCase.checkDuplicate")
+	err = uc.checkDuplicate(dupCtx, input)
+	endSpan(duplicateSpan, err)
+	if err != nil {
+		return nil, err
+	}
+
+	// Crear la entidad Crime
+	severity, weapon, violent := input.classification()
+	crime := &entities.Crime{
+		ID:          generateID(),
+		Type:        input.Type,
+		Description: input.Description,
+		Location: entities.Location{
+			Latitude:  input.Location.Latitude,
+			Longitude: input.Location.Longitude,
+			Address:   input.Location.Address,
+		},
+		Date:            input.Date,
+		Status:          entities.CrimeStatusReported,
+		Severity:        severity,
+		Weapon:          weapon,
+		Violent:         violent,
+		VictimCount:     input.VictimCount,
+		AddressMismatch: addressMismatch,
+		CreatedAt:       time.Now(),
+		UpdatedAt:       time.Now(),
+	}
+	if uc.zones != nil {
+		if crime.ZoneID, err = uc.zones.Locate(ctx, crime.Location); err != nil {
+			return nil, err
+		}
+	}
+
+	// Guardar en el repositorio junto con el evento CrimeReported
+	persistCtx, persistSpan := tracer.Start(ctx, "CreateCrimeUseCase.persist")
+	persistCtx, err = raise(persistCtx, events.CrimeReported, crime.ID, events.CrimeReportedPayload{Crime: crime})
+	if err == nil {
+		err = uc.crimeRepo.Create(persistCtx, crime)
+	}
+	endSpan(persistSpan, err)
+	if err != nil {
+		return nil, err
+	}
+
+	span.SetAttributes(attribute.String("crime.id", crime.ID))
+	slog.InfoContext(ctx, "delito reportado", slog.String("crime_id", crime.ID), slog.String("type", crime.Type))
+
+	return crime, nil
+}
+
+// validateCreateCrimeInput valida los datos de entrada del delito
+func validateCreateCrimeInput(input CreateCrimeInput) error {
 	// Validar que la fecha no sea futura
 	if input.Date.After(time.Now()) {
-		return nil, ErrFutureDate
+		return ErrFutureDate
 	}
 
 	// Validar que el tipo de delito sea válido
 	if !validCrimeTypes[input.Type] {
-		return nil, ErrInvalidType
+		return ErrInvalidType
 	}
 
 	// Validar que la descripción no esté vacía
 	if input.Description == "" {
-		return nil, ErrEmptyDescription
+		return ErrEmptyDescription
 	}
 
 	// Validar que la descripción no exceda el límite de caracteres
 	if len(input.Description) > maxDescriptionLength {
-		return nil, ErrDescriptionTooLong
+		return ErrDescriptionTooLong
 	}
 
 	// Validar que la ubicación sea válida
 	if input.Location.Latitude <= -90 || input.Location.Latitude >= 90 {
-		return nil, ErrInvalidLatitude
+		return ErrInvalidLatitude
 	}
 	if input.Location.Longitude <= -180 || input.Location.Longitude >= 180 {
-		return nil, ErrInvalidLongitude
+		return ErrInvalidLongitude
 	}
 
-	// Verificar si existe un delito duplicado
-	crimes, err := uc.crimeRepo.GetAll(ctx)
+	// Validar la clasificación, que es opcional
+	if input.Severity != "" && !input.Severity.IsValid() {
+		return ErrInvalidSeverity
+	}
+	if input.Weapon != "" && !input.Weapon.IsValid() {
+		return ErrInvalidWeapon
+	}
+	if input.VictimCount < 0 || input.VictimCount > maxVictimCount {
+		return ErrInvalidVictimCount
+	}
+
+	return nil
+}
+
+// checkDuplicate verifica que no exista un delito con los mismos datos. Solo se comparan
+// los delitos cercanos en fecha y coordenadas, así la importación masiva no recorre la
+// tabla completa por cada delito
+func (uc *CreateCrimeUseCase) checkDuplicate(ctx context.Context, input CreateCrimeInput) error {
+	crimes, err := uc.crimeRepo.FindNear(ctx, repositories.NearbyQuery{
+		Date:      input.Date,
+		Window:    time.Minute,
+		Latitude:  input.Location.Latitude,
+		Longitude: input.Location.Longitude,
+		Tolerance: 0.000001,
+	})
 	if err != nil {
-		return nil, err
+		return err
 	}
 
 	for _, crime := range crimes {
@@ -144,31 +297,37 @@ func (uc *CreateCrimeUseCase) Execute(ctx context.Context, input CreateCrimeInpu
 		// Si llegamos aquí, todas las comparaciones pasaron
 		if crime.Type == input.Type &&
 			crime.Description == input.Description {
-			return nil, ErrDuplicateCrime
+			slog.WarnContext(ctx, "delito duplicado rechazado", slog.String("duplicate_of", crime.ID))
+			return ErrDuplicateCrime
 		}
 	}
 
-	// Crear la entidad Crime
-	crime := &entities.Crime{
-		ID:          generateID(),
-		Type:        input.Type,
-		Description: input.Description,
-		Location: entities.Location{
-			Latitude:  input.Location.Latitude,
-			Longitude: input.Location.Longitude,
-			Address:   input.Location.Address,
-		},
-		Date:      input.Date,
-		CreatedAt: time.Now(),
-		UpdatedAt: time.Now(),
-	}
+	return nil
+}
 
-	// Guardar en el repositorio
-	if err := uc.crimeRepo.Create(ctx, crime); err != nil {
-		return nil, err
+// ValidCrimeTypes retorna los tipos de delito válidos ordenados alfabéticamente
+func ValidCrimeTypes() []string {
+	types := make([]string, 0, len(validCrimeTypes))
+	for crimeType := range validCrimeTypes {
+		types = append(types, crimeType)
 	}
+	sort.Strings(types)
+	return types
+}
 
-	return crime, nil
+// MaxDescriptionLength retorna la longitud máxima permitida para la descripción
+func MaxDescriptionLength() int {
+	return maxDescriptionLength
+}
+
+// ValidationErrorCode retorna el código estable de un error de validación
+func ValidationErrorCode(err error) (string, bool) {
+	for target, code := range validationErrorCodes {
+		if errors.Is(err, target) {
+			return code, true
+		}
+	}
+	return "", false
 }
 
 // generateID genera un ID único para el delito usando UUID v4
@@ -0,0 +1,147 @@
+package usecases
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"log/slog"
+	"net/url"
+	"time"
+
+	"go-crime_map_backend/internal/domain/entities"
+	"go-crime_map_backend/internal/domain/events"
+	"go-crime_map_backend/internal/domain/repositories"
+)
+
+var (
+	// ErrInvalidWebhookURL se retorna cuando la URL del webhook no es una URL HTTP absoluta
+	ErrInvalidWebhookURL = errors.New("la URL del webhook debe ser una URL http o https absoluta")
+
+	// ErrInvalidEventType se retorna cuando el tipo de evento no existe
+	ErrInvalidEventType = errors.New("tipo de evento inválido")
+
+	// ErrInvalidBoundingBox se retorna cuando los límites de la zona son inválidos
+	ErrInvalidBoundingBox = errors.New("los límites de la zona son inválidos")
+
+	// ErrWebhookSecretTooShort se retorna cuando el secreto indicado es demasiado corto
+	ErrWebhookSecretTooShort = errors.New("el secreto del webhook debe tener al menos 16 caracteres")
+
+	// ErrWebhookNotFound se retorna cuando la suscripción no existe
+	ErrWebhookNotFound = errors.New("suscripción de webhook no encontrada")
+
+	// minWebhookSecretLength define la longitud mínima de un secreto indicado por el cliente
+	minWebhookSecretLength = 16
+)
+
+// CreateWebhookSubscriptionInput representa los datos necesarios para suscribir un webhook
+type CreateWebhookSubscriptionInput struct {
+	URL         string
+	EventTypes  []string
+	BoundingBox *entities.BoundingBox
+	CrimeTypes  []string
+	Secret      string // Vacío para generar uno aleatorio
+	Actor       entities.Actor
+}
+
+// CreateWebhookSubscriptionUseCase maneja la lógica de negocio para suscribir un webhook
+type CreateWebhookSubscriptionUseCase struct {
+	webhookRepo repositories.WebhookRepository
+}
+
+// NewCreateWebhookSubscriptionUseCase crea una nueva instancia del caso de uso
+func NewCreateWebhookSubscriptionUseCase(repo repositories.WebhookRepository) *CreateWebhookSubscriptionUseCase {
+	return &CreateWebhookSubscriptionUseCase{
+		webhookRepo: repo,
+	}
+}
+
+// Execute valida y guarda la suscripción. Solo disponible para administradores. La
+// suscripción retornada incluye el secreto, que no vuelve a exponerse después
+func (uc *CreateWebhookSubscriptionUseCase) Execute(ctx context.Context, input CreateWebhookSubscriptionInput) (*entities.WebhookSubscription, error) {
+	if input.Actor.Role != entities.RoleAdmin {
+		return nil, ErrForbidden
+	}
+	if err := validateWebhookSubscription(input); err != nil {
+		return nil, err
+	}
+
+	secret := input.Secret
+	if secret == "" {
+		generated, err := generateWebhookSecret()
+		if err != nil {
+			return nil, err
+		}
+		secret = generated
+	}
+
+	subscription := &entities.WebhookSubscription{
+		ID:          generateID(),
+		URL:         input.URL,
+		EventTypes:  input.EventTypes,
+		BoundingBox: input.BoundingBox,
+		CrimeTypes:  input.CrimeTypes,
+		Secret:      secret,
+		CreatedBy:   input.Actor.ID,
+		CreatedAt:   time.Now(),
+	}
+	if err := uc.webhookRepo.CreateSubscription(ctx, subscription); err != nil {
+		return nil, err
+	}
+
+	slog.InfoContext(ctx, "webhook suscrito",
+		slog.String("subscription_id", subscription.ID),
+		slog.String("actor_id", input.Actor.ID),
+	)
+	return subscription, nil
+}
+
+// validateWebhookSubscription valida la URL, los eventos y los filtros de la suscripción
+func validateWebhookSubscription(input CreateWebhookSubscriptionInput) error {
+	parsed, err := url.Parse(input.URL)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return ErrInvalidWebhookURL
+	}
+
+	if len(input.EventTypes) == 0 {
+		return ErrInvalidEventType
+	}
+	for _, eventType := range input.EventTypes {
+		if !isEventType(eventType) {
+			return ErrInvalidEventType
+		}
+	}
+
+	if input.BoundingBox != nil && !input.BoundingBox.IsValid() {
+		return ErrInvalidBoundingBox
+	}
+	for _, crimeType := range input.CrimeTypes {
+		if !validCrimeTypes[crimeType] {
+			return ErrInvalidType
+		}
+	}
+
+	if input.Secret != "" && len(input.Secret) < minWebhookSecretLength {
+		return ErrWebhookSecretTooShort
+	}
+	return nil
+}
+
+// isEventType indica si el tipo corresponde a un evento de dominio
+func isEventType(eventType string) bool {
+	for _, known := range events.Types {
+		if string(known) == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+// generateWebhookSecret genera un secreto aleatorio de 256 bits en hexadecimal
+func generateWebhookSecret() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
@@ -0,0 +1,62 @@
+package usecases
+
+import "go-crime_map_backend/internal/domain/entities"
+
+// maxVictimCount es la cantidad máxima de víctimas de un delito
+const maxVictimCount = 1000
+
+var (
+	// typeSeverities asigna la gravedad por defecto de cada tipo de delito
+	typeSeverities = map[string]entities.Severity{
+		"VIOLENCIA":    entities.SeverityHigh,
+		"AGRESION":     entities.SeverityHigh,
+		"ROBO":         entities.SeverityHigh,
+		"ALLANAMIENTO": entities.SeverityMedium,
+		"ACOSO":        entities.SeverityMedium,
+		"TRAFICO":      entities.SeverityMedium,
+		"HURTO":        entities.SeverityLow,
+		"VANDALISMO":   entities.SeverityLow,
+		"FRAUDE":       entities.SeverityLow,
+		"ESTAFA":       entities.SeverityLow,
+	}
+
+	// violentTypes contiene los tipos de delito que por defecto se consideran violentos
+	violentTypes = map[string]bool{
+		"VIOLENCIA": true,
+		"AGRESION":  true,
+	}
+)
+
+// DefaultSeverity retorna la gravedad de un delito que no la indica: la de su tipo, al menos
+// high si fue violento o con un arma y critical si fue con un arma de fuego
+func DefaultSeverity(crimeType string, weapon entities.Weapon, violent bool) entities.Severity {
+	severity, ok := typeSeverities[crimeType]
+	if !ok {
+		severity = entities.SeverityLow
+	}
+	if (violent || weapon.Armed()) && severity.Weight() < entities.SeverityHigh.Weight() {
+		severity = entities.SeverityHigh
+	}
+	if weapon == entities.WeaponFirearm {
+		severity = entities.SeverityCritical
+	}
+	return severity
+}
+
+// classification completa la clasificación del delito con los valores por defecto: sin arma
+// se asume none, sin marca de violencia se deriva del tipo y sin gravedad se usa DefaultSeverity
+func (input CreateCrimeInput) classification() (entities.Severity, entities.Weapon, bool) {
+	weapon := input.Weapon
+	if weapon == "" {
+		weapon = entities.WeaponNone
+	}
+	violent := violentTypes[input.Type]
+	if input.Violent != nil {
+		violent = *input.Violent
+	}
+	severity := input.Severity
+	if severity == "" {
+		severity = DefaultSeverity(input.Type, weapon, violent)
+	}
+	return severity, weapon, violent
+}
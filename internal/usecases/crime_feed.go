@@ -0,0 +1,307 @@
+package usecases
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go-crime_map_backend/internal/domain/entities"
+	"go-crime_map_backend/internal/domain/events"
+)
+
+const (
+	// FeedActionCreated indica que el delito apareció para el cliente
+	FeedActionCreated = "created"
+
+	// FeedActionUpdated indica que el delito visible para el cliente cambió
+	FeedActionUpdated = "updated"
+
+	// FeedActionDeleted indica que el delito dejó de estar visible para el cliente
+	FeedActionDeleted = "deleted"
+)
+
+// CrimeFeedEvent representa un cambio de un delito enviado a los clientes en tiempo real
+type CrimeFeedEvent struct {
+	ID         string          `json:"id"` // ID del evento de dominio, usado para reanudar
+	Action     string          `json:"action"`
+	CrimeID    string          `json:"crime_id"`
+	Crime      *entities.Crime `json:"crime,omitempty"` // Omitido en las bajas
+	OccurredAt time.Time       `json:"occurred_at"`
+}
+
+// CrimeFeedFilter define qué cambios recibe una conexión
+type CrimeFeedFilter struct {
+	BoundingBox *entities.BoundingBox // Zona de interés, nil para todas
+	Types       []string              // Tipos de delito, vacío para todos
+	Actor       entities.Actor        // Determina qué estados son visibles
+}
+
+// CrimeFeedOptions configura el feed en tiempo real
+type CrimeFeedOptions struct {
+	BufferSize  int // Eventos pendientes por conexión antes de desconectarla
+	HistorySize int // Eventos recientes conservados para reanudar con Last-Event-ID
+}
+
+// feedEntry es un cambio de delito recibido del outbox
+type feedEntry struct {
+	eventID    string
+	crime      *entities.Crime
+	existed    bool                 // El delito existía antes del evento
+	before     entities.CrimeStatus // Estado anterior al evento, si existía
+	location   entities.Location    // Ubicación anterior al evento, si existía
+	crimeType  string               // Tipo anterior al evento, si existía
+	exists     bool                 // El delito existe después del evento
+	occurredAt time.Time
+}
+
+// CrimeFeed distribuye los cambios de los delitos a las conexiones en tiempo real. Es un
+// handler de eventos de dominio: el OutboxBroadcaster de la instancia le entrega todos los
+// eventos del outbox, por lo que los casos de uso nunca esperan a los clientes. Cada conexión tiene un buffer propio; si
+// se llena porque el cliente no lee a tiempo, la conexión se cierra y el cliente puede
+// reanudar desde el último evento recibido.
+type CrimeFeed struct {
+	opts CrimeFeedOptions
+
+	mu          sync.Mutex
+	history     []feedEntry
+	subscribers map[*CrimeFeedSubscription]struct{}
+}
+
+// NewCrimeFeed crea un feed sin conexiones
+func NewCrimeFeed(opts CrimeFeedOptions) *CrimeFeed {
+	return &CrimeFeed{
+		opts:        opts,
+		subscribers: make(map[*CrimeFeedSubscription]struct{}),
+	}
+}
+
+// CrimeFeedSubscription representa una conexión suscrita al feed
+type CrimeFeedSubscription struct {
+	// Backlog contiene los eventos posteriores a Last-Event-ID que deben enviarse primero
+	Backlog []CrimeFeedEvent
+	// Reset indica que Last-Event-ID ya no está en el historial y el cliente debe recargar los delitos
+	Reset bool
+
+	feed   *CrimeFeed
+	filter CrimeFeedFilter
+	events chan CrimeFeedEvent
+	lagged bool
+}
+
+// Events retorna el canal de eventos; se cierra si la conexión se retrasa
+func (s *CrimeFeedSubscription) Events() <-chan CrimeFeedEvent {
+	return s.events
+}
+
+// Lagged indica si la suscripción se cerró porque el cliente no leía a tiempo
+func (s *CrimeFeedSubscription) Lagged() bool {
+	s.feed.mu.Lock()
+	defer s.feed.mu.Unlock()
+	return s.lagged
+}
+
+// Close cancela la suscripción
+func (s *CrimeFeedSubscription) Close() {
+	s.feed.mu.Lock()
+	defer s.feed.mu.Unlock()
+	if _, active := s.feed.subscribers[s]; active {
+		delete(s.feed.subscribers, s)
+		close(s.events)
+	}
+}
+
+// Subscribe registra una conexión. Si lastEventID no está vacío, Backlog contiene los
+// eventos posteriores que sigan en el historial
+func (f *CrimeFeed) Subscribe(filter CrimeFeedFilter, lastEventID string) (*CrimeFeedSubscription, error) {
+	if filter.BoundingBox != nil && !filter.BoundingBox.IsValid() {
+		return nil, ErrInvalidBoundingBox
+	}
+	for _, crimeType := range filter.Types {
+		if !validCrimeTypes[crimeType] {
+			return nil, ErrInvalidType
+		}
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	subscription := &CrimeFeedSubscription{
+		feed:   f,
+		filter: filter,
+		events: make(chan CrimeFeedEvent, f.opts.BufferSize),
+	}
+	if lastEventID != "" {
+		position := f.position(lastEventID)
+		if position < 0 {
+			subscription.Reset = true
+		} else {
+			for _, entry := range f.history[position+1:] {
+				if event, ok := project(entry, filter); ok {
+					subscription.Backlog = append(subscription.Backlog, event)
+				}
+			}
+		}
+	}
+	f.subscribers[subscription] = struct{}{}
+	return subscription, nil
+}
+
+// Handle recibe un evento de dominio y lo envía a las conexiones interesadas sin bloquearse
+func (f *CrimeFeed) Handle(ctx context.Context, event events.Event) error {
+	entry, ok, err := newFeedEntry(event)
+	if err != nil || !ok {
+		return err
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	// La entrega es al menos una vez; un evento repetido ya se envió
+	if f.position(entry.eventID) >= 0 {
+		return nil
+	}
+	f.history = append(f.history, entry)
+	if overflow := len(f.history) - f.opts.HistorySize; overflow > 0 {
+		f.history = append([]feedEntry(nil), f.history[overflow:]...)
+	}
+
+	for subscription := range f.subscribers {
+		feedEvent, ok := project(entry, subscription.filter)
+		if !ok {
+			continue
+		}
+		select {
+		case subscription.events <- feedEvent:
+		default:
+			// El cliente no lee a tiempo: se desconecta para no retener memoria sin límite
+			subscription.lagged = true
+			delete(f.subscribers, subscription)
+			close(subscription.events)
+		}
+	}
+	return nil
+}
+
+// Connections retorna la cantidad de conexiones suscritas
+func (f *CrimeFeed) Connections() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return len(f.subscribers)
+}
+
+// position busca el evento en el historial; requiere tener el lock tomado
+func (f *CrimeFeed) position(eventID string) int {
+	for i := len(f.history) - 1; i >= 0; i-- {
+		if f.history[i].eventID == eventID {
+			return i
+		}
+	}
+	return -1
+}
+
+// newFeedEntry interpreta el evento de dominio; ok es false si no afecta al feed o si su
+// payload no trae el delito
+func newFeedEntry(event events.Event) (feedEntry, bool, error) {
+	entry := feedEntry{eventID: event.ID, occurredAt: event.OccurredAt}
+	switch event.Type {
+	case events.CrimeReported:
+		var payload events.CrimeReportedPayload
+		if err := event.Decode(&payload); err != nil {
+			return entry, false, err
+		}
+		entry.crime, entry.exists = payload.Crime, true
+	case events.CrimeUpdated:
+		var payload events.CrimeUpdatedPayload
+		if err := event.Decode(&payload); err != nil {
+			return entry, false, err
+		}
+		if payload.Crime == nil {
+			return entry, false, nil
+		}
+		entry.crime, entry.existed, entry.exists = payload.Crime, true, true
+		entry.before = payload.Crime.Status
+		entry.location, _ = previousLocation(payload.Crime.Location, payload.Changes)
+		entry.crimeType = previousType(payload.Crime.Type, payload.Changes)
+		return entry, true, nil
+	case events.CrimeStatusChanged:
+		var payload events.CrimeStatusChangedPayload
+		if err := event.Decode(&payload); err != nil {
+			return entry, false, err
+		}
+		if payload.Crime == nil || payload.Change == nil {
+			return entry, false, nil
+		}
+		entry.crime, entry.existed, entry.exists = payload.Crime, true, true
+		entry.before = payload.Change.From
+	case events.CrimeDeleted:
+		var payload events.CrimeDeletedPayload
+		if err := event.Decode(&payload); err != nil {
+			return entry, false, err
+		}
+		if payload.Crime == nil {
+			return entry, false, nil
+		}
+		entry.crime, entry.existed = payload.Crime, true
+		entry.before = payload.Crime.Status
+	default:
+		return entry, false, nil
+	}
+	if entry.crime == nil {
+		return entry, false, nil
+	}
+	entry.location, entry.crimeType = entry.crime.Location, entry.crime.Type
+	return entry, true, nil
+}
+
+// previousType retorna el tipo del delito anterior a los cambios
+func previousType(crimeType string, changes []entities.FieldChange) string {
+	for _, change := range changes {
+		if before, ok := change.Before.(string); ok && change.Field == "type" {
+			return before
+		}
+	}
+	return crimeType
+}
+
+// project traduce el cambio a la vista del cliente: un delito que pasa a ser visible para el
+// actor es un alta y uno que deja de serlo es una baja. Los filtros se evalúan sobre la
+// versión anterior y la posterior del delito, así un delito que entra o sale del área o de
+// los tipos del cliente también es un alta o una baja
+func project(entry feedEntry, filter CrimeFeedFilter) (CrimeFeedEvent, bool) {
+	crime := entry.crime
+	visibleBefore := entry.existed && filter.matches(entry.location, entry.crimeType) &&
+		(filter.Actor.IsStaff() || entry.before.IsPublic())
+	visibleAfter := entry.exists && filter.matches(crime.Location, crime.Type) &&
+		(filter.Actor.IsStaff() || crime.Status.IsPublic())
+
+	event := CrimeFeedEvent{ID: entry.eventID, CrimeID: crime.ID, OccurredAt: entry.occurredAt}
+	switch {
+	case !visibleBefore && visibleAfter:
+		event.Action, event.Crime = FeedActionCreated, crime
+	case visibleBefore && visibleAfter:
+		event.Action, event.Crime = FeedActionUpdated, crime
+	case visibleBefore && !visibleAfter:
+		event.Action = FeedActionDeleted
+	default:
+		return CrimeFeedEvent{}, false
+	}
+	return event, true
+}
+
+// matches indica si un delito con la ubicación y el tipo indicados pasa los filtros
+func (f CrimeFeedFilter) matches(location entities.Location, crimeType string) bool {
+	if f.BoundingBox != nil && !f.BoundingBox.Contains(location) {
+		return false
+	}
+	return len(f.Types) == 0 || containsType(f.Types, crimeType)
+}
+
+// containsType indica si el tipo de delito está en la lista
+func containsType(types []string, crimeType string) bool {
+	for _, t := range types {
+		if t == crimeType {
+			return true
+		}
+	}
+	return false
+}
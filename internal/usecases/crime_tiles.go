@@ -0,0 +1,347 @@
+package usecases
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"go-crime_map_backend/internal/domain/entities"
+	"go-crime_map_backend/internal/domain/events"
+	"go-crime_map_backend/internal/domain/repositories"
+	"go-crime_map_backend/pkg/cache"
+	"go-crime_map_backend/pkg/mvt"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	// CrimeTileLayer es la capa de los delitos individuales
+	CrimeTileLayer = "crimes"
+
+	// ClusterTileLayer es la capa de los grupos de delitos cercanos
+	ClusterTileLayer = "clusters"
+
+	// crimeTileBuffer es el margen en unidades del tile que se agrega por lado para que los
+	// puntos cercanos al borde no se corten al dibujarlos
+	crimeTileBuffer = 64
+
+	// clusterCellSize es el lado en unidades del tile de las celdas en que se agrupan los
+	// delitos, un octavo del tile
+	clusterCellSize = mvt.DefaultExtent / 8
+
+	// defaultClusterMaxZoom es el último zoom con delitos agrupados si no se configura otro
+	defaultClusterMaxZoom = 13
+
+	// defaultTileMaxCrimes es la cantidad de delitos por tile si no se configura otra
+	defaultTileMaxCrimes = 20000
+)
+
+// ErrInvalidTile se retorna cuando el zoom, la columna o la fila están fuera de la grilla
+var ErrInvalidTile = errors.New("el tile debe tener z entre 0 y 22 y x e y entre 0 y 2^z - 1")
+
+// CrimeTileInput representa el tile pedido y los filtros de sus delitos
+type CrimeTileInput struct {
+	Z, X, Y  int
+	From     time.Time // Inicio del período, por defecto 30 días antes del fin
+	To       time.Time // Fin del período, excluido; por defecto el fin del día actual en UTC
+	Types    []string
+	Statuses []entities.CrimeStatus
+	Actor    entities.Actor
+}
+
+// CrimeTile representa un tile vectorial de delitos codificado
+type CrimeTile struct {
+	Data      []byte // Tile en formato Mapbox Vector Tile, vacío si no hay delitos
+	ETag      string // Hash del contenido, entre comillas como en la cabecera HTTP
+	Crimes    int    // Delitos incluidos en el tile
+	Clustered bool   // Los delitos cercanos se agruparon en la capa clusters
+	Truncated bool   // Se alcanzó el máximo de delitos y se omitieron los más antiguos
+}
+
+// CrimeTileOptions representa la configuración de los tiles de delitos
+type CrimeTileOptions struct {
+	CacheTTL       time.Duration // Tiempo durante el que se reutiliza un tile; 0 deshabilita la caché
+	CacheSize      int           // Tiles conservados en la caché
+	MaxCrimes      int           // Delitos por tile como máximo, 20000 por defecto
+	ClusterMaxZoom int           // Último zoom en que se agrupan los delitos, 13 por defecto
+}
+
+// GetCrimeTileUseCase maneja la lógica de negocio de los tiles vectoriales del mapa de delitos.
+// Además es un handler de eventos de dominio que invalida los tiles de los delitos modificados
+type GetCrimeTileUseCase struct {
+	statsRepo repositories.StatsRepository
+	options   CrimeTileOptions
+	cache     *cache.LRU[*CrimeTile]
+	now       func() time.Time
+}
+
+// NewGetCrimeTileUseCase crea una nueva instancia del caso de uso
+func NewGetCrimeTileUseCase(repo repositories.StatsRepository, options CrimeTileOptions) *GetCrimeTileUseCase {
+	return NewGetCrimeTileUseCaseWithClock(repo, options, time.Now)
+}
+
+// NewGetCrimeTileUseCaseWithClock crea el caso de uso con un reloj propio, útil en pruebas
+func NewGetCrimeTileUseCaseWithClock(repo repositories.StatsRepository, options CrimeTileOptions, now func() time.Time) *GetCrimeTileUseCase {
+	if options.MaxCrimes <= 0 {
+		options.MaxCrimes = defaultTileMaxCrimes
+	}
+	if options.ClusterMaxZoom <= 0 {
+		options.ClusterMaxZoom = defaultClusterMaxZoom
+	}
+	uc := &GetCrimeTileUseCase{
+		statsRepo: repo,
+		options:   options,
+		now:       now,
+	}
+	if options.CacheTTL > 0 {
+		uc.cache = cache.NewWithClock[*CrimeTile](options.CacheSize, options.CacheTTL, now)
+	}
+	return uc
+}
+
+// Execute codifica los delitos del tile. Hasta ClusterMaxZoom los delitos de cada celda se
+// agrupan en la capa clusters con su cantidad y su puntaje de gravedad, y los que quedan solos
+// van a la capa crimes; en los zooms mayores cada delito es un punto de la capa crimes. Sin fin
+// de período se usa el fin del día actual para que los tiles se reutilicen durante el día.
+// Quienes no son moderadores solo ven los delitos públicos
+func (uc *GetCrimeTileUseCase) Execute(ctx context.Context, input CrimeTileInput) (_ *CrimeTile, err error) {
+	ctx, span := tracer.Start(ctx, "GetCrimeTileUseCase.Execute", trace.WithAttributes(
+		attribute.Int("tile.z", input.Z), attribute.Int("tile.x", input.X), attribute.Int("tile.y", input.Y)))
+	defer func() { endSpan(span, err) }()
+
+	tile := mvt.TileID{Z: input.Z, X: input.X, Y: input.Y}
+	if !tile.IsValid() {
+		return nil, ErrInvalidTile
+	}
+	if input.To.IsZero() {
+		input.To = uc.now().UTC().Truncate(24*time.Hour).AddDate(0, 0, 1)
+	}
+	minLat, minLon, maxLat, maxLon := tile.Bounds(mvt.DefaultExtent, crimeTileBuffer)
+	filter, _, err := StatsCriteria{
+		From:        input.From,
+		To:          input.To,
+		Types:       input.Types,
+		Statuses:    input.Statuses,
+		BoundingBox: &entities.BoundingBox{MinLatitude: minLat, MinLongitude: minLon, MaxLatitude: maxLat, MaxLongitude: maxLon},
+		Actor:       input.Actor,
+	}.resolve()
+	if err != nil {
+		return nil, err
+	}
+
+	key := tileCacheKey(tile) + statsFilterKey(filter)
+	if uc.cache != nil {
+		if cached, found := uc.cache.Get(key); found {
+			span.SetAttributes(attribute.Bool("tile.cached", true))
+			return cached, nil
+		}
+	}
+
+	points, err := uc.statsRepo.ListPoints(ctx, filter, uc.options.MaxCrimes+1)
+	if err != nil {
+		return nil, err
+	}
+	result := &CrimeTile{Clustered: tile.Z <= uc.options.ClusterMaxZoom}
+	if len(points) > uc.options.MaxCrimes {
+		points, result.Truncated = points[:uc.options.MaxCrimes], true
+	}
+
+	crimes := mvt.NewLayer(CrimeTileLayer, mvt.DefaultExtent)
+	clusters := mvt.NewLayer(ClusterTileLayer, mvt.DefaultExtent)
+	if result.Clustered {
+		result.Crimes, err = encodeClusters(tile, points, crimes, clusters)
+	} else {
+		result.Crimes, err = encodePoints(tile, points, crimes)
+	}
+	if err != nil {
+		return nil, err
+	}
+	result.Data = mvt.Marshal(crimes, clusters)
+	sum := sha256.Sum256(result.Data)
+	result.ETag = `"` + hex.EncodeToString(sum[:16]) + `"`
+	span.SetAttributes(attribute.Int("tile.crimes", result.Crimes))
+
+	if uc.cache != nil {
+		uc.cache.Set(key, result)
+	}
+	return result, nil
+}
+
+// Handle invalida los tiles en caché que incluyen la ubicación de un delito reportado,
+// modificado, eliminado o que cambió de estado; en las modificaciones también los de la
+// ubicación anterior. La caché es de cada instancia, por lo que se suscribe al
+// OutboxBroadcaster y no al despachador
+func (uc *GetCrimeTileUseCase) Handle(ctx context.Context, event events.Event) error {
+	if uc.cache == nil {
+		return nil
+	}
+	locations, err := eventLocations(event)
+	if err != nil || len(locations) == 0 {
+		return err
+	}
+
+	prefixes := make(map[string]bool)
+	for _, location := range locations {
+		for z := 0; z <= mvt.MaxZoom; z++ {
+			for _, tile := range mvt.TilesAt(location.Latitude, location.Longitude, z, mvt.DefaultExtent, crimeTileBuffer) {
+				prefixes[tileCacheKey(tile)] = true
+			}
+		}
+	}
+	uc.cache.DeleteFunc(func(key string) bool {
+		return prefixes[key[:strings.IndexByte(key, '|')+1]]
+	})
+	return nil
+}
+
+// tileCacheKey es el prefijo de las claves de caché de un tile
+func tileCacheKey(tile mvt.TileID) string {
+	return fmt.Sprintf("%d/%d/%d|", tile.Z, tile.X, tile.Y)
+}
+
+// eventLocations retorna las ubicaciones afectadas por un evento de delito
+func eventLocations(event events.Event) ([]entities.Location, error) {
+	var crime *entities.Crime
+	var changes []entities.FieldChange
+	switch event.Type {
+	case events.CrimeReported:
+		var payload events.CrimeReportedPayload
+		if err := event.Decode(&payload); err != nil {
+			return nil, err
+		}
+		crime = payload.Crime
+	case events.CrimeUpdated:
+		var payload events.CrimeUpdatedPayload
+		if err := event.Decode(&payload); err != nil {
+			return nil, err
+		}
+		crime, changes = payload.Crime, payload.Changes
+	case events.CrimeStatusChanged:
+		var payload events.CrimeStatusChangedPayload
+		if err := event.Decode(&payload); err != nil {
+			return nil, err
+		}
+		crime = payload.Crime
+	case events.CrimeDeleted:
+		var payload events.CrimeDeletedPayload
+		if err := event.Decode(&payload); err != nil {
+			return nil, err
+		}
+		crime = payload.Crime
+	}
+	if crime == nil {
+		return nil, nil
+	}
+
+	locations := []entities.Location{crime.Location}
+	if previous, moved := previousLocation(crime.Location, changes); moved {
+		locations = append(locations, previous)
+	}
+	return locations, nil
+}
+
+// previousLocation retorna la ubicación del delito anterior a los cambios y si cambiaron
+// sus coordenadas
+func previousLocation(location entities.Location, changes []entities.FieldChange) (entities.Location, bool) {
+	moved := false
+	for _, change := range changes {
+		before, ok := change.Before.(float64)
+		switch {
+		case !ok:
+		case change.Field == "location.latitude":
+			location.Latitude, moved = before, true
+		case change.Field == "location.longitude":
+			location.Longitude, moved = before, true
+		}
+	}
+	return location, moved
+}
+
+// encodePoints agrega cada delito del tile, incluido el margen, como un punto de la capa
+func encodePoints(tile mvt.TileID, points []repositories.CrimePoint, layer *mvt.Layer) (int, error) {
+	for _, point := range points {
+		x, y := tile.Project(point.Location.Latitude, point.Location.Longitude, mvt.DefaultExtent)
+		if err := layer.AddPoint(x, y, crimeTileProperties(point)); err != nil {
+			return 0, err
+		}
+	}
+	return len(points), nil
+}
+
+// tileCluster acumula los delitos de una celda
+type tileCluster struct {
+	cellX, cellY int
+	members      []repositories.CrimePoint
+	sumX, sumY   int
+	severity     float64
+}
+
+// encodeClusters agrupa los delitos del tile por celda. Las celdas con un delito lo agregan a
+// crimes y las demás agregan a clusters un punto en el promedio de sus delitos. Los delitos
+// del margen se omiten porque se agrupan en el tile vecino
+func encodeClusters(tile mvt.TileID, points []repositories.CrimePoint, crimes, clusters *mvt.Layer) (int, error) {
+	cells := make(map[[2]int]*tileCluster)
+	for _, point := range points {
+		x, y := tile.Project(point.Location.Latitude, point.Location.Longitude, mvt.DefaultExtent)
+		if x < 0 || y < 0 || x >= mvt.DefaultExtent || y >= mvt.DefaultExtent {
+			continue
+		}
+		key := [2]int{x / clusterCellSize, y / clusterCellSize}
+		cluster, found := cells[key]
+		if !found {
+			cluster = &tileCluster{cellX: key[0], cellY: key[1]}
+			cells[key] = cluster
+		}
+		cluster.members = append(cluster.members, point)
+		cluster.sumX += x
+		cluster.sumY += y
+		cluster.severity += point.Severity.Weight()
+	}
+
+	// Las celdas se recorren en orden para que el mismo contenido genere el mismo ETag
+	ordered := make([]*tileCluster, 0, len(cells))
+	for _, cluster := range cells {
+		ordered = append(ordered, cluster)
+	}
+	sort.Slice(ordered, func(i, j int) bool {
+		if ordered[i].cellY != ordered[j].cellY {
+			return ordered[i].cellY < ordered[j].cellY
+		}
+		return ordered[i].cellX < ordered[j].cellX
+	})
+
+	total := 0
+	for _, cluster := range ordered {
+		count := len(cluster.members)
+		total += count
+		var err error
+		if count == 1 {
+			err = crimes.AddPoint(cluster.sumX, cluster.sumY, crimeTileProperties(cluster.members[0]))
+		} else {
+			err = clusters.AddPoint(cluster.sumX/count, cluster.sumY/count, map[string]any{
+				"count":          count,
+				"severity_score": cluster.severity,
+			})
+		}
+		if err != nil {
+			return 0, err
+		}
+	}
+	return total, nil
+}
+
+// crimeTileProperties retorna las propiedades de un delito en la capa crimes
+func crimeTileProperties(point repositories.CrimePoint) map[string]any {
+	return map[string]any{
+		"id":       point.ID,
+		"type":     point.Type,
+		"severity": string(point.Severity),
+		"date":     point.Date.Unix(),
+	}
+}
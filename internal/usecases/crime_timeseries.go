@@ -0,0 +1,212 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"go-crime_map_backend/internal/domain/entities"
+	"go-crime_map_backend/internal/domain/repositories"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var (
+	// ErrInvalidTimeBucket se retorna cuando el intervalo de la serie temporal no existe
+	ErrInvalidTimeBucket = errors.New("el intervalo debe ser hour, day, week o month")
+
+	// ErrInvalidTimezone se retorna cuando la zona horaria no existe
+	ErrInvalidTimezone = errors.New("la zona horaria debe ser un nombre IANA válido, por ejemplo America/Argentina/Buenos_Aires")
+
+	// punchcardWeekdays son los días de las filas de la matriz, de lunes a domingo
+	punchcardWeekdays = []time.Weekday{
+		time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday, time.Saturday, time.Sunday,
+	}
+)
+
+// StatsCriteria representa los delitos incluidos en una estadística temporal
+type StatsCriteria struct {
+	From        time.Time // Inicio del período, por defecto 30 días antes del fin
+	To          time.Time // Fin del período, excluido; por defecto el instante actual
+	Timezone    string    // Zona horaria IANA en que se agrupan las fechas, UTC por defecto
+	Types       []string
+	ZoneIDs     []string
+	BoundingBox *entities.BoundingBox
+	Statuses    []entities.CrimeStatus
+	Actor       entities.Actor
+}
+
+// resolve valida los criterios y retorna el filtro del repositorio y la zona horaria
+func (c StatsCriteria) resolve() (repositories.StatsFilter, *time.Location, error) {
+	timezone := c.Timezone
+	if timezone == "" {
+		timezone = "UTC"
+	}
+	// Local depende del servidor, por lo que no se acepta
+	location, err := time.LoadLocation(timezone)
+	if err != nil || timezone == "Local" {
+		return repositories.StatsFilter{}, nil, ErrInvalidTimezone
+	}
+	from, to, err := statsPeriod(c.From, c.To)
+	if err != nil {
+		return repositories.StatsFilter{}, nil, err
+	}
+	if c.BoundingBox != nil && !c.BoundingBox.IsValid() {
+		return repositories.StatsFilter{}, nil, ErrInvalidBoundingBox
+	}
+	statuses, err := visibleStatuses(c.Statuses, c.Actor)
+	if err != nil {
+		return repositories.StatsFilter{}, nil, err
+	}
+
+	return repositories.StatsFilter{
+		CrimeFilter: repositories.CrimeFilter{Statuses: statuses, Types: c.Types, ZoneIDs: c.ZoneIDs},
+		BoundingBox: c.BoundingBox,
+		From:        from.In(location),
+		To:          to.In(location),
+	}, location, nil
+}
+
+// TimeSeriesInput representa los criterios de una serie temporal de delitos
+type TimeSeriesInput struct {
+	StatsCriteria
+	Bucket entities.TimeBucket // hour, day (por defecto), week o month
+}
+
+// TimeSeriesResult representa una serie temporal de delitos
+type TimeSeriesResult struct {
+	From          time.Time                  `json:"from"`
+	To            time.Time                  `json:"to"`
+	Bucket        entities.TimeBucket        `json:"bucket"`
+	Timezone      string                     `json:"timezone"`
+	Total         int                        `json:"total"`
+	SeverityScore float64                    `json:"severity_score"` // Suma de los pesos de gravedad de los delitos
+	Points        []entities.TimeSeriesPoint `json:"points"`         // Todos los intervalos del período, incluso los vacíos
+}
+
+// GetCrimeTimeSeriesUseCase maneja la lógica de negocio de las series temporales de delitos
+type GetCrimeTimeSeriesUseCase struct {
+	statsRepo repositories.StatsRepository
+}
+
+// NewGetCrimeTimeSeriesUseCase crea una nueva instancia del caso de uso
+func NewGetCrimeTimeSeriesUseCase(repo repositories.StatsRepository) *GetCrimeTimeSeriesUseCase {
+	return &GetCrimeTimeSeriesUseCase{
+		statsRepo: repo,
+	}
+}
+
+// Execute cuenta los delitos por intervalo según la fecha del delito en la zona horaria
+// indicada. El primer y el último intervalo pueden quedar parcialmente fuera del período;
+// solo se cuentan los delitos dentro de él
+func (uc *GetCrimeTimeSeriesUseCase) Execute(ctx context.Context, input TimeSeriesInput) (_ *TimeSeriesResult, err error) {
+	ctx, span := tracer.Start(ctx, "GetCrimeTimeSeriesUseCase.Execute",
+		trace.WithAttributes(attribute.String("stats.bucket", string(input.Bucket))))
+	defer func() { endSpan(span, err) }()
+
+	bucket := input.Bucket
+	if bucket == "" {
+		bucket = entities.TimeBucketDay
+	}
+	if !bucket.IsValid() {
+		return nil, ErrInvalidTimeBucket
+	}
+	filter, location, err := input.resolve()
+	if err != nil {
+		return nil, err
+	}
+
+	counts, err := uc.statsRepo.CountByBucket(ctx, filter, bucket, location)
+	if err != nil {
+		return nil, err
+	}
+	byStart := make(map[int64]repositories.BucketCount, len(counts))
+	for _, count := range counts {
+		total := byStart[count.Start.Unix()]
+		total.Count += count.Count
+		total.Weight += count.Weight
+		byStart[count.Start.Unix()] = total
+	}
+
+	result := &TimeSeriesResult{
+		From:     filter.From,
+		To:       filter.To,
+		Bucket:   bucket,
+		Timezone: location.String(),
+		Points:   []entities.TimeSeriesPoint{},
+	}
+	for start := bucket.Truncate(filter.From); start.Before(filter.To); start = bucket.Next(start) {
+		count := byStart[start.Unix()]
+		result.Points = append(result.Points, entities.TimeSeriesPoint{Start: start, Count: count.Count, SeverityScore: count.Weight})
+		result.Total += count.Count
+		result.SeverityScore += count.Weight
+	}
+	return result, nil
+}
+
+// PunchcardResult representa la cantidad de delitos por día de la semana y hora
+type PunchcardResult struct {
+	From           time.Time   `json:"from"`
+	To             time.Time   `json:"to"`
+	Timezone       string      `json:"timezone"`
+	Total          int         `json:"total"`
+	SeverityScore  float64     `json:"severity_score"`  // Suma de los pesos de gravedad de los delitos
+	Weekdays       []string    `json:"weekdays"`        // Días de las filas de la matriz, de lunes (monday) a domingo (sunday)
+	Counts         [][]int     `json:"counts"`          // Una fila por día con 24 columnas, una por hora local
+	SeverityScores [][]float64 `json:"severity_scores"` // Suma de los pesos de gravedad, con las mismas filas y columnas que Counts
+}
+
+// GetCrimePunchcardUseCase maneja la lógica de negocio de la matriz de día de la semana y hora
+type GetCrimePunchcardUseCase struct {
+	statsRepo repositories.StatsRepository
+}
+
+// NewGetCrimePunchcardUseCase crea una nueva instancia del caso de uso
+func NewGetCrimePunchcardUseCase(repo repositories.StatsRepository) *GetCrimePunchcardUseCase {
+	return &GetCrimePunchcardUseCase{
+		statsRepo: repo,
+	}
+}
+
+// Execute cuenta los delitos del período por día de la semana y hora según la fecha del
+// delito en la zona horaria indicada
+func (uc *GetCrimePunchcardUseCase) Execute(ctx context.Context, input StatsCriteria) (_ *PunchcardResult, err error) {
+	ctx, span := tracer.Start(ctx, "GetCrimePunchcardUseCase.Execute")
+	defer func() { endSpan(span, err) }()
+
+	filter, location, err := input.resolve()
+	if err != nil {
+		return nil, err
+	}
+	counts, err := uc.statsRepo.CountByWeekdayHour(ctx, filter, location)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &PunchcardResult{
+		From:           filter.From,
+		To:             filter.To,
+		Timezone:       location.String(),
+		Weekdays:       make([]string, len(punchcardWeekdays)),
+		Counts:         make([][]int, len(punchcardWeekdays)),
+		SeverityScores: make([][]float64, len(punchcardWeekdays)),
+	}
+	rows := make(map[time.Weekday]int, len(punchcardWeekdays))
+	for i, weekday := range punchcardWeekdays {
+		result.Weekdays[i] = strings.ToLower(weekday.String())
+		result.Counts[i] = make([]int, 24)
+		result.SeverityScores[i] = make([]float64, 24)
+		rows[weekday] = i
+	}
+	for _, count := range counts {
+		if row, found := rows[count.Weekday]; found && count.Hour >= 0 && count.Hour < 24 {
+			result.Counts[row][count.Hour] += count.Count
+			result.SeverityScores[row][count.Hour] += count.Weight
+			result.Total += count.Count
+			result.SeverityScore += count.Weight
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,56 @@
+package usecases
+
+import (
+	"context"
+	"log/slog"
+
+	"go-crime_map_backend/internal/domain/entities"
+	"go-crime_map_backend/internal/domain/events"
+	"go-crime_map_backend/internal/domain/repositories"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// DeleteCrimeUseCase maneja la lógica de negocio para eliminar un delito
+type DeleteCrimeUseCase struct {
+	crimeRepo repositories.CrimeRepository
+}
+
+// NewDeleteCrimeUseCase crea una nueva instancia del caso de uso
+func NewDeleteCrimeUseCase(repo repositories.CrimeRepository) *DeleteCrimeUseCase {
+	return &DeleteCrimeUseCase{
+		crimeRepo: repo,
+	}
+}
+
+// Execute elimina el delito. Solo disponible para administradores; las revisiones se
+// conservan para poder auditar la eliminación
+func (uc *DeleteCrimeUseCase) Execute(ctx context.Context, crimeID string, actor entities.Actor) (err error) {
+	ctx, span := tracer.Start(ctx, "DeleteCrimeUseCase.Execute",
+		trace.WithAttributes(attribute.String("crime.id", crimeID)))
+	defer func() { endSpan(span, err) }()
+
+	if actor.Role != entities.RoleAdmin {
+		return ErrForbidden
+	}
+
+	crime, err := uc.crimeRepo.GetByID(ctx, crimeID)
+	if err != nil {
+		return err
+	}
+	if crime == nil {
+		return ErrCrimeNotFound
+	}
+
+	eventCtx, err := raise(ctx, events.CrimeDeleted, crime.ID, events.CrimeDeletedPayload{Crime: crime})
+	if err != nil {
+		return err
+	}
+	if err := uc.crimeRepo.Delete(eventCtx, crime.ID); err != nil {
+		return err
+	}
+
+	slog.InfoContext(ctx, "delito eliminado", slog.String("crime_id", crime.ID), slog.String("actor_id", actor.ID))
+	return nil
+}
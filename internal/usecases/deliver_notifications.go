@@ -0,0 +1,140 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"go-crime_map_backend/internal/domain/entities"
+	"go-crime_map_backend/internal/domain/repositories"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// NotificationSender envía los avisos de un canal, por ejemplo por email o webhook
+type NotificationSender interface {
+	Send(ctx context.Context, notification *entities.Notification) error
+}
+
+// DeliverNotificationsOptions configura el envío de los avisos
+type DeliverNotificationsOptions struct {
+	BatchSize      int           // Avisos enviados por ejecución
+	Lease          time.Duration // Tiempo que un aviso queda reservado mientras se envía
+	MaxAttempts    int           // Intentos fallidos tras los que el aviso pasa a failed
+	RetryBaseDelay time.Duration // Espera tras el primer fallo, se duplica en cada reintento
+	RetryMaxDelay  time.Duration // Espera máxima entre reintentos
+}
+
+// DefaultDeliverNotificationsOptions retorna la configuración por defecto del envío de avisos
+func DefaultDeliverNotificationsOptions() DeliverNotificationsOptions {
+	return DeliverNotificationsOptions{
+		BatchSize:      50,
+		Lease:          time.Minute,
+		MaxAttempts:    5,
+		RetryBaseDelay: 30 * time.Second,
+		RetryMaxDelay:  30 * time.Minute,
+	}
+}
+
+// DeliverNotificationsUseCase envía los avisos pendientes con el emisor de cada canal
+type DeliverNotificationsUseCase struct {
+	alertRepo repositories.AlertRepository
+	senders   map[entities.NotificationChannel]NotificationSender
+	opts      DeliverNotificationsOptions
+	now       func() time.Time
+}
+
+// NewDeliverNotificationsUseCase crea una nueva instancia del caso de uso
+func NewDeliverNotificationsUseCase(repo repositories.AlertRepository, senders map[entities.NotificationChannel]NotificationSender, opts DeliverNotificationsOptions) *DeliverNotificationsUseCase {
+	return NewDeliverNotificationsUseCaseWithClock(repo, senders, opts, time.Now)
+}
+
+// NewDeliverNotificationsUseCaseWithClock crea el caso de uso con un reloj propio, útil en pruebas
+func NewDeliverNotificationsUseCaseWithClock(repo repositories.AlertRepository, senders map[entities.NotificationChannel]NotificationSender, opts DeliverNotificationsOptions, now func() time.Time) *DeliverNotificationsUseCase {
+	return &DeliverNotificationsUseCase{
+		alertRepo: repo,
+		senders:   senders,
+		opts:      opts,
+		now:       now,
+	}
+}
+
+// Execute envía un lote de avisos pendientes y retorna cuántos se entregaron. Los fallos se
+// reintentan con espera exponencial hasta MaxAttempts, tras lo cual el aviso pasa a failed
+func (uc *DeliverNotificationsUseCase) Execute(ctx context.Context) (_ int, err error) {
+	ctx, span := tracer.Start(ctx, "DeliverNotificationsUseCase.Execute")
+	defer func() { endSpan(span, err) }()
+
+	notifications, err := uc.alertRepo.ClaimDueNotifications(ctx, uc.now(), uc.opts.BatchSize, uc.opts.Lease)
+	if err != nil {
+		return 0, err
+	}
+
+	sent := 0
+	for _, notification := range notifications {
+		if ctx.Err() != nil {
+			// Los avisos reservados restantes se retoman al vencer la reserva
+			return sent, ctx.Err()
+		}
+		if uc.send(ctx, notification) {
+			sent++
+		}
+		if err := uc.alertRepo.UpdateNotification(ctx, notification); err != nil {
+			return sent, err
+		}
+	}
+	span.SetAttributes(attribute.Int("notification.sent", sent))
+	return sent, nil
+}
+
+// send realiza un intento de envío y actualiza el estado del aviso; retorna true si se entregó
+func (uc *DeliverNotificationsUseCase) send(ctx context.Context, notification *entities.Notification) bool {
+	ctx, span := tracer.Start(ctx, "DeliverNotificationsUseCase.send", trace.WithAttributes(
+		attribute.String("notification.id", notification.ID),
+		attribute.String("notification.channel", string(notification.Channel)),
+	))
+
+	var sendErr error
+	if sender, ok := uc.senders[notification.Channel]; ok {
+		sendErr = sender.Send(ctx, notification)
+	} else {
+		sendErr = fmt.Errorf("el canal %s no está configurado", notification.Channel)
+	}
+	endSpan(span, sendErr)
+
+	now := uc.now()
+	notification.Attempts++
+	if sendErr == nil {
+		notification.Status = entities.NotificationSent
+		notification.NextAttemptAt = nil
+		notification.LastError = ""
+		notification.SentAt = &now
+		return true
+	}
+
+	notification.LastError = sendErr.Error()
+	if notification.Attempts >= uc.opts.MaxAttempts {
+		notification.Status = entities.NotificationFailed
+		notification.NextAttemptAt = nil
+		slog.ErrorContext(ctx, "aviso abandonado tras agotar los reintentos",
+			slog.String("notification_id", notification.ID),
+			slog.String("channel", string(notification.Channel)),
+			slog.Int("attempts", notification.Attempts),
+			slog.Any("error", sendErr),
+		)
+		return false
+	}
+
+	next := now.Add(backoffDelay(uc.opts.RetryBaseDelay, uc.opts.RetryMaxDelay, notification.Attempts))
+	notification.NextAttemptAt = &next
+	slog.WarnContext(ctx, "error al enviar el aviso",
+		slog.String("notification_id", notification.ID),
+		slog.String("channel", string(notification.Channel)),
+		slog.Int("attempts", notification.Attempts),
+		slog.Time("next_attempt_at", next),
+		slog.Any("error", sendErr),
+	)
+	return false
+}
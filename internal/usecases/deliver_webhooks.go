@@ -0,0 +1,174 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"go-crime_map_backend/internal/domain/entities"
+	"go-crime_map_backend/internal/domain/repositories"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// WebhookRequest representa un envío firmado a la URL de una suscripción
+type WebhookRequest struct {
+	URL        string
+	Secret     string
+	DeliveryID string
+	EventID    string
+	EventType  string
+	Body       []byte
+}
+
+// WebhookSender envía las entregas de webhooks y retorna el código HTTP de la respuesta.
+// Un error indica que no se obtuvo respuesta, por ejemplo por un tiempo de espera agotado
+type WebhookSender interface {
+	Send(ctx context.Context, request WebhookRequest) (int, error)
+}
+
+// DeliverWebhooksOptions configura el envío de las entregas de webhooks
+type DeliverWebhooksOptions struct {
+	BatchSize      int           // Entregas enviadas por ejecución
+	Lease          time.Duration // Tiempo que una entrega queda reservada mientras se envía
+	MaxAttempts    int           // Intentos fallidos tras los que la entrega pasa a dead
+	RetryBaseDelay time.Duration // Espera tras el primer fallo, se duplica en cada reintento
+	RetryMaxDelay  time.Duration // Espera máxima entre reintentos
+}
+
+// DefaultDeliverWebhooksOptions retorna la configuración por defecto del envío de webhooks
+func DefaultDeliverWebhooksOptions() DeliverWebhooksOptions {
+	return DeliverWebhooksOptions{
+		BatchSize:      50,
+		Lease:          time.Minute,
+		MaxAttempts:    8,
+		RetryBaseDelay: 10 * time.Second,
+		RetryMaxDelay:  time.Hour,
+	}
+}
+
+// DeliverWebhooksUseCase envía las entregas pendientes de webhooks
+type DeliverWebhooksUseCase struct {
+	webhookRepo repositories.WebhookRepository
+	sender      WebhookSender
+	opts        DeliverWebhooksOptions
+	now         func() time.Time
+}
+
+// NewDeliverWebhooksUseCase crea una nueva instancia del caso de uso
+func NewDeliverWebhooksUseCase(repo repositories.WebhookRepository, sender WebhookSender, opts DeliverWebhooksOptions) *DeliverWebhooksUseCase {
+	return NewDeliverWebhooksUseCaseWithClock(repo, sender, opts, time.Now)
+}
+
+// NewDeliverWebhooksUseCaseWithClock crea el caso de uso con un reloj propio, útil en pruebas
+func NewDeliverWebhooksUseCaseWithClock(repo repositories.WebhookRepository, sender WebhookSender, opts DeliverWebhooksOptions, now func() time.Time) *DeliverWebhooksUseCase {
+	return &DeliverWebhooksUseCase{
+		webhookRepo: repo,
+		sender:      sender,
+		opts:        opts,
+		now:         now,
+	}
+}
+
+// Execute envía un lote de entregas pendientes y retorna cuántas fueron aceptadas. Una
+// respuesta 2xx completa la entrega; cualquier otra respuesta o error se reintenta con
+// espera exponencial hasta MaxAttempts, tras lo cual la entrega pasa a dead
+func (uc *DeliverWebhooksUseCase) Execute(ctx context.Context) (_ int, err error) {
+	ctx, span := tracer.Start(ctx, "DeliverWebhooksUseCase.Execute")
+	defer func() { endSpan(span, err) }()
+
+	deliveries, err := uc.webhookRepo.ClaimDueDeliveries(ctx, uc.now(), uc.opts.BatchSize, uc.opts.Lease)
+	if err != nil {
+		return 0, err
+	}
+
+	subscriptions := make(map[string]*entities.WebhookSubscription)
+	succeeded := 0
+	for _, delivery := range deliveries {
+		if ctx.Err() != nil {
+			// Las entregas reservadas restantes se retoman al vencer la reserva
+			return succeeded, ctx.Err()
+		}
+
+		subscription, cached := subscriptions[delivery.SubscriptionID]
+		if !cached {
+			if subscription, err = uc.webhookRepo.GetSubscription(ctx, delivery.SubscriptionID); err != nil {
+				return succeeded, err
+			}
+			subscriptions[delivery.SubscriptionID] = subscription
+		}
+		if subscription == nil {
+			// La suscripción se eliminó mientras la entrega estaba reservada
+			continue
+		}
+
+		if uc.send(ctx, subscription, delivery) {
+			succeeded++
+		}
+		if err := uc.webhookRepo.UpdateDelivery(ctx, delivery); err != nil {
+			return succeeded, err
+		}
+	}
+	span.SetAttributes(attribute.Int("webhook.delivered", succeeded))
+	return succeeded, nil
+}
+
+// send realiza un intento de entrega y actualiza su estado; retorna true si fue aceptada
+func (uc *DeliverWebhooksUseCase) send(ctx context.Context, subscription *entities.WebhookSubscription, delivery *entities.WebhookDelivery) bool {
+	ctx, span := tracer.Start(ctx, "DeliverWebhooksUseCase.send", trace.WithAttributes(
+		attribute.String("webhook.subscription_id", subscription.ID),
+		attribute.String("webhook.delivery_id", delivery.ID),
+		attribute.String("event.type", delivery.EventType),
+	))
+
+	statusCode, sendErr := uc.sender.Send(ctx, WebhookRequest{
+		URL:        subscription.URL,
+		Secret:     subscription.Secret,
+		DeliveryID: delivery.ID,
+		EventID:    delivery.EventID,
+		EventType:  delivery.EventType,
+		Body:       delivery.Payload,
+	})
+	if sendErr == nil && (statusCode < 200 || statusCode > 299) {
+		sendErr = fmt.Errorf("el receptor respondió con el código HTTP %d", statusCode)
+	}
+	span.SetAttributes(attribute.Int("http.response.status_code", statusCode))
+	endSpan(span, sendErr)
+
+	now := uc.now()
+	delivery.Attempts++
+	delivery.LastStatusCode = statusCode
+	if sendErr == nil {
+		delivery.Status = entities.WebhookDeliverySucceeded
+		delivery.NextAttemptAt = nil
+		delivery.LastError = ""
+		delivery.DeliveredAt = &now
+		return true
+	}
+
+	delivery.LastError = sendErr.Error()
+	if delivery.Attempts >= uc.opts.MaxAttempts {
+		delivery.Status = entities.WebhookDeliveryDead
+		delivery.NextAttemptAt = nil
+		slog.ErrorContext(ctx, "entrega de webhook abandonada tras agotar los reintentos",
+			slog.String("subscription_id", subscription.ID),
+			slog.String("delivery_id", delivery.ID),
+			slog.Int("attempts", delivery.Attempts),
+			slog.Any("error", sendErr),
+		)
+		return false
+	}
+
+	next := now.Add(backoffDelay(uc.opts.RetryBaseDelay, uc.opts.RetryMaxDelay, delivery.Attempts))
+	delivery.NextAttemptAt = &next
+	slog.WarnContext(ctx, "error al entregar el webhook",
+		slog.String("subscription_id", subscription.ID),
+		slog.String("delivery_id", delivery.ID),
+		slog.Int("attempts", delivery.Attempts),
+		slog.Time("next_attempt_at", next),
+		slog.Any("error", sendErr),
+	)
+	return false
+}
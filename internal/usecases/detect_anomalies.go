@@ -0,0 +1,262 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"math"
+	"sort"
+	"time"
+
+	"go-crime_map_backend/internal/domain/entities"
+	"go-crime_map_backend/internal/domain/events"
+	"go-crime_map_backend/internal/domain/repositories"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	// defaultAnomalyListLimit es la cantidad de anomalías que se listan si no se indica
+	defaultAnomalyListLimit = 50
+
+	// maxAnomalyListLimit es la cantidad máxima de anomalías de un listado
+	maxAnomalyListLimit = 500
+)
+
+var (
+	// ErrInvalidAnomalyLimit se retorna cuando la cantidad de anomalías solicitada es inválida
+	ErrInvalidAnomalyLimit = errors.New("el límite de anomalías debe estar entre 1 y 500")
+
+	// ErrInvalidAnomalyPeriod se retorna cuando el inicio del listado no es anterior al fin
+	ErrInvalidAnomalyPeriod = errors.New("el inicio del período debe ser anterior al fin")
+)
+
+// DetectAnomaliesOptions configura la detección de anomalías
+type DetectAnomaliesOptions struct {
+	Window          time.Duration // Duración de cada ventana; las ventanas se alinean a múltiplos de ella
+	BaselineWindows int           // Ventanas anteriores con las que se calcula el promedio
+	Threshold       float64       // Desvíos por encima del promedio a partir de los que hay anomalía
+	MinCount        int           // Delitos mínimos de la ventana para considerarla anómala
+}
+
+// DefaultDetectAnomaliesOptions retorna las opciones por defecto: ventanas diarias comparadas
+// con las 28 anteriores y un umbral de tres desvíos
+func DefaultDetectAnomaliesOptions() DetectAnomaliesOptions {
+	return DetectAnomaliesOptions{
+		Window:          24 * time.Hour,
+		BaselineWindows: 28,
+		Threshold:       3,
+		MinCount:        5,
+	}
+}
+
+// DetectAnomaliesUseCase detecta los aumentos inusuales de delitos de la última ventana
+// completa comparándola con las ventanas anteriores de la misma serie
+type DetectAnomaliesUseCase struct {
+	statsRepo   repositories.StatsRepository
+	anomalyRepo repositories.AnomalyRepository
+	options     DetectAnomaliesOptions
+	now         func() time.Time
+}
+
+// NewDetectAnomaliesUseCase crea una nueva instancia del caso de uso
+func NewDetectAnomaliesUseCase(statsRepo repositories.StatsRepository, anomalyRepo repositories.AnomalyRepository, options DetectAnomaliesOptions) *DetectAnomaliesUseCase {
+	return NewDetectAnomaliesUseCaseWithClock(statsRepo, anomalyRepo, options, time.Now)
+}
+
+// NewDetectAnomaliesUseCaseWithClock crea el caso de uso con un reloj propio, útil en pruebas
+func NewDetectAnomaliesUseCaseWithClock(statsRepo repositories.StatsRepository, anomalyRepo repositories.AnomalyRepository, options DetectAnomaliesOptions, now func() time.Time) *DetectAnomaliesUseCase {
+	defaults := DefaultDetectAnomaliesOptions()
+	if options.Window <= 0 {
+		options.Window = defaults.Window
+	}
+	if options.BaselineWindows < 2 {
+		options.BaselineWindows = defaults.BaselineWindows
+	}
+	if options.Threshold <= 0 {
+		options.Threshold = defaults.Threshold
+	}
+	if options.MinCount < 1 {
+		options.MinCount = 1
+	}
+	return &DetectAnomaliesUseCase{
+		statsRepo:   statsRepo,
+		anomalyRepo: anomalyRepo,
+		options:     options,
+		now:         now,
+	}
+}
+
+// anomalySeries identifica una serie de conteos; una zona o un tipo vacío agregan todas las
+// zonas o todos los tipos
+type anomalySeries struct {
+	zoneID, crimeType string
+}
+
+// Execute analiza la última ventana completa y guarda una anomalía por cada serie cuyo conteo
+// supera el promedio de las ventanas anteriores en al menos Threshold desvíos. Se analizan las
+// series por zona y tipo, por zona, por tipo y el total; solo se cuentan los delitos públicos.
+// Retorna cuántas anomalías nuevas guardó; volver a analizar una ventana no las repite
+func (uc *DetectAnomaliesUseCase) Execute(ctx context.Context) (_ int, err error) {
+	ctx, span := tracer.Start(ctx, "DetectAnomaliesUseCase.Execute")
+	defer func() { endSpan(span, err) }()
+
+	window, baselineWindows := uc.options.Window, uc.options.BaselineWindows
+	now := uc.now()
+	end := now.Truncate(window)
+	start := end.Add(-window * time.Duration(baselineWindows+1))
+	counts, err := uc.statsRepo.CountByWindow(ctx, repositories.StatsFilter{
+		CrimeFilter: repositories.CrimeFilter{Statuses: entities.PublicCrimeStatuses},
+		From:        start,
+		To:          end,
+	}, window)
+	if err != nil {
+		return 0, err
+	}
+
+	series := make(map[anomalySeries][]int)
+	add := func(key anomalySeries, index, count int) {
+		values, found := series[key]
+		if !found {
+			values = make([]int, baselineWindows+1)
+			series[key] = values
+		}
+		values[index] += count
+	}
+	for _, count := range counts {
+		if count.Window < 0 || count.Window > baselineWindows {
+			continue
+		}
+		// Los delitos sin zona solo se cuentan en las series de toda la ciudad
+		if count.ZoneID != "" {
+			add(anomalySeries{count.ZoneID, count.Type}, count.Window, count.Count)
+			add(anomalySeries{count.ZoneID, ""}, count.Window, count.Count)
+		}
+		add(anomalySeries{"", count.Type}, count.Window, count.Count)
+		add(anomalySeries{"", ""}, count.Window, count.Count)
+	}
+
+	keys := make([]anomalySeries, 0, len(series))
+	for key := range series {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].zoneID != keys[j].zoneID {
+			return keys[i].zoneID < keys[j].zoneID
+		}
+		return keys[i].crimeType < keys[j].crimeType
+	})
+
+	saved := 0
+	for _, key := range keys {
+		values := series[key]
+		current := values[baselineWindows]
+		if current < uc.options.MinCount {
+			continue
+		}
+		mean, stdDev := meanStdDev(values[:baselineWindows])
+		// Con un desvío menor a uno, una serie casi constante marcaría cualquier aumento mínimo
+		zScore := (float64(current) - mean) / math.Max(stdDev, 1)
+		if zScore < uc.options.Threshold {
+			continue
+		}
+
+		anomaly := &entities.Anomaly{
+			ID:          generateID(),
+			ZoneID:      key.zoneID,
+			Type:        key.crimeType,
+			WindowStart: end.Add(-window),
+			WindowEnd:   end,
+			Count:       current,
+			Baseline:    mean,
+			StdDev:      stdDev,
+			ZScore:      zScore,
+			DetectedAt:  now,
+		}
+		eventCtx, err := raise(ctx, events.AnomalyDetected, anomaly.ID, events.AnomalyDetectedPayload{Anomaly: anomaly})
+		if err != nil {
+			return saved, err
+		}
+		created, err := uc.anomalyRepo.SaveAnomaly(eventCtx, anomaly)
+		if err != nil {
+			return saved, err
+		}
+		if !created {
+			continue
+		}
+		saved++
+		slog.InfoContext(ctx, "anomalía de delitos detectada",
+			slog.String("anomaly_id", anomaly.ID),
+			slog.String("zone_id", anomaly.ZoneID),
+			slog.String("type", anomaly.Type),
+			slog.Int("count", anomaly.Count),
+			slog.Float64("baseline", anomaly.Baseline),
+			slog.Float64("z_score", anomaly.ZScore),
+		)
+	}
+	span.SetAttributes(attribute.Int("anomalies.saved", saved))
+	return saved, nil
+}
+
+// meanStdDev calcula el promedio y la desviación estándar poblacional de los conteos
+func meanStdDev(values []int) (float64, float64) {
+	if len(values) == 0 {
+		return 0, 0
+	}
+	sum := 0.0
+	for _, value := range values {
+		sum += float64(value)
+	}
+	mean := sum / float64(len(values))
+	variance := 0.0
+	for _, value := range values {
+		diff := float64(value) - mean
+		variance += diff * diff
+	}
+	return mean, math.Sqrt(variance / float64(len(values)))
+}
+
+// ListAnomaliesInput representa los criterios del listado de anomalías
+type ListAnomaliesInput struct {
+	ZoneID string    // Zona de la serie; vacío incluye todas
+	Type   string    // Tipo de delito de la serie; vacío incluye todos
+	From   time.Time // Inicio mínimo de la ventana, cero no filtra
+	To     time.Time // Inicio máximo de la ventana, excluido; cero no filtra
+	Limit  int       // Cantidad máxima de anomalías, por defecto 50
+}
+
+// ListAnomaliesUseCase maneja la lógica de negocio del listado de anomalías
+type ListAnomaliesUseCase struct {
+	anomalyRepo repositories.AnomalyRepository
+}
+
+// NewListAnomaliesUseCase crea una nueva instancia del caso de uso
+func NewListAnomaliesUseCase(repo repositories.AnomalyRepository) *ListAnomaliesUseCase {
+	return &ListAnomaliesUseCase{anomalyRepo: repo}
+}
+
+// Execute lista las anomalías detectadas, de la ventana más reciente a la más antigua
+func (uc *ListAnomaliesUseCase) Execute(ctx context.Context, input ListAnomaliesInput) (_ []*entities.Anomaly, err error) {
+	ctx, span := tracer.Start(ctx, "ListAnomaliesUseCase.Execute",
+		trace.WithAttributes(attribute.String("zone.id", input.ZoneID)))
+	defer func() { endSpan(span, err) }()
+
+	limit := input.Limit
+	if limit == 0 {
+		limit = defaultAnomalyListLimit
+	}
+	if limit < 0 || limit > maxAnomalyListLimit {
+		return nil, ErrInvalidAnomalyLimit
+	}
+	if !input.From.IsZero() && !input.To.IsZero() && !input.From.Before(input.To) {
+		return nil, ErrInvalidAnomalyPeriod
+	}
+	return uc.anomalyRepo.ListAnomalies(ctx, repositories.AnomalyFilter{
+		ZoneID: input.ZoneID,
+		Type:   input.Type,
+		From:   input.From,
+		To:     input.To,
+		Limit:  limit,
+	})
+}
@@ -0,0 +1,171 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"go-crime_map_backend/internal/domain/events"
+	"go-crime_map_backend/internal/domain/repositories"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// OutboxDispatcherOptions configura la entrega de los eventos del outbox
+type OutboxDispatcherOptions struct {
+	BatchSize      int           // Eventos reservados por ejecución
+	Lease          time.Duration // Tiempo que un evento queda reservado mientras se entrega
+	MaxAttempts    int           // Entregas fallidas tras las que el evento se abandona
+	RetryBaseDelay time.Duration // Espera tras el primer fallo, se duplica en cada reintento
+	RetryMaxDelay  time.Duration // Espera máxima entre reintentos
+}
+
+// DefaultOutboxDispatcherOptions retorna la configuración por defecto del despachador
+func DefaultOutboxDispatcherOptions() OutboxDispatcherOptions {
+	return OutboxDispatcherOptions{
+		BatchSize:      100,
+		Lease:          time.Minute,
+		MaxAttempts:    10,
+		RetryBaseDelay: time.Second,
+		RetryMaxDelay:  5 * time.Minute,
+	}
+}
+
+// OutboxDispatcher entrega los eventos del outbox a los handlers registrados. Un evento
+// se marca como entregado solo cuando todos sus handlers terminan sin error; si alguno
+// falla, el evento completo se reintenta, por lo que los handlers deben ser idempotentes.
+type OutboxDispatcher struct {
+	eventHandlers
+	outboxRepo repositories.OutboxRepository
+	opts       OutboxDispatcherOptions
+	now        func() time.Time
+}
+
+// eventHandlers registra los handlers de eventos por tipo
+type eventHandlers struct {
+	mu       sync.RWMutex
+	handlers map[events.Type][]events.Handler
+	all      []events.Handler
+}
+
+// NewOutboxDispatcher crea un despachador sin handlers
+func NewOutboxDispatcher(repo repositories.OutboxRepository, opts OutboxDispatcherOptions) *OutboxDispatcher {
+	return NewOutboxDispatcherWithClock(repo, opts, time.Now)
+}
+
+// NewOutboxDispatcherWithClock crea el despachador con un reloj propio, útil en pruebas
+func NewOutboxDispatcherWithClock(repo repositories.OutboxRepository, opts OutboxDispatcherOptions, now func() time.Time) *OutboxDispatcher {
+	return &OutboxDispatcher{
+		eventHandlers: eventHandlers{handlers: make(map[events.Type][]events.Handler)},
+		outboxRepo:    repo,
+		opts:          opts,
+		now:           now,
+	}
+}
+
+// Subscribe registra un handler para un tipo de evento
+func (h *eventHandlers) Subscribe(eventType events.Type, handler events.Handler) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.handlers[eventType] = append(h.handlers[eventType], handler)
+}
+
+// SubscribeAll registra un handler para todos los tipos de evento
+func (h *eventHandlers) SubscribeAll(handler events.Handler) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.all = append(h.all, handler)
+}
+
+// DispatchPending entrega un lote de eventos pendientes y retorna cuántos se entregaron
+func (d *OutboxDispatcher) DispatchPending(ctx context.Context) (_ int, err error) {
+	ctx, span := tracer.Start(ctx, "OutboxDispatcher.DispatchPending")
+	defer func() { endSpan(span, err) }()
+
+	entries, err := d.outboxRepo.ClaimPending(ctx, d.now(), d.opts.BatchSize, d.opts.Lease)
+	if err != nil {
+		return 0, err
+	}
+
+	dispatched := 0
+	for _, entry := range entries {
+		if ctx.Err() != nil {
+			// Los eventos reservados restantes se retoman al vencer la reserva
+			return dispatched, ctx.Err()
+		}
+		if deliverErr := d.deliver(ctx, entry.Event); deliverErr != nil {
+			if err := d.fail(ctx, entry, deliverErr); err != nil {
+				return dispatched, err
+			}
+			continue
+		}
+		if err := d.outboxRepo.MarkDispatched(ctx, entry.Event.ID, d.now()); err != nil {
+			return dispatched, err
+		}
+		dispatched++
+	}
+	span.SetAttributes(attribute.Int("outbox.dispatched", dispatched))
+	return dispatched, nil
+}
+
+// deliver entrega el evento a cada handler suscrito y se detiene en el primer error
+func (d *OutboxDispatcher) deliver(ctx context.Context, event events.Event) (err error) {
+	ctx, span := tracer.Start(ctx, "OutboxDispatcher.deliver", trace.WithAttributes(
+		attribute.String("event.id", event.ID),
+		attribute.String("event.type", string(event.Type)),
+	))
+	defer func() { endSpan(span, err) }()
+
+	for _, handler := range d.subscribers(event.Type) {
+		if err := safeHandle(ctx, handler, event); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// fail registra la entrega fallida y programa el reintento con espera exponencial
+func (d *OutboxDispatcher) fail(ctx context.Context, entry *repositories.OutboxEntry, cause error) error {
+	attempts := entry.Attempts + 1
+	if attempts >= d.opts.MaxAttempts {
+		slog.ErrorContext(ctx, "evento de dominio abandonado tras agotar los reintentos",
+			slog.String("event_id", entry.Event.ID),
+			slog.String("event_type", string(entry.Event.Type)),
+			slog.Int("attempts", attempts),
+			slog.Any("error", cause),
+		)
+		return d.outboxRepo.MarkFailed(ctx, entry.Event.ID, attempts, nil, cause.Error())
+	}
+
+	next := d.now().Add(backoffDelay(d.opts.RetryBaseDelay, d.opts.RetryMaxDelay, attempts))
+	slog.WarnContext(ctx, "error al entregar el evento de dominio",
+		slog.String("event_id", entry.Event.ID),
+		slog.String("event_type", string(entry.Event.Type)),
+		slog.Int("attempts", attempts),
+		slog.Time("next_attempt_at", next),
+		slog.Any("error", cause),
+	)
+	return d.outboxRepo.MarkFailed(ctx, entry.Event.ID, attempts, &next, cause.Error())
+}
+
+// subscribers retorna los handlers que reciben el tipo de evento
+func (h *eventHandlers) subscribers(eventType events.Type) []events.Handler {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	handlers := make([]events.Handler, 0, len(h.all)+len(h.handlers[eventType]))
+	handlers = append(handlers, h.all...)
+	return append(handlers, h.handlers[eventType]...)
+}
+
+// safeHandle ejecuta el handler convirtiendo un panic en error para no detener el despachador
+func safeHandle(ctx context.Context, handler events.Handler, event events.Event) (err error) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			err = fmt.Errorf("panic en el handler del evento %s: %v", event.Type, recovered)
+		}
+	}()
+	return handler.Handle(ctx, event)
+}
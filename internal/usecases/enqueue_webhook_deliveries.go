@@ -0,0 +1,74 @@
+package usecases
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"go-crime_map_backend/internal/domain/entities"
+	"go-crime_map_backend/internal/domain/events"
+	"go-crime_map_backend/internal/domain/repositories"
+)
+
+// WebhookFanout es el handler de eventos de dominio que encola una entrega por cada
+// suscripción interesada en el evento. El envío lo realiza DeliverWebhooksUseCase
+type WebhookFanout struct {
+	webhookRepo repositories.WebhookRepository
+	now         func() time.Time
+}
+
+// NewWebhookFanout crea el handler que encola las entregas de webhooks
+func NewWebhookFanout(repo repositories.WebhookRepository) *WebhookFanout {
+	return &WebhookFanout{
+		webhookRepo: repo,
+		now:         time.Now,
+	}
+}
+
+// Handle encola las entregas del evento. Es idempotente: el repositorio ignora las
+// entregas que ya existen para el mismo evento y suscripción
+func (f *WebhookFanout) Handle(ctx context.Context, event events.Event) error {
+	subscriptions, err := f.webhookRepo.ListSubscriptions(ctx)
+	if err != nil {
+		return err
+	}
+	if len(subscriptions) == 0 {
+		return nil
+	}
+
+	// Todos los eventos de delitos incluyen el delito en el payload
+	var payload struct {
+		Crime *entities.Crime `json:"crime"`
+	}
+	if err := event.Decode(&payload); err != nil {
+		return err
+	}
+	body, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("error al serializar el evento %s: %w", event.Type, err)
+	}
+
+	now := f.now()
+	var deliveries []*entities.WebhookDelivery
+	for _, subscription := range subscriptions {
+		if !subscription.Matches(string(event.Type), payload.Crime) {
+			continue
+		}
+		nextAttemptAt := now
+		deliveries = append(deliveries, &entities.WebhookDelivery{
+			ID:             generateID(),
+			SubscriptionID: subscription.ID,
+			EventID:        event.ID,
+			EventType:      string(event.Type),
+			Payload:        body,
+			Status:         entities.WebhookDeliveryPending,
+			NextAttemptAt:  &nextAttemptAt,
+			CreatedAt:      now,
+		})
+	}
+	if len(deliveries) == 0 {
+		return nil
+	}
+	return f.webhookRepo.EnqueueDeliveries(ctx, deliveries)
+}
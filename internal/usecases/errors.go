@@ -0,0 +1,20 @@
+package usecases
+
+import "errors"
+
+var (
+	// ErrCrimeNotFound se retorna cuando el delito no existe o no es visible para el actor
+	ErrCrimeNotFound = errors.New("delito no encontrado")
+
+	// ErrForbidden se retorna cuando el actor no tiene permisos para la operación
+	ErrForbidden = errors.New("no tiene permisos para realizar esta operación")
+
+	// ErrInvalidStatus se retorna cuando el estado indicado no existe
+	ErrInvalidStatus = errors.New("estado de delito inválido")
+
+	// ErrNotPending se retorna cuando el delito ya no está pendiente de moderación
+	ErrNotPending = errors.New("el delito no está pendiente de moderación")
+
+	// ErrClaimNotHeld se retorna cuando el moderador no tiene reservado el delito
+	ErrClaimNotHeld = errors.New("el delito no está reservado por el moderador")
+)
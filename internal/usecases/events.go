@@ -0,0 +1,22 @@
+package usecases
+
+import (
+	"context"
+	"time"
+
+	"go-crime_map_backend/internal/domain/entities"
+	"go-crime_map_backend/internal/domain/events"
+	"go-crime_map_backend/pkg/requestid"
+)
+
+// raise crea un evento de dominio con el actor y la solicitud del contexto y lo agrega
+// al contexto para que el repositorio lo guarde en el outbox junto con la escritura
+func raise(ctx context.Context, eventType events.Type, aggregateID string, payload any) (context.Context, error) {
+	event, err := events.New(generateID(), eventType, aggregateID, payload, time.Now())
+	if err != nil {
+		return ctx, err
+	}
+	event.ActorID = entities.ActorFromContext(ctx).ID
+	event.RequestID = requestid.FromContext(ctx)
+	return events.WithEvents(ctx, event), nil
+}
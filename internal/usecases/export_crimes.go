@@ -0,0 +1,243 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"go-crime_map_backend/internal/domain/entities"
+	"go-crime_map_backend/internal/domain/repositories"
+	"go-crime_map_backend/pkg/gpx"
+	"go-crime_map_backend/pkg/kml"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	// ExportFormatKML exporta los delitos como placemarks KML para Google Earth
+	ExportFormatKML = "kml"
+
+	// ExportFormatGPX exporta los delitos como waypoints GPX para navegadores GPS
+	ExportFormatGPX = "gpx"
+
+	// exportPageSize es la cantidad de delitos que se leen y escriben por vez
+	exportPageSize = 500
+
+	// exportIcon es el ícono de los placemarks KML, blanco para teñirlo con el color del tipo
+	exportIcon = "https://maps.google.com/mapfiles/kml/paddle/wht-blank.png"
+
+	// exportDefaultStyle es el estilo KML de los tipos sin color asignado
+	exportDefaultStyle = "crime"
+)
+
+// ErrInvalidExportFormat se retorna cuando el formato de exportación no existe
+var ErrInvalidExportFormat = errors.New("el formato debe ser kml o gpx")
+
+// exportTypeColors asigna el color de cada tipo de delito en KML, en formato aabbggrr
+var exportTypeColors = map[string]string{
+	"ROBO":         "ff0000ff", // Rojo
+	"HURTO":        "ff0080ff", // Naranja
+	"VANDALISMO":   "ff00ffff", // Amarillo
+	"AGRESION":     "ff800080", // Violeta
+	"FRAUDE":       "ffff0000", // Azul
+	"TRAFICO":      "ff008000", // Verde
+	"ACOSO":        "ffff00ff", // Magenta
+	"VIOLENCIA":    "ff000080", // Bordó
+	"ALLANAMIENTO": "ff808080", // Gris
+	"ESTAFA":       "ffffff00", // Cian
+}
+
+// ExportCrimesInput representa los criterios y el formato de una exportación de delitos
+type ExportCrimesInput struct {
+	StatsCriteria
+	Format string // kml o gpx
+}
+
+// CrimeExport es una exportación validada, lista para escribirse
+type CrimeExport struct {
+	Format      string
+	ContentType string
+	Filename    string // Nombre sugerido del archivo, con el período exportado
+	statsRepo   repositories.StatsRepository
+	filter      repositories.StatsFilter
+}
+
+// ExportCrimesUseCase maneja la lógica de negocio de la exportación de delitos para los
+// equipos de calle
+type ExportCrimesUseCase struct {
+	statsRepo repositories.StatsRepository
+}
+
+// NewExportCrimesUseCase crea una nueva instancia del caso de uso
+func NewExportCrimesUseCase(repo repositories.StatsRepository) *ExportCrimesUseCase {
+	return &ExportCrimesUseCase{statsRepo: repo}
+}
+
+// Execute valida el formato y los criterios de la exportación. Los delitos se leen al
+// escribirla con CrimeExport.WriteTo, para poder responder los errores de validación antes
+// de empezar a enviar el archivo. Quienes no son moderadores solo exportan los delitos públicos
+func (uc *ExportCrimesUseCase) Execute(ctx context.Context, input ExportCrimesInput) (_ *CrimeExport, err error) {
+	_, span := tracer.Start(ctx, "ExportCrimesUseCase.Execute",
+		trace.WithAttributes(attribute.String("export.format", input.Format)))
+	defer func() { endSpan(span, err) }()
+
+	export := &CrimeExport{Format: strings.ToLower(strings.TrimSpace(input.Format)), statsRepo: uc.statsRepo}
+	switch export.Format {
+	case ExportFormatKML:
+		export.ContentType = "application/vnd.google-earth.kml+xml"
+	case ExportFormatGPX:
+		export.ContentType = "application/gpx+xml"
+	default:
+		return nil, ErrInvalidExportFormat
+	}
+	export.filter, _, err = input.resolve()
+	if err != nil {
+		return nil, err
+	}
+	export.Filename = fmt.Sprintf("delitos-%s-%s.%s", export.filter.From.UTC().Format("20060102"),
+		export.filter.To.UTC().Format("20060102"), export.Format)
+	return export, nil
+}
+
+// WriteTo escribe el documento leyendo los delitos por páginas, de los más antiguos a los más
+// recientes, y envía cada página al destino si este admite Flush. Retorna la cantidad de
+// delitos escritos; si falla a mitad de camino el documento queda incompleto
+func (e *CrimeExport) WriteTo(ctx context.Context, w io.Writer) (written int, err error) {
+	ctx, span := tracer.Start(ctx, "CrimeExport.WriteTo", trace.WithAttributes(attribute.String("export.format", e.Format)))
+	defer func() {
+		span.SetAttributes(attribute.Int("export.crimes", written))
+		endSpan(span, err)
+	}()
+
+	encoder, err := e.newEncoder(w)
+	if err != nil {
+		return 0, err
+	}
+	flusher, _ := w.(interface{ Flush() })
+
+	var after *repositories.CrimeCursor
+	for {
+		crimes, err := e.statsRepo.ListPage(ctx, e.filter, after, exportPageSize)
+		if err != nil {
+			return written, err
+		}
+		for _, crime := range crimes {
+			if err := encoder.write(crime); err != nil {
+				return written, err
+			}
+			written++
+		}
+		if err := encoder.Flush(); err != nil {
+			return written, err
+		}
+		if flusher != nil {
+			flusher.Flush()
+		}
+		if len(crimes) < exportPageSize {
+			break
+		}
+		last := crimes[len(crimes)-1]
+		after = &repositories.CrimeCursor{Date: last.Date, ID: last.ID}
+	}
+	return written, encoder.Close()
+}
+
+// crimeEncoder escribe los delitos en un formato de exportación
+type crimeEncoder interface {
+	write(crime *entities.Crime) error
+	Flush() error
+	Close() error
+}
+
+// newEncoder escribe el encabezado del documento y retorna el codificador de sus delitos
+func (e *CrimeExport) newEncoder(w io.Writer) (crimeEncoder, error) {
+	name := fmt.Sprintf("Delitos del %s al %s", e.filter.From.UTC().Format("2006-01-02"),
+		e.filter.To.UTC().Format("2006-01-02"))
+	if e.Format == ExportFormatGPX {
+		writer, err := gpx.NewWriter(w, "go-crime_map_backend", name)
+		return gpxEncoder{writer}, err
+	}
+
+	types := make([]string, 0, len(exportTypeColors))
+	for crimeType := range exportTypeColors {
+		types = append(types, crimeType)
+	}
+	sort.Strings(types)
+	styles := []kml.Style{{ID: exportDefaultStyle, Color: "ffffffff", Icon: exportIcon}}
+	for _, crimeType := range types {
+		styles = append(styles, kml.Style{ID: kmlStyleID(crimeType), Color: exportTypeColors[crimeType], Icon: exportIcon})
+	}
+	writer, err := kml.NewWriter(w, name, styles)
+	return kmlEncoder{writer}, err
+}
+
+// kmlStyleID retorna el estilo KML del tipo de delito
+func kmlStyleID(crimeType string) string {
+	if _, found := exportTypeColors[crimeType]; found {
+		return "type-" + crimeType
+	}
+	return exportDefaultStyle
+}
+
+// exportName es el nombre corto de un delito exportado, apto para las pantallas de los GPS
+func exportName(crime *entities.Crime) string {
+	return crime.Type + " " + crime.Date.UTC().Format("2006-01-02 15:04")
+}
+
+// kmlEncoder escribe cada delito como un placemark con el estilo de su tipo y su fecha para
+// el control de tiempo de Google Earth
+type kmlEncoder struct {
+	*kml.Writer
+}
+
+func (e kmlEncoder) write(crime *entities.Crime) error {
+	return e.WritePlacemark(kml.Placemark{
+		Name:        exportName(crime),
+		Description: crime.Description,
+		StyleID:     kmlStyleID(crime.Type),
+		When:        crime.Date,
+		Latitude:    crime.Location.Latitude,
+		Longitude:   crime.Location.Longitude,
+		Data: []kml.Data{
+			{Name: "id", Value: crime.ID},
+			{Name: "type", Value: crime.Type},
+			{Name: "status", Value: string(crime.Status)},
+			{Name: "severity", Value: string(crime.Severity)},
+			{Name: "weapon", Value: string(crime.Weapon)},
+			{Name: "violent", Value: strconv.FormatBool(crime.Violent)},
+			{Name: "victim_count", Value: strconv.Itoa(crime.VictimCount)},
+			{Name: "address", Value: crime.Location.Address},
+			{Name: "zone_id", Value: crime.ZoneID},
+		},
+	})
+}
+
+// gpxEncoder escribe cada delito como un waypoint; los delitos graves usan una bandera roja
+type gpxEncoder struct {
+	*gpx.Writer
+}
+
+func (e gpxEncoder) write(crime *entities.Crime) error {
+	symbol := "Flag, Blue"
+	if crime.Severity.Weight() >= entities.SeverityHigh.Weight() {
+		symbol = "Flag, Red"
+	}
+	description := crime.Description
+	if crime.Location.Address != "" {
+		description += " (" + crime.Location.Address + ")"
+	}
+	return e.WriteWaypoint(gpx.Waypoint{
+		Latitude:    crime.Location.Latitude,
+		Longitude:   crime.Location.Longitude,
+		Time:        crime.Date,
+		Name:        exportName(crime),
+		Description: description,
+		Symbol:      symbol,
+		Type:        crime.Type,
+	})
+}
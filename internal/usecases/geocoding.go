@@ -0,0 +1,143 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+
+	"go-crime_map_backend/internal/domain/entities"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+var (
+	// ErrAddressNotFound se retorna cuando el geocodificador no conoce la dirección o no hay
+	// ninguna dirección cerca de las coordenadas
+	ErrAddressNotFound = errors.New("la dirección no se encontró en el nomenclador")
+
+	// ErrMissingCoordinates se retorna cuando el delito no tiene coordenadas ni una dirección
+	// que un geocodificador pueda ubicar
+	ErrMissingCoordinates = errors.New("las coordenadas son requeridas")
+
+	// ErrMissingAddress se retorna cuando el delito no tiene dirección y no hay un
+	// geocodificador que la complete
+	ErrMissingAddress = errors.New("la dirección es requerida")
+
+	// defaultMismatchMeters es la distancia por defecto a partir de la cual la dirección no
+	// coincide con las coordenadas
+	defaultMismatchMeters = 300.0
+)
+
+// Geocoder traduce direcciones a coordenadas y coordenadas a direcciones
+type Geocoder interface {
+	// Geocode retorna las coordenadas y la dirección normalizada; ErrAddressNotFound si no
+	// conoce la dirección
+	Geocode(ctx context.Context, address string) (entities.Location, error)
+
+	// Reverse retorna la dirección más cercana a las coordenadas con su ubicación;
+	// ErrAddressNotFound si no hay ninguna cerca
+	Reverse(ctx context.Context, latitude, longitude float64) (entities.Location, error)
+}
+
+// LocationResolver completa la ubicación de los delitos con un geocodificador: las
+// coordenadas de los que solo tienen dirección, la dirección normalizada de los que solo
+// tienen coordenadas, y marca los que informan una dirección lejos de sus coordenadas
+type LocationResolver struct {
+	geocoder       Geocoder
+	mismatchMeters float64
+}
+
+// NewLocationResolver crea un resolutor sobre el geocodificador. Una dirección a más de
+// mismatchMeters metros de las coordenadas no coincide con ellas; sin distancia se usan 300 m
+func NewLocationResolver(geocoder Geocoder, mismatchMeters float64) *LocationResolver {
+	if mismatchMeters <= 0 {
+		mismatchMeters = defaultMismatchMeters
+	}
+	return &LocationResolver{
+		geocoder:       geocoder,
+		mismatchMeters: mismatchMeters,
+	}
+}
+
+// Resolve completa la ubicación y retorna si la dirección no coincide con las coordenadas.
+// Las coordenadas en 0, 0 se consideran ausentes; sin coordenadas ni dirección retorna
+// ErrMissingCoordinates. Si ambas están presentes se conservan tal como se informaron,
+// salvo que la dirección coincida y se reemplace por la normalizada
+func (r *LocationResolver) Resolve(ctx context.Context, location Location) (_ Location, mismatch bool, err error) {
+	ctx, span := tracer.Start(ctx, "LocationResolver.Resolve")
+	defer func() { endSpan(span, err) }()
+
+	address := strings.TrimSpace(location.Address)
+	switch {
+	case !location.hasCoordinates() && address == "":
+		return location, false, ErrMissingCoordinates
+
+	case !location.hasCoordinates():
+		found, err := r.geocoder.Geocode(ctx, address)
+		if err != nil {
+			return location, false, err
+		}
+		span.SetAttributes(attribute.String("geocoding.result", "geocoded"))
+		return Location{Latitude: found.Latitude, Longitude: found.Longitude, Address: found.Address}, false, nil
+
+	case address == "":
+		found, err := r.geocoder.Reverse(ctx, location.Latitude, location.Longitude)
+		if errors.Is(err, ErrAddressNotFound) {
+			span.SetAttributes(attribute.String("geocoding.result", "not_found"))
+			return location, false, nil
+		}
+		if err != nil {
+			return location, false, err
+		}
+		span.SetAttributes(attribute.String("geocoding.result", "reversed"))
+		location.Address = found.Address
+		return location, false, nil
+	}
+
+	// Con ambos datos se verifica que la dirección esté cerca de las coordenadas
+	found, err := r.geocoder.Geocode(ctx, address)
+	if errors.Is(err, ErrAddressNotFound) {
+		span.SetAttributes(attribute.String("geocoding.result", "not_found"))
+		return location, false, nil
+	}
+	if err != nil {
+		return location, false, err
+	}
+	distance := entities.DistanceMeters(
+		entities.Coordinate{Latitude: location.Latitude, Longitude: location.Longitude},
+		entities.Coordinate{Latitude: found.Latitude, Longitude: found.Longitude},
+	)
+	span.SetAttributes(attribute.Float64("geocoding.distance_meters", distance))
+	if distance > r.mismatchMeters {
+		span.SetAttributes(attribute.String("geocoding.result", "mismatch"))
+		slog.WarnContext(ctx, "la dirección no coincide con las coordenadas",
+			slog.String("address", address),
+			slog.Float64("distance_meters", distance),
+		)
+		return location, true, nil
+	}
+	span.SetAttributes(attribute.String("geocoding.result", "matched"))
+	location.Address = found.Address
+	return location, false, nil
+}
+
+// resolveLocation completa la ubicación con el resolutor si existe. Sin resolutor no hay
+// quien complete la parte que falta, así que se requieren las coordenadas y la dirección
+func resolveLocation(ctx context.Context, resolver *LocationResolver, location Location) (Location, bool, error) {
+	if resolver != nil {
+		return resolver.Resolve(ctx, location)
+	}
+	if !location.hasCoordinates() {
+		return location, false, ErrMissingCoordinates
+	}
+	if strings.TrimSpace(location.Address) == "" {
+		return location, false, ErrMissingAddress
+	}
+	return location, false, nil
+}
+
+// hasCoordinates indica si la ubicación tiene coordenadas; 0, 0 se considera ausente
+func (l Location) hasCoordinates() bool {
+	return l.Latitude != 0 || l.Longitude != 0
+}
@@ -0,0 +1,32 @@
+package usecases
+
+import (
+	"context"
+
+	"go-crime_map_backend/internal/domain/entities"
+	"go-crime_map_backend/internal/domain/repositories"
+)
+
+// GetCrimeUseCase maneja la lógica de negocio para obtener un delito
+type GetCrimeUseCase struct {
+	crimeRepo repositories.CrimeRepository
+}
+
+// NewGetCrimeUseCase crea una nueva instancia del caso de uso
+func NewGetCrimeUseCase(repo repositories.CrimeRepository) *GetCrimeUseCase {
+	return &GetCrimeUseCase{
+		crimeRepo: repo,
+	}
+}
+
+// Execute obtiene un delito; los delitos no públicos solo son visibles para moderadores
+func (uc *GetCrimeUseCase) Execute(ctx context.Context, id string, actor entities.Actor) (*entities.Crime, error) {
+	crime, err := uc.crimeRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if crime == nil || (!crime.Status.IsPublic() && !actor.IsStaff()) {
+		return nil, ErrCrimeNotFound
+	}
+	return crime, nil
+}
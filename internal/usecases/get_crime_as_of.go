@@ -0,0 +1,38 @@
+package usecases
+
+import (
+	"context"
+	"time"
+
+	"go-crime_map_backend/internal/domain/entities"
+	"go-crime_map_backend/internal/domain/repositories"
+)
+
+// GetCrimeAsOfUseCase maneja la lógica de negocio para ver un delito tal como estaba en un instante
+type GetCrimeAsOfUseCase struct {
+	revisionRepo repositories.RevisionRepository
+}
+
+// NewGetCrimeAsOfUseCase crea una nueva instancia del caso de uso
+func NewGetCrimeAsOfUseCase(repo repositories.RevisionRepository) *GetCrimeAsOfUseCase {
+	return &GetCrimeAsOfUseCase{
+		revisionRepo: repo,
+	}
+}
+
+// Execute reconstruye el delito a partir de la última revisión registrada hasta at.
+// Aplica las mismas reglas de visibilidad que GetCrimeUseCase sobre el estado de ese momento.
+func (uc *GetCrimeAsOfUseCase) Execute(ctx context.Context, id string, at time.Time, actor entities.Actor) (*entities.Crime, error) {
+	revision, err := uc.revisionRepo.GetRevisionAt(ctx, id, at)
+	if err != nil {
+		return nil, err
+	}
+	// Sin revisión el delito aún no existía; sin snapshot ya había sido eliminado
+	if revision == nil || revision.Snapshot == nil {
+		return nil, ErrCrimeNotFound
+	}
+	if !revision.Snapshot.Status.IsPublic() && !actor.IsStaff() {
+		return nil, ErrCrimeNotFound
+	}
+	return revision.Snapshot, nil
+}
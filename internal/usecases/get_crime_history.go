@@ -0,0 +1,38 @@
+package usecases
+
+import (
+	"context"
+
+	"go-crime_map_backend/internal/domain/entities"
+	"go-crime_map_backend/internal/domain/repositories"
+)
+
+// GetCrimeHistoryUseCase maneja la lógica de negocio para consultar las revisiones de un delito
+type GetCrimeHistoryUseCase struct {
+	revisionRepo repositories.RevisionRepository
+}
+
+// NewGetCrimeHistoryUseCase crea una nueva instancia del caso de uso
+func NewGetCrimeHistoryUseCase(repo repositories.RevisionRepository) *GetCrimeHistoryUseCase {
+	return &GetCrimeHistoryUseCase{
+		revisionRepo: repo,
+	}
+}
+
+// Execute obtiene todas las revisiones de un delito, incluso si fue eliminado.
+// Solo disponible para moderadores, ya que expone a los autores de cada cambio.
+func (uc *GetCrimeHistoryUseCase) Execute(ctx context.Context, crimeID string, actor entities.Actor) ([]*entities.CrimeRevision, error) {
+	if !actor.IsStaff() {
+		return nil, ErrForbidden
+	}
+
+	revisions, err := uc.revisionRepo.GetRevisions(ctx, crimeID)
+	if err != nil {
+		return nil, err
+	}
+	if len(revisions) == 0 {
+		return nil, ErrCrimeNotFound
+	}
+
+	return revisions, nil
+}
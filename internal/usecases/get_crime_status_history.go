@@ -0,0 +1,37 @@
+package usecases
+
+import (
+	"context"
+
+	"go-crime_map_backend/internal/domain/entities"
+	"go-crime_map_backend/internal/domain/repositories"
+)
+
+// GetCrimeStatusHistoryUseCase maneja la lógica de negocio para consultar el historial de estados
+type GetCrimeStatusHistoryUseCase struct {
+	crimeRepo repositories.CrimeRepository
+}
+
+// NewGetCrimeStatusHistoryUseCase crea una nueva instancia del caso de uso
+func NewGetCrimeStatusHistoryUseCase(repo repositories.CrimeRepository) *GetCrimeStatusHistoryUseCase {
+	return &GetCrimeStatusHistoryUseCase{
+		crimeRepo: repo,
+	}
+}
+
+// Execute obtiene el historial de estados de un delito; solo disponible para moderadores
+func (uc *GetCrimeStatusHistoryUseCase) Execute(ctx context.Context, crimeID string, actor entities.Actor) ([]*entities.CrimeStatusChange, error) {
+	if !actor.IsStaff() {
+		return nil, ErrForbidden
+	}
+
+	crime, err := uc.crimeRepo.GetByID(ctx, crimeID)
+	if err != nil {
+		return nil, err
+	}
+	if crime == nil {
+		return nil, ErrCrimeNotFound
+	}
+
+	return uc.crimeRepo.GetStatusHistory(ctx, crimeID)
+}
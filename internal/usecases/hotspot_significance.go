@@ -0,0 +1,355 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"go-crime_map_backend/internal/domain/entities"
+	"go-crime_map_backend/internal/domain/repositories"
+	"go-crime_map_backend/pkg/geojson"
+	"go-crime_map_backend/pkg/spatial"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	// AggregationGrid agrega los delitos en una grilla de celdas cuadradas
+	AggregationGrid = "grid"
+
+	// AggregationZone agrega los delitos en las zonas administrativas de un tipo
+	AggregationZone = "zone"
+
+	// maxSignificanceCells es la cantidad máxima de celdas de la grilla del análisis Gi*
+	maxSignificanceCells = 250000
+)
+
+var (
+	// ErrInvalidAggregation se retorna cuando la agregación no existe o le falta el tipo de zona
+	ErrInvalidAggregation = errors.New("la agregación debe ser grid o zone; zone requiere zone_kind")
+
+	// ErrInvalidSignificanceParameters se retorna cuando los parámetros del análisis Gi* están fuera de rango
+	ErrInvalidSignificanceParameters = errors.New("cell_meters debe estar entre 50 y 5000, distance_meters entre 0 y 50000 y la grilla no puede superar las 250000 celdas")
+
+	// significanceLevels son los p-valores máximos de cada nivel de confianza, de 99 % a 90 %
+	significanceLevels = []float64{0.01, 0.05, 0.10}
+)
+
+// HotspotSignificanceInput representa los criterios y parámetros del análisis Gi*
+type HotspotSignificanceInput struct {
+	StatsCriteria
+	Aggregation     string  // grid (por defecto) o zone
+	CellMeters      float64 // grid: lado de las celdas en metros, 250 por defecto
+	ZoneKind        string  // zone: tipo de las zonas analizadas, obligatorio
+	DistanceMeters  float64 // Distancia máxima entre los centros de dos vecinos; 0 usa la distancia por defecto
+	SignificantOnly bool    // Retorna solo las áreas con al menos 90 % de confianza
+}
+
+// HotspotSignificanceResult es un GeoJSON FeatureCollection con una feature por área y los
+// parámetros del análisis como miembros adicionales
+type HotspotSignificanceResult struct {
+	Type           string            `json:"type"` // Siempre FeatureCollection
+	Aggregation    string            `json:"aggregation"`
+	CellMeters     float64           `json:"cell_meters,omitempty"`
+	ZoneKind       string            `json:"zone_kind,omitempty"`
+	DistanceMeters float64           `json:"distance_meters"` // Distancia de vecindad usada
+	From           time.Time         `json:"from"`
+	To             time.Time         `json:"to"`
+	CrimeCount     int               `json:"crime_count"` // Delitos agregados en las áreas
+	Features       []geojson.Feature `json:"features"`
+}
+
+// significanceArea es un área agregada del análisis: una celda o una zona
+type significanceArea struct {
+	id         string
+	center     entities.Coordinate
+	geometry   geojson.Geometry
+	properties map[string]any
+	count      int
+}
+
+// GetHotspotSignificanceUseCase maneja la lógica de negocio del análisis de puntos calientes
+// y fríos estadísticamente significativos
+type GetHotspotSignificanceUseCase struct {
+	statsRepo repositories.StatsRepository
+	zoneRepo  repositories.ZoneRepository
+	maxCrimes int
+}
+
+// NewGetHotspotSignificanceUseCase crea una nueva instancia del caso de uso. maxCrimes limita
+// los delitos agregados en la grilla; 0 usa 50000
+func NewGetHotspotSignificanceUseCase(statsRepo repositories.StatsRepository, zoneRepo repositories.ZoneRepository, maxCrimes int) *GetHotspotSignificanceUseCase {
+	if maxCrimes <= 0 {
+		maxCrimes = defaultHotspotMaxCrimes
+	}
+	return &GetHotspotSignificanceUseCase{
+		statsRepo: statsRepo,
+		zoneRepo:  zoneRepo,
+		maxCrimes: maxCrimes,
+	}
+}
+
+// Execute agrega los delitos del período por celda o por zona y calcula el estadístico Gi*
+// de Getis-Ord de cada área con pesos binarios por distancia entre centros. Cada feature
+// incluye la cantidad de delitos, el z-score, el p-valor bilateral y la confianza, de -3
+// (punto frío al 99 %) a 3 (punto caliente al 99 %). En la grilla se omiten las celdas sin
+// delitos que no son significativas
+func (uc *GetHotspotSignificanceUseCase) Execute(ctx context.Context, input HotspotSignificanceInput) (_ *HotspotSignificanceResult, err error) {
+	ctx, span := tracer.Start(ctx, "GetHotspotSignificanceUseCase.Execute",
+		trace.WithAttributes(attribute.String("hotspots.aggregation", input.Aggregation)))
+	defer func() { endSpan(span, err) }()
+
+	result := &HotspotSignificanceResult{
+		Type:        "FeatureCollection",
+		Aggregation: strings.ToLower(strings.TrimSpace(input.Aggregation)),
+		ZoneKind:    strings.ToLower(strings.TrimSpace(input.ZoneKind)),
+	}
+	switch result.Aggregation {
+	case "", AggregationGrid:
+		result.Aggregation, result.ZoneKind = AggregationGrid, ""
+		result.CellMeters = input.CellMeters
+		if result.CellMeters == 0 {
+			result.CellMeters = 250
+		}
+		if result.CellMeters < 50 || result.CellMeters > 5000 {
+			return nil, ErrInvalidSignificanceParameters
+		}
+	case AggregationZone:
+		if result.ZoneKind == "" {
+			return nil, ErrInvalidAggregation
+		}
+	default:
+		return nil, ErrInvalidAggregation
+	}
+	if input.DistanceMeters < 0 || input.DistanceMeters > 50000 {
+		return nil, ErrInvalidSignificanceParameters
+	}
+	filter, _, err := input.resolve()
+	if err != nil {
+		return nil, err
+	}
+	result.From, result.To = filter.From.UTC(), filter.To.UTC()
+
+	var (
+		areas  []significanceArea
+		scores []float64
+	)
+	if result.Aggregation == AggregationGrid {
+		var cols int
+		if areas, cols, err = uc.gridAreas(ctx, filter, result.CellMeters); err != nil {
+			return nil, err
+		}
+		// Por defecto son vecinas las celdas que comparten un lado o una esquina
+		result.DistanceMeters = result.CellMeters * 1.5
+		if input.DistanceMeters > 0 {
+			result.DistanceMeters = input.DistanceMeters
+		}
+		// Con distancias grandes cada celda tiene miles de vecinas, por lo que no se listan:
+		// las sumas de cada vecindario salen de sumas acumuladas de la grilla
+		values := areaValues(areas)
+		sums, weights := spatial.GridDiskSums(values, cols, result.DistanceMeters/result.CellMeters)
+		scores = spatial.GiStarSums(values, sums, weights)
+	} else {
+		if areas, err = uc.zoneAreas(ctx, filter, result.ZoneKind); err != nil {
+			return nil, err
+		}
+		result.DistanceMeters = nearestNeighborDistance(areas)
+		if input.DistanceMeters > 0 {
+			result.DistanceMeters = input.DistanceMeters
+		}
+		scores = spatial.GiStar(areaValues(areas), distanceNeighbors(areas, result.DistanceMeters))
+	}
+	for _, area := range areas {
+		result.CrimeCount += area.count
+	}
+
+	result.Features = []geojson.Feature{}
+	for i, area := range areas {
+		confidence := significanceConfidence(scores[i])
+		if (input.SignificantOnly || result.Aggregation == AggregationGrid && area.count == 0) && confidence == 0 {
+			continue
+		}
+		feature := geojson.NewFeature(area.geometry, area.properties)
+		feature.ID = area.id
+		feature.Properties["count"] = area.count
+		feature.Properties["z_score"] = scores[i]
+		feature.Properties["p_value"] = spatial.PValue(scores[i])
+		feature.Properties["confidence"] = confidence
+		feature.Properties["class"] = significanceClass(confidence)
+		result.Features = append(result.Features, feature)
+	}
+	return result, nil
+}
+
+// gridAreas agrega los delitos en una grilla de celdas de lado cellMeters que cubre el
+// rectángulo del filtro o, sin él, los delitos. Retorna las celdas fila por fila desde el sur
+// y la cantidad de columnas
+func (uc *GetHotspotSignificanceUseCase) gridAreas(ctx context.Context, filter repositories.StatsFilter, cellMeters float64) ([]significanceArea, int, error) {
+	points, err := uc.statsRepo.ListPoints(ctx, filter, uc.maxCrimes+1)
+	if err != nil {
+		return nil, 0, err
+	}
+	if len(points) > uc.maxCrimes {
+		return nil, 0, ErrTooManyHotspotCrimes
+	}
+
+	var box entities.BoundingBox
+	switch {
+	case filter.BoundingBox != nil:
+		box = *filter.BoundingBox
+	case len(points) > 0:
+		box = entities.BoundingBox{MinLatitude: 90, MinLongitude: 180, MaxLatitude: -90, MaxLongitude: -180}
+		for _, point := range points {
+			box.MinLatitude = math.Min(box.MinLatitude, point.Location.Latitude)
+			box.MinLongitude = math.Min(box.MinLongitude, point.Location.Longitude)
+			box.MaxLatitude = math.Max(box.MaxLatitude, point.Location.Latitude)
+			box.MaxLongitude = math.Max(box.MaxLongitude, point.Location.Longitude)
+		}
+	default:
+		return nil, 0, nil
+	}
+
+	projection := newProjectionAt(entities.Coordinate{
+		Latitude:  (box.MinLatitude + box.MaxLatitude) / 2,
+		Longitude: (box.MinLongitude + box.MaxLongitude) / 2,
+	})
+	origin := projection.project(entities.Coordinate{Latitude: box.MinLatitude, Longitude: box.MinLongitude})
+	corner := projection.project(entities.Coordinate{Latitude: box.MaxLatitude, Longitude: box.MaxLongitude})
+	cols := max(1, int(math.Ceil((corner.X-origin.X)/cellMeters)))
+	rows := max(1, int(math.Ceil((corner.Y-origin.Y)/cellMeters)))
+	if cols*rows > maxSignificanceCells {
+		return nil, 0, ErrInvalidSignificanceParameters
+	}
+
+	areas := make([]significanceArea, cols*rows)
+	for row := 0; row < rows; row++ {
+		for col := 0; col < cols; col++ {
+			x, y := origin.X+float64(col)*cellMeters, origin.Y+float64(row)*cellMeters
+			ring := []geojson.Position{}
+			for _, vertex := range []spatial.Point{{X: x, Y: y}, {X: x + cellMeters, Y: y}, {X: x + cellMeters, Y: y + cellMeters}, {X: x, Y: y + cellMeters}, {X: x, Y: y}} {
+				c := projection.unproject(vertex)
+				ring = append(ring, geojson.Position{c.Longitude, c.Latitude})
+			}
+			areas[row*cols+col] = significanceArea{
+				id:         fmt.Sprintf("%d:%d", col, row),
+				center:     projection.unproject(spatial.Point{X: x + cellMeters/2, Y: y + cellMeters/2}),
+				geometry:   geojson.NewPolygon([][]geojson.Position{ring}),
+				properties: map[string]any{"col": col, "row": row},
+			}
+		}
+	}
+	for _, point := range points {
+		p := projection.project(point.Location)
+		// Los delitos sobre el borde norte o este quedan en la última celda
+		col := min(cols-1, max(0, int((p.X-origin.X)/cellMeters)))
+		row := min(rows-1, max(0, int((p.Y-origin.Y)/cellMeters)))
+		areas[row*cols+col].count++
+	}
+	return areas, cols, nil
+}
+
+// zoneAreas agrega los delitos en las zonas del tipo indicado; cada delito se cuenta en la
+// zona que tiene asignada
+func (uc *GetHotspotSignificanceUseCase) zoneAreas(ctx context.Context, filter repositories.StatsFilter, kind string) ([]significanceArea, error) {
+	zones, err := uc.zoneRepo.ListZones(ctx, kind)
+	if err != nil || len(zones) == 0 {
+		return nil, err
+	}
+	filter.ZoneIDs = make([]string, len(zones))
+	for i, zone := range zones {
+		filter.ZoneIDs[i] = zone.ID
+	}
+	counts, err := uc.statsRepo.CountByZone(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	byZone := make(map[string]int, len(zones))
+	for _, count := range counts {
+		byZone[count.ZoneID] += count.Count
+	}
+
+	areas := make([]significanceArea, len(zones))
+	for i, zone := range zones {
+		areas[i] = significanceArea{
+			id:         zone.ID,
+			center:     zone.Geometry.Centroid(),
+			geometry:   zone.Geometry.GeoJSON(),
+			properties: map[string]any{"zone_id": zone.ID, "name": zone.Name, "kind": zone.Kind},
+			count:      byZone[zone.ID],
+		}
+	}
+	return areas, nil
+}
+
+// nearestNeighborDistance retorna la menor distancia que da al menos un vecino a cada área
+func nearestNeighborDistance(areas []significanceArea) float64 {
+	distance := 0.0
+	for i := range areas {
+		nearest := math.Inf(1)
+		for j := range areas {
+			if i != j {
+				nearest = math.Min(nearest, entities.DistanceMeters(areas[i].center, areas[j].center))
+			}
+		}
+		if !math.IsInf(nearest, 1) {
+			distance = math.Max(distance, nearest)
+		}
+	}
+	return math.Ceil(distance)
+}
+
+// distanceNeighbors retorna los vecinos de cada área, incluida ella misma, cuyos centros
+// están a distance metros o menos
+func distanceNeighbors(areas []significanceArea, distance float64) [][]int {
+	neighbors := make([][]int, len(areas))
+	for i := range areas {
+		for j := range areas {
+			if i == j || entities.DistanceMeters(areas[i].center, areas[j].center) <= distance {
+				neighbors[i] = append(neighbors[i], j)
+			}
+		}
+	}
+	return neighbors
+}
+
+// areaValues retorna la cantidad de delitos de cada área
+func areaValues(areas []significanceArea) []float64 {
+	values := make([]float64, len(areas))
+	for i, area := range areas {
+		values[i] = float64(area.count)
+	}
+	return values
+}
+
+// significanceConfidence retorna el nivel de confianza del z-score: 3, 2 o 1 para puntos
+// calientes al 99, 95 o 90 %, los mismos valores negativos para puntos fríos y 0 si no es
+// significativo
+func significanceConfidence(z float64) int {
+	p := spatial.PValue(z)
+	for i, level := range significanceLevels {
+		if p < level {
+			confidence := len(significanceLevels) - i
+			if z < 0 {
+				return -confidence
+			}
+			return confidence
+		}
+	}
+	return 0
+}
+
+// significanceClass describe el nivel de confianza como hot, cold o not_significant
+func significanceClass(confidence int) string {
+	switch {
+	case confidence > 0:
+		return "hot"
+	case confidence < 0:
+		return "cold"
+	default:
+		return "not_significant"
+	}
+}
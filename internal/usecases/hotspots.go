@@ -0,0 +1,408 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"go-crime_map_backend/internal/domain/entities"
+	"go-crime_map_backend/internal/domain/repositories"
+	"go-crime_map_backend/pkg/cache"
+	"go-crime_map_backend/pkg/spatial"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	// HotspotMethodDBSCAN agrupa los delitos con el algoritmo DBSCAN
+	HotspotMethodDBSCAN = "dbscan"
+
+	// HotspotMethodKDE detecta las zonas donde la densidad de kernel supera un umbral
+	HotspotMethodKDE = "kde"
+
+	// metersPerDegree es la longitud en metros de un grado de latitud
+	metersPerDegree = 6371008.8 * math.Pi / 180
+
+	// maxDensityCells es la cantidad máxima de celdas de la grilla de densidad; si la
+	// superficie analizada la excede se agrandan las celdas
+	maxDensityCells = 1000000
+
+	// defaultHotspotMaxCrimes es la cantidad de delitos analizados si no se configura otra
+	defaultHotspotMaxCrimes = 50000
+)
+
+var (
+	// ErrInvalidHotspotMethod se retorna cuando el método de análisis no existe
+	ErrInvalidHotspotMethod = errors.New("el método debe ser dbscan o kde")
+
+	// ErrInvalidHotspotParameters se retorna cuando los parámetros del análisis están fuera de rango
+	ErrInvalidHotspotParameters = errors.New("eps_meters debe estar entre 10 y 5000, min_points entre 2 y 1000, bandwidth_meters entre 25 y 5000 y threshold entre 0 y 1")
+
+	// ErrTooManyHotspotCrimes se retorna cuando los filtros incluyen más delitos de los que se analizan
+	ErrTooManyHotspotCrimes = errors.New("los filtros incluyen demasiados delitos para el análisis; acote el período, el área o los tipos")
+)
+
+// HotspotInput representa los criterios y parámetros de la detección de zonas calientes
+type HotspotInput struct {
+	StatsCriteria
+	Method          string  // dbscan (por defecto) o kde
+	EpsMeters       float64 // DBSCAN: distancia máxima entre vecinos, 200 por defecto
+	MinPoints       int     // DBSCAN: delitos necesarios para formar un núcleo, 5 por defecto
+	BandwidthMeters float64 // KDE: desviación del kernel gaussiano, 250 por defecto
+	Threshold       float64 // KDE: fracción de la densidad máxima que delimita las zonas, 0.5 por defecto
+}
+
+// HotspotResult representa las zonas calientes detectadas en un período
+type HotspotResult struct {
+	Method          string             `json:"method"`
+	EpsMeters       float64            `json:"eps_meters,omitempty"`
+	MinPoints       int                `json:"min_points,omitempty"`
+	BandwidthMeters float64            `json:"bandwidth_meters,omitempty"`
+	Threshold       float64            `json:"threshold,omitempty"`
+	From            time.Time          `json:"from"`
+	To              time.Time          `json:"to"`
+	CrimeCount      int                `json:"crime_count"` // Delitos analizados
+	Noise           int                `json:"noise"`       // Delitos que no pertenecen a ninguna zona caliente
+	GeneratedAt     time.Time          `json:"generated_at"`
+	Hotspots        []entities.Hotspot `json:"hotspots"` // De mayor a menor cantidad de delitos
+}
+
+// HotspotOptions representa la configuración de la detección de zonas calientes
+type HotspotOptions struct {
+	CacheTTL  time.Duration // Tiempo durante el que se reutiliza un análisis; 0 deshabilita la caché
+	CacheSize int           // Análisis conservados en la caché
+	MaxCrimes int           // Delitos analizados como máximo por consulta, 50000 por defecto
+}
+
+// DetectHotspotsUseCase maneja la lógica de negocio de la detección de zonas calientes
+type DetectHotspotsUseCase struct {
+	statsRepo repositories.StatsRepository
+	options   HotspotOptions
+	cache     *cache.LRU[*HotspotResult]
+	now       func() time.Time
+}
+
+// NewDetectHotspotsUseCase crea una nueva instancia del caso de uso
+func NewDetectHotspotsUseCase(repo repositories.StatsRepository, options HotspotOptions) *DetectHotspotsUseCase {
+	return NewDetectHotspotsUseCaseWithClock(repo, options, time.Now)
+}
+
+// NewDetectHotspotsUseCaseWithClock crea el caso de uso con un reloj propio, útil en pruebas
+func NewDetectHotspotsUseCaseWithClock(repo repositories.StatsRepository, options HotspotOptions, now func() time.Time) *DetectHotspotsUseCase {
+	if options.MaxCrimes <= 0 {
+		options.MaxCrimes = defaultHotspotMaxCrimes
+	}
+	uc := &DetectHotspotsUseCase{
+		statsRepo: repo,
+		options:   options,
+		now:       now,
+	}
+	if options.CacheTTL > 0 {
+		uc.cache = cache.NewWithClock[*HotspotResult](options.CacheSize, options.CacheTTL, now)
+	}
+	return uc
+}
+
+// Execute detecta las zonas calientes de los delitos del período. Los resultados se guardan
+// en caché por conjunto de parámetros; sin fin de período se usa el minuto actual para que
+// las consultas repetidas reutilicen el análisis. Quienes no son moderadores solo analizan
+// los delitos públicos
+func (uc *DetectHotspotsUseCase) Execute(ctx context.Context, input HotspotInput) (_ *HotspotResult, err error) {
+	ctx, span := tracer.Start(ctx, "DetectHotspotsUseCase.Execute",
+		trace.WithAttributes(attribute.String("hotspots.method", input.Method)))
+	defer func() { endSpan(span, err) }()
+
+	result, err := input.parameters()
+	if err != nil {
+		return nil, err
+	}
+	if input.To.IsZero() {
+		input.To = uc.now().Truncate(time.Minute)
+	}
+	filter, _, err := input.resolve()
+	if err != nil {
+		return nil, err
+	}
+	result.From, result.To = filter.From.UTC(), filter.To.UTC()
+
+	key := hotspotCacheKey(result, filter)
+	if uc.cache != nil {
+		if cached, found := uc.cache.Get(key); found {
+			span.SetAttributes(attribute.Bool("hotspots.cached", true))
+			return cached, nil
+		}
+	}
+
+	points, err := uc.statsRepo.ListPoints(ctx, filter, uc.options.MaxCrimes+1)
+	if err != nil {
+		return nil, err
+	}
+	if len(points) > uc.options.MaxCrimes {
+		return nil, ErrTooManyHotspotCrimes
+	}
+
+	result.CrimeCount = len(points)
+	result.GeneratedAt = uc.now().UTC()
+	if result.Method == HotspotMethodKDE {
+		result.Hotspots = detectDensityHotspots(points, result.BandwidthMeters, result.Threshold)
+	} else {
+		result.Hotspots = detectClusterHotspots(points, result.EpsMeters, result.MinPoints)
+	}
+	result.Noise = result.CrimeCount
+	for _, hotspot := range result.Hotspots {
+		result.Noise -= hotspot.Count
+	}
+
+	if uc.cache != nil {
+		uc.cache.Set(key, result)
+	}
+	return result, nil
+}
+
+// parameters completa y valida el método y sus parámetros
+func (input HotspotInput) parameters() (*HotspotResult, error) {
+	method := strings.ToLower(strings.TrimSpace(input.Method))
+	switch method {
+	case "", HotspotMethodDBSCAN:
+		result := &HotspotResult{Method: HotspotMethodDBSCAN, EpsMeters: input.EpsMeters, MinPoints: input.MinPoints}
+		if result.EpsMeters == 0 {
+			result.EpsMeters = 200
+		}
+		if result.MinPoints == 0 {
+			result.MinPoints = 5
+		}
+		if result.EpsMeters < 10 || result.EpsMeters > 5000 || result.MinPoints < 2 || result.MinPoints > 1000 {
+			return nil, ErrInvalidHotspotParameters
+		}
+		return result, nil
+	case HotspotMethodKDE:
+		result := &HotspotResult{Method: HotspotMethodKDE, BandwidthMeters: input.BandwidthMeters, Threshold: input.Threshold}
+		if result.BandwidthMeters == 0 {
+			result.BandwidthMeters = 250
+		}
+		if result.Threshold == 0 {
+			result.Threshold = 0.5
+		}
+		if result.BandwidthMeters < 25 || result.BandwidthMeters > 5000 || result.Threshold < 0 || result.Threshold > 1 {
+			return nil, ErrInvalidHotspotParameters
+		}
+		return result, nil
+	default:
+		return nil, ErrInvalidHotspotMethod
+	}
+}
+
+// hotspotCacheKey identifica un análisis por su método, sus parámetros y los delitos incluidos
+func hotspotCacheKey(result *HotspotResult, filter repositories.StatsFilter) string {
+	return fmt.Sprintf("%s|%g|%d|%g|%g|%s", result.Method, result.EpsMeters, result.MinPoints,
+		result.BandwidthMeters, result.Threshold, statsFilterKey(filter))
+}
+
+// statsFilterKey identifica los delitos que incluye un filtro, sin importar el orden de sus listas
+func statsFilterKey(filter repositories.StatsFilter) string {
+	sorted := func(values []string) string {
+		values = append([]string(nil), values...)
+		sort.Strings(values)
+		return strings.Join(values, ",")
+	}
+	statuses := make([]string, len(filter.Statuses))
+	for i, status := range filter.Statuses {
+		statuses[i] = string(status)
+	}
+	box := ""
+	if filter.BoundingBox != nil {
+		box = fmt.Sprintf("%g,%g,%g,%g", filter.BoundingBox.MinLatitude, filter.BoundingBox.MinLongitude,
+			filter.BoundingBox.MaxLatitude, filter.BoundingBox.MaxLongitude)
+	}
+	return fmt.Sprintf("%d|%d|%s|%s|%s|%s", filter.From.UnixNano(), filter.To.UnixNano(),
+		sorted(filter.Types), sorted(filter.ZoneIDs), sorted(statuses), box)
+}
+
+// detectClusterHotspots agrupa los delitos con DBSCAN; cada cluster es una zona caliente
+// delimitada por la envolvente convexa de sus delitos
+func detectClusterHotspots(points []repositories.CrimePoint, eps float64, minPoints int) []entities.Hotspot {
+	projection := newLocalProjection(points)
+	planar := projection.projectAll(points)
+	labels := spatial.DBSCAN(planar, eps, minPoints)
+
+	groups := map[int][]int{}
+	for i, label := range labels {
+		if label != spatial.Noise {
+			groups[label] = append(groups[label], i)
+		}
+	}
+	hotspots := make([]entities.Hotspot, 0, len(groups))
+	for _, members := range groups {
+		hull := make([]spatial.Point, len(members))
+		for i, member := range members {
+			hull[i] = planar[member]
+		}
+		hotspot := newHotspot(points, members)
+		projection.setArea(&hotspot, spatial.ConvexHull(hull))
+		hotspots = append(hotspots, hotspot)
+	}
+	return rankHotspots(hotspots)
+}
+
+// detectDensityHotspots estima la densidad de los delitos con un kernel gaussiano; cada
+// región de celdas contiguas con densidad de al menos threshold veces la máxima es una zona
+// caliente formada por los delitos que caen en ella
+func detectDensityHotspots(points []repositories.CrimePoint, bandwidth, threshold float64) []entities.Hotspot {
+	if len(points) == 0 {
+		return []entities.Hotspot{}
+	}
+	projection := newLocalProjection(points)
+	planar := projection.projectAll(points)
+	grid := spatial.KernelDensity(planar, bandwidth, densityCellSize(planar, bandwidth))
+	regions := grid.Regions(threshold * grid.Max())
+
+	groups := map[int][]int{}
+	for i, p := range planar {
+		col, row := grid.Cell(p)
+		if region := regions[row*grid.Cols+col]; region != spatial.Noise {
+			groups[region] = append(groups[region], i)
+		}
+	}
+	corners := map[int][]spatial.Point{}
+	peaks := map[int]float64{}
+	for cell, region := range regions {
+		if _, found := groups[region]; !found {
+			continue
+		}
+		col, row := cell%grid.Cols, cell/grid.Cols
+		x, y := grid.MinX+float64(col)*grid.CellSize, grid.MinY+float64(row)*grid.CellSize
+		corners[region] = append(corners[region],
+			spatial.Point{X: x, Y: y}, spatial.Point{X: x + grid.CellSize, Y: y},
+			spatial.Point{X: x, Y: y + grid.CellSize}, spatial.Point{X: x + grid.CellSize, Y: y + grid.CellSize})
+		peaks[region] = math.Max(peaks[region], grid.Values[cell])
+	}
+
+	hotspots := make([]entities.Hotspot, 0, len(groups))
+	for region, members := range groups {
+		hotspot := newHotspot(points, members)
+		projection.setArea(&hotspot, spatial.ConvexHull(corners[region]))
+		peak := peaks[region] * 1e6
+		hotspot.PeakDensity = &peak
+		hotspots = append(hotspots, hotspot)
+	}
+	return rankHotspots(hotspots)
+}
+
+// densityCellSize usa celdas de la mitad del ancho de banda, agrandándolas si la grilla
+// excediera maxDensityCells
+func densityCellSize(points []spatial.Point, bandwidth float64) float64 {
+	minX, minY, maxX, maxY := math.Inf(1), math.Inf(1), math.Inf(-1), math.Inf(-1)
+	for _, p := range points {
+		minX, minY = math.Min(minX, p.X), math.Min(minY, p.Y)
+		maxX, maxY = math.Max(maxX, p.X), math.Max(maxY, p.Y)
+	}
+	width, height := maxX-minX+6*bandwidth, maxY-minY+6*bandwidth
+	cellSize := bandwidth / 2
+	for (width/cellSize+2)*(height/cellSize+2) > maxDensityCells {
+		cellSize *= 1.25
+	}
+	return cellSize
+}
+
+// newHotspot arma la zona caliente formada por los delitos indicados
+func newHotspot(points []repositories.CrimePoint, members []int) entities.Hotspot {
+	hotspot := entities.Hotspot{Count: len(members), ByType: map[string]int{}}
+	for _, member := range members {
+		hotspot.Centroid.Latitude += points[member].Location.Latitude
+		hotspot.Centroid.Longitude += points[member].Location.Longitude
+		hotspot.ByType[points[member].Type]++
+		hotspot.SeverityScore += points[member].Severity.Weight()
+	}
+	hotspot.Centroid.Latitude /= float64(len(members))
+	hotspot.Centroid.Longitude /= float64(len(members))
+	return hotspot
+}
+
+// rankHotspots ordena las zonas calientes de mayor a menor cantidad de delitos y las numera
+func rankHotspots(hotspots []entities.Hotspot) []entities.Hotspot {
+	sort.Slice(hotspots, func(i, j int) bool {
+		a, b := hotspots[i], hotspots[j]
+		if a.Count != b.Count {
+			return a.Count > b.Count
+		}
+		if a.Centroid.Latitude != b.Centroid.Latitude {
+			return a.Centroid.Latitude < b.Centroid.Latitude
+		}
+		return a.Centroid.Longitude < b.Centroid.Longitude
+	})
+	for i := range hotspots {
+		hotspots[i].Rank = i + 1
+	}
+	return hotspots
+}
+
+// localProjection proyecta coordenadas a metros sobre un plano equirectangular centrado en
+// los delitos, adecuado para áreas de escala urbana
+type localProjection struct {
+	latitude, longitude float64
+	scale               float64 // Metros por grado de longitud
+}
+
+// newLocalProjection centra la proyección en el promedio de las ubicaciones
+func newLocalProjection(points []repositories.CrimePoint) localProjection {
+	var center entities.Coordinate
+	for _, point := range points {
+		center.Latitude += point.Location.Latitude
+		center.Longitude += point.Location.Longitude
+	}
+	if len(points) > 0 {
+		center.Latitude /= float64(len(points))
+		center.Longitude /= float64(len(points))
+	}
+	return newProjectionAt(center)
+}
+
+// newProjectionAt centra la proyección en la coordenada indicada
+func newProjectionAt(center entities.Coordinate) localProjection {
+	return localProjection{
+		latitude:  center.Latitude,
+		longitude: center.Longitude,
+		scale:     metersPerDegree * math.Cos(center.Latitude*math.Pi/180),
+	}
+}
+
+// project convierte una coordenada en un punto del plano
+func (p localProjection) project(c entities.Coordinate) spatial.Point {
+	return spatial.Point{X: (c.Longitude - p.longitude) * p.scale, Y: (c.Latitude - p.latitude) * metersPerDegree}
+}
+
+// projectAll proyecta las ubicaciones de los delitos
+func (p localProjection) projectAll(points []repositories.CrimePoint) []spatial.Point {
+	planar := make([]spatial.Point, len(points))
+	for i, point := range points {
+		planar[i] = p.project(point.Location)
+	}
+	return planar
+}
+
+// unproject convierte un punto del plano en coordenadas
+func (p localProjection) unproject(point spatial.Point) entities.Coordinate {
+	return entities.Coordinate{
+		Latitude:  p.latitude + point.Y/metersPerDegree,
+		Longitude: p.longitude + point.X/p.scale,
+	}
+}
+
+// setArea asigna a la zona caliente el polígono de la envolvente; las envolventes de menos
+// de tres vértices no delimitan un área y se omiten
+func (p localProjection) setArea(hotspot *entities.Hotspot, hull []spatial.Point) {
+	if len(hull) < 3 {
+		return
+	}
+	ring := make(entities.Polygon, len(hull))
+	for i, vertex := range hull {
+		ring[i] = p.unproject(vertex)
+	}
+	hotspot.Area = entities.MultiPolygon{{ring}}
+	hotspot.AreaSqM = hotspot.Area.AreaSquareMeters()
+}
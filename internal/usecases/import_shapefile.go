@@ -0,0 +1,295 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"go-crime_map_backend/internal/domain/entities"
+	"go-crime_map_backend/pkg/proj"
+	"go-crime_map_backend/pkg/shapefile"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var (
+	// ErrInvalidCrimeImport se retorna cuando el archivo de delitos no se puede importar
+	ErrInvalidCrimeImport = errors.New("el archivo de delitos es inválido")
+
+	// errMissingProjection se retorna cuando el shapefile no indica su sistema de coordenadas
+	errMissingProjection = errors.New("el zip no tiene .prj; indique el sistema de coordenadas con srid")
+
+	// maxImportedCrimes limita los delitos de un archivo
+	maxImportedCrimes = 10000
+
+	// maxImportErrors limita los rechazos que se detallan en el resultado
+	maxImportErrors = 100
+
+	// importDateLayouts son los formatos aceptados en la columna de fecha; los campos de tipo
+	// fecha del .dbf usan el primero
+	importDateLayouts = []string{
+		"20060102", "2006-01-02", "02/01/2006", "2006/01/02", "2006-01-02 15:04:05",
+		"2006-01-02T15:04:05", "02/01/2006 15:04", time.RFC3339,
+	}
+
+	// importTimeLayouts son los formatos aceptados en la columna de hora
+	importTimeLayouts = []string{"15:04", "15:04:05", "15"}
+
+	// removeAccents quita las tildes de los tipos de delito importados
+	removeAccents = strings.NewReplacer("Á", "A", "É", "E", "Í", "I", "Ó", "O", "Ú", "U", "Ü", "U")
+)
+
+// CrimeFieldMapping indica las columnas del .dbf con los datos de cada delito. Las columnas
+// vacías usan su valor por defecto; los nombres no distinguen mayúsculas. El .dbf limita los
+// nombres a 10 caracteres
+type CrimeFieldMapping struct {
+	Type        string // Tipo de delito, "tipo" por defecto
+	Description string // Descripción, "descripcio" por defecto
+	Date        string // Fecha o fecha y hora, "fecha" por defecto
+	Time        string // Hora, "hora" por defecto; se ignora si la columna no existe
+	Address     string // Dirección, "direccion" por defecto
+	Severity    string // Gravedad, "gravedad" por defecto
+	Weapon      string // Arma, "arma" por defecto
+	VictimCount string // Cantidad de víctimas, "victimas" por defecto
+}
+
+// withDefaults completa las columnas sin indicar
+func (m CrimeFieldMapping) withDefaults() CrimeFieldMapping {
+	defaults := CrimeFieldMapping{
+		Type:        "tipo",
+		Description: "descripcio",
+		Date:        "fecha",
+		Time:        "hora",
+		Address:     "direccion",
+		Severity:    "gravedad",
+		Weapon:      "arma",
+		VictimCount: "victimas",
+	}
+	for _, field := range []struct{ value, fallback *string }{
+		{&m.Type, &defaults.Type},
+		{&m.Description, &defaults.Description},
+		{&m.Date, &defaults.Date},
+		{&m.Time, &defaults.Time},
+		{&m.Address, &defaults.Address},
+		{&m.Severity, &defaults.Severity},
+		{&m.Weapon, &defaults.Weapon},
+		{&m.VictimCount, &defaults.VictimCount},
+	} {
+		if strings.TrimSpace(*field.value) == "" {
+			*field.value = *field.fallback
+		}
+	}
+	return m
+}
+
+// ImportCrimesInput representa un shapefile de delitos a importar
+type ImportCrimesInput struct {
+	Data     []byte // Zip con el .shp de puntos, el .dbf y el .prj
+	Fields   CrimeFieldMapping
+	SRID     int    // Código EPSG del sistema de coordenadas; si no es cero reemplaza al .prj
+	Timezone string // Zona horaria IANA de las fechas sin zona, UTC por defecto
+}
+
+// ImportRecordError describe un registro rechazado
+type ImportRecordError struct {
+	Record int    `json:"record"` // Número del registro en el shapefile, desde 1
+	Code   string `json:"code"`
+	Error  string `json:"error"`
+}
+
+// ImportCrimesResult resume el resultado de una importación de delitos
+type ImportCrimesResult struct {
+	Created  int                 `json:"created"`
+	Rejected int                 `json:"rejected"`
+	Errors   []ImportRecordError `json:"errors"` // Los primeros 100 rechazos
+	CRS      string              `json:"crs"`    // Sistema de coordenadas de origen
+}
+
+// ImportCrimesUseCase maneja la lógica de negocio para importar delitos desde shapefiles,
+// como los que publican las estadísticas oficiales
+type ImportCrimesUseCase struct {
+	create CreateCrimeExecutor
+}
+
+// NewImportCrimesUseCase crea una nueva instancia del caso de uso. Cada delito se crea con el
+// caso de uso de creación, por lo que pasa por las mismas validaciones y eventos
+func NewImportCrimesUseCase(create CreateCrimeExecutor) *ImportCrimesUseCase {
+	return &ImportCrimesUseCase{create: create}
+}
+
+// Execute importa los delitos del shapefile. Los registros inválidos o duplicados se
+// rechazan sin detener la importación; un error del repositorio la detiene y los delitos ya
+// creados se conservan. Solo disponible para administradores
+func (uc *ImportCrimesUseCase) Execute(ctx context.Context, input ImportCrimesInput, actor entities.Actor) (_ *ImportCrimesResult, err error) {
+	ctx, span := tracer.Start(ctx, "ImportCrimesUseCase.Execute",
+		trace.WithAttributes(attribute.Int("import.srid", input.SRID)))
+	defer func() { endSpan(span, err) }()
+
+	if actor.Role != entities.RoleAdmin {
+		return nil, ErrForbidden
+	}
+	location := time.UTC
+	if input.Timezone != "" {
+		if location, err = time.LoadLocation(input.Timezone); err != nil {
+			return nil, ErrInvalidTimezone
+		}
+	}
+	file, crs, err := openShapefile(input.Data, input.SRID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidCrimeImport, err)
+	}
+	if len(file.Records) > maxImportedCrimes {
+		return nil, fmt.Errorf("%w: el archivo no puede tener más de %d delitos", ErrInvalidCrimeImport, maxImportedCrimes)
+	}
+	fields := input.Fields.withDefaults()
+
+	result := &ImportCrimesResult{Errors: []ImportRecordError{}, CRS: crs.Name}
+	reject := func(record int, code string, err error) {
+		result.Rejected++
+		if len(result.Errors) < maxImportErrors {
+			result.Errors = append(result.Errors, ImportRecordError{Record: record, Code: code, Error: err.Error()})
+		}
+	}
+	for _, record := range file.Records {
+		crime, code, err := crimeFromRecord(record, fields, crs, location, file.Name)
+		if err != nil {
+			reject(record.Number, code, err)
+			continue
+		}
+		if _, err := uc.create.Execute(ctx, crime); err != nil {
+			if errors.Is(err, ErrDuplicateCrime) {
+				reject(record.Number, "duplicate", err)
+				continue
+			}
+			if code, ok := ValidationErrorCode(err); ok {
+				reject(record.Number, code, err)
+				continue
+			}
+			return nil, err
+		}
+		result.Created++
+	}
+	span.SetAttributes(attribute.Int("import.created", result.Created), attribute.Int("import.rejected", result.Rejected))
+
+	slog.InfoContext(ctx, "delitos importados",
+		slog.String("file", file.Name),
+		slog.String("crs", crs.Name),
+		slog.Int("created", result.Created),
+		slog.Int("rejected", result.Rejected),
+		slog.String("actor_id", actor.ID),
+	)
+	return result, nil
+}
+
+// crimeFromRecord arma los datos del delito de un registro. Si el registro no se puede leer
+// retorna el código del rechazo
+func crimeFromRecord(record shapefile.Record, fields CrimeFieldMapping, crs *proj.CRS, location *time.Location, fileName string) (CreateCrimeInput, string, error) {
+	if record.Type.Base() != shapefile.ShapePoint || len(record.Parts) == 0 || len(record.Parts[0]) == 0 {
+		return CreateCrimeInput{}, "invalid_geometry", errors.New("el registro no es un punto")
+	}
+	point := record.Parts[0][0]
+	lat, lon := crs.LatLon(point.X, point.Y)
+
+	date, err := parseImportDate(record.Attribute(fields.Date), record.Attribute(fields.Time), location)
+	if err != nil {
+		return CreateCrimeInput{}, "invalid_date", err
+	}
+
+	crime := CreateCrimeInput{
+		Type:        removeAccents.Replace(strings.ToUpper(record.Attribute(fields.Type))),
+		Description: record.Attribute(fields.Description),
+		Location:    Location{Latitude: lat, Longitude: lon, Address: record.Attribute(fields.Address)},
+		Date:        date,
+		Severity:    entities.Severity(strings.ToLower(record.Attribute(fields.Severity))),
+		Weapon:      entities.Weapon(strings.ToLower(record.Attribute(fields.Weapon))),
+	}
+	if crime.Description == "" {
+		crime.Description = "Importado de " + fileName + ".shp"
+	}
+	if raw := record.Attribute(fields.VictimCount); raw != "" {
+		count, err := strconv.ParseFloat(raw, 64)
+		if err != nil || count != math.Trunc(count) {
+			return CreateCrimeInput{}, validationErrorCodes[ErrInvalidVictimCount], ErrInvalidVictimCount
+		}
+		crime.VictimCount = int(count)
+	}
+	return crime, "", nil
+}
+
+// parseImportDate interpreta la fecha y, si existe, la hora de un registro
+func parseImportDate(rawDate, rawTime string, location *time.Location) (time.Time, error) {
+	if rawDate == "" {
+		return time.Time{}, errors.New("el registro no tiene fecha")
+	}
+	var date time.Time
+	var err error
+	for _, layout := range importDateLayouts {
+		if date, err = time.ParseInLocation(layout, rawDate, location); err == nil {
+			break
+		}
+	}
+	if err != nil {
+		return time.Time{}, fmt.Errorf("fecha %q inválida", rawDate)
+	}
+	if rawTime == "" {
+		return date, nil
+	}
+	for _, layout := range importTimeLayouts {
+		if clock, err := time.Parse(layout, rawTime); err == nil {
+			return time.Date(date.Year(), date.Month(), date.Day(), clock.Hour(), clock.Minute(), clock.Second(), 0, location), nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("hora %q inválida", rawTime)
+}
+
+// openShapefile lee el shapefile del zip y su sistema de coordenadas: el del código EPSG si
+// se indica y si no el del .prj
+func openShapefile(data []byte, srid int) (*shapefile.File, *proj.CRS, error) {
+	file, err := shapefile.ReadZip(data)
+	if err != nil {
+		return nil, nil, err
+	}
+	var crs *proj.CRS
+	switch {
+	case srid != 0:
+		crs, err = proj.EPSG(srid)
+	case file.Projection == "":
+		err = errMissingProjection
+	default:
+		crs, err = proj.ParseWKT(file.Projection)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	return file, crs, nil
+}
+
+// multiPolygonFromRecord convierte a WGS84 los anillos de un registro de polígonos
+func multiPolygonFromRecord(record shapefile.Record, crs *proj.CRS) (entities.MultiPolygon, error) {
+	if record.Type.Base() != shapefile.ShapePolygon {
+		return nil, errors.New("el registro no es un polígono")
+	}
+	polygons := record.Polygons()
+	geometry := make(entities.MultiPolygon, len(polygons))
+	for i, rings := range polygons {
+		geometry[i] = make([]entities.Polygon, len(rings))
+		for j, points := range rings {
+			// El último vértice repite el primero; Polygon cierra el anillo implícitamente
+			if n := len(points); n > 1 && points[0] == points[n-1] {
+				points = points[:n-1]
+			}
+			ring := make(entities.Polygon, len(points))
+			for k, point := range points {
+				ring[k].Latitude, ring[k].Longitude = crs.LatLon(point.X, point.Y)
+			}
+			geometry[i][j] = ring
+		}
+	}
+	return geometry, nil
+}
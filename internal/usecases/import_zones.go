@@ -0,0 +1,272 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"go-crime_map_backend/internal/domain/entities"
+	"go-crime_map_backend/internal/domain/repositories"
+	"go-crime_map_backend/pkg/geojson"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var (
+	// ErrInvalidZoneImport se retorna cuando el archivo de zonas no se puede importar
+	ErrInvalidZoneImport = errors.New("el archivo de zonas es inválido")
+
+	// maxImportedZones limita las zonas de un archivo
+	maxImportedZones = 1000
+)
+
+// ImportZonesInput representa un archivo GeoJSON de zonas a importar
+type ImportZonesInput struct {
+	Data         []byte // FeatureCollection con geometrías Polygon o MultiPolygon
+	Kind         string // Tipo de las zonas importadas
+	NameProperty string // Propiedad de cada feature con el nombre de la zona, "name" por defecto
+
+	// PopulationProperty es la propiedad de cada feature con la población de la zona,
+	// "population" por defecto. Las zonas sin esa propiedad quedan con población 0
+	PopulationProperty string
+}
+
+// ImportZonesResult resume el resultado de una importación
+type ImportZonesResult struct {
+	Created   int              `json:"created"`
+	Updated   int              `json:"updated"`
+	Relocated int              `json:"relocated_crimes"` // Delitos cuya zona cambió
+	Zones     []*entities.Zone `json:"zones"`
+}
+
+// ImportZonesUseCase maneja la lógica de negocio para importar zonas desde GeoJSON
+type ImportZonesUseCase struct {
+	zoneRepo  repositories.ZoneRepository
+	crimeRepo repositories.CrimeRepository
+	locator   *ZoneLocator
+}
+
+// NewImportZonesUseCase crea una nueva instancia del caso de uso
+func NewImportZonesUseCase(zoneRepo repositories.ZoneRepository, crimeRepo repositories.CrimeRepository) *ImportZonesUseCase {
+	return &ImportZonesUseCase{
+		zoneRepo:  zoneRepo,
+		crimeRepo: crimeRepo,
+		locator:   NewZoneLocator(zoneRepo),
+	}
+}
+
+// Execute importa las zonas del archivo: crea las nuevas y reemplaza la geometría de las que
+// ya existen con el mismo nombre y tipo, y luego reasigna la zona de los delitos. El archivo
+// se valida completo antes de guardar. Solo disponible para administradores
+func (uc *ImportZonesUseCase) Execute(ctx context.Context, input ImportZonesInput, actor entities.Actor) (_ *ImportZonesResult, err error) {
+	ctx, span := tracer.Start(ctx, "ImportZonesUseCase.Execute",
+		trace.WithAttributes(attribute.String("zone.kind", input.Kind)))
+	defer func() { endSpan(span, err) }()
+
+	if actor.Role != entities.RoleAdmin {
+		return nil, ErrForbidden
+	}
+	inputs, err := parseZoneFeatures(input)
+	if err != nil {
+		return nil, err
+	}
+	return uc.save(ctx, inputs, actor)
+}
+
+// ImportZonesShapefileInput representa un shapefile de zonas a importar
+type ImportZonesShapefileInput struct {
+	Data            []byte // Zip con el .shp de polígonos, el .dbf y el .prj
+	Kind            string // Tipo de las zonas importadas
+	NameField       string // Columna del .dbf con el nombre de la zona, "nombre" por defecto
+	PopulationField string // Columna con la población, "poblacion" por defecto; sin ella queda en 0
+	SRID            int    // Código EPSG del sistema de coordenadas; si no es cero reemplaza al .prj
+}
+
+// ExecuteShapefile importa las zonas de un shapefile de polígonos, convertidos a WGS84, igual
+// que Execute. Solo disponible para administradores
+func (uc *ImportZonesUseCase) ExecuteShapefile(ctx context.Context, input ImportZonesShapefileInput, actor entities.Actor) (_ *ImportZonesResult, err error) {
+	ctx, span := tracer.Start(ctx, "ImportZonesUseCase.ExecuteShapefile",
+		trace.WithAttributes(attribute.String("zone.kind", input.Kind), attribute.Int("import.srid", input.SRID)))
+	defer func() { endSpan(span, err) }()
+
+	if actor.Role != entities.RoleAdmin {
+		return nil, ErrForbidden
+	}
+	inputs, err := parseZoneShapefile(input)
+	if err != nil {
+		return nil, err
+	}
+	return uc.save(ctx, inputs, actor)
+}
+
+// parseZoneShapefile lee y valida las zonas del shapefile
+func parseZoneShapefile(input ImportZonesShapefileInput) ([]ZoneInput, error) {
+	file, crs, err := openShapefile(input.Data, input.SRID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidZoneImport, err)
+	}
+	if len(file.Records) == 0 {
+		return nil, fmt.Errorf("%w: el archivo no tiene zonas", ErrInvalidZoneImport)
+	}
+	if len(file.Records) > maxImportedZones {
+		return nil, fmt.Errorf("%w: el archivo no puede tener más de %d zonas", ErrInvalidZoneImport, maxImportedZones)
+	}
+
+	nameField := input.NameField
+	if nameField == "" {
+		nameField = "nombre"
+	}
+	populationField := input.PopulationField
+	if populationField == "" {
+		populationField = "poblacion"
+	}
+
+	inputs := make([]ZoneInput, 0, len(file.Records))
+	seen := make(map[string]bool, len(file.Records))
+	for i, record := range file.Records {
+		geometry, err := multiPolygonFromRecord(record, crs)
+		if err != nil {
+			return nil, fmt.Errorf("%w: zona %d: %v", ErrInvalidZoneImport, i+1, err)
+		}
+		zone := ZoneInput{Name: record.Attribute(nameField), Kind: input.Kind, Geometry: geometry}
+		if raw := record.Attribute(populationField); raw != "" {
+			population, err := strconv.ParseFloat(raw, 64)
+			if err != nil {
+				return nil, fmt.Errorf("%w: zona %d: población %q inválida", ErrInvalidZoneImport, i+1, raw)
+			}
+			zone.Population = int(math.Round(population))
+		}
+		if inputs, err = appendImportedZone(inputs, seen, i+1, zone); err != nil {
+			return nil, err
+		}
+	}
+	return inputs, nil
+}
+
+// save crea o actualiza las zonas ya validadas y reasigna la zona de los delitos
+func (uc *ImportZonesUseCase) save(ctx context.Context, inputs []ZoneInput, actor entities.Actor) (*ImportZonesResult, error) {
+	// Todas las zonas del archivo son del mismo tipo
+	zones, err := uc.zoneRepo.ListZones(ctx, inputs[0].Kind)
+	if err != nil {
+		return nil, err
+	}
+	existing := make(map[string]*entities.Zone, len(zones))
+	for _, zone := range zones {
+		existing[zoneKey(zone.Kind, zone.Name)] = zone
+	}
+
+	result := &ImportZonesResult{Zones: make([]*entities.Zone, 0, len(inputs))}
+	now := time.Now()
+	for _, input := range inputs {
+		if zone, found := existing[zoneKey(input.Kind, input.Name)]; found {
+			zone.Population = input.Population
+			zone.Geometry = input.Geometry
+			zone.UpdatedAt = now
+			if err := uc.zoneRepo.UpdateZone(ctx, zone); err != nil {
+				return nil, err
+			}
+			result.Updated++
+			result.Zones = append(result.Zones, zone)
+			continue
+		}
+
+		zone := &entities.Zone{
+			ID:         generateID(),
+			Name:       input.Name,
+			Kind:       input.Kind,
+			Population: input.Population,
+			Geometry:   input.Geometry,
+			CreatedAt:  now,
+			UpdatedAt:  now,
+		}
+		if err := uc.zoneRepo.CreateZone(ctx, zone); err != nil {
+			return nil, err
+		}
+		result.Created++
+		result.Zones = append(result.Zones, zone)
+	}
+
+	relocated, err := uc.locator.Relocate(ctx, uc.crimeRepo)
+	if err != nil {
+		return nil, err
+	}
+	result.Relocated = relocated
+
+	slog.InfoContext(ctx, "zonas importadas",
+		slog.Int("created", result.Created),
+		slog.Int("updated", result.Updated),
+		slog.Int("relocated", relocated),
+		slog.String("actor_id", actor.ID),
+	)
+	return result, nil
+}
+
+// parseZoneFeatures lee y valida las zonas del archivo
+func parseZoneFeatures(input ImportZonesInput) ([]ZoneInput, error) {
+	collection, err := geojson.ParseFeatureCollection(input.Data)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidZoneImport, err)
+	}
+	if len(collection.Features) == 0 {
+		return nil, fmt.Errorf("%w: el archivo no tiene zonas", ErrInvalidZoneImport)
+	}
+	if len(collection.Features) > maxImportedZones {
+		return nil, fmt.Errorf("%w: el archivo no puede tener más de %d zonas", ErrInvalidZoneImport, maxImportedZones)
+	}
+
+	nameProperty := input.NameProperty
+	if nameProperty == "" {
+		nameProperty = "name"
+	}
+	populationProperty := input.PopulationProperty
+	if populationProperty == "" {
+		populationProperty = "population"
+	}
+
+	inputs := make([]ZoneInput, 0, len(collection.Features))
+	seen := make(map[string]bool, len(collection.Features))
+	for i, feature := range collection.Features {
+		name, _ := feature.StringProperty(nameProperty)
+		if feature.Geometry == nil {
+			return nil, fmt.Errorf("%w: la zona %d no tiene geometría", ErrInvalidZoneImport, i+1)
+		}
+		geometry, err := entities.MultiPolygonFromGeoJSON(*feature.Geometry)
+		if err != nil {
+			return nil, fmt.Errorf("%w: zona %d: %v", ErrInvalidZoneImport, i+1, err)
+		}
+
+		zone := ZoneInput{Name: name, Kind: input.Kind, Geometry: geometry}
+		if population, found := feature.NumberProperty(populationProperty); found {
+			zone.Population = int(math.Round(population))
+		}
+		if inputs, err = appendImportedZone(inputs, seen, i+1, zone); err != nil {
+			return nil, err
+		}
+	}
+	return inputs, nil
+}
+
+// appendImportedZone valida la zona número n del archivo y la agrega si no está repetida
+func appendImportedZone(inputs []ZoneInput, seen map[string]bool, n int, zone ZoneInput) ([]ZoneInput, error) {
+	var err error
+	if zone.Name, zone.Kind, err = validateZoneInput(zone); err != nil {
+		return nil, fmt.Errorf("%w: zona %d: %v", ErrInvalidZoneImport, n, err)
+	}
+	key := zoneKey(zone.Kind, zone.Name)
+	if seen[key] {
+		return nil, fmt.Errorf("%w: la zona %q está repetida", ErrInvalidZoneImport, zone.Name)
+	}
+	seen[key] = true
+	return append(inputs, zone), nil
+}
+
+// zoneKey identifica una zona por su tipo y su nombre sin distinguir mayúsculas
+func zoneKey(kind, name string) string {
+	return kind + "\x00" + strings.ToLower(name)
+}
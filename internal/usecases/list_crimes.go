@@ -0,0 +1,69 @@
+package usecases
+
+import (
+	"context"
+
+	"go-crime_map_backend/internal/domain/entities"
+	"go-crime_map_backend/internal/domain/repositories"
+)
+
+// ListCrimesInput representa los criterios para listar delitos
+type ListCrimesInput struct {
+	Statuses []entities.CrimeStatus
+	Types    []string
+	ZoneIDs  []string
+	Actor    entities.Actor
+}
+
+// ListCrimesUseCase maneja la lógica de negocio para listar delitos
+type ListCrimesUseCase struct {
+	crimeRepo repositories.CrimeRepository
+}
+
+// NewListCrimesUseCase crea una nueva instancia del caso de uso
+func NewListCrimesUseCase(repo repositories.CrimeRepository) *ListCrimesUseCase {
+	return &ListCrimesUseCase{
+		crimeRepo: repo,
+	}
+}
+
+// Execute lista los delitos visibles para el actor que cumplen los criterios.
+// Quienes no son moderadores solo pueden ver los estados públicos.
+func (uc *ListCrimesUseCase) Execute(ctx context.Context, input ListCrimesInput) ([]*entities.Crime, error) {
+	statuses, err := visibleStatuses(input.Statuses, input.Actor)
+	if err != nil {
+		return nil, err
+	}
+
+	crimes, err := uc.crimeRepo.List(ctx, repositories.CrimeFilter{
+		Statuses: statuses,
+		Types:    input.Types,
+		ZoneIDs:  input.ZoneIDs,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if crimes == nil {
+		crimes = []*entities.Crime{}
+	}
+
+	return crimes, nil
+}
+
+// visibleStatuses valida los estados pedidos por el actor. Quienes no son moderadores solo
+// pueden pedir los estados públicos y, si no piden ninguno, se limitan a ellos
+func visibleStatuses(statuses []entities.CrimeStatus, actor entities.Actor) ([]entities.CrimeStatus, error) {
+	for _, status := range statuses {
+		if !status.IsValid() {
+			return nil, ErrInvalidStatus
+		}
+		if !status.IsPublic() && !actor.IsStaff() {
+			return nil, ErrForbidden
+		}
+	}
+
+	if len(statuses) == 0 && !actor.IsStaff() {
+		return entities.PublicCrimeStatuses, nil
+	}
+	return statuses, nil
+}
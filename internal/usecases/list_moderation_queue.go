@@ -0,0 +1,116 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go-crime_map_backend/internal/domain/entities"
+	"go-crime_map_backend/internal/domain/repositories"
+)
+
+const (
+	// ModerationSortAge ordena la cola del reporte más antiguo al más reciente
+	ModerationSortAge = "age"
+
+	// ModerationSortPriority ordena la cola por prioridad y luego por antigüedad
+	ModerationSortPriority = "priority"
+
+	// defaultModerationQueueLimit es la cantidad de elementos retornados si no se indica un límite
+	defaultModerationQueueLimit = 50
+
+	// maxModerationQueueLimit es la cantidad máxima de elementos por consulta
+	maxModerationQueueLimit = 200
+)
+
+var (
+	// ErrInvalidQueueSort se retorna cuando el orden solicitado no existe
+	ErrInvalidQueueSort = errors.New("el orden de la cola debe ser age o priority")
+
+	// moderationPriorities asigna la prioridad de revisión por tipo de delito;
+	// los delitos contra las personas se revisan primero
+	moderationPriorities = map[string]int{
+		"VIOLENCIA":    3,
+		"AGRESION":     3,
+		"ACOSO":        2,
+		"ROBO":         2,
+		"ALLANAMIENTO": 2,
+	}
+
+	// defaultModerationPriority es la prioridad de los tipos no listados
+	defaultModerationPriority = 1
+)
+
+// ListModerationQueueInput representa los criterios para consultar la cola de moderación
+type ListModerationQueueInput struct {
+	Sort          string // age (por defecto) o priority
+	Limit         int    // Cantidad máxima de elementos, 0 usa el valor por defecto
+	OnlyAvailable bool   // Excluye los delitos reservados por otros moderadores
+	Actor         entities.Actor
+}
+
+// ListModerationQueueUseCase maneja la lógica de negocio para consultar la cola de moderación
+type ListModerationQueueUseCase struct {
+	moderationRepo repositories.ModerationRepository
+	now            func() time.Time
+}
+
+// NewListModerationQueueUseCase crea una nueva instancia del caso de uso
+func NewListModerationQueueUseCase(repo repositories.ModerationRepository) *ListModerationQueueUseCase {
+	return NewListModerationQueueUseCaseWithClock(repo, time.Now)
+}
+
+// NewListModerationQueueUseCaseWithClock crea el caso de uso con un reloj propio, útil en pruebas
+func NewListModerationQueueUseCaseWithClock(repo repositories.ModerationRepository, now func() time.Time) *ListModerationQueueUseCase {
+	return &ListModerationQueueUseCase{
+		moderationRepo: repo,
+		now:            now,
+	}
+}
+
+// Execute obtiene los delitos pendientes de moderación; solo disponible para moderadores
+func (uc *ListModerationQueueUseCase) Execute(ctx context.Context, input ListModerationQueueInput) ([]*entities.ModerationItem, error) {
+	if !input.Actor.IsStaff() {
+		return nil, ErrForbidden
+	}
+	if input.Sort != "" && input.Sort != ModerationSortAge && input.Sort != ModerationSortPriority {
+		return nil, ErrInvalidQueueSort
+	}
+
+	limit := input.Limit
+	if limit <= 0 {
+		limit = defaultModerationQueueLimit
+	}
+	if limit > maxModerationQueueLimit {
+		limit = maxModerationQueueLimit
+	}
+
+	filter := repositories.ModerationQueueFilter{
+		Now:             uc.now(),
+		DefaultPriority: defaultModerationPriority,
+		Limit:           limit,
+	}
+	if input.Sort == ModerationSortPriority {
+		filter.Priorities = moderationPriorities
+	}
+	if input.OnlyAvailable {
+		filter.AvailableTo = input.Actor.ID
+	}
+
+	items, err := uc.moderationRepo.ListPending(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	for _, item := range items {
+		item.Priority = ModerationPriority(item.Crime.Type)
+	}
+	return items, nil
+}
+
+// ModerationPriority retorna la prioridad de revisión de un tipo de delito
+func ModerationPriority(crimeType string) int {
+	if priority, ok := moderationPriorities[crimeType]; ok {
+		return priority
+	}
+	return defaultModerationPriority
+}
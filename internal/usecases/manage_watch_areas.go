@@ -0,0 +1,273 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/mail"
+	"net/url"
+	"strings"
+	"time"
+
+	"go-crime_map_backend/internal/domain/entities"
+	"go-crime_map_backend/internal/domain/repositories"
+)
+
+var (
+	// ErrWatchAreaNotFound se retorna cuando la zona no existe o pertenece a otro usuario
+	ErrWatchAreaNotFound = errors.New("zona vigilada no encontrada")
+
+	// ErrInvalidWatchAreaGeometry se retorna cuando la zona no es un círculo o un polígono válido
+	ErrInvalidWatchAreaGeometry = errors.New("la zona debe ser un círculo de 50 m a 50 km de radio o un polígono de 3 a 500 vértices")
+
+	// ErrWatchAreaNameTooLong se retorna cuando el nombre de la zona excede el límite de caracteres
+	ErrWatchAreaNameTooLong = errors.New("el nombre de la zona no puede exceder los 100 caracteres")
+
+	// ErrInvalidQuietHours se retorna cuando la franja silenciosa es inválida
+	ErrInvalidQuietHours = errors.New("la franja silenciosa debe tener horarios HH:MM distintos y una zona horaria válida")
+
+	// ErrInvalidNotificationChannel se retorna cuando los canales de aviso son inválidos
+	ErrInvalidNotificationChannel = errors.New("los canales de aviso deben ser inbox, email o webhook")
+
+	// ErrInvalidEmail se retorna cuando el canal email no tiene una dirección válida
+	ErrInvalidEmail = errors.New("el canal email requiere una dirección de correo válida")
+
+	// ErrTooManyWatchAreas se retorna cuando el usuario alcanzó el máximo de zonas
+	ErrTooManyWatchAreas = errors.New("se alcanzó el máximo de zonas vigiladas por usuario")
+
+	// minWatchRadius y maxWatchRadius limitan el radio en metros de una zona circular
+	minWatchRadius, maxWatchRadius = 50.0, 50000.0
+
+	// maxPolygonVertices limita los vértices de una zona poligonal
+	maxPolygonVertices = 500
+
+	// maxWatchAreaNameLength define la longitud máxima del nombre de una zona
+	maxWatchAreaNameLength = 100
+)
+
+// WatchAreaInput representa los datos de una zona vigilada
+type WatchAreaInput struct {
+	Name       string
+	Circle     *entities.Circle
+	Polygon    entities.Polygon
+	CrimeTypes []string
+	QuietHours *entities.QuietHours
+	Channels   []entities.NotificationChannel
+	Email      string
+	WebhookURL string
+	Active     *bool // nil para activa
+}
+
+// CreateWatchAreaUseCase maneja la lógica de negocio para crear una zona vigilada
+type CreateWatchAreaUseCase struct {
+	alertRepo repositories.AlertRepository
+	maxAreas  int
+}
+
+// NewCreateWatchAreaUseCase crea una nueva instancia del caso de uso; maxAreas limita las
+// zonas de cada usuario
+func NewCreateWatchAreaUseCase(repo repositories.AlertRepository, maxAreas int) *CreateWatchAreaUseCase {
+	return &CreateWatchAreaUseCase{
+		alertRepo: repo,
+		maxAreas:  maxAreas,
+	}
+}
+
+// Execute valida y guarda la zona del actor. Requiere un usuario autenticado
+func (uc *CreateWatchAreaUseCase) Execute(ctx context.Context, input WatchAreaInput, actor entities.Actor) (*entities.WatchArea, error) {
+	if actor.ID == "" {
+		return nil, ErrForbidden
+	}
+	if err := validateWatchArea(input); err != nil {
+		return nil, err
+	}
+
+	existing, err := uc.alertRepo.ListWatchAreas(ctx, actor.ID)
+	if err != nil {
+		return nil, err
+	}
+	if len(existing) >= uc.maxAreas {
+		return nil, ErrTooManyWatchAreas
+	}
+
+	now := time.Now()
+	area := &entities.WatchArea{
+		ID:        generateID(),
+		OwnerID:   actor.ID,
+		CreatedAt: now,
+	}
+	applyWatchAreaInput(area, input, now)
+	if err := uc.alertRepo.CreateWatchArea(ctx, area); err != nil {
+		return nil, err
+	}
+
+	slog.InfoContext(ctx, "zona vigilada creada", slog.String("area_id", area.ID), slog.String("actor_id", actor.ID))
+	return area, nil
+}
+
+// ListWatchAreasUseCase maneja la lógica de negocio para listar las zonas de un usuario
+type ListWatchAreasUseCase struct {
+	alertRepo repositories.AlertRepository
+}
+
+// NewListWatchAreasUseCase crea una nueva instancia del caso de uso
+func NewListWatchAreasUseCase(repo repositories.AlertRepository) *ListWatchAreasUseCase {
+	return &ListWatchAreasUseCase{
+		alertRepo: repo,
+	}
+}
+
+// Execute obtiene las zonas del actor. Requiere un usuario autenticado
+func (uc *ListWatchAreasUseCase) Execute(ctx context.Context, actor entities.Actor) ([]*entities.WatchArea, error) {
+	if actor.ID == "" {
+		return nil, ErrForbidden
+	}
+	return uc.alertRepo.ListWatchAreas(ctx, actor.ID)
+}
+
+// UpdateWatchAreaUseCase maneja la lógica de negocio para modificar una zona vigilada
+type UpdateWatchAreaUseCase struct {
+	alertRepo repositories.AlertRepository
+}
+
+// NewUpdateWatchAreaUseCase crea una nueva instancia del caso de uso
+func NewUpdateWatchAreaUseCase(repo repositories.AlertRepository) *UpdateWatchAreaUseCase {
+	return &UpdateWatchAreaUseCase{
+		alertRepo: repo,
+	}
+}
+
+// Execute reemplaza los datos de una zona del actor
+func (uc *UpdateWatchAreaUseCase) Execute(ctx context.Context, id string, input WatchAreaInput, actor entities.Actor) (*entities.WatchArea, error) {
+	if actor.ID == "" {
+		return nil, ErrForbidden
+	}
+	if err := validateWatchArea(input); err != nil {
+		return nil, err
+	}
+
+	area, err := uc.alertRepo.GetWatchArea(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	// Las zonas de otros usuarios se tratan como inexistentes para no revelarlas
+	if area == nil || area.OwnerID != actor.ID {
+		return nil, ErrWatchAreaNotFound
+	}
+
+	applyWatchAreaInput(area, input, time.Now())
+	if err := uc.alertRepo.UpdateWatchArea(ctx, area); err != nil {
+		return nil, err
+	}
+	return area, nil
+}
+
+// DeleteWatchAreaUseCase maneja la lógica de negocio para eliminar una zona vigilada
+type DeleteWatchAreaUseCase struct {
+	alertRepo repositories.AlertRepository
+}
+
+// NewDeleteWatchAreaUseCase crea una nueva instancia del caso de uso
+func NewDeleteWatchAreaUseCase(repo repositories.AlertRepository) *DeleteWatchAreaUseCase {
+	return &DeleteWatchAreaUseCase{
+		alertRepo: repo,
+	}
+}
+
+// Execute elimina una zona del actor junto con sus avisos
+func (uc *DeleteWatchAreaUseCase) Execute(ctx context.Context, id string, actor entities.Actor) error {
+	if actor.ID == "" {
+		return ErrForbidden
+	}
+
+	area, err := uc.alertRepo.GetWatchArea(ctx, id)
+	if err != nil {
+		return err
+	}
+	if area == nil || area.OwnerID != actor.ID {
+		return ErrWatchAreaNotFound
+	}
+	if _, err := uc.alertRepo.DeleteWatchArea(ctx, id); err != nil {
+		return err
+	}
+
+	slog.InfoContext(ctx, "zona vigilada eliminada", slog.String("area_id", id), slog.String("actor_id", actor.ID))
+	return nil
+}
+
+// validateWatchArea valida la geometría, los filtros y los canales de la zona
+func validateWatchArea(input WatchAreaInput) error {
+	if len(input.Name) > maxWatchAreaNameLength {
+		return ErrWatchAreaNameTooLong
+	}
+
+	switch {
+	case input.Circle != nil && input.Polygon == nil:
+		circle := input.Circle
+		if !circle.Center.IsValid() || circle.RadiusMeters < minWatchRadius || circle.RadiusMeters > maxWatchRadius {
+			return ErrInvalidWatchAreaGeometry
+		}
+	case input.Circle == nil && input.Polygon != nil:
+		if !input.Polygon.IsValid() || len(input.Polygon) > maxPolygonVertices {
+			return ErrInvalidWatchAreaGeometry
+		}
+	default:
+		return ErrInvalidWatchAreaGeometry
+	}
+
+	for _, crimeType := range input.CrimeTypes {
+		if !validCrimeTypes[crimeType] {
+			return ErrInvalidType
+		}
+	}
+	if input.QuietHours != nil && !input.QuietHours.IsValid() {
+		return ErrInvalidQuietHours
+	}
+
+	if len(input.Channels) == 0 {
+		return ErrInvalidNotificationChannel
+	}
+	for _, channel := range input.Channels {
+		switch {
+		case !channel.IsValid():
+			return ErrInvalidNotificationChannel
+		case channel == entities.NotificationEmail:
+			if address, err := mail.ParseAddress(input.Email); err != nil || address.Address != input.Email {
+				return ErrInvalidEmail
+			}
+		case channel == entities.NotificationWebhook:
+			parsed, err := url.Parse(input.WebhookURL)
+			if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+				return ErrInvalidWebhookURL
+			}
+		}
+	}
+	return nil
+}
+
+// applyWatchAreaInput copia los datos validados en la zona
+func applyWatchAreaInput(area *entities.WatchArea, input WatchAreaInput, now time.Time) {
+	area.Name = strings.TrimSpace(input.Name)
+	area.Circle = input.Circle
+	area.Polygon = input.Polygon
+	area.CrimeTypes = input.CrimeTypes
+	area.QuietHours = input.QuietHours
+	area.Channels = uniqueChannels(input.Channels)
+	area.Email = input.Email
+	area.WebhookURL = input.WebhookURL
+	area.Active = input.Active == nil || *input.Active
+	area.UpdatedAt = now
+}
+
+// uniqueChannels elimina los canales repetidos conservando el orden
+func uniqueChannels(channels []entities.NotificationChannel) []entities.NotificationChannel {
+	seen := make(map[entities.NotificationChannel]bool, len(channels))
+	unique := make([]entities.NotificationChannel, 0, len(channels))
+	for _, channel := range channels {
+		if !seen[channel] {
+			seen[channel] = true
+			unique = append(unique, channel)
+		}
+	}
+	return unique
+}
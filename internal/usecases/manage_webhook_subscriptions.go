@@ -0,0 +1,59 @@
+package usecases
+
+import (
+	"context"
+	"log/slog"
+
+	"go-crime_map_backend/internal/domain/entities"
+	"go-crime_map_backend/internal/domain/repositories"
+)
+
+// ListWebhookSubscriptionsUseCase maneja la lógica de negocio para listar los webhooks suscritos
+type ListWebhookSubscriptionsUseCase struct {
+	webhookRepo repositories.WebhookRepository
+}
+
+// NewListWebhookSubscriptionsUseCase crea una nueva instancia del caso de uso
+func NewListWebhookSubscriptionsUseCase(repo repositories.WebhookRepository) *ListWebhookSubscriptionsUseCase {
+	return &ListWebhookSubscriptionsUseCase{
+		webhookRepo: repo,
+	}
+}
+
+// Execute obtiene todas las suscripciones. Solo disponible para administradores
+func (uc *ListWebhookSubscriptionsUseCase) Execute(ctx context.Context, actor entities.Actor) ([]*entities.WebhookSubscription, error) {
+	if actor.Role != entities.RoleAdmin {
+		return nil, ErrForbidden
+	}
+	return uc.webhookRepo.ListSubscriptions(ctx)
+}
+
+// DeleteWebhookSubscriptionUseCase maneja la lógica de negocio para dar de baja un webhook
+type DeleteWebhookSubscriptionUseCase struct {
+	webhookRepo repositories.WebhookRepository
+}
+
+// NewDeleteWebhookSubscriptionUseCase crea una nueva instancia del caso de uso
+func NewDeleteWebhookSubscriptionUseCase(repo repositories.WebhookRepository) *DeleteWebhookSubscriptionUseCase {
+	return &DeleteWebhookSubscriptionUseCase{
+		webhookRepo: repo,
+	}
+}
+
+// Execute elimina la suscripción y sus entregas pendientes. Solo disponible para administradores
+func (uc *DeleteWebhookSubscriptionUseCase) Execute(ctx context.Context, id string, actor entities.Actor) error {
+	if actor.Role != entities.RoleAdmin {
+		return ErrForbidden
+	}
+
+	deleted, err := uc.webhookRepo.DeleteSubscription(ctx, id)
+	if err != nil {
+		return err
+	}
+	if !deleted {
+		return ErrWebhookNotFound
+	}
+
+	slog.InfoContext(ctx, "webhook dado de baja", slog.String("subscription_id", id), slog.String("actor_id", actor.ID))
+	return nil
+}
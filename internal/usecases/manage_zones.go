@@ -0,0 +1,309 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"go-crime_map_backend/internal/domain/entities"
+	"go-crime_map_backend/internal/domain/repositories"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var (
+	// ErrZoneNotFound se retorna cuando la zona no existe
+	ErrZoneNotFound = errors.New("la zona no existe")
+
+	// ErrInvalidZoneName se retorna cuando el nombre de la zona está vacío o es demasiado largo
+	ErrInvalidZoneName = errors.New("el nombre de la zona es requerido y no puede exceder los 100 caracteres")
+
+	// ErrInvalidZoneKind se retorna cuando el tipo de zona está vacío o es demasiado largo
+	ErrInvalidZoneKind = errors.New("el tipo de zona es requerido y no puede exceder los 50 caracteres")
+
+	// ErrInvalidZoneGeometry se retorna cuando la geometría de la zona es inválida
+	ErrInvalidZoneGeometry = errors.New("la geometría de la zona debe ser un Polygon o MultiPolygon válido de hasta 100000 vértices")
+
+	// ErrInvalidZonePopulation se retorna cuando la población de la zona es negativa o excesiva
+	ErrInvalidZonePopulation = errors.New("la población de la zona debe estar entre 0 y 100000000")
+
+	// ErrDuplicateZone se retorna cuando ya existe una zona con el mismo nombre y tipo
+	ErrDuplicateZone = errors.New("ya existe una zona con el mismo nombre y tipo")
+)
+
+const (
+	// maxZoneNameLength es la longitud máxima del nombre de una zona
+	maxZoneNameLength = 100
+
+	// maxZoneKindLength es la longitud máxima del tipo de zona
+	maxZoneKindLength = 50
+
+	// maxZoneVertices limita los vértices de una zona para acotar el costo de cada búsqueda
+	maxZoneVertices = 100000
+
+	// maxZonePopulation es la población máxima de una zona
+	maxZonePopulation = 100000000
+)
+
+// ZoneInput representa los datos editables de una zona
+type ZoneInput struct {
+	Name       string
+	Kind       string
+	Population int // Cantidad de habitantes, 0 si se desconoce
+	Geometry   entities.MultiPolygon
+}
+
+// validateZoneInput valida los datos de una zona y retorna el nombre y el tipo normalizados
+func validateZoneInput(input ZoneInput) (string, string, error) {
+	name, kind := strings.TrimSpace(input.Name), strings.ToLower(strings.TrimSpace(input.Kind))
+	if name == "" || utf8.RuneCountInString(name) > maxZoneNameLength {
+		return "", "", ErrInvalidZoneName
+	}
+	if kind == "" || utf8.RuneCountInString(kind) > maxZoneKindLength {
+		return "", "", ErrInvalidZoneKind
+	}
+	if input.Population < 0 || input.Population > maxZonePopulation {
+		return "", "", ErrInvalidZonePopulation
+	}
+	if !input.Geometry.IsValid() || input.Geometry.Vertices() > maxZoneVertices {
+		return "", "", ErrInvalidZoneGeometry
+	}
+	return name, kind, nil
+}
+
+// findZoneByName obtiene la zona con el nombre y el tipo indicados, nil si no existe
+func findZoneByName(ctx context.Context, repo repositories.ZoneRepository, kind, name string) (*entities.Zone, error) {
+	zones, err := repo.ListZones(ctx, kind)
+	if err != nil {
+		return nil, err
+	}
+	for _, zone := range zones {
+		if strings.EqualFold(zone.Name, name) {
+			return zone, nil
+		}
+	}
+	return nil, nil
+}
+
+// CreateZoneUseCase maneja la lógica de negocio para crear una zona
+type CreateZoneUseCase struct {
+	zoneRepo  repositories.ZoneRepository
+	crimeRepo repositories.CrimeRepository
+	locator   *ZoneLocator
+}
+
+// NewCreateZoneUseCase crea una nueva instancia del caso de uso
+func NewCreateZoneUseCase(zoneRepo repositories.ZoneRepository, crimeRepo repositories.CrimeRepository) *CreateZoneUseCase {
+	return &CreateZoneUseCase{
+		zoneRepo:  zoneRepo,
+		crimeRepo: crimeRepo,
+		locator:   NewZoneLocator(zoneRepo),
+	}
+}
+
+// Execute crea la zona y reasigna la zona de los delitos. Solo disponible para administradores
+func (uc *CreateZoneUseCase) Execute(ctx context.Context, input ZoneInput, actor entities.Actor) (_ *entities.Zone, err error) {
+	ctx, span := tracer.Start(ctx, "CreateZoneUseCase.Execute")
+	defer func() { endSpan(span, err) }()
+
+	if actor.Role != entities.RoleAdmin {
+		return nil, ErrForbidden
+	}
+	name, kind, err := validateZoneInput(input)
+	if err != nil {
+		return nil, err
+	}
+	existing, err := findZoneByName(ctx, uc.zoneRepo, kind, name)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return nil, ErrDuplicateZone
+	}
+
+	now := time.Now()
+	zone := &entities.Zone{
+		ID:         generateID(),
+		Name:       name,
+		Kind:       kind,
+		Population: input.Population,
+		Geometry:   input.Geometry,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}
+	if err := uc.zoneRepo.CreateZone(ctx, zone); err != nil {
+		return nil, err
+	}
+	relocated, err := uc.locator.Relocate(ctx, uc.crimeRepo)
+	if err != nil {
+		return nil, err
+	}
+
+	span.SetAttributes(attribute.String("zone.id", zone.ID))
+	slog.InfoContext(ctx, "zona creada",
+		slog.String("zone_id", zone.ID),
+		slog.String("kind", zone.Kind),
+		slog.Int("relocated", relocated),
+		slog.String("actor_id", actor.ID),
+	)
+	return zone, nil
+}
+
+// ListZonesUseCase maneja la lógica de negocio para listar las zonas
+type ListZonesUseCase struct {
+	zoneRepo repositories.ZoneRepository
+}
+
+// NewListZonesUseCase crea una nueva instancia del caso de uso
+func NewListZonesUseCase(repo repositories.ZoneRepository) *ListZonesUseCase {
+	return &ListZonesUseCase{
+		zoneRepo: repo,
+	}
+}
+
+// Execute obtiene las zonas del tipo indicado, o todas si kind está vacío. Las zonas son públicas
+func (uc *ListZonesUseCase) Execute(ctx context.Context, kind string) ([]*entities.Zone, error) {
+	return uc.zoneRepo.ListZones(ctx, strings.ToLower(strings.TrimSpace(kind)))
+}
+
+// GetZoneUseCase maneja la lógica de negocio para obtener una zona
+type GetZoneUseCase struct {
+	zoneRepo repositories.ZoneRepository
+}
+
+// NewGetZoneUseCase crea una nueva instancia del caso de uso
+func NewGetZoneUseCase(repo repositories.ZoneRepository) *GetZoneUseCase {
+	return &GetZoneUseCase{
+		zoneRepo: repo,
+	}
+}
+
+// Execute obtiene una zona por su ID
+func (uc *GetZoneUseCase) Execute(ctx context.Context, id string) (*entities.Zone, error) {
+	zone, err := uc.zoneRepo.GetZone(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if zone == nil {
+		return nil, ErrZoneNotFound
+	}
+	return zone, nil
+}
+
+// UpdateZoneUseCase maneja la lógica de negocio para actualizar una zona
+type UpdateZoneUseCase struct {
+	zoneRepo  repositories.ZoneRepository
+	crimeRepo repositories.CrimeRepository
+	locator   *ZoneLocator
+}
+
+// NewUpdateZoneUseCase crea una nueva instancia del caso de uso
+func NewUpdateZoneUseCase(zoneRepo repositories.ZoneRepository, crimeRepo repositories.CrimeRepository) *UpdateZoneUseCase {
+	return &UpdateZoneUseCase{
+		zoneRepo:  zoneRepo,
+		crimeRepo: crimeRepo,
+		locator:   NewZoneLocator(zoneRepo),
+	}
+}
+
+// Execute reemplaza los datos de la zona y reasigna la zona de los delitos. Solo disponible
+// para administradores
+func (uc *UpdateZoneUseCase) Execute(ctx context.Context, id string, input ZoneInput, actor entities.Actor) (_ *entities.Zone, err error) {
+	ctx, span := tracer.Start(ctx, "UpdateZoneUseCase.Execute",
+		trace.WithAttributes(attribute.String("zone.id", id)))
+	defer func() { endSpan(span, err) }()
+
+	if actor.Role != entities.RoleAdmin {
+		return nil, ErrForbidden
+	}
+	name, kind, err := validateZoneInput(input)
+	if err != nil {
+		return nil, err
+	}
+
+	zone, err := uc.zoneRepo.GetZone(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if zone == nil {
+		return nil, ErrZoneNotFound
+	}
+	existing, err := findZoneByName(ctx, uc.zoneRepo, kind, name)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil && existing.ID != zone.ID {
+		return nil, ErrDuplicateZone
+	}
+
+	zone.Name = name
+	zone.Kind = kind
+	zone.Population = input.Population
+	zone.Geometry = input.Geometry
+	zone.UpdatedAt = time.Now()
+	if err := uc.zoneRepo.UpdateZone(ctx, zone); err != nil {
+		return nil, err
+	}
+	relocated, err := uc.locator.Relocate(ctx, uc.crimeRepo)
+	if err != nil {
+		return nil, err
+	}
+
+	slog.InfoContext(ctx, "zona actualizada",
+		slog.String("zone_id", zone.ID),
+		slog.Int("relocated", relocated),
+		slog.String("actor_id", actor.ID),
+	)
+	return zone, nil
+}
+
+// DeleteZoneUseCase maneja la lógica de negocio para eliminar una zona
+type DeleteZoneUseCase struct {
+	zoneRepo  repositories.ZoneRepository
+	crimeRepo repositories.CrimeRepository
+	locator   *ZoneLocator
+}
+
+// NewDeleteZoneUseCase crea una nueva instancia del caso de uso
+func NewDeleteZoneUseCase(zoneRepo repositories.ZoneRepository, crimeRepo repositories.CrimeRepository) *DeleteZoneUseCase {
+	return &DeleteZoneUseCase{
+		zoneRepo:  zoneRepo,
+		crimeRepo: crimeRepo,
+		locator:   NewZoneLocator(zoneRepo),
+	}
+}
+
+// Execute elimina la zona; sus delitos pasan a la zona superpuesta que los contenga, si existe.
+// Solo disponible para administradores
+func (uc *DeleteZoneUseCase) Execute(ctx context.Context, id string, actor entities.Actor) (err error) {
+	ctx, span := tracer.Start(ctx, "DeleteZoneUseCase.Execute",
+		trace.WithAttributes(attribute.String("zone.id", id)))
+	defer func() { endSpan(span, err) }()
+
+	if actor.Role != entities.RoleAdmin {
+		return ErrForbidden
+	}
+
+	deleted, err := uc.zoneRepo.DeleteZone(ctx, id)
+	if err != nil {
+		return err
+	}
+	if !deleted {
+		return ErrZoneNotFound
+	}
+	relocated, err := uc.locator.Relocate(ctx, uc.crimeRepo)
+	if err != nil {
+		return err
+	}
+
+	slog.InfoContext(ctx, "zona eliminada",
+		slog.String("zone_id", id),
+		slog.Int("relocated", relocated),
+		slog.String("actor_id", actor.ID),
+	)
+	return nil
+}
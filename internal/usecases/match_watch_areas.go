@@ -0,0 +1,137 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go-crime_map_backend/internal/domain/entities"
+	"go-crime_map_backend/internal/domain/events"
+	"go-crime_map_backend/internal/domain/repositories"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// WatchAreaMatcher es el handler de eventos de dominio que avisa a los usuarios de los delitos
+// ocurridos en sus zonas vigiladas. Cada delito nuevo se avisa al reportarse, aunque no esté
+// verificado, y el aviso lo indica; al verificarse se vuelve a buscar las zonas, pero el
+// repositorio descarta los avisos repetidos. El envío por email y webhook lo realiza
+// DeliverNotificationsUseCase
+type WatchAreaMatcher struct {
+	alertRepo repositories.AlertRepository
+	now       func() time.Time
+}
+
+// NewWatchAreaMatcher crea el handler que genera los avisos de las zonas vigiladas
+func NewWatchAreaMatcher(repo repositories.AlertRepository) *WatchAreaMatcher {
+	return NewWatchAreaMatcherWithClock(repo, time.Now)
+}
+
+// NewWatchAreaMatcherWithClock crea el handler con un reloj propio, útil en pruebas
+func NewWatchAreaMatcherWithClock(repo repositories.AlertRepository, now func() time.Time) *WatchAreaMatcher {
+	return &WatchAreaMatcher{
+		alertRepo: repo,
+		now:       now,
+	}
+}
+
+// Handle genera los avisos del delito. Es idempotente: el repositorio ignora los avisos que
+// ya existen para la misma zona, delito y canal
+func (m *WatchAreaMatcher) Handle(ctx context.Context, event events.Event) (err error) {
+	var crime *entities.Crime
+	switch event.Type {
+	case events.CrimeReported:
+		var payload events.CrimeReportedPayload
+		if err := event.Decode(&payload); err != nil {
+			return err
+		}
+		crime = payload.Crime
+	case events.CrimeStatusChanged:
+		var payload events.CrimeStatusChangedPayload
+		if err := event.Decode(&payload); err != nil {
+			return err
+		}
+		if payload.Change == nil || payload.Change.From.IsPublic() || !payload.Change.To.IsPublic() {
+			return nil
+		}
+		crime = payload.Crime
+	}
+	if crime == nil {
+		return nil
+	}
+
+	ctx, span := tracer.Start(ctx, "WatchAreaMatcher.Handle", trace.WithAttributes(attribute.String("crime.id", crime.ID)))
+	defer func() { endSpan(span, err) }()
+
+	// El índice espacial retorna las zonas cuyo rectángulo contiene el delito
+	candidates, err := m.alertRepo.FindWatchAreas(ctx, crime.Location)
+	if err != nil {
+		return err
+	}
+
+	now := m.now()
+	var notifications []*entities.Notification
+	for _, area := range candidates {
+		if !area.Matches(crime) {
+			continue
+		}
+		for _, channel := range area.Channels {
+			notifications = append(notifications, newNotification(area, channel, crime, now))
+		}
+	}
+	span.SetAttributes(attribute.Int("alert.candidates", len(candidates)), attribute.Int("alert.notifications", len(notifications)))
+	if len(notifications) == 0 {
+		return nil
+	}
+	return m.alertRepo.EnqueueNotifications(ctx, notifications)
+}
+
+// newNotification crea el aviso de la zona para el canal. Los avisos de la bandeja quedan
+// entregados; los demás se envían de inmediato o al terminar la franja silenciosa
+func newNotification(area *entities.WatchArea, channel entities.NotificationChannel, crime *entities.Crime, now time.Time) *entities.Notification {
+	notification := &entities.Notification{
+		ID:        generateID(),
+		UserID:    area.OwnerID,
+		AreaID:    area.ID,
+		CrimeID:   crime.ID,
+		Channel:   channel,
+		Target:    area.Target(channel),
+		Title:     notificationTitle(area, crime),
+		Body:      fmt.Sprintf("%s en %s el %s", crime.Type, crime.Location.Address, crime.Date.Format("02/01/2006 15:04")),
+		Crime:     crime,
+		Status:    entities.NotificationPending,
+		CreatedAt: now,
+	}
+
+	if channel == entities.NotificationInbox {
+		notification.Status = entities.NotificationSent
+		notification.SentAt = &now
+		return notification
+	}
+
+	nextAttemptAt := now
+	if area.QuietHours != nil {
+		if until, quiet := area.QuietHours.Until(now); quiet {
+			nextAttemptAt = until
+		}
+	}
+	notification.NextAttemptAt = &nextAttemptAt
+	return notification
+}
+
+// notificationTitle retorna el título del aviso, que advierte si el delito no está verificado
+func notificationTitle(area *entities.WatchArea, crime *entities.Crime) string {
+	if !crime.Status.IsPublic() {
+		return fmt.Sprintf("Nuevo reporte sin verificar en %s", watchAreaLabel(area))
+	}
+	return fmt.Sprintf("Nuevo delito en %s", watchAreaLabel(area))
+}
+
+// watchAreaLabel retorna el nombre con el que se menciona la zona en los avisos
+func watchAreaLabel(area *entities.WatchArea) string {
+	if area.Name != "" {
+		return area.Name
+	}
+	return "tu zona vigilada"
+}
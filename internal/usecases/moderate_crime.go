@@ -0,0 +1,105 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go-crime_map_backend/internal/domain/entities"
+	"go-crime_map_backend/internal/domain/repositories"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	// ModerationApprove verifica el delito reportado
+	ModerationApprove = "approve"
+
+	// ModerationReject rechaza el delito reportado; requiere una nota
+	ModerationReject = "reject"
+)
+
+// ErrInvalidDecision se retorna cuando la decisión de moderación no existe
+var ErrInvalidDecision = errors.New("la decisión debe ser approve o reject")
+
+// moderationDecisions asocia cada decisión con el estado al que lleva el delito
+var moderationDecisions = map[string]entities.CrimeStatus{
+	ModerationApprove: entities.CrimeStatusVerified,
+	ModerationReject:  entities.CrimeStatusRejected,
+}
+
+// ModerateCrimeInput representa la decisión de un moderador sobre un delito reservado
+type ModerateCrimeInput struct {
+	CrimeID  string
+	Decision string // approve o reject
+	Note     string // Nota del moderador, se guarda como motivo en el historial
+	Actor    entities.Actor
+}
+
+// ModerateCrimeUseCase maneja la aprobación o el rechazo de los delitos de la cola de moderación
+type ModerateCrimeUseCase struct {
+	crimeRepo      repositories.CrimeRepository
+	moderationRepo repositories.ModerationRepository
+	transition     *TransitionCrimeStatusUseCase
+	now            func() time.Time
+}
+
+// NewModerateCrimeUseCase crea una nueva instancia del caso de uso
+func NewModerateCrimeUseCase(crimeRepo repositories.CrimeRepository, moderationRepo repositories.ModerationRepository) *ModerateCrimeUseCase {
+	return NewModerateCrimeUseCaseWithClock(crimeRepo, moderationRepo, time.Now)
+}
+
+// NewModerateCrimeUseCaseWithClock crea el caso de uso con un reloj propio, útil en pruebas
+func NewModerateCrimeUseCaseWithClock(crimeRepo repositories.CrimeRepository, moderationRepo repositories.ModerationRepository, now func() time.Time) *ModerateCrimeUseCase {
+	return &ModerateCrimeUseCase{
+		crimeRepo:      crimeRepo,
+		moderationRepo: moderationRepo,
+		transition:     NewTransitionCrimeStatusUseCase(crimeRepo),
+		now:            now,
+	}
+}
+
+// Execute aplica la decisión si el moderador tiene la reserva vigente del delito.
+// El cambio de estado libera la reserva.
+func (uc *ModerateCrimeUseCase) Execute(ctx context.Context, input ModerateCrimeInput) (_ *entities.Crime, err error) {
+	ctx, span := tracer.Start(ctx, "ModerateCrimeUseCase.Execute", trace.WithAttributes(
+		attribute.String("crime.id", input.CrimeID),
+		attribute.String("moderation.decision", input.Decision),
+	))
+	defer func() { endSpan(span, err) }()
+
+	if !input.Actor.IsStaff() {
+		return nil, ErrForbidden
+	}
+	status, ok := moderationDecisions[input.Decision]
+	if !ok {
+		return nil, ErrInvalidDecision
+	}
+
+	crime, err := uc.crimeRepo.GetByID(ctx, input.CrimeID)
+	if err != nil {
+		return nil, err
+	}
+	if crime == nil {
+		return nil, ErrCrimeNotFound
+	}
+	if crime.Status != entities.CrimeStatusReported {
+		return nil, ErrNotPending
+	}
+
+	claim, err := uc.moderationRepo.GetClaim(ctx, crime.ID, uc.now())
+	if err != nil {
+		return nil, err
+	}
+	if claim == nil || claim.ModeratorID != input.Actor.ID {
+		return nil, ErrClaimNotHeld
+	}
+
+	return uc.transition.Execute(ctx, TransitionCrimeStatusInput{
+		CrimeID: crime.ID,
+		Status:  status,
+		Reason:  input.Note,
+		Actor:   input.Actor,
+	})
+}
@@ -0,0 +1,79 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go-crime_map_backend/internal/domain/entities"
+	"go-crime_map_backend/internal/domain/repositories"
+)
+
+var (
+	// ErrNotificationNotFound se retorna cuando el aviso no existe o pertenece a otro usuario
+	ErrNotificationNotFound = errors.New("aviso no encontrado")
+
+	// maxNotificationsLimit limita la cantidad de avisos retornados por consulta
+	maxNotificationsLimit = 200
+)
+
+// ListNotificationsInput representa los criterios para consultar la bandeja de avisos
+type ListNotificationsInput struct {
+	UnreadOnly bool
+	Limit      int
+	Actor      entities.Actor
+}
+
+// ListNotificationsUseCase maneja la lógica de negocio de la bandeja de avisos de un usuario
+type ListNotificationsUseCase struct {
+	alertRepo repositories.AlertRepository
+}
+
+// NewListNotificationsUseCase crea una nueva instancia del caso de uso
+func NewListNotificationsUseCase(repo repositories.AlertRepository) *ListNotificationsUseCase {
+	return &ListNotificationsUseCase{
+		alertRepo: repo,
+	}
+}
+
+// Execute obtiene los avisos de la bandeja del actor, del más reciente al más antiguo
+func (uc *ListNotificationsUseCase) Execute(ctx context.Context, input ListNotificationsInput) ([]*entities.Notification, error) {
+	if input.Actor.ID == "" {
+		return nil, ErrForbidden
+	}
+	if input.Limit <= 0 || input.Limit > maxNotificationsLimit {
+		input.Limit = maxNotificationsLimit
+	}
+	return uc.alertRepo.ListNotifications(ctx, input.Actor.ID, repositories.NotificationFilter{
+		Channel:    entities.NotificationInbox,
+		UnreadOnly: input.UnreadOnly,
+		Limit:      input.Limit,
+	})
+}
+
+// MarkNotificationReadUseCase maneja la lógica de negocio para marcar un aviso como leído
+type MarkNotificationReadUseCase struct {
+	alertRepo repositories.AlertRepository
+}
+
+// NewMarkNotificationReadUseCase crea una nueva instancia del caso de uso
+func NewMarkNotificationReadUseCase(repo repositories.AlertRepository) *MarkNotificationReadUseCase {
+	return &MarkNotificationReadUseCase{
+		alertRepo: repo,
+	}
+}
+
+// Execute marca el aviso del actor como leído; marcarlo de nuevo no cambia la fecha de lectura
+func (uc *MarkNotificationReadUseCase) Execute(ctx context.Context, id string, actor entities.Actor) error {
+	if actor.ID == "" {
+		return ErrForbidden
+	}
+	found, err := uc.alertRepo.MarkNotificationRead(ctx, actor.ID, id, time.Now())
+	if err != nil {
+		return err
+	}
+	if !found {
+		return ErrNotificationNotFound
+	}
+	return nil
+}
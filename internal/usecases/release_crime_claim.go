@@ -0,0 +1,34 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+
+	"go-crime_map_backend/internal/domain/entities"
+	"go-crime_map_backend/internal/domain/repositories"
+)
+
+// ReleaseCrimeClaimUseCase maneja la liberación de una reserva de moderación
+type ReleaseCrimeClaimUseCase struct {
+	moderationRepo repositories.ModerationRepository
+}
+
+// NewReleaseCrimeClaimUseCase crea una nueva instancia del caso de uso
+func NewReleaseCrimeClaimUseCase(repo repositories.ModerationRepository) *ReleaseCrimeClaimUseCase {
+	return &ReleaseCrimeClaimUseCase{
+		moderationRepo: repo,
+	}
+}
+
+// Execute libera la reserva del delito para que otro moderador pueda tomarlo
+func (uc *ReleaseCrimeClaimUseCase) Execute(ctx context.Context, crimeID string, actor entities.Actor) error {
+	if !actor.IsStaff() {
+		return ErrForbidden
+	}
+
+	err := uc.moderationRepo.ReleaseClaim(ctx, crimeID, actor.ID)
+	if errors.Is(err, repositories.ErrClaimConflict) {
+		return ErrClaimNotHeld
+	}
+	return err
+}
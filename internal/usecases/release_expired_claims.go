@@ -0,0 +1,44 @@
+package usecases
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"go-crime_map_backend/internal/domain/repositories"
+)
+
+// ReleaseExpiredClaimsUseCase libera las reservas de moderación vencidas
+type ReleaseExpiredClaimsUseCase struct {
+	moderationRepo repositories.ModerationRepository
+	now            func() time.Time
+}
+
+// NewReleaseExpiredClaimsUseCase crea una nueva instancia del caso de uso
+func NewReleaseExpiredClaimsUseCase(repo repositories.ModerationRepository) *ReleaseExpiredClaimsUseCase {
+	return NewReleaseExpiredClaimsUseCaseWithClock(repo, time.Now)
+}
+
+// NewReleaseExpiredClaimsUseCaseWithClock crea el caso de uso con un reloj propio, útil en pruebas
+func NewReleaseExpiredClaimsUseCaseWithClock(repo repositories.ModerationRepository, now func() time.Time) *ReleaseExpiredClaimsUseCase {
+	return &ReleaseExpiredClaimsUseCase{
+		moderationRepo: repo,
+		now:            now,
+	}
+}
+
+// Execute libera las reservas vencidas y retorna cuántas liberó. Las reservas vencidas
+// ya no bloquean a otros moderadores; liberarlas mantiene limpia la tabla de reservas.
+func (uc *ReleaseExpiredClaimsUseCase) Execute(ctx context.Context) (_ int64, err error) {
+	ctx, span := tracer.Start(ctx, "ReleaseExpiredClaimsUseCase.Execute")
+	defer func() { endSpan(span, err) }()
+
+	released, err := uc.moderationRepo.ReleaseExpiredClaims(ctx, uc.now())
+	if err != nil {
+		return 0, err
+	}
+	if released > 0 {
+		slog.InfoContext(ctx, "reservas de moderación vencidas liberadas", slog.Int64("released", released))
+	}
+	return released, nil
+}
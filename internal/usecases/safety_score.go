@@ -0,0 +1,278 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"math"
+	"sort"
+	"time"
+
+	"go-crime_map_backend/internal/domain/entities"
+	"go-crime_map_backend/internal/domain/repositories"
+	"go-crime_map_backend/pkg/spatial"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	// defaultSafetyRadius es el radio en metros alrededor del punto o de la ruta si no se indica
+	defaultSafetyRadius = 500
+
+	// defaultSafetyHalfLifeDays es la vida media del peso de un delito si no se indica
+	defaultSafetyHalfLifeDays = 90
+
+	// maxSafetyRouteVertices es la cantidad máxima de vértices de una ruta
+	maxSafetyRouteVertices = 1000
+
+	// maxSafetyRouteMeters es la longitud máxima de una ruta
+	maxSafetyRouteMeters = 100000
+)
+
+var (
+	// ErrInvalidSafetyLocation se retorna cuando falta el punto o la ruta o tienen coordenadas inválidas
+	ErrInvalidSafetyLocation = errors.New("se debe indicar un punto o una ruta con coordenadas válidas")
+
+	// ErrInvalidSafetyRoute se retorna cuando la ruta no tiene entre 2 y 1000 vértices o excede los 100 km
+	ErrInvalidSafetyRoute = errors.New("la ruta debe tener entre 2 y 1000 vértices y no puede exceder los 100 km")
+
+	// ErrInvalidSafetyParameters se retorna cuando el radio o la vida media están fuera de rango
+	ErrInvalidSafetyParameters = errors.New("radius_meters debe estar entre 50 y 5000 y half_life_days entre 1 y 365")
+)
+
+// SafetyInput representa el área de un puntaje de seguridad: un punto o una ruta
+type SafetyInput struct {
+	Point        *entities.Coordinate  // Punto analizado, excluyente con Route
+	Route        []entities.Coordinate // Vértices de la ruta analizada, excluyente con Point
+	RadiusMeters float64               // Radio alrededor del punto o ancho a cada lado de la ruta, 500 por defecto
+	HalfLifeDays float64               // Días en que el peso de un delito se reduce a la mitad, 90 por defecto
+}
+
+// SafetyScoreResult representa el puntaje de seguridad de un punto o una ruta
+type SafetyScoreResult struct {
+	Score          float64                 `json:"score"` // De 0, sin delitos, a 100
+	Level          entities.SafetyLevel    `json:"level"`
+	RadiusMeters   float64                 `json:"radius_meters"`
+	HalfLifeDays   float64                 `json:"half_life_days"`
+	LengthMeters   float64                 `json:"length_meters,omitempty"` // Longitud de la ruta
+	AreaSqKm       float64                 `json:"area_sq_km"`              // Superficie analizada
+	From           time.Time               `json:"from"`
+	To             time.Time               `json:"to"`
+	CrimeCount     int                     `json:"crime_count"`     // Delitos dentro del área
+	WeightedCrimes float64                 `json:"weighted_crimes"` // Suma de los pesos de los delitos
+	Density        float64                 `json:"density"`         // Peso de los delitos por km²
+	Truncated      bool                    `json:"truncated,omitempty"`
+	Breakdown      []entities.SafetyFactor `json:"breakdown"` // De mayor a menor peso
+}
+
+// SafetyOptions representa la configuración del puntaje de seguridad
+type SafetyOptions struct {
+	DensityScale float64       // Densidad de delitos ponderados por km² que da un puntaje de 63
+	Lookback     time.Duration // Antigüedad máxima de los delitos considerados
+	MaxCrimes    int           // Delitos considerados como máximo; se descartan los más antiguos
+}
+
+// DefaultSafetyOptions retorna las opciones por defecto del puntaje de seguridad
+func DefaultSafetyOptions() SafetyOptions {
+	return SafetyOptions{
+		DensityScale: 50,
+		Lookback:     365 * 24 * time.Hour,
+		MaxCrimes:    defaultHotspotMaxCrimes,
+	}
+}
+
+// GetSafetyScoreUseCase calcula el puntaje de riesgo de un punto o de una ruta
+type GetSafetyScoreUseCase struct {
+	statsRepo repositories.StatsRepository
+	options   SafetyOptions
+	now       func() time.Time
+}
+
+// NewGetSafetyScoreUseCase crea una nueva instancia del caso de uso
+func NewGetSafetyScoreUseCase(repo repositories.StatsRepository, options SafetyOptions) *GetSafetyScoreUseCase {
+	return NewGetSafetyScoreUseCaseWithClock(repo, options, time.Now)
+}
+
+// NewGetSafetyScoreUseCaseWithClock crea el caso de uso con un reloj propio, útil en pruebas
+func NewGetSafetyScoreUseCaseWithClock(repo repositories.StatsRepository, options SafetyOptions, now func() time.Time) *GetSafetyScoreUseCase {
+	defaults := DefaultSafetyOptions()
+	if options.DensityScale <= 0 {
+		options.DensityScale = defaults.DensityScale
+	}
+	if options.Lookback <= 0 {
+		options.Lookback = defaults.Lookback
+	}
+	if options.MaxCrimes <= 0 {
+		options.MaxCrimes = defaults.MaxCrimes
+	}
+	return &GetSafetyScoreUseCase{
+		statsRepo: repo,
+		options:   options,
+		now:       now,
+	}
+}
+
+// Execute calcula la densidad de delitos públicos dentro del radio del punto o de la franja
+// alrededor de la ruta. Cada delito pesa su gravedad multiplicada por un decaimiento
+// exponencial según su antigüedad, y el puntaje es 100·(1 − e^(−densidad/DensityScale))
+func (uc *GetSafetyScoreUseCase) Execute(ctx context.Context, input SafetyInput) (_ *SafetyScoreResult, err error) {
+	ctx, span := tracer.Start(ctx, "GetSafetyScoreUseCase.Execute",
+		trace.WithAttributes(attribute.Int("safety.route_vertices", len(input.Route))))
+	defer func() { endSpan(span, err) }()
+
+	result, err := input.parameters()
+	if err != nil {
+		return nil, err
+	}
+	area, err := newSafetyArea(input, result.RadiusMeters)
+	if err != nil {
+		return nil, err
+	}
+	result.LengthMeters = area.length
+	result.AreaSqKm = area.squareMeters() / 1e6
+
+	now := uc.now()
+	result.From, result.To = now.Add(-uc.options.Lookback), now
+	bounds := area.bounds()
+	points, err := uc.statsRepo.ListPoints(ctx, repositories.StatsFilter{
+		CrimeFilter: repositories.CrimeFilter{Statuses: entities.PublicCrimeStatuses},
+		BoundingBox: &bounds,
+		From:        result.From,
+		To:          result.To,
+	}, uc.options.MaxCrimes)
+	if err != nil {
+		return nil, err
+	}
+	result.Truncated = len(points) >= uc.options.MaxCrimes
+
+	halfLife := result.HalfLifeDays * 24 * float64(time.Hour)
+	factors := make(map[string]*entities.SafetyFactor)
+	for _, point := range points {
+		if !area.contains(point.Location) {
+			continue
+		}
+		factor, found := factors[point.Type]
+		if !found {
+			factor = &entities.SafetyFactor{Type: point.Type}
+			factors[point.Type] = factor
+		}
+		age := math.Max(0, float64(now.Sub(point.Date)))
+		weight := point.Severity.Weight() * math.Pow(0.5, age/halfLife)
+		factor.Count++
+		factor.Severity += point.Severity.Weight()
+		factor.Weight += weight
+		result.CrimeCount++
+		result.WeightedCrimes += weight
+	}
+
+	result.Breakdown = make([]entities.SafetyFactor, 0, len(factors))
+	for _, factor := range factors {
+		factor.Severity /= float64(factor.Count)
+		if result.WeightedCrimes > 0 {
+			factor.Share = factor.Weight / result.WeightedCrimes
+		}
+		result.Breakdown = append(result.Breakdown, *factor)
+	}
+	sort.Slice(result.Breakdown, func(i, j int) bool {
+		a, b := result.Breakdown[i], result.Breakdown[j]
+		if a.Weight != b.Weight {
+			return a.Weight > b.Weight
+		}
+		return a.Type < b.Type
+	})
+
+	result.Density = result.WeightedCrimes / result.AreaSqKm
+	result.Score = 100 * (1 - math.Exp(-result.Density/uc.options.DensityScale))
+	result.Level = entities.SafetyLevelFor(result.Score)
+	span.SetAttributes(attribute.Int("safety.crimes", result.CrimeCount), attribute.Float64("safety.score", result.Score))
+	return result, nil
+}
+
+// parameters completa y valida el radio y la vida media
+func (input SafetyInput) parameters() (*SafetyScoreResult, error) {
+	result := &SafetyScoreResult{RadiusMeters: input.RadiusMeters, HalfLifeDays: input.HalfLifeDays}
+	if result.RadiusMeters == 0 {
+		result.RadiusMeters = defaultSafetyRadius
+	}
+	if result.HalfLifeDays == 0 {
+		result.HalfLifeDays = defaultSafetyHalfLifeDays
+	}
+	if result.RadiusMeters < 50 || result.RadiusMeters > 5000 || result.HalfLifeDays < 1 || result.HalfLifeDays > 365 {
+		return nil, ErrInvalidSafetyParameters
+	}
+	return result, nil
+}
+
+// safetyArea es el círculo alrededor de un punto o la franja alrededor de una ruta
+type safetyArea struct {
+	radius     float64
+	vertices   []entities.Coordinate
+	projection localProjection
+	planar     []spatial.Point
+	length     float64 // Longitud de la ruta en metros, cero para un punto
+}
+
+// newSafetyArea valida el punto o la ruta y prepara el área analizada
+func newSafetyArea(input SafetyInput, radius float64) (*safetyArea, error) {
+	area := &safetyArea{radius: radius}
+	switch {
+	case input.Point != nil && input.Route == nil:
+		if !input.Point.IsValid() {
+			return nil, ErrInvalidSafetyLocation
+		}
+		area.vertices = []entities.Coordinate{*input.Point}
+	case input.Point == nil && input.Route != nil:
+		if len(input.Route) < 2 || len(input.Route) > maxSafetyRouteVertices {
+			return nil, ErrInvalidSafetyRoute
+		}
+		for i, vertex := range input.Route {
+			if !vertex.IsValid() {
+				return nil, ErrInvalidSafetyLocation
+			}
+			if i > 0 {
+				area.length += entities.DistanceMeters(input.Route[i-1], vertex)
+			}
+		}
+		if area.length > maxSafetyRouteMeters {
+			return nil, ErrInvalidSafetyRoute
+		}
+		area.vertices = input.Route
+	default:
+		return nil, ErrInvalidSafetyLocation
+	}
+
+	area.projection = newProjectionAt(area.vertices[0])
+	area.planar = make([]spatial.Point, len(area.vertices))
+	for i, vertex := range area.vertices {
+		area.planar[i] = area.projection.project(vertex)
+	}
+	return area, nil
+}
+
+// bounds retorna el rectángulo que contiene al área: la unión de los rectángulos de los
+// círculos centrados en cada vértice contiene a la franja de cada tramo
+func (a *safetyArea) bounds() entities.BoundingBox {
+	box := entities.BoundingBox{MinLatitude: 90, MinLongitude: 180, MaxLatitude: -90, MaxLongitude: -180}
+	for _, vertex := range a.vertices {
+		circle := entities.Circle{Center: vertex, RadiusMeters: a.radius}.Bounds()
+		box.MinLatitude = math.Min(box.MinLatitude, circle.MinLatitude)
+		box.MinLongitude = math.Min(box.MinLongitude, circle.MinLongitude)
+		box.MaxLatitude = math.Max(box.MaxLatitude, circle.MaxLatitude)
+		box.MaxLongitude = math.Max(box.MaxLongitude, circle.MaxLongitude)
+	}
+	return box
+}
+
+// contains indica si la ubicación está a una distancia del punto o de la ruta menor o igual al radio
+func (a *safetyArea) contains(location entities.Coordinate) bool {
+	if len(a.vertices) == 1 {
+		return entities.DistanceMeters(a.vertices[0], location) <= a.radius
+	}
+	return spatial.PolylineDistance(a.projection.project(location), a.planar) <= a.radius
+}
+
+// squareMeters retorna la superficie del área. La de la franja se aproxima como un rectángulo
+// del largo de la ruta más los extremos redondeados, por lo que sobreestima las rutas con giros cerrados
+func (a *safetyArea) squareMeters() float64 {
+	return 2*a.radius*a.length + math.Pi*a.radius*a.radius
+}
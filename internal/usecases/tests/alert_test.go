@@ -0,0 +1,246 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"go-crime_map_backend/internal/domain/entities"
+	"go-crime_map_backend/internal/domain/events"
+	memory "go-crime_map_backend/internal/infrastructure/repositories"
+	"go-crime_map_backend/internal/usecases"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// outbox es un emisor de avisos que los guarda en memoria y falla las primeras failures veces
+type outbox struct {
+	sent     []*entities.Notification
+	failures int
+}
+
+func (o *outbox) Send(ctx context.Context, notification *entities.Notification) error {
+	if o.failures > 0 {
+		o.failures--
+		return errors.New("servidor no disponible")
+	}
+	o.sent = append(o.sent, notification)
+	return nil
+}
+
+var (
+	// obelisco está en el centro de Buenos Aires
+	obelisco = entities.Coordinate{Latitude: -34.603722, Longitude: -58.381592}
+
+	// vecina es una ciudadana con zonas vigiladas
+	vecina = entities.Actor{ID: "user-2", Role: entities.RoleCitizen}
+)
+
+// crimeAt retorna un delito en la ubicación indicada
+func crimeAt(id, crimeType string, at entities.Coordinate, status entities.CrimeStatus) *entities.Crime {
+	return &entities.Crime{
+		ID:          id,
+		Type:        crimeType,
+		Description: "Robo a mano armada",
+		Location:    entities.Location{Latitude: at.Latitude, Longitude: at.Longitude, Address: "Av. Corrientes 1234, CABA"},
+		Date:        time.Date(2025, 1, 10, 21, 0, 0, 0, time.UTC),
+		Status:      status,
+	}
+}
+
+// verifiedEvent retorna el evento de la verificación de un delito en la ubicación indicada
+func verifiedEvent(t *testing.T, id, crimeType string, at entities.Coordinate) events.Event {
+	t.Helper()
+	crime := crimeAt(id, crimeType, at, entities.CrimeStatusVerified)
+	change := &entities.CrimeStatusChange{CrimeID: id, From: entities.CrimeStatusReported, To: entities.CrimeStatusVerified}
+	event, err := events.New("event-"+id, events.CrimeStatusChanged, id, events.CrimeStatusChangedPayload{Crime: crime, Change: change}, time.Now())
+	require.NoError(t, err)
+	return event
+}
+
+func TestWatchAreaValidation(t *testing.T) {
+	create := usecases.NewCreateWatchAreaUseCase(memory.NewMemoryAlertRepository(), 2)
+	ctx := context.Background()
+	circle := &entities.Circle{Center: obelisco, RadiusMeters: 1000}
+	inbox := []entities.NotificationChannel{entities.NotificationInbox}
+
+	tests := []struct {
+		name  string
+		input usecases.WatchAreaInput
+		want  error
+	}{
+		{"sin geometría", usecases.WatchAreaInput{Channels: inbox}, usecases.ErrInvalidWatchAreaGeometry},
+		{"círculo y polígono", usecases.WatchAreaInput{Circle: circle, Polygon: entities.Polygon{obelisco, obelisco, obelisco}, Channels: inbox}, usecases.ErrInvalidWatchAreaGeometry},
+		{"radio excesivo", usecases.WatchAreaInput{Circle: &entities.Circle{Center: obelisco, RadiusMeters: 100000}, Channels: inbox}, usecases.ErrInvalidWatchAreaGeometry},
+		{"polígono incompleto", usecases.WatchAreaInput{Polygon: entities.Polygon{obelisco, obelisco}, Channels: inbox}, usecases.ErrInvalidWatchAreaGeometry},
+		{"tipo inválido", usecases.WatchAreaInput{Circle: circle, CrimeTypes: []string{"INEXISTENTE"}, Channels: inbox}, usecases.ErrInvalidType},
+		{"franja vacía", usecases.WatchAreaInput{Circle: circle, QuietHours: &entities.QuietHours{Start: "22:00", End: "22:00"}, Channels: inbox}, usecases.ErrInvalidQuietHours},
+		{"zona horaria inválida", usecases.WatchAreaInput{Circle: circle, QuietHours: &entities.QuietHours{Start: "22:00", End: "07:00", TimeZone: "Marte/Olympus"}, Channels: inbox}, usecases.ErrInvalidQuietHours},
+		{"sin canales", usecases.WatchAreaInput{Circle: circle}, usecases.ErrInvalidNotificationChannel},
+		{"canal inválido", usecases.WatchAreaInput{Circle: circle, Channels: []entities.NotificationChannel{"sms"}}, usecases.ErrInvalidNotificationChannel},
+		{"email sin dirección", usecases.WatchAreaInput{Circle: circle, Channels: []entities.NotificationChannel{entities.NotificationEmail}}, usecases.ErrInvalidEmail},
+		{"webhook sin URL", usecases.WatchAreaInput{Circle: circle, Channels: []entities.NotificationChannel{entities.NotificationWebhook}}, usecases.ErrInvalidWebhookURL},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := create.Execute(ctx, tt.input, vecina)
+			assert.ErrorIs(t, err, tt.want)
+		})
+	}
+
+	_, err := create.Execute(ctx, usecases.WatchAreaInput{Circle: circle, Channels: inbox}, entities.AnonymousActor)
+	assert.ErrorIs(t, err, usecases.ErrForbidden)
+
+	for i := 0; i < 2; i++ {
+		_, err = create.Execute(ctx, usecases.WatchAreaInput{Circle: circle, Channels: inbox}, vecina)
+		require.NoError(t, err)
+	}
+	_, err = create.Execute(ctx, usecases.WatchAreaInput{Circle: circle, Channels: inbox}, vecina)
+	assert.ErrorIs(t, err, usecases.ErrTooManyWatchAreas)
+}
+
+func TestWatchAreaAlerts(t *testing.T) {
+	repo := memory.NewMemoryAlertRepository()
+	ctx := context.Background()
+	create := usecases.NewCreateWatchAreaUseCase(repo, 10)
+
+	home, err := create.Execute(ctx, usecases.WatchAreaInput{
+		Name:       "Casa",
+		Circle:     &entities.Circle{Center: obelisco, RadiusMeters: 500},
+		QuietHours: &entities.QuietHours{Start: "22:00", End: "07:00", TimeZone: "UTC"},
+		Channels:   []entities.NotificationChannel{entities.NotificationInbox, entities.NotificationEmail},
+		Email:      "vecina@example.com",
+	}, vecina)
+	require.NoError(t, err)
+
+	// Un polígono alrededor del obelisco que solo sigue los hurtos
+	_, err = create.Execute(ctx, usecases.WatchAreaInput{
+		Polygon:    entities.Polygon{{Latitude: -34.61, Longitude: -58.39}, {Latitude: -34.61, Longitude: -58.37}, {Latitude: -34.59, Longitude: -58.38}},
+		CrimeTypes: []string{"HURTO"},
+		Channels:   []entities.NotificationChannel{entities.NotificationWebhook},
+		WebhookURL: "https://example.com/alertas",
+	}, citizen)
+	require.NoError(t, err)
+
+	inactive := false
+	_, err = create.Execute(ctx, usecases.WatchAreaInput{
+		Circle:   &entities.Circle{Center: obelisco, RadiusMeters: 500},
+		Channels: []entities.NotificationChannel{entities.NotificationInbox},
+		Active:   &inactive,
+	}, citizen)
+	require.NoError(t, err)
+
+	// El delito se verifica a las 23:30 UTC, dentro de la franja silenciosa de la vecina
+	now := time.Date(2025, 1, 10, 23, 30, 0, 0, time.UTC)
+	matcher := usecases.NewWatchAreaMatcherWithClock(repo, func() time.Time { return now })
+	near := entities.Coordinate{Latitude: -34.6045, Longitude: -58.3820}
+	require.NoError(t, matcher.Handle(ctx, verifiedEvent(t, "crime-near", "ROBO", near)))
+	require.NoError(t, matcher.Handle(ctx, verifiedEvent(t, "crime-near", "ROBO", near)), "un evento repetido no duplica avisos")
+	require.NoError(t, matcher.Handle(ctx, verifiedEvent(t, "crime-far", "ROBO", entities.Coordinate{Latitude: -34.55, Longitude: -58.45})))
+	require.NoError(t, matcher.Handle(ctx, verifiedEvent(t, "crime-hurto", "HURTO", near)))
+
+	// El aviso de la bandeja se entrega de inmediato
+	inbox, err := usecases.NewListNotificationsUseCase(repo).Execute(ctx, usecases.ListNotificationsInput{Actor: vecina})
+	require.NoError(t, err)
+	require.Len(t, inbox, 2)
+	assert.Equal(t, []string{"crime-hurto", "crime-near"}, []string{inbox[0].CrimeID, inbox[1].CrimeID})
+	assert.Equal(t, "Nuevo delito en Casa", inbox[1].Title)
+	assert.Equal(t, home.ID, inbox[1].AreaID)
+	assert.Equal(t, entities.NotificationSent, inbox[1].Status)
+
+	// El email espera al final de la franja; el webhook del polígono sale de inmediato
+	emails, webhooks := &outbox{}, &outbox{failures: 1}
+	senders := map[entities.NotificationChannel]usecases.NotificationSender{
+		entities.NotificationEmail:   emails,
+		entities.NotificationWebhook: webhooks,
+	}
+	opts := usecases.DeliverNotificationsOptions{BatchSize: 10, Lease: time.Minute, MaxAttempts: 3, RetryBaseDelay: time.Minute, RetryMaxDelay: time.Hour}
+	deliver := usecases.NewDeliverNotificationsUseCaseWithClock(repo, senders, opts, func() time.Time { return now })
+
+	sent, err := deliver.Execute(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, sent)
+	assert.Empty(t, emails.sent)
+
+	// El webhook se reintenta tras el fallo
+	now = now.Add(time.Minute)
+	sent, err = deliver.Execute(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, sent)
+	require.Len(t, webhooks.sent, 1)
+	assert.Equal(t, "https://example.com/alertas", webhooks.sent[0].Target)
+	assert.Equal(t, "crime-hurto", webhooks.sent[0].CrimeID)
+	assert.Equal(t, 2, webhooks.sent[0].Attempts, "el aviso registra el intento fallido y el exitoso")
+
+	now = time.Date(2025, 1, 11, 7, 0, 0, 0, time.UTC)
+	sent, err = deliver.Execute(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 2, sent)
+	require.Len(t, emails.sent, 2)
+	assert.Equal(t, "vecina@example.com", emails.sent[0].Target)
+
+	// Solo la dueña puede marcar sus avisos como leídos
+	markRead := usecases.NewMarkNotificationReadUseCase(repo)
+	assert.ErrorIs(t, markRead.Execute(ctx, inbox[0].ID, citizen), usecases.ErrNotificationNotFound)
+	require.NoError(t, markRead.Execute(ctx, inbox[0].ID, vecina))
+	unread, err := usecases.NewListNotificationsUseCase(repo).Execute(ctx, usecases.ListNotificationsInput{UnreadOnly: true, Actor: vecina})
+	require.NoError(t, err)
+	require.Len(t, unread, 1)
+	assert.Equal(t, "crime-near", unread[0].CrimeID)
+
+	// Las zonas de otros usuarios no se pueden modificar
+	deleteArea := usecases.NewDeleteWatchAreaUseCase(repo)
+	assert.ErrorIs(t, deleteArea.Execute(ctx, home.ID, citizen), usecases.ErrWatchAreaNotFound)
+	require.NoError(t, deleteArea.Execute(ctx, home.ID, vecina))
+	inbox, err = usecases.NewListNotificationsUseCase(repo).Execute(ctx, usecases.ListNotificationsInput{Actor: vecina})
+	require.NoError(t, err)
+	assert.Empty(t, inbox)
+}
+
+func TestQuietHoursUntil(t *testing.T) {
+	quiet := entities.QuietHours{Start: "22:00", End: "07:00", TimeZone: "America/Argentina/Buenos_Aires"}
+	buenosAires, err := time.LoadLocation("America/Argentina/Buenos_Aires")
+	require.NoError(t, err)
+
+	until, inside := quiet.Until(time.Date(2025, 1, 10, 23, 0, 0, 0, buenosAires))
+	assert.True(t, inside)
+	assert.True(t, until.Equal(time.Date(2025, 1, 11, 7, 0, 0, 0, buenosAires)))
+
+	until, inside = quiet.Until(time.Date(2025, 1, 11, 6, 59, 0, 0, buenosAires))
+	assert.True(t, inside)
+	assert.True(t, until.Equal(time.Date(2025, 1, 11, 7, 0, 0, 0, buenosAires)))
+
+	_, inside = quiet.Until(time.Date(2025, 1, 11, 12, 0, 0, 0, buenosAires))
+	assert.False(t, inside)
+}
+
+func TestWatchAreaAlertsOnReport(t *testing.T) {
+	repo := memory.NewMemoryAlertRepository()
+	ctx := context.Background()
+	_, err := usecases.NewCreateWatchAreaUseCase(repo, 10).Execute(ctx, usecases.WatchAreaInput{
+		Name:     "Casa",
+		Circle:   &entities.Circle{Center: obelisco, RadiusMeters: 500},
+		Channels: []entities.NotificationChannel{entities.NotificationInbox},
+	}, vecina)
+	require.NoError(t, err)
+	matcher := usecases.NewWatchAreaMatcher(repo)
+
+	// Un reporte nuevo se avisa aunque nadie lo modere, advirtiendo que no está verificado
+	crime := crimeAt("crime-1", "ROBO", obelisco, entities.CrimeStatusReported)
+	reported, err := events.New("event-reported", events.CrimeReported, crime.ID, events.CrimeReportedPayload{Crime: crime}, time.Now())
+	require.NoError(t, err)
+	require.NoError(t, matcher.Handle(ctx, reported))
+
+	inbox, err := usecases.NewListNotificationsUseCase(repo).Execute(ctx, usecases.ListNotificationsInput{Actor: vecina})
+	require.NoError(t, err)
+	require.Len(t, inbox, 1)
+	assert.Equal(t, "Nuevo reporte sin verificar en Casa", inbox[0].Title)
+
+	// La verificación posterior no repite el aviso
+	require.NoError(t, matcher.Handle(ctx, verifiedEvent(t, "crime-1", "ROBO", obelisco)))
+	inbox, err = usecases.NewListNotificationsUseCase(repo).Execute(ctx, usecases.ListNotificationsInput{Actor: vecina})
+	require.NoError(t, err)
+	assert.Len(t, inbox, 1)
+}
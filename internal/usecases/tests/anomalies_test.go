@@ -0,0 +1,101 @@
+package tests
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"go-crime_map_backend/internal/domain/entities"
+	"go-crime_map_backend/internal/domain/events"
+	memory "go-crime_map_backend/internal/infrastructure/repositories"
+	"go-crime_map_backend/internal/usecases"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDetectAnomalies(t *testing.T) {
+	ctx := context.Background()
+	repo := memory.NewMemoryCrimeRepository()
+	now := time.Date(2026, 3, 10, 6, 0, 0, 0, time.UTC)
+	current := time.Date(2026, 3, 9, 12, 0, 0, 0, time.UTC)
+	n := 0
+	add := func(zoneID, crimeType string, status entities.CrimeStatus, date time.Time, count int) {
+		for i := 0; i < count; i++ {
+			n++
+			require.NoError(t, repo.Create(ctx, &entities.Crime{
+				ID:          fmt.Sprintf("crime-%d", n),
+				Type:        crimeType,
+				Description: "delito de prueba",
+				Date:        date,
+				Status:      status,
+				ZoneID:      zoneID,
+			}))
+		}
+	}
+	// Una semana con un robo diario en zone-a y dos hurtos diarios en zone-b
+	for day := 1; day <= 7; day++ {
+		add("zone-a", "ROBO", entities.CrimeStatusVerified, current.AddDate(0, 0, -day), 1)
+		add("zone-b", "HURTO", entities.CrimeStatusVerified, current.AddDate(0, 0, -day), 2)
+	}
+	add("zone-a", "ROBO", entities.CrimeStatusVerified, current, 6)
+	add("zone-a", "ROBO", entities.CrimeStatusReported, current, 4)
+	add("zone-b", "HURTO", entities.CrimeStatusVerified, current, 2)
+	// Un aumento grande en relación con el promedio pero por debajo del mínimo de delitos
+	add("zone-c", "ROBO", entities.CrimeStatusVerified, current, 3)
+	// La ventana en curso todavía no se analiza
+	add("zone-b", "HURTO", entities.CrimeStatusVerified, now.Add(-time.Hour), 10)
+
+	detect := usecases.NewDetectAnomaliesUseCaseWithClock(repo, repo, usecases.DetectAnomaliesOptions{
+		Window: 24 * time.Hour, BaselineWindows: 7, Threshold: 3, MinCount: 5,
+	}, func() time.Time { return now })
+
+	saved, err := detect.Execute(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 4, saved, "zona y tipo, zona, tipo y total")
+
+	anomalies, err := usecases.NewListAnomaliesUseCase(repo).Execute(ctx, usecases.ListAnomaliesInput{ZoneID: "zone-a"})
+	require.NoError(t, err)
+	require.Len(t, anomalies, 2)
+	for _, anomaly := range anomalies {
+		assert.Equal(t, 6, anomaly.Count, "los reportes sin verificar no se cuentan")
+		assert.InDelta(t, 1, anomaly.Baseline, 1e-9)
+		assert.InDelta(t, 0, anomaly.StdDev, 1e-9)
+		assert.InDelta(t, 5, anomaly.ZScore, 1e-9)
+		assert.True(t, anomaly.WindowStart.Equal(time.Date(2026, 3, 9, 0, 0, 0, 0, time.UTC)))
+		assert.True(t, anomaly.WindowEnd.Equal(time.Date(2026, 3, 10, 0, 0, 0, 0, time.UTC)))
+	}
+
+	all, err := usecases.NewListAnomaliesUseCase(repo).Execute(ctx, usecases.ListAnomaliesInput{})
+	require.NoError(t, err)
+	require.Len(t, all, 4)
+	assert.Equal(t, "", all[0].ZoneID, "las series de toda la ciudad tienen el mayor z-score")
+	assert.InDelta(t, 8, all[0].ZScore, 1e-9)
+
+	// Volver a analizar la misma ventana no repite las anomalías
+	saved, err = detect.Execute(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, saved)
+
+	handler := &recorder{}
+	dispatcher := usecases.NewOutboxDispatcher(repo, testDispatcherOptions())
+	dispatcher.SubscribeAll(handler)
+	_, err = dispatcher.DispatchPending(ctx)
+	require.NoError(t, err)
+	var detected []events.Event
+	for _, event := range handler.received {
+		if event.Type == events.AnomalyDetected {
+			detected = append(detected, event)
+		}
+	}
+	require.Len(t, detected, 4)
+	var payload events.AnomalyDetectedPayload
+	require.NoError(t, detected[0].Decode(&payload))
+	assert.Equal(t, detected[0].AggregateID, payload.Anomaly.ID)
+
+	_, err = usecases.NewListAnomaliesUseCase(repo).Execute(ctx, usecases.ListAnomaliesInput{Limit: 501})
+	assert.ErrorIs(t, err, usecases.ErrInvalidAnomalyLimit)
+	_, err = usecases.NewListAnomaliesUseCase(repo).Execute(ctx, usecases.ListAnomaliesInput{From: now, To: now})
+	assert.ErrorIs(t, err, usecases.ErrInvalidAnomalyPeriod)
+}
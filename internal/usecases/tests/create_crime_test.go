@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"go-crime_map_backend/internal/domain/entities"
+	"go-crime_map_backend/internal/domain/repositories"
 	"go-crime_map_backend/internal/usecases"
 
 	"github.com/stretchr/testify/assert"
@@ -38,6 +39,35 @@ func (m *MockCrimeRepository) GetAll(ctx context.Context) ([]*entities.Crime, er
 	return args.Get(0).([]*entities.Crime), args.Error(1)
 }
 
+func (m *MockCrimeRepository) List(ctx context.Context, filter repositories.CrimeFilter) ([]*entities.Crime, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entities.Crime), args.Error(1)
+}
+
+func (m *MockCrimeRepository) FindNear(ctx context.Context, query repositories.NearbyQuery) ([]*entities.Crime, error) {
+	args := m.Called(ctx, query)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entities.Crime), args.Error(1)
+}
+
+func (m *MockCrimeRepository) UpdateStatus(ctx context.Context, change *entities.CrimeStatusChange) error {
+	args := m.Called(ctx, change)
+	return args.Error(0)
+}
+
+func (m *MockCrimeRepository) GetStatusHistory(ctx context.Context, crimeID string) ([]*entities.CrimeStatusChange, error) {
+	args := m.Called(ctx, crimeID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entities.CrimeStatusChange), args.Error(1)
+}
+
 func (m *MockCrimeRepository) Update(ctx context.Context, crime *entities.Crime) error {
 	args := m.Called(ctx, crime)
 	return args.Error(0)
@@ -48,6 +78,11 @@ func (m *MockCrimeRepository) Delete(ctx context.Context, id string) error {
 	return args.Error(0)
 }
 
+func (m *MockCrimeRepository) AssignZones(ctx context.Context, zoneIDs map[string]string) error {
+	args := m.Called(ctx, zoneIDs)
+	return args.Error(0)
+}
+
 func TestCreateCrimeUseCase_Execute(t *testing.T) {
 	mockRepo := new(MockCrimeRepository)
 	useCase := usecases.NewCreateCrimeUseCase(mockRepo)
@@ -75,7 +110,7 @@ func TestCreateCrimeUseCase_Execute(t *testing.T) {
 			},
 			setupMock: func() {
 				mockRepo.On("Create", mock.Anything, mock.AnythingOfType("*entities.Crime")).Return(nil)
-				mockRepo.On("GetAll", mock.Anything).Return([]*entities.Crime{}, nil)
+				mockRepo.On("FindNear", mock.Anything, mock.Anything).Return([]*entities.Crime{}, nil)
 			},
 		},
 		{
@@ -146,7 +181,7 @@ func TestCreateCrimeUseCase_Execute(t *testing.T) {
 			},
 			expectedError: "assert.AnError general error for testing",
 			setupMock: func() {
-				mockRepo.On("GetAll", mock.Anything).Return([]*entities.Crime{}, nil)
+				mockRepo.On("FindNear", mock.Anything, mock.Anything).Return([]*entities.Crime{}, nil)
 				mockRepo.On("Create", mock.Anything, mock.AnythingOfType("*entities.Crime")).Return(assert.AnError)
 			},
 		},
@@ -162,7 +197,7 @@ func TestCreateCrimeUseCase_Execute(t *testing.T) {
 				tt.setupMock()
 			} else if tt.expectedError == "" {
 				// Si no hay error esperado y no hay setup específico, configurar el mock por defecto
-				mockRepo.On("GetAll", mock.Anything).Return([]*entities.Crime{}, nil)
+				mockRepo.On("FindNear", mock.Anything, mock.Anything).Return([]*entities.Crime{}, nil)
 				mockRepo.On("Create", mock.Anything, mock.AnythingOfType("*entities.Crime")).Return(nil)
 			}
 
@@ -192,3 +227,35 @@ func TestCreateCrimeUseCase_Execute(t *testing.T) {
 		})
 	}
 }
+
+func TestCreateCrimeChecksOnlyNearbyCrimes(t *testing.T) {
+	mockRepo := new(MockCrimeRepository)
+	date := time.Now().Add(-time.Hour).Truncate(time.Second)
+	input := usecases.CreateCrimeInput{
+		Type:        "ROBO",
+		Description: "Robo a mano armada",
+		Location:    usecases.Location{Latitude: -34.603722, Longitude: -58.381592, Address: "Av. Corrientes 1234"},
+		Date:        date,
+	}
+	query := repositories.NearbyQuery{
+		Date:      date,
+		Window:    time.Minute,
+		Latitude:  -34.603722,
+		Longitude: -58.381592,
+		Tolerance: 0.000001,
+	}
+	existing := &entities.Crime{
+		ID:          "crime-1",
+		Type:        "ROBO",
+		Description: "Robo a mano armada",
+		Location:    entities.Location{Latitude: -34.603722, Longitude: -58.381592},
+		Date:        date.Add(30 * time.Second),
+	}
+	mockRepo.On("FindNear", mock.Anything, query).Return([]*entities.Crime{existing}, nil)
+
+	// El duplicado se detecta sin leer todos los delitos
+	_, err := usecases.NewCreateCrimeUseCase(mockRepo).Execute(context.Background(), input)
+	assert.ErrorIs(t, err, usecases.ErrDuplicateCrime)
+	mockRepo.AssertExpectations(t)
+	mockRepo.AssertNotCalled(t, "GetAll", mock.Anything)
+}
@@ -0,0 +1,70 @@
+package tests
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"go-crime_map_backend/internal/domain/entities"
+	"go-crime_map_backend/internal/usecases"
+)
+
+func TestCreateCrimeUseCase_Tracing(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(provider)
+	defer otel.SetTracerProvider(previous)
+
+	input := usecases.CreateCrimeInput{
+		Type:        "ROBO",
+		Description: "Robo a mano armada",
+		Location: usecases.Location{
+			Latitude:  -34.603722,
+			Longitude: -58.381592,
+			Address:   "Av. Corrientes 1234",
+		},
+		Date: time.Now().Add(-1 * time.Hour),
+	}
+
+	t.Run("un span por etapa dentro del span del caso de uso", func(t *testing.T) {
+		mockRepo := new(MockCrimeRepository)
+		mockRepo.On("FindNear", mock.Anything, mock.Anything).Return([]*entities.Crime{}, nil)
+		mockRepo.On("Create", mock.Anything, mock.AnythingOfType("*entities.Crime")).Return(nil)
+
+		_, err := usecases.NewCreateCrimeUseCase(mockRepo).Execute(context.Background(), input)
+		require.NoError(t, err)
+
+		spans := recorder.Ended()
+		require.Len(t, spans, 4)
+		root := spans[3]
+		assert.Equal(t, "CreateCrimeUseCase.Execute", root.Name())
+		for i, name := range []string{"CreateCrimeUseCase.validate", "CreateCrimeUseCase.checkDuplicate", "CreateCrimeUseCase.persist"} {
+			assert.Equal(t, name, spans[i].Name())
+			assert.Equal(t, root.SpanContext().SpanID(), spans[i].Parent().SpanID())
+		}
+	})
+
+	t.Run("los errores marcan el span", func(t *testing.T) {
+		recorder.Reset()
+		mockRepo := new(MockCrimeRepository)
+
+		invalid := input
+		invalid.Type = ""
+		_, err := usecases.NewCreateCrimeUseCase(mockRepo).Execute(context.Background(), invalid)
+		require.Error(t, err)
+
+		spans := recorder.Ended()
+		require.Len(t, spans, 2)
+		assert.Equal(t, codes.Error, spans[0].Status().Code)
+		assert.Equal(t, codes.Error, spans[1].Status().Code)
+	})
+}
@@ -0,0 +1,80 @@
+package tests
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"go-crime_map_backend/internal/domain/entities"
+	memory "go-crime_map_backend/internal/infrastructure/repositories"
+	"go-crime_map_backend/internal/usecases"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCrimeClassification(t *testing.T) {
+	ctx := context.Background()
+	repo := memory.NewMemoryCrimeRepository()
+	create := usecases.NewCreateCrimeUseCase(repo)
+	date := time.Date(2026, 3, 2, 15, 0, 0, 0, time.UTC)
+	location := usecases.Location{Latitude: -34.6037, Longitude: -58.3816, Address: "Av. Corrientes 1234"}
+	report := func(input usecases.CreateCrimeInput) (*entities.Crime, error) {
+		input.Description = "delito de prueba"
+		input.Location = location
+		date = date.Add(time.Hour)
+		input.Date = date
+		return create.Execute(ctx, input)
+	}
+	yes, no := true, false
+
+	// Sin clasificación se usan los valores por defecto del tipo
+	theft, err := report(usecases.CreateCrimeInput{Type: "HURTO"})
+	require.NoError(t, err)
+	assert.Equal(t, entities.SeverityLow, theft.Severity)
+	assert.Equal(t, entities.WeaponNone, theft.Weapon)
+	assert.False(t, theft.Violent)
+
+	assault, err := report(usecases.CreateCrimeInput{Type: "AGRESION", VictimCount: 2})
+	require.NoError(t, err)
+	assert.Equal(t, entities.SeverityHigh, assault.Severity)
+	assert.True(t, assault.Violent)
+	assert.Equal(t, 2, assault.VictimCount)
+
+	// Un arma de fuego eleva la gravedad y la violencia indicada prevalece sobre la del tipo
+	armed, err := report(usecases.CreateCrimeInput{Type: "HURTO", Weapon: entities.WeaponFirearm, Violent: &no})
+	require.NoError(t, err)
+	assert.Equal(t, entities.SeverityCritical, armed.Severity)
+	assert.False(t, armed.Violent)
+
+	violent, err := report(usecases.CreateCrimeInput{Type: "VANDALISMO", Violent: &yes})
+	require.NoError(t, err)
+	assert.Equal(t, entities.SeverityHigh, violent.Severity)
+
+	// La gravedad indicada no se modifica
+	explicit, err := report(usecases.CreateCrimeInput{Type: "ROBO", Severity: entities.SeverityMedium, Weapon: entities.WeaponKnife})
+	require.NoError(t, err)
+	assert.Equal(t, entities.SeverityMedium, explicit.Severity)
+
+	_, err = report(usecases.CreateCrimeInput{Type: "ROBO", Severity: "extreme"})
+	assert.ErrorIs(t, err, usecases.ErrInvalidSeverity)
+	_, err = report(usecases.CreateCrimeInput{Type: "ROBO", Weapon: "sword"})
+	assert.ErrorIs(t, err, usecases.ErrInvalidWeapon)
+	_, err = report(usecases.CreateCrimeInput{Type: "ROBO", VictimCount: -1})
+	assert.ErrorIs(t, err, usecases.ErrInvalidVictimCount)
+
+	// La serie suma los pesos de gravedad: 1 + 4 + 8 + 4 + 2
+	series, err := usecases.NewGetCrimeTimeSeriesUseCase(repo).Execute(ctx, usecases.TimeSeriesInput{
+		StatsCriteria: usecases.StatsCriteria{
+			From:     time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC),
+			To:       time.Date(2026, 3, 8, 0, 0, 0, 0, time.UTC),
+			Statuses: []entities.CrimeStatus{entities.CrimeStatusReported},
+			Actor:    moderator,
+		},
+		Bucket: entities.TimeBucketDay,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 5, series.Total)
+	assert.Equal(t, 19.0, series.SeverityScore)
+	assert.Equal(t, 19.0, series.Points[1].SeverityScore)
+}
@@ -0,0 +1,220 @@
+package tests
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"go-crime_map_backend/internal/domain/entities"
+	"go-crime_map_backend/internal/domain/events"
+	memory "go-crime_map_backend/internal/infrastructure/repositories"
+	"go-crime_map_backend/internal/usecases"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// drain retorna los eventos pendientes de la suscripción sin bloquearse
+func drain(subscription *usecases.CrimeFeedSubscription) []usecases.CrimeFeedEvent {
+	var received []usecases.CrimeFeedEvent
+	for {
+		select {
+		case event, open := <-subscription.Events():
+			if !open {
+				return received
+			}
+			received = append(received, event)
+		default:
+			return received
+		}
+	}
+}
+
+func actions(received []usecases.CrimeFeedEvent) []string {
+	result := make([]string, len(received))
+	for i, event := range received {
+		result[i] = event.Action
+	}
+	return result
+}
+
+func TestCrimeFeedVisibilityAndFilters(t *testing.T) {
+	repo := memory.NewMemoryCrimeRepository()
+	feed := usecases.NewCrimeFeed(usecases.CrimeFeedOptions{BufferSize: 16, HistorySize: 16})
+	dispatcher := usecases.NewOutboxDispatcher(repo, testDispatcherOptions())
+	dispatcher.SubscribeAll(feed)
+	ctx := context.Background()
+
+	public, err := feed.Subscribe(usecases.CrimeFeedFilter{Actor: citizen}, "")
+	require.NoError(t, err)
+	staff, err := feed.Subscribe(usecases.CrimeFeedFilter{
+		Actor:       moderator,
+		BoundingBox: &entities.BoundingBox{MinLatitude: -35, MinLongitude: -59, MaxLatitude: -34, MaxLongitude: -58},
+	}, "")
+	require.NoError(t, err)
+	elsewhere, err := feed.Subscribe(usecases.CrimeFeedFilter{
+		Actor:       moderator,
+		BoundingBox: &entities.BoundingBox{MinLatitude: 40, MinLongitude: -4, MaxLatitude: 41, MaxLongitude: -3},
+	}, "")
+	require.NoError(t, err)
+	otherType, err := feed.Subscribe(usecases.CrimeFeedFilter{Actor: moderator, Types: []string{"HURTO"}}, "")
+	require.NoError(t, err)
+
+	created, err := usecases.NewCreateCrimeUseCase(repo).Execute(ctx, usecases.CreateCrimeInput{
+		Type:        "ROBO",
+		Description: "Robo a mano armada",
+		Location:    usecases.Location{Latitude: -34.603722, Longitude: -58.381592, Address: "Av. Corrientes 1234, CABA"},
+		Date:        time.Now().Add(-time.Hour),
+	})
+	require.NoError(t, err)
+	_, err = usecases.NewTransitionCrimeStatusUseCase(repo).Execute(ctx, usecases.TransitionCrimeStatusInput{
+		CrimeID: created.ID,
+		Status:  entities.CrimeStatusVerified,
+		Actor:   moderator,
+	})
+	require.NoError(t, err)
+	require.NoError(t, usecases.NewDeleteCrimeUseCase(repo).Execute(ctx, created.ID, admin))
+
+	_, err = dispatcher.DispatchPending(ctx)
+	require.NoError(t, err)
+
+	// Un reporte sin verificar no es público: el ciudadano lo recibe al verificarse
+	publicEvents := drain(public)
+	assert.Equal(t, []string{usecases.FeedActionCreated, usecases.FeedActionDeleted}, actions(publicEvents))
+	assert.Equal(t, entities.CrimeStatusVerified, publicEvents[0].Crime.Status)
+	assert.Nil(t, publicEvents[1].Crime)
+	assert.Equal(t, created.ID, publicEvents[1].CrimeID)
+
+	staffEvents := drain(staff)
+	assert.Equal(t, []string{usecases.FeedActionCreated, usecases.FeedActionUpdated, usecases.FeedActionDeleted}, actions(staffEvents))
+	assert.Empty(t, drain(elsewhere))
+	assert.Empty(t, drain(otherType))
+
+	// Reanudar desde el primer evento retorna los siguientes visibles para el actor
+	resumed, err := feed.Subscribe(usecases.CrimeFeedFilter{Actor: citizen}, staffEvents[0].ID)
+	require.NoError(t, err)
+	assert.False(t, resumed.Reset)
+	assert.Equal(t, publicEvents, resumed.Backlog)
+
+	unknown, err := feed.Subscribe(usecases.CrimeFeedFilter{Actor: citizen}, "desconocido")
+	require.NoError(t, err)
+	assert.True(t, unknown.Reset)
+	assert.Empty(t, unknown.Backlog)
+
+	_, err = feed.Subscribe(usecases.CrimeFeedFilter{BoundingBox: &entities.BoundingBox{MinLatitude: 10, MaxLatitude: 5}}, "")
+	assert.ErrorIs(t, err, usecases.ErrInvalidBoundingBox)
+	_, err = feed.Subscribe(usecases.CrimeFeedFilter{Types: []string{"INEXISTENTE"}}, "")
+	assert.ErrorIs(t, err, usecases.ErrInvalidType)
+}
+
+func TestCrimeFeedDisconnectsSlowClients(t *testing.T) {
+	feed := usecases.NewCrimeFeed(usecases.CrimeFeedOptions{BufferSize: 1, HistorySize: 2})
+	slow, err := feed.Subscribe(usecases.CrimeFeedFilter{Actor: moderator}, "")
+	require.NoError(t, err)
+
+	crime := &entities.Crime{ID: "crime-1", Type: "ROBO", Status: entities.CrimeStatusReported}
+	var ids []string
+	for i := 0; i < 3; i++ {
+		event, err := events.New(fmt.Sprintf("event-%d", i), events.CrimeReported, crime.ID, events.CrimeReportedPayload{Crime: crime}, time.Now())
+		require.NoError(t, err)
+		// Handle no se bloquea aunque el cliente no lea
+		require.NoError(t, feed.Handle(context.Background(), event))
+		ids = append(ids, event.ID)
+	}
+
+	// Un evento repetido por la entrega al menos una vez se ignora
+	duplicate, err := events.New(ids[2], events.CrimeReported, crime.ID, events.CrimeReportedPayload{Crime: crime}, time.Now())
+	require.NoError(t, err)
+	require.NoError(t, feed.Handle(context.Background(), duplicate))
+
+	assert.Len(t, drain(slow), 1)
+	assert.True(t, slow.Lagged())
+	assert.Zero(t, feed.Connections())
+	slow.Close()
+
+	// El historial conserva solo los últimos eventos
+	resumed, err := feed.Subscribe(usecases.CrimeFeedFilter{Actor: moderator}, ids[0])
+	require.NoError(t, err)
+	assert.True(t, resumed.Reset)
+	resumed, err = feed.Subscribe(usecases.CrimeFeedFilter{Actor: moderator}, ids[1])
+	require.NoError(t, err)
+	require.Len(t, resumed.Backlog, 1)
+	assert.Equal(t, ids[2], resumed.Backlog[0].ID)
+}
+
+func TestCrimeFeedCrimesMovedAcrossFilters(t *testing.T) {
+	repo := memory.NewMemoryCrimeRepository()
+	feed := usecases.NewCrimeFeed(usecases.CrimeFeedOptions{BufferSize: 16, HistorySize: 16})
+	dispatcher := usecases.NewOutboxDispatcher(repo, testDispatcherOptions())
+	dispatcher.SubscribeAll(feed)
+	ctx := context.Background()
+
+	buenosAires, err := feed.Subscribe(usecases.CrimeFeedFilter{
+		Actor:       moderator,
+		BoundingBox: &entities.BoundingBox{MinLatitude: -35, MinLongitude: -59, MaxLatitude: -34, MaxLongitude: -58},
+	}, "")
+	require.NoError(t, err)
+	cordoba, err := feed.Subscribe(usecases.CrimeFeedFilter{
+		Actor:       moderator,
+		BoundingBox: &entities.BoundingBox{MinLatitude: -32, MinLongitude: -65, MaxLatitude: -31, MaxLongitude: -64},
+	}, "")
+	require.NoError(t, err)
+	thefts, err := feed.Subscribe(usecases.CrimeFeedFilter{Actor: moderator, Types: []string{"HURTO"}}, "")
+	require.NoError(t, err)
+
+	input := usecases.CreateCrimeInput{
+		Type:        "ROBO",
+		Description: "Robo a mano armada",
+		Location:    usecases.Location{Latitude: -34.603722, Longitude: -58.381592, Address: "Av. Corrientes 1234, CABA"},
+		Date:        time.Now().Add(-time.Hour),
+	}
+	created, err := usecases.NewCreateCrimeUseCase(repo).Execute(ctx, input)
+	require.NoError(t, err)
+	_, err = dispatcher.DispatchPending(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []string{usecases.FeedActionCreated}, actions(drain(buenosAires)))
+	assert.Empty(t, drain(cordoba))
+	assert.Empty(t, drain(thefts))
+
+	// Corregir la ubicación saca al delito del área de un cliente y lo lleva a la de otro
+	input.Location = usecases.Location{Latitude: -31.416668, Longitude: -64.183334, Address: "Av. Colón 500, Córdoba"}
+	input.Type = "HURTO"
+	_, err = usecases.NewUpdateCrimeUseCase(repo).Execute(ctx, usecases.UpdateCrimeInput{CrimeID: created.ID, Data: input, Actor: moderator})
+	require.NoError(t, err)
+	_, err = dispatcher.DispatchPending(ctx)
+	require.NoError(t, err)
+
+	left := drain(buenosAires)
+	assert.Equal(t, []string{usecases.FeedActionDeleted}, actions(left))
+	assert.Equal(t, created.ID, left[0].CrimeID)
+	assert.Nil(t, left[0].Crime)
+
+	entered := drain(cordoba)
+	assert.Equal(t, []string{usecases.FeedActionCreated}, actions(entered))
+	require.NotNil(t, entered[0].Crime)
+	assert.Equal(t, -31.416668, entered[0].Crime.Location.Latitude)
+
+	// El cambio de tipo también es un alta para los clientes que filtran por el nuevo tipo
+	assert.Equal(t, []string{usecases.FeedActionCreated}, actions(drain(thefts)))
+}
+
+func TestCrimeFeedIgnoresEventsWithoutCrime(t *testing.T) {
+	feed := usecases.NewCrimeFeed(usecases.CrimeFeedOptions{BufferSize: 4, HistorySize: 4})
+	subscription, err := feed.Subscribe(usecases.CrimeFeedFilter{Actor: moderator}, "")
+	require.NoError(t, err)
+
+	payloads := map[events.Type]any{
+		events.CrimeUpdated:       events.CrimeUpdatedPayload{},
+		events.CrimeStatusChanged: events.CrimeStatusChangedPayload{},
+		events.CrimeDeleted:       events.CrimeDeletedPayload{},
+	}
+	for eventType, payload := range payloads {
+		event, err := events.New("event-"+string(eventType), eventType, "crime-1", payload, time.Now())
+		require.NoError(t, err)
+		require.NotPanics(t, func() {
+			assert.NoError(t, feed.Handle(context.Background(), event))
+		}, string(eventType))
+	}
+	assert.Empty(t, drain(subscription))
+}
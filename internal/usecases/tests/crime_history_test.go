@@ -0,0 +1,97 @@
+package tests
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"go-crime_map_backend/internal/domain/entities"
+	memory "go-crime_map_backend/internal/infrastructure/repositories"
+	"go-crime_map_backend/internal/usecases"
+	"go-crime_map_backend/pkg/requestid"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCrimeHistory(t *testing.T) {
+	repo := memory.NewMemoryCrimeRepository()
+	citizenCtx := requestid.WithRequestID(entities.ContextWithActor(context.Background(), citizen), "req-create")
+	moderatorCtx := requestid.WithRequestID(entities.ContextWithActor(context.Background(), moderator), "req-verify")
+
+	created, err := usecases.NewCreateCrimeUseCase(repo).Execute(citizenCtx, usecases.CreateCrimeInput{
+		Type:        "ROBO",
+		Description: "Robo a mano armada",
+		Location:    usecases.Location{Latitude: -34.603722, Longitude: -58.381592, Address: "Av. Corrientes 1234, CABA"},
+		Date:        time.Now().Add(-time.Hour),
+	})
+	require.NoError(t, err)
+	afterCreate := time.Now()
+
+	time.Sleep(2 * time.Millisecond)
+	_, err = usecases.NewTransitionCrimeStatusUseCase(repo).Execute(moderatorCtx, usecases.TransitionCrimeStatusInput{
+		CrimeID: created.ID,
+		Status:  entities.CrimeStatusVerified,
+		Actor:   moderator,
+	})
+	require.NoError(t, err)
+	afterVerify := time.Now()
+
+	time.Sleep(2 * time.Millisecond)
+	require.NoError(t, repo.Delete(moderatorCtx, created.ID))
+
+	historyUseCase := usecases.NewGetCrimeHistoryUseCase(repo)
+	_, err = historyUseCase.Execute(context.Background(), created.ID, citizen)
+	assert.ErrorIs(t, err, usecases.ErrForbidden)
+
+	// El historial se conserva después de eliminar el delito
+	revisions, err := historyUseCase.Execute(context.Background(), created.ID, moderator)
+	require.NoError(t, err)
+	require.Len(t, revisions, 3)
+
+	assert.Equal(t, 1, revisions[0].Version)
+	assert.Equal(t, entities.RevisionCreated, revisions[0].Action)
+	assert.Equal(t, citizen.ID, revisions[0].ActorID)
+	assert.Equal(t, "req-create", revisions[0].RequestID)
+	assert.Contains(t, revisions[0].Changes, entities.FieldChange{Field: "type", Before: nil, After: "ROBO"})
+
+	assert.Equal(t, entities.RevisionStatusChanged, revisions[1].Action)
+	assert.Equal(t, moderator.ID, revisions[1].ActorID)
+	assert.Equal(t, "req-verify", revisions[1].RequestID)
+	assert.Equal(t, []entities.FieldChange{{Field: "status", Before: "reported", After: "verified"}}, revisions[1].Changes)
+
+	assert.Equal(t, entities.RevisionDeleted, revisions[2].Action)
+	assert.Nil(t, revisions[2].Snapshot)
+
+	// Vista del delito en distintos instantes
+	asOf := usecases.NewGetCrimeAsOfUseCase(repo)
+	crime, err := asOf.Execute(context.Background(), created.ID, afterCreate, moderator)
+	require.NoError(t, err)
+	assert.Equal(t, entities.CrimeStatusReported, crime.Status)
+
+	// Un reporte sin verificar no es público
+	_, err = asOf.Execute(context.Background(), created.ID, afterCreate, citizen)
+	assert.ErrorIs(t, err, usecases.ErrCrimeNotFound)
+
+	crime, err = asOf.Execute(context.Background(), created.ID, afterVerify, citizen)
+	require.NoError(t, err)
+	assert.Equal(t, entities.CrimeStatusVerified, crime.Status)
+
+	_, err = asOf.Execute(context.Background(), created.ID, time.Now(), moderator)
+	assert.ErrorIs(t, err, usecases.ErrCrimeNotFound)
+	_, err = asOf.Execute(context.Background(), created.ID, afterCreate.Add(-time.Hour), moderator)
+	assert.ErrorIs(t, err, usecases.ErrCrimeNotFound)
+}
+
+func TestDiffCrimes(t *testing.T) {
+	before := &entities.Crime{Type: "ROBO", Description: "Robo", Location: entities.Location{Latitude: 1, Longitude: 2}, Status: entities.CrimeStatusReported}
+	after := *before
+	after.Description = "Robo a mano armada"
+	after.Location.Latitude = 1.5
+
+	assert.Equal(t, []entities.FieldChange{
+		{Field: "description", Before: "Robo", After: "Robo a mano armada"},
+		{Field: "location.latitude", Before: 1.0, After: 1.5},
+	}, entities.DiffCrimes(before, &after))
+	assert.Empty(t, entities.DiffCrimes(before, before))
+}
@@ -0,0 +1,135 @@
+package tests
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"go-crime_map_backend/internal/domain/entities"
+	"go-crime_map_backend/internal/infrastructure/repositories"
+	"go-crime_map_backend/internal/usecases"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	citizen   = entities.Actor{ID: "user-1", Role: entities.RoleCitizen}
+	moderator = entities.Actor{ID: "mod-1", Role: entities.RoleModerator}
+	admin     = entities.Actor{ID: "admin-1", Role: entities.RoleAdmin}
+)
+
+func seedCrime(t *testing.T, repo *repositories.MemoryCrimeRepository, id string, status entities.CrimeStatus) {
+	t.Helper()
+	now := time.Now()
+	require.NoError(t, repo.Create(context.Background(), &entities.Crime{
+		ID:          id,
+		Type:        "ROBO",
+		Description: "Robo a mano armada",
+		Location:    entities.Location{Latitude: -34.603722, Longitude: -58.381592, Address: "Av. Corrientes 1234, CABA"},
+		Date:        now.Add(-time.Hour),
+		Status:      status,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}))
+}
+
+func TestTransitionCrimeStatusUseCase_Execute(t *testing.T) {
+	tests := []struct {
+		name          string
+		from          entities.CrimeStatus
+		to            entities.CrimeStatus
+		reason        string
+		actor         entities.Actor
+		expectedError error
+	}{
+		{name: "Moderador verifica un delito reportado", from: entities.CrimeStatusReported, to: entities.CrimeStatusVerified, actor: moderator},
+		{name: "Moderador resuelve un delito verificado", from: entities.CrimeStatusVerified, to: entities.CrimeStatusResolved, actor: moderator},
+		{name: "Rechazo con motivo", from: entities.CrimeStatusReported, to: entities.CrimeStatusRejected, reason: "Reporte falso", actor: moderator},
+		{name: "Administrador reabre un delito rechazado", from: entities.CrimeStatusRejected, to: entities.CrimeStatusReported, reason: "Nueva evidencia", actor: admin},
+		{name: "Ciudadano no puede verificar", from: entities.CrimeStatusReported, to: entities.CrimeStatusVerified, actor: citizen, expectedError: usecases.ErrForbidden},
+		{name: "Moderador no puede reabrir", from: entities.CrimeStatusRejected, to: entities.CrimeStatusReported, reason: "Nueva evidencia", actor: moderator, expectedError: usecases.ErrForbidden},
+		{name: "Rechazo sin motivo", from: entities.CrimeStatusReported, to: entities.CrimeStatusRejected, reason: "   ", actor: moderator, expectedError: usecases.ErrReasonRequired},
+		{name: "Motivo demasiado largo", from: entities.CrimeStatusReported, to: entities.CrimeStatusRejected, reason: strings.Repeat("a", 501), actor: moderator, expectedError: usecases.ErrReasonTooLong},
+		{name: "Transición no permitida", from: entities.CrimeStatusReported, to: entities.CrimeStatusResolved, actor: admin, expectedError: usecases.ErrInvalidTransition},
+		{name: "Estado inválido", from: entities.CrimeStatusReported, to: "ARCHIVED", actor: admin, expectedError: usecases.ErrInvalidStatus},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := repositories.NewMemoryCrimeRepository()
+			seedCrime(t, repo, "crime-1", tt.from)
+			useCase := usecases.NewTransitionCrimeStatusUseCase(repo)
+
+			crime, err := useCase.Execute(context.Background(), usecases.TransitionCrimeStatusInput{
+				CrimeID: "crime-1",
+				Status:  tt.to,
+				Reason:  tt.reason,
+				Actor:   tt.actor,
+			})
+
+			history, historyErr := repo.GetStatusHistory(context.Background(), "crime-1")
+			require.NoError(t, historyErr)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				assert.Nil(t, crime)
+				assert.Empty(t, history)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.to, crime.Status)
+			require.Len(t, history, 1)
+			assert.Equal(t, tt.from, history[0].From)
+			assert.Equal(t, tt.to, history[0].To)
+			assert.Equal(t, tt.actor.ID, history[0].ActorID)
+			assert.Equal(t, strings.TrimSpace(tt.reason), history[0].Reason)
+		})
+	}
+
+	t.Run("Delito inexistente", func(t *testing.T) {
+		useCase := usecases.NewTransitionCrimeStatusUseCase(repositories.NewMemoryCrimeRepository())
+		_, err := useCase.Execute(context.Background(), usecases.TransitionCrimeStatusInput{
+			CrimeID: "no-existe",
+			Status:  entities.CrimeStatusVerified,
+			Actor:   moderator,
+		})
+		assert.ErrorIs(t, err, usecases.ErrCrimeNotFound)
+	})
+}
+
+func TestListCrimesUseCase_Visibility(t *testing.T) {
+	repo := repositories.NewMemoryCrimeRepository()
+	seedCrime(t, repo, "reported", entities.CrimeStatusReported)
+	seedCrime(t, repo, "verified", entities.CrimeStatusVerified)
+	seedCrime(t, repo, "rejected", entities.CrimeStatusRejected)
+	useCase := usecases.NewListCrimesUseCase(repo)
+
+	ids := func(crimes []*entities.Crime) []string {
+		var result []string
+		for _, crime := range crimes {
+			result = append(result, crime.ID)
+		}
+		return result
+	}
+
+	public, err := useCase.Execute(context.Background(), usecases.ListCrimesInput{Actor: entities.AnonymousActor})
+	require.NoError(t, err)
+	assert.NotContains(t, ids(public), "rejected")
+
+	all, err := useCase.Execute(context.Background(), usecases.ListCrimesInput{Actor: moderator})
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"reported", "verified", "rejected"}, ids(all))
+
+	_, err = useCase.Execute(context.Background(), usecases.ListCrimesInput{
+		Statuses: []entities.CrimeStatus{entities.CrimeStatusRejected},
+		Actor:    citizen,
+	})
+	assert.ErrorIs(t, err, usecases.ErrForbidden)
+
+	getUseCase := usecases.NewGetCrimeUseCase(repo)
+	_, err = getUseCase.Execute(context.Background(), "rejected", citizen)
+	assert.ErrorIs(t, err, usecases.ErrCrimeNotFound)
+}
@@ -0,0 +1,137 @@
+package tests
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"go-crime_map_backend/internal/domain/entities"
+	"go-crime_map_backend/internal/domain/events"
+	memory "go-crime_map_backend/internal/infrastructure/repositories"
+	"go-crime_map_backend/internal/usecases"
+	"go-crime_map_backend/pkg/mvt"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCrimeTiles(t *testing.T) {
+	ctx := context.Background()
+	repo := memory.NewMemoryCrimeRepository()
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	n := 0
+	add := func(status entities.CrimeStatus, lat, lon float64) *entities.Crime {
+		n++
+		crime := &entities.Crime{
+			ID:          fmt.Sprintf("crime-%d", n),
+			Type:        "ROBO",
+			Description: "delito de prueba",
+			Location:    entities.Location{Latitude: lat, Longitude: lon},
+			Date:        now.Add(-time.Duration(n) * time.Hour),
+			Status:      status,
+			Severity:    entities.SeverityHigh,
+		}
+		require.NoError(t, repo.Create(ctx, crime))
+		return crime
+	}
+	// Dos delitos junto al Obelisco, un reporte sin verificar y uno en Córdoba
+	add(entities.CrimeStatusVerified, -34.6037, -58.3816)
+	add(entities.CrimeStatusVerified, -34.6040, -58.3820)
+	add(entities.CrimeStatusReported, -34.6037, -58.3816)
+	add(entities.CrimeStatusVerified, -31.4201, -64.1888)
+
+	tiles := usecases.NewGetCrimeTileUseCaseWithClock(repo, usecases.CrimeTileOptions{CacheTTL: time.Hour, CacheSize: 10},
+		func() time.Time { return now })
+	execute := func(tile mvt.TileID, actor entities.Actor) *usecases.CrimeTile {
+		t.Helper()
+		result, err := tiles.Execute(ctx, usecases.CrimeTileInput{Z: tile.Z, X: tile.X, Y: tile.Y, Actor: actor})
+		require.NoError(t, err)
+		return result
+	}
+	obelisco := func(z int) mvt.TileID {
+		return mvt.TilesAt(-34.6037, -58.3816, z, mvt.DefaultExtent, 0)[0]
+	}
+
+	// En zoom alto cada delito visible es un punto
+	street := execute(obelisco(16), citizen)
+	assert.False(t, street.Clustered)
+	assert.Equal(t, 2, street.Crimes)
+	assert.NotEmpty(t, street.Data)
+	assert.Len(t, street.ETag, 34)
+	assert.Equal(t, 3, execute(obelisco(16), moderator).Crimes, "los moderadores ven los reportes")
+
+	// En zoom bajo los delitos cercanos se agrupan y el tile vacío no tiene contenido
+	city := execute(obelisco(10), citizen)
+	assert.True(t, city.Clustered)
+	assert.Equal(t, 2, city.Crimes)
+	assert.Less(t, len(city.Data), len(street.Data))
+	empty := execute(mvt.TileID{Z: 10, X: 0, Y: 0}, citizen)
+	assert.Zero(t, empty.Crimes)
+	assert.Empty(t, empty.Data)
+
+	// El tile se reutiliza hasta que un evento invalida los tiles de la ubicación del delito
+	crime := add(entities.CrimeStatusVerified, -34.6038, -58.3817)
+	assert.Equal(t, street.ETag, execute(obelisco(16), citizen).ETag)
+	event, err := events.New("event-1", events.CrimeReported, crime.ID, events.CrimeReportedPayload{Crime: crime}, now)
+	require.NoError(t, err)
+	require.NoError(t, tiles.Handle(ctx, event))
+	updated := execute(obelisco(16), citizen)
+	assert.Equal(t, 3, updated.Crimes)
+	assert.NotEqual(t, street.ETag, updated.ETag)
+	assert.Equal(t, 3, execute(obelisco(10), citizen).Crimes)
+
+	_, err = tiles.Execute(ctx, usecases.CrimeTileInput{Z: 2, X: 4, Y: 0, Actor: citizen})
+	assert.ErrorIs(t, err, usecases.ErrInvalidTile)
+}
+
+func TestCrimeTilesInvalidatedOnEveryInstance(t *testing.T) {
+	ctx := context.Background()
+	repo := memory.NewMemoryCrimeRepository()
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	tile := mvt.TilesAt(-34.6037, -58.3816, 16, mvt.DefaultExtent, 0)[0]
+
+	// Cada instancia tiene su caché de tiles y su difusor del outbox
+	type instance struct {
+		tiles       *usecases.GetCrimeTileUseCase
+		broadcaster *usecases.OutboxBroadcaster
+	}
+	instances := make([]instance, 2)
+	for i := range instances {
+		tiles := usecases.NewGetCrimeTileUseCaseWithClock(repo, usecases.CrimeTileOptions{CacheTTL: time.Hour, CacheSize: 10},
+			func() time.Time { return now })
+		broadcaster := usecases.NewOutboxBroadcasterSince(repo, usecases.DefaultOutboxBroadcasterOptions(), now.Add(-time.Hour))
+		broadcaster.SubscribeAll(tiles)
+		instances[i] = instance{tiles: tiles, broadcaster: broadcaster}
+	}
+	crimes := func(in instance) int {
+		t.Helper()
+		result, err := in.tiles.Execute(ctx, usecases.CrimeTileInput{Z: tile.Z, X: tile.X, Y: tile.Y, Actor: moderator})
+		require.NoError(t, err)
+		return result.Crimes
+	}
+	for _, in := range instances {
+		assert.Zero(t, crimes(in))
+	}
+
+	crime := &entities.Crime{
+		ID:       "crime-1",
+		Type:     "ROBO",
+		Location: entities.Location{Latitude: -34.6037, Longitude: -58.3816},
+		Date:     now.Add(-time.Hour),
+		Status:   entities.CrimeStatusReported,
+	}
+	event, err := events.New("event-1", events.CrimeReported, crime.ID, events.CrimeReportedPayload{Crime: crime}, now)
+	require.NoError(t, err)
+	require.NoError(t, repo.Create(events.WithEvents(ctx, event), crime))
+
+	// El despachador entrega el evento una sola vez, pero todas las cachés se invalidan
+	_, err = usecases.NewOutboxDispatcher(repo, testDispatcherOptions()).DispatchPending(ctx)
+	require.NoError(t, err)
+	for _, in := range instances {
+		assert.Zero(t, crimes(in), "la caché sigue vigente hasta difundir el evento")
+		_, err := in.broadcaster.Poll(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 1, crimes(in))
+	}
+}
@@ -0,0 +1,115 @@
+package tests
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"go-crime_map_backend/internal/domain/entities"
+	memory "go-crime_map_backend/internal/infrastructure/repositories"
+	"go-crime_map_backend/internal/usecases"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCrimeTimeSeries(t *testing.T) {
+	ctx := context.Background()
+	repo := memory.NewMemoryCrimeRepository()
+	n := 0
+	add := func(crimeType string, status entities.CrimeStatus, date time.Time, lat, lon float64) {
+		n++
+		require.NoError(t, repo.Create(ctx, &entities.Crime{
+			ID:          fmt.Sprintf("crime-%d", n),
+			Type:        crimeType,
+			Description: "delito de prueba",
+			Location:    entities.Location{Latitude: lat, Longitude: lon},
+			Date:        date,
+			Status:      status,
+		}))
+	}
+	// Lunes 2 de marzo de 2026 a las 02:30 UTC, domingo 1 a las 23:30 en Buenos Aires (UTC-3)
+	monday := time.Date(2026, 3, 2, 2, 30, 0, 0, time.UTC)
+	add("ROBO", entities.CrimeStatusVerified, monday, -34.60, -58.38)
+	add("ROBO", entities.CrimeStatusVerified, monday.Add(10*time.Minute), -34.60, -58.38)
+	add("HURTO", entities.CrimeStatusVerified, monday.Add(26*time.Hour), -34.60, -58.38)
+	add("ROBO", entities.CrimeStatusVerified, monday.AddDate(0, 0, 3), -31.42, -64.18)
+	add("ROBO", entities.CrimeStatusReported, monday.Add(time.Hour), -34.60, -58.38)
+
+	series := usecases.NewGetCrimeTimeSeriesUseCase(repo)
+	from := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 0, 7)
+	execute := func(bucket entities.TimeBucket, criteria usecases.StatsCriteria) *usecases.TimeSeriesResult {
+		t.Helper()
+		criteria.From, criteria.To = from, to
+		result, err := series.Execute(ctx, usecases.TimeSeriesInput{StatsCriteria: criteria, Bucket: bucket})
+		require.NoError(t, err)
+		return result
+	}
+	counts := func(result *usecases.TimeSeriesResult) []int {
+		values := make([]int, len(result.Points))
+		for i, point := range result.Points {
+			values[i] = point.Count
+		}
+		return values
+	}
+
+	// Los días sin delitos se completan con cero y los reportes sin verificar no se cuentan
+	daily := execute("", usecases.StatsCriteria{Actor: citizen})
+	assert.Equal(t, entities.TimeBucketDay, daily.Bucket)
+	assert.Equal(t, "UTC", daily.Timezone)
+	assert.Equal(t, []int{0, 2, 1, 0, 1, 0, 0}, counts(daily))
+	assert.Equal(t, 4, daily.Total)
+	assert.True(t, daily.Points[1].Start.Equal(time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)))
+
+	// En la hora local de Buenos Aires los dos primeros delitos son del domingo y el período
+	// empieza el sábado 28 a las 21, por lo que el primer día queda parcialmente afuera
+	local := execute(entities.TimeBucketDay, usecases.StatsCriteria{Timezone: "America/Argentina/Buenos_Aires", Actor: moderator})
+	require.Len(t, local.Points, 8)
+	assert.Equal(t, []int{0, 2, 1, 1, 1, 0, 0, 0}, counts(local))
+	assert.Equal(t, 28, local.Points[0].Start.Day())
+
+	hourly := execute(entities.TimeBucketHour, usecases.StatsCriteria{Types: []string{"ROBO"}, Actor: citizen})
+	assert.Len(t, hourly.Points, 7*24)
+	assert.Equal(t, 2, hourly.Points[26].Count)
+	assert.Equal(t, 3, hourly.Total)
+
+	// Las semanas empiezan el lunes, así que el domingo 1 queda en la semana anterior
+	weekly := execute(entities.TimeBucketWeek, usecases.StatsCriteria{Actor: citizen})
+	assert.Equal(t, []int{0, 4}, counts(weekly))
+	assert.Equal(t, time.Monday, weekly.Points[1].Start.Weekday())
+
+	box := &entities.BoundingBox{MinLatitude: -35, MinLongitude: -59, MaxLatitude: -34, MaxLongitude: -58}
+	monthly := execute(entities.TimeBucketMonth, usecases.StatsCriteria{BoundingBox: box, Actor: citizen})
+	assert.Equal(t, []int{3}, counts(monthly))
+
+	// Matriz de día de la semana y hora
+	punchcard, err := usecases.NewGetCrimePunchcardUseCase(repo).Execute(ctx, usecases.StatsCriteria{
+		From: from, To: to, Timezone: "America/Argentina/Buenos_Aires", Actor: citizen,
+	})
+	require.NoError(t, err)
+	require.Len(t, punchcard.Counts, 7)
+	assert.Equal(t, "monday", punchcard.Weekdays[0])
+	assert.Equal(t, 2, punchcard.Counts[6][23], "domingo a las 23")
+	assert.Equal(t, 1, punchcard.Counts[1][1], "martes a la 1")
+	assert.Equal(t, 1, punchcard.Counts[2][23], "miércoles a las 23")
+	assert.Equal(t, 4, punchcard.Total)
+
+	// Errores
+	for name, input := range map[string]usecases.TimeSeriesInput{
+		"intervalo":    {Bucket: "year"},
+		"zona horaria": {StatsCriteria: usecases.StatsCriteria{Timezone: "Marte/Olympus"}},
+		"local":        {StatsCriteria: usecases.StatsCriteria{Timezone: "Local"}},
+		"período":      {StatsCriteria: usecases.StatsCriteria{From: to, To: from}},
+		"rectángulo":   {StatsCriteria: usecases.StatsCriteria{BoundingBox: &entities.BoundingBox{MinLatitude: 10, MaxLatitude: 0}}},
+		"estado":       {StatsCriteria: usecases.StatsCriteria{Statuses: []entities.CrimeStatus{entities.CrimeStatusReported}, Actor: citizen}},
+	} {
+		_, err := series.Execute(ctx, input)
+		assert.Error(t, err, name)
+	}
+	_, err = series.Execute(ctx, usecases.TimeSeriesInput{Bucket: "year"})
+	assert.ErrorIs(t, err, usecases.ErrInvalidTimeBucket)
+	_, err = series.Execute(ctx, usecases.TimeSeriesInput{StatsCriteria: usecases.StatsCriteria{Timezone: "Marte/Olympus"}})
+	assert.ErrorIs(t, err, usecases.ErrInvalidTimezone)
+}
@@ -0,0 +1,107 @@
+package tests
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"fmt"
+	"testing"
+	"time"
+
+	"go-crime_map_backend/internal/domain/entities"
+	memory "go-crime_map_backend/internal/infrastructure/repositories"
+	"go-crime_map_backend/internal/usecases"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExportCrimes(t *testing.T) {
+	ctx := context.Background()
+	repo := memory.NewMemoryCrimeRepository()
+	from := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	// Más delitos que una página, de a tres por minuto para que el cursor desempate por ID
+	for i := 0; i < 1203; i++ {
+		crime := &entities.Crime{
+			ID:          fmt.Sprintf("crime-%04d", i),
+			Type:        "ROBO",
+			Description: "delito de prueba",
+			Location:    entities.Location{Latitude: -34.6037, Longitude: -58.3816, Address: "Av. Corrientes 1000"},
+			Date:        from.Add(time.Duration(i/3) * time.Minute),
+			Status:      entities.CrimeStatusVerified,
+			Severity:    entities.SeverityHigh,
+		}
+		if i%2 == 1 {
+			crime.Type = "HURTO"
+			crime.Severity = entities.SeverityLow
+		}
+		require.NoError(t, repo.Create(ctx, crime))
+	}
+	require.NoError(t, repo.Create(ctx, &entities.Crime{
+		ID: "reported", Type: "ROBO", Description: "reporte sin verificar", Date: from,
+		Location: entities.Location{Latitude: -34.6, Longitude: -58.38}, Status: entities.CrimeStatusReported,
+	}))
+
+	uc := usecases.NewExportCrimesUseCase(repo)
+	export := func(format string, actor entities.Actor) (*usecases.CrimeExport, string, int) {
+		t.Helper()
+		result, err := uc.Execute(ctx, usecases.ExportCrimesInput{
+			StatsCriteria: usecases.StatsCriteria{From: from, To: from.AddDate(0, 0, 1), Actor: actor},
+			Format:        format,
+		})
+		require.NoError(t, err)
+		var buf bytes.Buffer
+		written, err := result.WriteTo(ctx, &buf)
+		require.NoError(t, err)
+		return result, buf.String(), written
+	}
+
+	// KML: todos los delitos visibles, en orden, con el estilo de su tipo y su fecha
+	result, body, written := export("KML", citizen)
+	assert.Equal(t, "application/vnd.google-earth.kml+xml", result.ContentType)
+	assert.Equal(t, "delitos-20260301-20260302.kml", result.Filename)
+	assert.Equal(t, 1203, written, "los ciudadanos no exportan los reportes")
+	var doc struct {
+		Styles     []string `xml:"Document>Style>IconStyle>color"`
+		Placemarks []struct {
+			Data     []string `xml:"ExtendedData>Data>value"`
+			When     string   `xml:"TimeStamp>when"`
+			StyleURL string   `xml:"styleUrl"`
+		} `xml:"Document>Placemark"`
+	}
+	require.NoError(t, xml.Unmarshal([]byte(body), &doc))
+	assert.Len(t, doc.Styles, 11, "un estilo por tipo más el de por defecto")
+	require.Len(t, doc.Placemarks, 1203)
+	for i, placemark := range doc.Placemarks {
+		require.Equal(t, fmt.Sprintf("crime-%04d", i), placemark.Data[0], "el primer dato es el ID")
+	}
+	assert.Equal(t, "2026-03-01T00:00:00Z", doc.Placemarks[0].When)
+	assert.Equal(t, "#type-ROBO", doc.Placemarks[0].StyleURL)
+	assert.Equal(t, "#type-HURTO", doc.Placemarks[1].StyleURL)
+
+	_, _, written = export("kml", moderator)
+	assert.Equal(t, 1204, written)
+
+	// GPX: waypoints con bandera según la gravedad
+	result, body, written = export("gpx", citizen)
+	assert.Equal(t, "application/gpx+xml", result.ContentType)
+	assert.Equal(t, 1203, written)
+	var gpxDoc struct {
+		Waypoints []struct {
+			Name   string `xml:"name"`
+			Desc   string `xml:"desc"`
+			Symbol string `xml:"sym"`
+			Type   string `xml:"type"`
+		} `xml:"wpt"`
+	}
+	require.NoError(t, xml.Unmarshal([]byte(body), &gpxDoc))
+	require.Len(t, gpxDoc.Waypoints, 1203)
+	assert.Equal(t, "ROBO 2026-03-01 00:00", gpxDoc.Waypoints[0].Name)
+	assert.Equal(t, "delito de prueba (Av. Corrientes 1000)", gpxDoc.Waypoints[0].Desc)
+	assert.Equal(t, "Flag, Red", gpxDoc.Waypoints[0].Symbol)
+	assert.Equal(t, "Flag, Blue", gpxDoc.Waypoints[1].Symbol)
+	assert.Equal(t, "HURTO", gpxDoc.Waypoints[1].Type)
+
+	_, err := uc.Execute(ctx, usecases.ExportCrimesInput{Format: "shp", StatsCriteria: usecases.StatsCriteria{Actor: citizen}})
+	assert.ErrorIs(t, err, usecases.ErrInvalidExportFormat)
+}
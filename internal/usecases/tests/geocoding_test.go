@@ -0,0 +1,113 @@
+package tests
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"go-crime_map_backend/internal/domain/entities"
+	"go-crime_map_backend/internal/infrastructure/geocoding"
+	memory "go-crime_map_backend/internal/infrastructure/repositories"
+	"go-crime_map_backend/internal/usecases"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateCrimeGeocoding(t *testing.T) {
+	ctx := context.Background()
+	gazetteer, err := geocoding.NewGazetteer(strings.NewReader(`street,number,latitude,longitude,locality
+Av. Corrientes,1000,-34.6035,-58.3800,CABA
+Av. Corrientes,1200,-34.6035,-58.3840,CABA
+`), 0)
+	require.NoError(t, err)
+	repo := memory.NewMemoryCrimeRepository()
+	resolver := usecases.NewLocationResolver(gazetteer, 300)
+	create := usecases.NewCreateCrimeUseCaseWithGeocoding(repo, nil, resolver)
+	date := time.Date(2026, 3, 2, 15, 0, 0, 0, time.UTC)
+	report := func(location usecases.Location) (*entities.Crime, error) {
+		date = date.Add(time.Hour)
+		return create.Execute(ctx, usecases.CreateCrimeInput{
+			Type: "ROBO", Description: "robo de celular", Location: location, Date: date,
+		})
+	}
+
+	// Solo con dirección se completan las coordenadas
+	crime, err := report(usecases.Location{Address: "corrientes 1100"})
+	require.NoError(t, err)
+	assert.InDelta(t, -58.3820, crime.Location.Longitude, 1e-9)
+	assert.Equal(t, "Av. Corrientes 1100, CABA", crime.Location.Address)
+	assert.False(t, crime.AddressMismatch)
+
+	// Solo con coordenadas se completa la dirección más cercana
+	crime, err = report(usecases.Location{Latitude: -34.6036, Longitude: -58.3839})
+	require.NoError(t, err)
+	assert.Equal(t, "Av. Corrientes 1200, CABA", crime.Location.Address)
+
+	// Con ambas se normaliza la dirección si coincide y se marca si está lejos
+	crime, err = report(usecases.Location{Latitude: -34.6036, Longitude: -58.3801, Address: "Av Corrientes 1000"})
+	require.NoError(t, err)
+	assert.Equal(t, "Av. Corrientes 1000, CABA", crime.Location.Address)
+	assert.False(t, crime.AddressMismatch)
+
+	crime, err = report(usecases.Location{Latitude: -34.6200, Longitude: -58.4000, Address: "Av Corrientes 1000"})
+	require.NoError(t, err)
+	assert.Equal(t, "Av Corrientes 1000", crime.Location.Address, "se conserva lo informado")
+	assert.InDelta(t, -34.62, crime.Location.Latitude, 1e-9)
+	assert.True(t, crime.AddressMismatch)
+
+	// Una dirección desconocida sin coordenadas no se puede ubicar
+	_, err = report(usecases.Location{Address: "Av. Rivadavia 5000"})
+	assert.ErrorIs(t, err, usecases.ErrAddressNotFound)
+	code, _ := usecases.ValidationErrorCode(err)
+	assert.Equal(t, "address_not_found", code)
+
+	// Sin coordenadas ni dirección no hay ubicación, con o sin geocodificador
+	_, err = report(usecases.Location{})
+	assert.ErrorIs(t, err, usecases.ErrMissingCoordinates)
+	withoutGeocoder := usecases.NewCreateCrimeUseCase(repo)
+	for _, location := range []usecases.Location{{}, {Address: "Corrientes 1100"}} {
+		_, err = withoutGeocoder.Execute(ctx, usecases.CreateCrimeInput{
+			Type: "ROBO", Description: "robo de celular", Location: location, Date: date,
+		})
+		assert.ErrorIs(t, err, usecases.ErrMissingCoordinates, "sin geocodificador")
+	}
+	// Sin geocodificador tampoco hay quien complete la dirección
+	_, err = withoutGeocoder.Execute(ctx, usecases.CreateCrimeInput{
+		Type: "ROBO", Description: "robo de celular", Location: usecases.Location{Latitude: -34.6037, Longitude: -58.3816}, Date: date,
+	})
+	assert.ErrorIs(t, err, usecases.ErrMissingAddress)
+	code, ok := usecases.ValidationErrorCode(err)
+	assert.True(t, ok)
+	assert.Equal(t, "missing_address", code)
+	crimes, err := repo.GetAll(ctx)
+	require.NoError(t, err)
+	assert.Len(t, crimes, 4, "solo se guardan los delitos ubicados")
+}
+
+func TestUpdateCrimeGeocoding(t *testing.T) {
+	ctx := context.Background()
+	gazetteer, err := geocoding.NewGazetteer(strings.NewReader("street,number,latitude,longitude\nCorrientes,1000,-34.6035,-58.3800\n"), 0)
+	require.NoError(t, err)
+	repo := memory.NewMemoryCrimeRepository()
+	resolver := usecases.NewLocationResolver(gazetteer, 0)
+	input := usecases.CreateCrimeInput{
+		Type:        "HURTO",
+		Description: "hurto en la vía pública",
+		Location:    usecases.Location{Latitude: -34.6200, Longitude: -58.4000, Address: "Corrientes 1000"},
+		Date:        time.Date(2026, 3, 2, 15, 0, 0, 0, time.UTC),
+	}
+	crime, err := usecases.NewCreateCrimeUseCaseWithGeocoding(repo, nil, resolver).Execute(ctx, input)
+	require.NoError(t, err)
+	require.True(t, crime.AddressMismatch)
+
+	// La corrección del moderador vuelve a verificar la dirección
+	input.Location = usecases.Location{Address: "Corrientes 1000"}
+	updated, err := usecases.NewUpdateCrimeUseCaseWithGeocoding(repo, nil, resolver).Execute(ctx, usecases.UpdateCrimeInput{
+		CrimeID: crime.ID, Data: input, Actor: moderator,
+	})
+	require.NoError(t, err)
+	assert.False(t, updated.AddressMismatch)
+	assert.InDelta(t, -34.6035, updated.Location.Latitude, 1e-9)
+}
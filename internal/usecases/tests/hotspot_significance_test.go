@@ -0,0 +1,150 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"go-crime_map_backend/internal/domain/entities"
+	memory "go-crime_map_backend/internal/infrastructure/repositories"
+	"go-crime_map_backend/internal/usecases"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHotspotSignificance(t *testing.T) {
+	ctx := context.Background()
+	crimeRepo := memory.NewMemoryCrimeRepository()
+	zoneRepo := memory.NewMemoryZoneRepository()
+	date := time.Now().Add(-time.Hour)
+	n := 0
+	add := func(lat, lon float64, zoneID string) {
+		n++
+		require.NoError(t, crimeRepo.Create(ctx, &entities.Crime{
+			ID:          fmt.Sprintf("crime-%d", n),
+			Type:        "ROBO",
+			Description: "delito de prueba",
+			Location:    entities.Location{Latitude: lat, Longitude: lon},
+			Date:        date,
+			Status:      entities.CrimeStatusVerified,
+			ZoneID:      zoneID,
+		}))
+	}
+
+	// Grilla de 5 x 5 barrios; el central tiene 20 delitos, sus cuatro vecinos 10 y el resto 1
+	createZone := usecases.NewCreateZoneUseCase(zoneRepo, crimeRepo)
+	var center, corner *entities.Zone
+	for row := 0; row < 5; row++ {
+		for col := 0; col < 5; col++ {
+			lat, lon := -34.62+float64(row)*0.01, -58.42+float64(col)*0.01
+			zone, err := createZone.Execute(ctx, usecases.ZoneInput{
+				Name: fmt.Sprintf("Barrio %d-%d", row, col), Kind: "barrio", Geometry: square(lat, lon, 0.005),
+			}, admin)
+			require.NoError(t, err)
+			crimes := 1
+			switch distance := abs(row-2) + abs(col-2); distance {
+			case 0:
+				crimes, center = 20, zone
+			case 1:
+				crimes = 10
+			}
+			if row == 0 && col == 0 {
+				corner = zone
+			}
+			for i := 0; i < crimes; i++ {
+				add(lat, lon, zone.ID)
+			}
+		}
+	}
+
+	significance := usecases.NewGetHotspotSignificanceUseCase(crimeRepo, zoneRepo, 1000)
+	zones, err := significance.Execute(ctx, usecases.HotspotSignificanceInput{
+		StatsCriteria: usecases.StatsCriteria{Actor: citizen}, Aggregation: "zone", ZoneKind: "barrio", DistanceMeters: 1200,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "FeatureCollection", zones.Type)
+	assert.Equal(t, 80, zones.CrimeCount)
+	require.Len(t, zones.Features, 25)
+	byID := map[any]map[string]any{}
+	for _, feature := range zones.Features {
+		byID[feature.ID] = feature.Properties
+	}
+	assert.Equal(t, 20, byID[center.ID]["count"])
+	assert.InDelta(t, 4.54, byID[center.ID]["z_score"], 0.01)
+	assert.Equal(t, 3, byID[center.ID]["confidence"])
+	assert.Equal(t, "hot", byID[center.ID]["class"])
+	assert.Less(t, byID[center.ID]["p_value"], 0.01)
+	assert.Equal(t, "not_significant", byID[corner.ID]["class"])
+	assert.Equal(t, "Barrio 0-0", byID[corner.ID]["name"])
+
+	// Por defecto la distancia es la menor que da un vecino a cada zona: el barrio al este
+	defaults, err := significance.Execute(ctx, usecases.HotspotSignificanceInput{
+		StatsCriteria: usecases.StatsCriteria{Actor: citizen}, Aggregation: "zone", ZoneKind: "barrio", SignificantOnly: true,
+	})
+	require.NoError(t, err)
+	assert.InDelta(t, 916, defaults.DistanceMeters, 2)
+	for _, feature := range defaults.Features {
+		assert.NotEqual(t, 0, feature.Properties["confidence"])
+	}
+
+	// Sobre la grilla de 500 m, la celda con los delitos del barrio central es un punto caliente
+	box := &entities.BoundingBox{MinLatitude: -34.625, MinLongitude: -58.425, MaxLatitude: -34.575, MaxLongitude: -58.375}
+	grid, err := significance.Execute(ctx, usecases.HotspotSignificanceInput{
+		StatsCriteria: usecases.StatsCriteria{BoundingBox: box, Actor: citizen}, CellMeters: 500,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, usecases.AggregationGrid, grid.Aggregation)
+	assert.Equal(t, 750.0, grid.DistanceMeters)
+	var central map[string]any
+	for _, feature := range grid.Features {
+		assert.Equal(t, "Polygon", feature.Geometry.Type)
+		if feature.Properties["count"] == 0 {
+			assert.NotEqual(t, 0, feature.Properties["confidence"], "las celdas vacías solo se incluyen si son significativas")
+		}
+		if feature.Properties["count"] == 20 {
+			central = feature.Properties
+		}
+	}
+	require.NotNil(t, central)
+	assert.Equal(t, "hot", central["class"])
+	body, err := json.Marshal(grid)
+	require.NoError(t, err)
+	assert.Contains(t, string(body), `"type":"FeatureCollection"`)
+
+	// Con celdas chicas y la distancia máxima cada celda es vecina de casi toda la grilla; el
+	// análisis no lista los pares vecinos
+	wide, err := significance.Execute(ctx, usecases.HotspotSignificanceInput{
+		StatsCriteria: usecases.StatsCriteria{BoundingBox: box, Actor: citizen}, CellMeters: 50, DistanceMeters: 50000,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, grid.CrimeCount, wide.CrimeCount)
+
+	// Errores
+	for name, input := range map[string]usecases.HotspotSignificanceInput{
+		"agregación": {Aggregation: "hex"},
+		"tipo":       {Aggregation: "zone"},
+	} {
+		_, err := significance.Execute(ctx, input)
+		assert.ErrorIs(t, err, usecases.ErrInvalidAggregation, name)
+	}
+	for name, input := range map[string]usecases.HotspotSignificanceInput{
+		"celda":     {CellMeters: 10},
+		"distancia": {DistanceMeters: -1},
+		"celdas": {CellMeters: 50, StatsCriteria: usecases.StatsCriteria{
+			BoundingBox: &entities.BoundingBox{MinLatitude: -35, MinLongitude: -59, MaxLatitude: -34, MaxLongitude: -58},
+		}},
+	} {
+		_, err := significance.Execute(ctx, input)
+		assert.ErrorIs(t, err, usecases.ErrInvalidSignificanceParameters, name)
+	}
+}
+
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
@@ -0,0 +1,121 @@
+package tests
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"go-crime_map_backend/internal/domain/entities"
+	memory "go-crime_map_backend/internal/infrastructure/repositories"
+	"go-crime_map_backend/internal/usecases"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDetectHotspots(t *testing.T) {
+	ctx := context.Background()
+	repo := memory.NewMemoryCrimeRepository()
+	now := time.Date(2026, 3, 10, 12, 0, 30, 0, time.UTC)
+	n := 0
+	add := func(crimeType string, status entities.CrimeStatus, lat, lon float64) {
+		n++
+		require.NoError(t, repo.Create(ctx, &entities.Crime{
+			ID:          fmt.Sprintf("crime-%d", n),
+			Type:        crimeType,
+			Description: "delito de prueba",
+			Location:    entities.Location{Latitude: lat, Longitude: lon},
+			Date:        now.AddDate(0, 0, -1),
+			Status:      status,
+		}))
+	}
+	// Seis delitos en unos 70 m alrededor del Obelisco, cinco en Plaza de Mayo y dos aislados
+	for i := 0; i < 6; i++ {
+		add("ROBO", entities.CrimeStatusVerified, -34.6037+float64(i%3)*0.0003, -58.3816+float64(i/3)*0.0003)
+	}
+	for i := 0; i < 5; i++ {
+		add("HURTO", entities.CrimeStatusVerified, -34.6083+float64(i)*0.0002, -58.3712)
+	}
+	add("ROBO", entities.CrimeStatusVerified, -34.5800, -58.4300)
+	add("ROBO", entities.CrimeStatusVerified, -34.6300, -58.4600)
+	add("ROBO", entities.CrimeStatusReported, -34.6037, -58.3816)
+
+	hotspots := usecases.NewDetectHotspotsUseCaseWithClock(repo, usecases.HotspotOptions{
+		CacheTTL: time.Minute, CacheSize: 10, MaxCrimes: 100,
+	}, func() time.Time { return now })
+
+	// DBSCAN con los parámetros por defecto: 200 m y 5 delitos
+	result, err := hotspots.Execute(ctx, usecases.HotspotInput{StatsCriteria: usecases.StatsCriteria{Actor: citizen}})
+	require.NoError(t, err)
+	assert.Equal(t, usecases.HotspotMethodDBSCAN, result.Method)
+	assert.Equal(t, 13, result.CrimeCount, "los reportes sin verificar no se analizan")
+	assert.Equal(t, 2, result.Noise)
+	assert.True(t, result.To.Equal(time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)), "el fin se trunca al minuto")
+	require.Len(t, result.Hotspots, 2)
+	obelisco := result.Hotspots[0]
+	assert.Equal(t, 1, obelisco.Rank)
+	assert.Equal(t, 6, obelisco.Count)
+	assert.Equal(t, map[string]int{"ROBO": 6}, obelisco.ByType)
+	assert.InDelta(t, -34.6034, obelisco.Centroid.Latitude, 1e-6)
+	assert.InDelta(t, -58.38145, obelisco.Centroid.Longitude, 1e-6)
+	require.Len(t, obelisco.Area, 1)
+	assert.Len(t, obelisco.Area[0][0], 4)
+	assert.InDelta(t, 66.7*27.5, obelisco.AreaSqM, 20)
+
+	// Los delitos de Plaza de Mayo están alineados, así que no delimitan un área
+	plaza := result.Hotspots[1]
+	assert.Equal(t, 5, plaza.Count)
+	assert.Nil(t, plaza.Area)
+	assert.Zero(t, plaza.AreaSqM)
+
+	// Los mismos parámetros reutilizan el análisis hasta que vence la caché
+	add("ROBO", entities.CrimeStatusVerified, -34.6037, -58.3816)
+	cached, err := hotspots.Execute(ctx, usecases.HotspotInput{StatsCriteria: usecases.StatsCriteria{Actor: citizen}})
+	require.NoError(t, err)
+	assert.Same(t, result, cached)
+	now = now.Add(2 * time.Minute)
+	fresh, err := hotspots.Execute(ctx, usecases.HotspotInput{StatsCriteria: usecases.StatsCriteria{Actor: citizen}})
+	require.NoError(t, err)
+	assert.NotSame(t, result, fresh)
+	assert.Equal(t, 7, fresh.Hotspots[0].Count)
+
+	// Con min_points 6 Plaza de Mayo deja de ser una zona caliente
+	strict, err := hotspots.Execute(ctx, usecases.HotspotInput{
+		StatsCriteria: usecases.StatsCriteria{Actor: citizen}, MinPoints: 6,
+	})
+	require.NoError(t, err)
+	require.Len(t, strict.Hotspots, 1)
+	assert.Equal(t, 7, strict.Noise)
+
+	// Densidad de kernel
+	kde, err := hotspots.Execute(ctx, usecases.HotspotInput{
+		StatsCriteria: usecases.StatsCriteria{Actor: citizen}, Method: "kde", BandwidthMeters: 100,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 0.5, kde.Threshold)
+	require.NotEmpty(t, kde.Hotspots)
+	peak := kde.Hotspots[0]
+	assert.Equal(t, 7, peak.Count)
+	require.NotNil(t, peak.PeakDensity)
+	assert.Greater(t, *peak.PeakDensity, 0.0)
+	assert.NotEmpty(t, peak.Area)
+	assert.True(t, peak.Area.Contains(entities.Location{Latitude: peak.Centroid.Latitude, Longitude: peak.Centroid.Longitude}))
+
+	// Errores
+	for name, input := range map[string]usecases.HotspotInput{
+		"eps":       {EpsMeters: 5},
+		"min":       {MinPoints: 1},
+		"bandwidth": {Method: "kde", BandwidthMeters: 10000},
+		"threshold": {Method: "kde", Threshold: 2},
+	} {
+		_, err := hotspots.Execute(ctx, input)
+		assert.ErrorIs(t, err, usecases.ErrInvalidHotspotParameters, name)
+	}
+	_, err = hotspots.Execute(ctx, usecases.HotspotInput{Method: "kmeans"})
+	assert.ErrorIs(t, err, usecases.ErrInvalidHotspotMethod)
+	_, err = usecases.NewDetectHotspotsUseCase(repo, usecases.HotspotOptions{MaxCrimes: 5}).Execute(ctx, usecases.HotspotInput{
+		StatsCriteria: usecases.StatsCriteria{From: now.AddDate(0, 0, -7), To: now, Actor: citizen},
+	})
+	assert.ErrorIs(t, err, usecases.ErrTooManyHotspotCrimes)
+}
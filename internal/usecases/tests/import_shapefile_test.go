@@ -0,0 +1,231 @@
+package tests
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/binary"
+	"math"
+	"testing"
+	"time"
+
+	"go-crime_map_backend/internal/domain/entities"
+	memory "go-crime_map_backend/internal/infrastructure/repositories"
+	"go-crime_map_backend/internal/usecases"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	// webMercatorPRJ es el .prj que exporta ArcGIS para Web Mercator
+	webMercatorPRJ = `PROJCS["WGS_1984_Web_Mercator_Auxiliary_Sphere",GEOGCS["GCS_WGS_1984",DATUM["D_WGS_1984",` +
+		`SPHEROID["WGS_1984",6378137.0,298.257223563]],PRIMEM["Greenwich",0.0],UNIT["Degree",0.0174532925199433]],` +
+		`PROJECTION["Mercator_Auxiliary_Sphere"],PARAMETER["False_Easting",0.0],PARAMETER["False_Northing",0.0],` +
+		`PARAMETER["Central_Meridian",0.0],PARAMETER["Standard_Parallel_1",0.0],PARAMETER["Auxiliary_Sphere_Type",0.0],UNIT["Meter",1.0]]`
+
+	// wgs84PRJ es el .prj de coordenadas geográficas WGS84
+	wgs84PRJ = `GEOGCS["GCS_WGS_1984",DATUM["D_WGS_1984",SPHEROID["WGS_1984",6378137.0,298.257223563]],` +
+		`PRIMEM["Greenwich",0.0],UNIT["Degree",0.0174532925199433]]`
+)
+
+// xy es un vértice del shapefile de prueba
+type xy [2]float64
+
+// buildShp arma un .shp con un registro por geometría: un vértice para los puntos y una
+// lista de anillos para los polígonos
+func buildShp(shapeType int, shapes [][][]xy) []byte {
+	var records bytes.Buffer
+	for i, rings := range shapes {
+		var content bytes.Buffer
+		le := func(v any) { _ = binary.Write(&content, binary.LittleEndian, v) }
+		le(int32(shapeType))
+		if shapeType == 1 {
+			le(rings[0][0])
+		} else {
+			total := 0
+			for _, ring := range rings {
+				total += len(ring)
+			}
+			le([4]float64{}) // El rectángulo no se usa al leer
+			le(int32(len(rings)))
+			le(int32(total))
+			start := 0
+			for _, ring := range rings {
+				le(int32(start))
+				start += len(ring)
+			}
+			for _, ring := range rings {
+				le(ring)
+			}
+		}
+		_ = binary.Write(&records, binary.BigEndian, [2]int32{int32(i + 1), int32(content.Len() / 2)})
+		records.Write(content.Bytes())
+	}
+
+	header := make([]byte, 100)
+	binary.BigEndian.PutUint32(header[0:], 9994)
+	binary.BigEndian.PutUint32(header[24:], uint32((100+records.Len())/2))
+	binary.LittleEndian.PutUint32(header[28:], 1000)
+	binary.LittleEndian.PutUint32(header[32:], uint32(shapeType))
+	return append(header, records.Bytes()...)
+}
+
+// buildDbf arma un .dbf con columnas de texto codificado en Latin-1
+func buildDbf(fields []string, rows [][]string) []byte {
+	const width = 40
+	var buf bytes.Buffer
+	header := make([]byte, 32)
+	header[0] = 0x03
+	binary.LittleEndian.PutUint32(header[4:], uint32(len(rows)))
+	binary.LittleEndian.PutUint16(header[8:], uint16(32+32*len(fields)+1))
+	binary.LittleEndian.PutUint16(header[10:], uint16(1+width*len(fields)))
+	buf.Write(header)
+	for _, field := range fields {
+		descriptor := make([]byte, 32)
+		copy(descriptor, field)
+		descriptor[11] = 'C'
+		descriptor[16] = width
+		buf.Write(descriptor)
+	}
+	buf.WriteByte(0x0D)
+	for _, row := range rows {
+		buf.WriteByte(' ')
+		for _, value := range row {
+			cell := bytes.Repeat([]byte{' '}, width)
+			latin1 := make([]byte, 0, len(value))
+			for _, r := range value {
+				latin1 = append(latin1, byte(r))
+			}
+			copy(cell, latin1)
+			buf.Write(cell)
+		}
+	}
+	buf.WriteByte(0x1A)
+	return buf.Bytes()
+}
+
+// buildZip comprime los archivos del shapefile
+func buildZip(t *testing.T, files map[string][]byte) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	writer := zip.NewWriter(&buf)
+	for name, content := range files {
+		w, err := writer.Create(name)
+		require.NoError(t, err)
+		_, err = w.Write(content)
+		require.NoError(t, err)
+	}
+	require.NoError(t, writer.Close())
+	return buf.Bytes()
+}
+
+// mercator proyecta un punto a Web Mercator
+func mercator(lat, lon float64) xy {
+	const radius = 6378137.0
+	return xy{radius * lon * math.Pi / 180, radius * math.Log(math.Tan(math.Pi/4+lat*math.Pi/360))}
+}
+
+func TestImportCrimesShapefile(t *testing.T) {
+	ctx := context.Background()
+	repo := memory.NewMemoryCrimeRepository()
+	uc := usecases.NewImportCrimesUseCase(usecases.NewCreateCrimeUseCase(repo))
+
+	points := [][][]xy{
+		{{mercator(-34.6037, -58.3816)}},
+		{{mercator(-34.6100, -58.3900)}},
+		{{mercator(-34.6200, -58.4000)}},
+		{{mercator(-34.6300, -58.4100)}},
+		{{mercator(-34.6037, -58.3816)}},
+		{{mercator(-34.6400, -58.4200)}},
+	}
+	shp := buildShp(1, points)
+	dbf := buildDbf([]string{"TIPO_DELIT", "FECHA", "HORA", "VICTIMAS", "GRAVEDAD", "DIRECCION"}, [][]string{
+		{"Robo", "20260301", "14:30", "2", "HIGH", "Av. Corrientes 1234"},
+		{"Agresión", "20260302", "", "", "", "Av. Belgrano 1500"},
+		{"Secuestro", "20260303", "", "", "", "Av. Rivadavia 3000"},
+		{"ROBO", "marzo", "", "", "", "Av. La Plata 100"},
+		{"Robo", "20260301", "14:30", "2", "HIGH", "Av. Corrientes 1234"}, // Repite el primero
+		{"Hurto", "20260304", "", "", "", ""},                             // Sin nomenclador la dirección es requerida
+	})
+	input := usecases.ImportCrimesInput{
+		Data:     buildZip(t, map[string][]byte{"delitos/delitos.shp": shp, "delitos/delitos.dbf": dbf, "delitos/delitos.prj": []byte(webMercatorPRJ)}),
+		Fields:   usecases.CrimeFieldMapping{Type: "tipo_delit"},
+		Timezone: "America/Argentina/Buenos_Aires",
+	}
+
+	_, err := uc.Execute(ctx, input, moderator)
+	assert.ErrorIs(t, err, usecases.ErrForbidden)
+
+	result, err := uc.Execute(ctx, input, admin)
+	require.NoError(t, err)
+	assert.Equal(t, 2, result.Created)
+	assert.Equal(t, 4, result.Rejected)
+	assert.Equal(t, "WGS_1984_Web_Mercator_Auxiliary_Sphere", result.CRS)
+	codes := make(map[int]string, len(result.Errors))
+	for _, rejection := range result.Errors {
+		codes[rejection.Record] = rejection.Code
+	}
+	assert.Equal(t, map[int]string{3: "invalid_type", 4: "invalid_date", 5: "duplicate", 6: "missing_address"}, codes)
+
+	crimes, err := repo.GetAll(ctx)
+	require.NoError(t, err)
+	require.Len(t, crimes, 2)
+	byType := make(map[string]*entities.Crime)
+	for _, crime := range crimes {
+		byType[crime.Type] = crime
+	}
+	robo := byType["ROBO"]
+	require.NotNil(t, robo)
+	assert.InDelta(t, -34.6037, robo.Location.Latitude, 1e-9)
+	assert.InDelta(t, -58.3816, robo.Location.Longitude, 1e-9)
+	assert.True(t, robo.Date.Equal(time.Date(2026, 3, 1, 17, 30, 0, 0, time.UTC)), "14:30 en Buenos Aires")
+	assert.Equal(t, 2, robo.VictimCount)
+	assert.Equal(t, entities.SeverityHigh, robo.Severity)
+	assert.Equal(t, entities.CrimeStatusReported, robo.Status)
+	assert.Equal(t, "Importado de delitos.shp", robo.Description)
+	require.NotNil(t, byType["AGRESION"], "el tipo se lee en Latin-1 y sin tildes")
+
+	// Sin .prj hay que indicar el sistema de coordenadas
+	noPRJ := buildZip(t, map[string][]byte{"delitos.shp": shp, "delitos.dbf": dbf})
+	_, err = uc.Execute(ctx, usecases.ImportCrimesInput{Data: noPRJ}, admin)
+	assert.ErrorIs(t, err, usecases.ErrInvalidCrimeImport)
+	input.Data, input.SRID = noPRJ, 3857
+	result, err = uc.Execute(ctx, input, admin)
+	require.NoError(t, err)
+	assert.Zero(t, result.Created, "todos los delitos ya fueron importados")
+
+	_, err = uc.Execute(ctx, usecases.ImportCrimesInput{Data: []byte("no es un zip")}, admin)
+	assert.ErrorIs(t, err, usecases.ErrInvalidCrimeImport)
+}
+
+func TestImportZonesShapefile(t *testing.T) {
+	ctx := context.Background()
+	zoneRepo := memory.NewMemoryZoneRepository()
+	uc := usecases.NewImportZonesUseCase(zoneRepo, memory.NewMemoryCrimeRepository())
+
+	// Un barrio con un parque como hueco: el contorno en sentido horario y el hueco al revés
+	shp := buildShp(5, [][][]xy{{
+		{{-58.40, -34.62}, {-58.40, -34.58}, {-58.36, -34.58}, {-58.36, -34.62}, {-58.40, -34.62}},
+		{{-58.385, -34.605}, {-58.375, -34.605}, {-58.375, -34.595}, {-58.385, -34.595}, {-58.385, -34.605}},
+	}})
+	dbf := buildDbf([]string{"NOMBRE", "POBLACION"}, [][]string{{"Núñez", "51949.0"}})
+	data := buildZip(t, map[string][]byte{"barrios.shp": shp, "barrios.dbf": dbf, "barrios.prj": []byte(wgs84PRJ)})
+
+	result, err := uc.ExecuteShapefile(ctx, usecases.ImportZonesShapefileInput{Data: data, Kind: "barrio"}, admin)
+	require.NoError(t, err)
+	require.Equal(t, 1, result.Created)
+	zone := result.Zones[0]
+	assert.Equal(t, "Núñez", zone.Name)
+	assert.Equal(t, 51949, zone.Population)
+	require.Len(t, zone.Geometry, 1)
+	require.Len(t, zone.Geometry[0], 2, "el hueco queda dentro del polígono")
+	assert.Len(t, zone.Geometry[0][0], 4, "sin el vértice que cierra el anillo")
+	assert.True(t, zone.Contains(entities.Location{Latitude: -34.61, Longitude: -58.39}))
+	assert.False(t, zone.Contains(entities.Location{Latitude: -34.60, Longitude: -58.38}), "el parque no es parte del barrio")
+
+	// Los puntos no son zonas
+	points := buildZip(t, map[string][]byte{"barrios.shp": buildShp(1, [][][]xy{{{{-58.4, -34.6}}}}), "barrios.prj": []byte(wgs84PRJ)})
+	_, err = uc.ExecuteShapefile(ctx, usecases.ImportZonesShapefileInput{Data: points, Kind: "barrio"}, admin)
+	assert.ErrorIs(t, err, usecases.ErrInvalidZoneImport)
+}
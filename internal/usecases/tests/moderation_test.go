@@ -0,0 +1,203 @@
+package tests
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"go-crime_map_backend/internal/domain/entities"
+	"go-crime_map_backend/internal/domain/repositories"
+	memory "go-crime_map_backend/internal/infrastructure/repositories"
+	"go-crime_map_backend/internal/usecases"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestModerationQueue_ClaimLease(t *testing.T) {
+	ctx := context.Background()
+	repo := memory.NewMemoryCrimeRepository()
+	seedCrime(t, repo, "crime-1", entities.CrimeStatusReported)
+	otherModerator := entities.Actor{ID: "mod-2", Role: entities.RoleModerator}
+
+	now := time.Now()
+	clock := func() time.Time { return now }
+	claimUseCase := usecases.NewClaimCrimeUseCaseWithClock(repo, repo, 10*time.Minute, clock)
+
+	claim, err := claimUseCase.Execute(ctx, "crime-1", moderator)
+	require.NoError(t, err)
+	assert.Equal(t, moderator.ID, claim.ModeratorID)
+	assert.Equal(t, now.Add(10*time.Minute), claim.ExpiresAt)
+
+	// Otro moderador no puede tomar un delito con reserva vigente
+	_, err = claimUseCase.Execute(ctx, "crime-1", otherModerator)
+	assert.ErrorIs(t, err, repositories.ErrClaimConflict)
+
+	// El mismo moderador renueva su reserva
+	now = now.Add(5 * time.Minute)
+	claim, err = claimUseCase.Execute(ctx, "crime-1", moderator)
+	require.NoError(t, err)
+	assert.Equal(t, now.Add(10*time.Minute), claim.ExpiresAt)
+
+	// Vencida la reserva, el delito vuelve a estar disponible
+	now = now.Add(10 * time.Minute)
+	claim, err = claimUseCase.Execute(ctx, "crime-1", otherModerator)
+	require.NoError(t, err)
+	assert.Equal(t, otherModerator.ID, claim.ModeratorID)
+
+	_, err = claimUseCase.Execute(ctx, "crime-1", citizen)
+	assert.ErrorIs(t, err, usecases.ErrForbidden)
+}
+
+func TestModerationQueue_Decisions(t *testing.T) {
+	ctx := context.Background()
+	repo := memory.NewMemoryCrimeRepository()
+	seedCrime(t, repo, "crime-1", entities.CrimeStatusReported)
+	seedCrime(t, repo, "crime-2", entities.CrimeStatusReported)
+
+	claimUseCase := usecases.NewClaimCrimeUseCase(repo, repo, time.Minute)
+	moderateUseCase := usecases.NewModerateCrimeUseCase(repo, repo)
+
+	// Sin reserva no se puede moderar
+	_, err := moderateUseCase.Execute(ctx, usecases.ModerateCrimeInput{CrimeID: "crime-1", Decision: usecases.ModerationApprove, Actor: moderator})
+	assert.ErrorIs(t, err, usecases.ErrClaimNotHeld)
+
+	_, err = claimUseCase.Execute(ctx, "crime-1", moderator)
+	require.NoError(t, err)
+	crime, err := moderateUseCase.Execute(ctx, usecases.ModerateCrimeInput{CrimeID: "crime-1", Decision: usecases.ModerationApprove, Note: "Confirmado por testigos", Actor: moderator})
+	require.NoError(t, err)
+	assert.Equal(t, entities.CrimeStatusVerified, crime.Status)
+
+	// La decisión libera la reserva y el delito sale de la cola
+	claim, err := repo.GetClaim(ctx, "crime-1", time.Now())
+	require.NoError(t, err)
+	assert.Nil(t, claim)
+	_, err = claimUseCase.Execute(ctx, "crime-1", moderator)
+	assert.ErrorIs(t, err, usecases.ErrNotPending)
+
+	history, err := repo.GetStatusHistory(ctx, "crime-1")
+	require.NoError(t, err)
+	require.Len(t, history, 1)
+	assert.Equal(t, "Confirmado por testigos", history[0].Reason)
+
+	// Rechazar exige una nota
+	_, err = claimUseCase.Execute(ctx, "crime-2", moderator)
+	require.NoError(t, err)
+	_, err = moderateUseCase.Execute(ctx, usecases.ModerateCrimeInput{CrimeID: "crime-2", Decision: usecases.ModerationReject, Actor: moderator})
+	assert.ErrorIs(t, err, usecases.ErrReasonRequired)
+	crime, err = moderateUseCase.Execute(ctx, usecases.ModerateCrimeInput{CrimeID: "crime-2", Decision: usecases.ModerationReject, Note: "Reporte duplicado", Actor: moderator})
+	require.NoError(t, err)
+	assert.Equal(t, entities.CrimeStatusRejected, crime.Status)
+}
+
+func TestModerationQueue_ListAndReleaseExpired(t *testing.T) {
+	ctx := context.Background()
+	repo := memory.NewMemoryCrimeRepository()
+	now := time.Now()
+	for i, crimeType := range []string{"HURTO", "VIOLENCIA", "ROBO"} {
+		require.NoError(t, repo.Create(ctx, &entities.Crime{
+			ID:        crimeType,
+			Type:      crimeType,
+			Status:    entities.CrimeStatusReported,
+			CreatedAt: now.Add(time.Duration(i) * time.Minute),
+		}))
+	}
+	seedCrime(t, repo, "verified", entities.CrimeStatusVerified)
+
+	otherModerator := entities.Actor{ID: "mod-2", Role: entities.RoleModerator}
+	_, err := usecases.NewClaimCrimeUseCase(repo, repo, time.Minute).Execute(ctx, "ROBO", otherModerator)
+	require.NoError(t, err)
+
+	listUseCase := usecases.NewListModerationQueueUseCase(repo)
+	ids := func(items []*entities.ModerationItem) []string {
+		var result []string
+		for _, item := range items {
+			result = append(result, item.Crime.ID)
+		}
+		return result
+	}
+
+	items, err := listUseCase.Execute(ctx, usecases.ListModerationQueueInput{Actor: moderator})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"HURTO", "VIOLENCIA", "ROBO"}, ids(items))
+	assert.Equal(t, otherModerator.ID, items[2].Claim.ModeratorID)
+
+	items, err = listUseCase.Execute(ctx, usecases.ListModerationQueueInput{Sort: usecases.ModerationSortPriority, Actor: moderator})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"VIOLENCIA", "ROBO", "HURTO"}, ids(items))
+
+	items, err = listUseCase.Execute(ctx, usecases.ListModerationQueueInput{OnlyAvailable: true, Limit: 1, Actor: moderator})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"HURTO"}, ids(items))
+
+	_, err = listUseCase.Execute(ctx, usecases.ListModerationQueueInput{Sort: "random", Actor: moderator})
+	assert.ErrorIs(t, err, usecases.ErrInvalidQueueSort)
+
+	// Las reservas vencidas se liberan
+	reaper := usecases.NewReleaseExpiredClaimsUseCaseWithClock(repo, func() time.Time { return time.Now().Add(2 * time.Minute) })
+	released, err := reaper.Execute(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), released)
+	assert.ErrorIs(t, usecases.NewReleaseCrimeClaimUseCase(repo).Execute(ctx, "ROBO", otherModerator), usecases.ErrClaimNotHeld)
+}
+
+func TestModerationQueue_ListHidesExpiredClaims(t *testing.T) {
+	ctx := context.Background()
+	repo := memory.NewMemoryCrimeRepository()
+	seedCrime(t, repo, "crime-1", entities.CrimeStatusReported)
+	otherModerator := entities.Actor{ID: "mod-2", Role: entities.RoleModerator}
+
+	now := time.Now()
+	clock := func() time.Time { return now }
+	_, err := usecases.NewClaimCrimeUseCaseWithClock(repo, repo, 10*time.Minute, clock).Execute(ctx, "crime-1", otherModerator)
+	require.NoError(t, err)
+	listUseCase := usecases.NewListModerationQueueUseCaseWithClock(repo, clock)
+
+	// Con la reserva vigente el delito no está disponible para otros moderadores
+	now = now.Add(9 * time.Minute)
+	items, err := listUseCase.Execute(ctx, usecases.ListModerationQueueInput{Actor: moderator})
+	require.NoError(t, err)
+	require.Len(t, items, 1)
+	require.NotNil(t, items[0].Claim)
+	assert.Equal(t, otherModerator.ID, items[0].Claim.ModeratorID)
+	items, err = listUseCase.Execute(ctx, usecases.ListModerationQueueInput{OnlyAvailable: true, Actor: moderator})
+	require.NoError(t, err)
+	assert.Empty(t, items)
+
+	// Vencida la reserva, el listado la ignora aunque todavía no se haya liberado
+	now = now.Add(2 * time.Minute)
+	items, err = listUseCase.Execute(ctx, usecases.ListModerationQueueInput{OnlyAvailable: true, Actor: moderator})
+	require.NoError(t, err)
+	require.Len(t, items, 1)
+	assert.Nil(t, items[0].Claim)
+}
+
+// moderationFilterRecorder guarda el filtro con que se consultó la cola
+type moderationFilterRecorder struct {
+	repositories.ModerationRepository
+	filter repositories.ModerationQueueFilter
+}
+
+func (r *moderationFilterRecorder) ListPending(ctx context.Context, filter repositories.ModerationQueueFilter) ([]*entities.ModerationItem, error) {
+	r.filter = filter
+	return []*entities.ModerationItem{}, nil
+}
+
+func TestModerationQueue_ListDelegatesOrderAndLimit(t *testing.T) {
+	ctx := context.Background()
+	repo := &moderationFilterRecorder{}
+	listUseCase := usecases.NewListModerationQueueUseCase(repo)
+
+	// El orden por antigüedad no envía prioridades y el límite se acota al máximo
+	_, err := listUseCase.Execute(ctx, usecases.ListModerationQueueInput{Limit: 1000, Actor: moderator})
+	require.NoError(t, err)
+	assert.Nil(t, repo.filter.Priorities)
+	assert.Empty(t, repo.filter.AvailableTo)
+	assert.Equal(t, 200, repo.filter.Limit)
+
+	_, err = listUseCase.Execute(ctx, usecases.ListModerationQueueInput{Sort: usecases.ModerationSortPriority, OnlyAvailable: true, Actor: moderator})
+	require.NoError(t, err)
+	assert.Equal(t, usecases.ModerationPriority("VIOLENCIA"), repo.filter.Priorities["VIOLENCIA"])
+	assert.Equal(t, moderator.ID, repo.filter.AvailableTo)
+	assert.Equal(t, 50, repo.filter.Limit)
+}
@@ -0,0 +1,261 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"go-crime_map_backend/internal/domain/entities"
+	"go-crime_map_backend/internal/domain/events"
+	memory "go-crime_map_backend/internal/infrastructure/repositories"
+	"go-crime_map_backend/internal/usecases"
+	"go-crime_map_backend/pkg/requestid"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recorder es un handler que guarda los eventos recibidos y falla las primeras failures veces
+type recorder struct {
+	received []events.Event
+	failures int
+}
+
+func (r *recorder) Handle(ctx context.Context, event events.Event) error {
+	r.received = append(r.received, event)
+	if r.failures > 0 {
+		r.failures--
+		return errors.New("handler no disponible")
+	}
+	return nil
+}
+
+func (r *recorder) types() []events.Type {
+	types := make([]events.Type, len(r.received))
+	for i, event := range r.received {
+		types[i] = event.Type
+	}
+	return types
+}
+
+func testDispatcherOptions() usecases.OutboxDispatcherOptions {
+	return usecases.OutboxDispatcherOptions{
+		BatchSize:      10,
+		Lease:          time.Minute,
+		MaxAttempts:    3,
+		RetryBaseDelay: time.Second,
+		RetryMaxDelay:  time.Minute,
+	}
+}
+
+func TestDomainEventsAreRaisedByUseCases(t *testing.T) {
+	repo := memory.NewMemoryCrimeRepository()
+	citizenCtx := requestid.WithRequestID(entities.ContextWithActor(context.Background(), citizen), "req-create")
+	moderatorCtx := entities.ContextWithActor(context.Background(), moderator)
+	adminCtx := entities.ContextWithActor(context.Background(), admin)
+
+	input := usecases.CreateCrimeInput{
+		Type:        "ROBO",
+		Description: "Robo a mano armada",
+		Location:    usecases.Location{Latitude: -34.603722, Longitude: -58.381592, Address: "Av. Corrientes 1234, CABA"},
+		Date:        time.Now().Add(-time.Hour),
+	}
+	created, err := usecases.NewCreateCrimeUseCase(repo).Execute(citizenCtx, input)
+	require.NoError(t, err)
+
+	input.Description = "Robo de celular"
+	_, err = usecases.NewUpdateCrimeUseCase(repo).Execute(moderatorCtx, usecases.UpdateCrimeInput{CrimeID: created.ID, Data: input, Actor: moderator})
+	require.NoError(t, err)
+
+	// Una corrección sin cambios no genera eventos
+	_, err = usecases.NewUpdateCrimeUseCase(repo).Execute(moderatorCtx, usecases.UpdateCrimeInput{CrimeID: created.ID, Data: input, Actor: moderator})
+	require.NoError(t, err)
+
+	_, err = usecases.NewTransitionCrimeStatusUseCase(repo).Execute(moderatorCtx, usecases.TransitionCrimeStatusInput{
+		CrimeID: created.ID,
+		Status:  entities.CrimeStatusVerified,
+		Actor:   moderator,
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, usecases.NewDeleteCrimeUseCase(repo).Execute(adminCtx, created.ID, admin))
+
+	handler := &recorder{}
+	dispatcher := usecases.NewOutboxDispatcher(repo, testDispatcherOptions())
+	dispatcher.SubscribeAll(handler)
+
+	dispatched, err := dispatcher.DispatchPending(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 4, dispatched)
+	assert.Equal(t, []events.Type{events.CrimeReported, events.CrimeUpdated, events.CrimeStatusChanged, events.CrimeDeleted}, handler.types())
+
+	reported := handler.received[0]
+	assert.Equal(t, created.ID, reported.AggregateID)
+	assert.Equal(t, citizen.ID, reported.ActorID)
+	assert.Equal(t, "req-create", reported.RequestID)
+
+	var updated events.CrimeUpdatedPayload
+	require.NoError(t, handler.received[1].Decode(&updated))
+	assert.Equal(t, "Robo de celular", updated.Crime.Description)
+	assert.Equal(t, []entities.FieldChange{{Field: "description", Before: "Robo a mano armada", After: "Robo de celular"}}, updated.Changes)
+
+	var statusChanged events.CrimeStatusChangedPayload
+	require.NoError(t, handler.received[2].Decode(&statusChanged))
+	assert.Equal(t, entities.CrimeStatusVerified, statusChanged.Crime.Status)
+	assert.Equal(t, entities.CrimeStatusReported, statusChanged.Change.From)
+
+	// Los eventos entregados no se vuelven a entregar
+	dispatched, err = dispatcher.DispatchPending(context.Background())
+	require.NoError(t, err)
+	assert.Zero(t, dispatched)
+	assert.Len(t, handler.received, 4)
+}
+
+func TestEditCrimePermissions(t *testing.T) {
+	repo := memory.NewMemoryCrimeRepository()
+	seedCrime(t, repo, "crime-1", entities.CrimeStatusReported)
+	input := usecases.CreateCrimeInput{
+		Type:        "HURTO",
+		Description: "Hurto de bicicleta",
+		Location:    usecases.Location{Latitude: -34.603722, Longitude: -58.381592, Address: "Av. Corrientes 1234, CABA"},
+		Date:        time.Now().Add(-time.Hour),
+	}
+
+	_, err := usecases.NewUpdateCrimeUseCase(repo).Execute(context.Background(), usecases.UpdateCrimeInput{CrimeID: "crime-1", Data: input, Actor: citizen})
+	assert.ErrorIs(t, err, usecases.ErrForbidden)
+	_, err = usecases.NewUpdateCrimeUseCase(repo).Execute(context.Background(), usecases.UpdateCrimeInput{CrimeID: "missing", Data: input, Actor: moderator})
+	assert.ErrorIs(t, err, usecases.ErrCrimeNotFound)
+	input.Type = "INVALIDO"
+	_, err = usecases.NewUpdateCrimeUseCase(repo).Execute(context.Background(), usecases.UpdateCrimeInput{CrimeID: "crime-1", Data: input, Actor: moderator})
+	assert.ErrorIs(t, err, usecases.ErrInvalidType)
+
+	assert.ErrorIs(t, usecases.NewDeleteCrimeUseCase(repo).Execute(context.Background(), "crime-1", moderator), usecases.ErrForbidden)
+	assert.ErrorIs(t, usecases.NewDeleteCrimeUseCase(repo).Execute(context.Background(), "missing", admin), usecases.ErrCrimeNotFound)
+}
+
+func TestOutboxDispatcherRetriesWithBackoff(t *testing.T) {
+	repo := memory.NewMemoryCrimeRepository()
+	seedCrimeWithEvent(t, repo)
+
+	now := time.Now().Add(time.Second)
+	handler := &recorder{failures: 2}
+	dispatcher := usecases.NewOutboxDispatcherWithClock(repo, testDispatcherOptions(), func() time.Time { return now })
+	dispatcher.Subscribe(events.CrimeReported, handler)
+
+	dispatched, err := dispatcher.DispatchPending(context.Background())
+	require.NoError(t, err)
+	assert.Zero(t, dispatched)
+
+	// El primer reintento espera RetryBaseDelay
+	now = now.Add(500 * time.Millisecond)
+	dispatched, err = dispatcher.DispatchPending(context.Background())
+	require.NoError(t, err)
+	assert.Zero(t, dispatched)
+	assert.Len(t, handler.received, 1)
+
+	now = now.Add(500 * time.Millisecond)
+	dispatched, err = dispatcher.DispatchPending(context.Background())
+	require.NoError(t, err)
+	assert.Zero(t, dispatched)
+	assert.Len(t, handler.received, 2)
+
+	// El segundo reintento espera el doble
+	now = now.Add(2 * time.Second)
+	dispatched, err = dispatcher.DispatchPending(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 1, dispatched)
+	assert.Len(t, handler.received, 3)
+
+	// Todas las entregas corresponden al mismo evento
+	assert.Equal(t, handler.received[0].ID, handler.received[2].ID)
+}
+
+func TestOutboxDispatcherAbandonsAfterMaxAttempts(t *testing.T) {
+	repo := memory.NewMemoryCrimeRepository()
+	seedCrimeWithEvent(t, repo)
+
+	now := time.Now().Add(time.Second)
+	panics := 0
+	dispatcher := usecases.NewOutboxDispatcherWithClock(repo, testDispatcherOptions(), func() time.Time { return now })
+	dispatcher.SubscribeAll(events.HandlerFunc(func(ctx context.Context, event events.Event) error {
+		panics++
+		panic("handler roto")
+	}))
+
+	for i := 0; i < 5; i++ {
+		_, err := dispatcher.DispatchPending(context.Background())
+		require.NoError(t, err)
+		now = now.Add(time.Hour)
+	}
+
+	// El panic se trata como un fallo y el evento se abandona tras MaxAttempts
+	assert.Equal(t, 3, panics)
+}
+
+// seedCrimeWithEvent crea un delito dejando el evento CrimeReported en el outbox
+func seedCrimeWithEvent(t *testing.T, repo *memory.MemoryCrimeRepository) {
+	t.Helper()
+	_, err := usecases.NewCreateCrimeUseCase(repo).Execute(context.Background(), usecases.CreateCrimeInput{
+		Type:        "ROBO",
+		Description: "Robo a mano armada",
+		Location:    usecases.Location{Latitude: -34.603722, Longitude: -58.381592, Address: "Av. Corrientes 1234, CABA"},
+		Date:        time.Now().Add(-time.Hour),
+	})
+	require.NoError(t, err)
+}
+
+func TestOutboxBroadcasterReachesEveryInstance(t *testing.T) {
+	ctx := context.Background()
+	repo := memory.NewMemoryCrimeRepository()
+	start := time.Now().Add(-time.Hour)
+	record := func(id string, occurredAt time.Time) {
+		crime := &entities.Crime{ID: id, Type: "ROBO", Status: entities.CrimeStatusReported}
+		event, err := events.New("event-"+id, events.CrimeReported, id, events.CrimeReportedPayload{Crime: crime}, occurredAt)
+		require.NoError(t, err)
+		require.NoError(t, repo.Create(events.WithEvents(ctx, event), crime))
+	}
+
+	// Cada instancia tiene su propio difusor; el despachador reparte los eventos entre ellas
+	opts := usecases.OutboxBroadcasterOptions{BatchSize: 1, Overlap: time.Minute}
+	received := make([][]string, 2)
+	var broadcasters []*usecases.OutboxBroadcaster
+	for i := range received {
+		i := i
+		broadcaster := usecases.NewOutboxBroadcasterSince(repo, opts, start)
+		broadcaster.SubscribeAll(events.HandlerFunc(func(_ context.Context, event events.Event) error {
+			received[i] = append(received[i], event.ID)
+			return nil
+		}))
+		broadcasters = append(broadcasters, broadcaster)
+	}
+
+	record("crime-0", start.Add(-2*time.Minute)) // Anterior al inicio y al margen
+	record("crime-1", start.Add(time.Second))
+	record("crime-2", start.Add(2*time.Second))
+	dispatched, err := usecases.NewOutboxDispatcher(repo, testDispatcherOptions()).DispatchPending(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 3, dispatched)
+
+	for i, broadcaster := range broadcasters {
+		delivered, err := broadcaster.Poll(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 2, delivered)
+		assert.Equal(t, []string{"event-crime-1", "event-crime-2"}, received[i])
+	}
+
+	// Un evento que confirma tarde con una fecha anterior al cursor llega si está dentro del
+	// margen y se pierde si no; los ya entregados no se repiten
+	record("crime-3", start.Add(1500*time.Millisecond))
+	record("crime-4", start.Add(-time.Minute))
+	for i, broadcaster := range broadcasters {
+		delivered, err := broadcaster.Poll(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 1, delivered)
+		assert.Equal(t, []string{"event-crime-1", "event-crime-2", "event-crime-3"}, received[i])
+	}
+
+	delivered, err := broadcasters[0].Poll(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, delivered)
+}
@@ -0,0 +1,80 @@
+package tests
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"testing"
+	"time"
+
+	"go-crime_map_backend/internal/domain/entities"
+	memory "go-crime_map_backend/internal/infrastructure/repositories"
+	"go-crime_map_backend/internal/usecases"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSafetyScore(t *testing.T) {
+	ctx := context.Background()
+	repo := memory.NewMemoryCrimeRepository()
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	n := 0
+	add := func(crimeType string, status entities.CrimeStatus, lat, lon float64, age time.Duration) {
+		n++
+		require.NoError(t, repo.Create(ctx, &entities.Crime{
+			ID:          fmt.Sprintf("crime-%d", n),
+			Type:        crimeType,
+			Description: "delito de prueba",
+			Location:    entities.Location{Latitude: lat, Longitude: lon},
+			Date:        now.Add(-age),
+			Status:      status,
+			Severity:    usecases.DefaultSeverity(crimeType, entities.WeaponNone, false),
+		}))
+	}
+	day := 24 * time.Hour
+	// Alrededor del Obelisco: una agresión reciente, un hurto de hace 90 días y delitos excluidos
+	add("AGRESION", entities.CrimeStatusVerified, -34.6037, -58.3816, time.Hour)
+	add("HURTO", entities.CrimeStatusVerified, -34.6040, -58.3820, 90*day)
+	add("ROBO", entities.CrimeStatusReported, -34.6037, -58.3816, 0)
+	add("ROBO", entities.CrimeStatusVerified, -34.6037, -58.3816, 400*day)
+	// A unos 1,1 km al este, sobre la Avenida de Mayo
+	add("ROBO", entities.CrimeStatusVerified, -34.6090, -58.3705, day)
+
+	safety := usecases.NewGetSafetyScoreUseCaseWithClock(repo, usecases.SafetyOptions{}, func() time.Time { return now })
+
+	result, err := safety.Execute(ctx, usecases.SafetyInput{Point: &entities.Coordinate{Latitude: -34.6037, Longitude: -58.3816}})
+	require.NoError(t, err)
+	assert.Equal(t, 2, result.CrimeCount, "sin reportes sin verificar ni delitos fuera del período o del radio")
+	weighted := 4*math.Pow(0.5, 1.0/(90*24)) + 1*0.5
+	assert.InDelta(t, weighted, result.WeightedCrimes, 1e-9)
+	area := math.Pi * 0.5 * 0.5
+	assert.InDelta(t, area, result.AreaSqKm, 1e-9)
+	assert.InDelta(t, weighted/area, result.Density, 1e-9)
+	assert.InDelta(t, 100*(1-math.Exp(-weighted/area/50)), result.Score, 1e-9)
+	assert.Equal(t, entities.SafetyLevelLow, result.Level)
+	require.Len(t, result.Breakdown, 2)
+	assert.Equal(t, "AGRESION", result.Breakdown[0].Type)
+	assert.InDelta(t, 1-0.5/weighted, result.Breakdown[0].Share, 1e-9)
+	assert.Equal(t, 1, result.Breakdown[1].Count)
+	assert.Equal(t, entities.SeverityLow.Weight(), result.Breakdown[1].Severity)
+
+	// La ruta del Obelisco a Plaza de Mayo incluye el robo sobre la avenida
+	route, err := safety.Execute(ctx, usecases.SafetyInput{
+		Route:        []entities.Coordinate{{Latitude: -34.6037, Longitude: -58.3816}, {Latitude: -34.6083, Longitude: -58.3712}},
+		RadiusMeters: 200,
+		HalfLifeDays: 30,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 3, route.CrimeCount)
+	assert.InDelta(t, 1070, route.LengthMeters, 30)
+	assert.Equal(t, "ROBO", route.Breakdown[1].Type)
+	assert.Greater(t, route.Score, result.Score, "la franja es más angosta y suma el robo")
+
+	_, err = safety.Execute(ctx, usecases.SafetyInput{})
+	assert.ErrorIs(t, err, usecases.ErrInvalidSafetyLocation)
+	_, err = safety.Execute(ctx, usecases.SafetyInput{Route: []entities.Coordinate{{Latitude: -34.6, Longitude: -58.4}}})
+	assert.ErrorIs(t, err, usecases.ErrInvalidSafetyRoute)
+	_, err = safety.Execute(ctx, usecases.SafetyInput{Point: &entities.Coordinate{Latitude: -34.6, Longitude: -58.4}, RadiusMeters: 10})
+	assert.ErrorIs(t, err, usecases.ErrInvalidSafetyParameters)
+}
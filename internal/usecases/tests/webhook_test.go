@@ -0,0 +1,235 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"go-crime_map_backend/internal/domain/entities"
+	"go-crime_map_backend/internal/domain/events"
+	"go-crime_map_backend/internal/domain/repositories"
+	memory "go-crime_map_backend/internal/infrastructure/repositories"
+	"go-crime_map_backend/internal/infrastructure/webhooks"
+	"go-crime_map_backend/internal/usecases"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// receiver es un servidor de prueba que verifica la firma de las entregas y responde con status
+type receiver struct {
+	mu       sync.Mutex
+	secret   string
+	status   int
+	received []*http.Request
+	bodies   [][]byte
+	valid    []bool
+}
+
+func (r *receiver) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	body, _ := io.ReadAll(req.Body)
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.received = append(r.received, req)
+	r.bodies = append(r.bodies, body)
+	r.valid = append(r.valid, webhooks.Verify(r.secret, req.Header.Get(webhooks.HeaderTimestamp), body, req.Header.Get(webhooks.HeaderSignature)))
+	w.WriteHeader(r.status)
+}
+
+func testDeliverOptions() usecases.DeliverWebhooksOptions {
+	return usecases.DeliverWebhooksOptions{
+		BatchSize:      10,
+		Lease:          time.Minute,
+		MaxAttempts:    3,
+		RetryBaseDelay: time.Second,
+		RetryMaxDelay:  time.Minute,
+	}
+}
+
+func TestCreateWebhookSubscriptionValidation(t *testing.T) {
+	useCase := usecases.NewCreateWebhookSubscriptionUseCase(memory.NewMemoryWebhookRepository())
+	valid := usecases.CreateWebhookSubscriptionInput{
+		URL:        "https://partner.example.com/hooks",
+		EventTypes: []string{string(events.CrimeReported)},
+		Actor:      admin,
+	}
+
+	tests := []struct {
+		name          string
+		modify        func(input *usecases.CreateWebhookSubscriptionInput)
+		expectedError error
+	}{
+		{name: "Solo administradores", modify: func(input *usecases.CreateWebhookSubscriptionInput) { input.Actor = moderator }, expectedError: usecases.ErrForbidden},
+		{name: "URL sin esquema HTTP", modify: func(input *usecases.CreateWebhookSubscriptionInput) { input.URL = "ftp://partner.example.com" }, expectedError: usecases.ErrInvalidWebhookURL},
+		{name: "URL relativa", modify: func(input *usecases.CreateWebhookSubscriptionInput) { input.URL = "/hooks" }, expectedError: usecases.ErrInvalidWebhookURL},
+		{name: "Evento desconocido", modify: func(input *usecases.CreateWebhookSubscriptionInput) { input.EventTypes = []string{"crime.archived"} }, expectedError: usecases.ErrInvalidEventType},
+		{name: "Zona invertida", modify: func(input *usecases.CreateWebhookSubscriptionInput) {
+			input.BoundingBox = &entities.BoundingBox{MinLatitude: -34, MinLongitude: -58, MaxLatitude: -35, MaxLongitude: -57}
+		}, expectedError: usecases.ErrInvalidBoundingBox},
+		{name: "Tipo de delito inválido", modify: func(input *usecases.CreateWebhookSubscriptionInput) { input.CrimeTypes = []string{"INVALIDO"} }, expectedError: usecases.ErrInvalidType},
+		{name: "Secreto corto", modify: func(input *usecases.CreateWebhookSubscriptionInput) { input.Secret = "corto" }, expectedError: usecases.ErrWebhookSecretTooShort},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			input := valid
+			tt.modify(&input)
+			_, err := useCase.Execute(context.Background(), input)
+			assert.ErrorIs(t, err, tt.expectedError)
+		})
+	}
+
+	subscription, err := useCase.Execute(context.Background(), valid)
+	require.NoError(t, err)
+	assert.Len(t, subscription.Secret, 64, "se genera un secreto aleatorio si no se indica")
+}
+
+func TestWebhookDeliveries(t *testing.T) {
+	crimeRepo := memory.NewMemoryCrimeRepository()
+	webhookRepo := memory.NewMemoryWebhookRepository()
+	const secret = "secreto-compartido-de-prueba"
+
+	partner := &receiver{secret: secret, status: http.StatusOK}
+	server := httptest.NewServer(partner)
+	defer server.Close()
+
+	create := usecases.NewCreateWebhookSubscriptionUseCase(webhookRepo)
+	subscription, err := create.Execute(context.Background(), usecases.CreateWebhookSubscriptionInput{
+		URL:         server.URL,
+		EventTypes:  []string{string(events.CrimeReported)},
+		BoundingBox: &entities.BoundingBox{MinLatitude: -35, MinLongitude: -59, MaxLatitude: -34, MaxLongitude: -58},
+		Secret:      secret,
+		Actor:       admin,
+	})
+	require.NoError(t, err)
+
+	report := func(latitude, longitude float64) {
+		_, err := usecases.NewCreateCrimeUseCase(crimeRepo).Execute(context.Background(), usecases.CreateCrimeInput{
+			Type:        "ROBO",
+			Description: "Robo a mano armada",
+			Location:    usecases.Location{Latitude: latitude, Longitude: longitude, Address: "Av. Corrientes 1234, CABA"},
+			Date:        time.Now().Add(-time.Hour),
+		})
+		require.NoError(t, err)
+	}
+	report(-34.603722, -58.381592) // Dentro de la zona
+	report(-31.420083, -64.188776) // Fuera de la zona
+
+	dispatcher := usecases.NewOutboxDispatcher(crimeRepo, testDispatcherOptions())
+	dispatcher.SubscribeAll(usecases.NewWebhookFanout(webhookRepo))
+	_, err = dispatcher.DispatchPending(context.Background())
+	require.NoError(t, err)
+
+	now := time.Now().Add(time.Second)
+	deliver := usecases.NewDeliverWebhooksUseCaseWithClock(webhookRepo, webhooks.NewHTTPSender(time.Second), testDeliverOptions(), func() time.Time { return now })
+	delivered, err := deliver.Execute(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 1, delivered)
+
+	require.Len(t, partner.received, 1)
+	assert.True(t, partner.valid[0], "la firma debe verificarse con el secreto de la suscripción")
+	assert.Equal(t, string(events.CrimeReported), partner.received[0].Header.Get(webhooks.HeaderEvent))
+	assert.False(t, webhooks.Verify("otro-secreto-de-prueba", partner.received[0].Header.Get(webhooks.HeaderTimestamp), partner.bodies[0], partner.received[0].Header.Get(webhooks.HeaderSignature)))
+
+	deliveries, err := usecases.NewListWebhookDeliveriesUseCase(webhookRepo).Execute(context.Background(), usecases.ListWebhookDeliveriesInput{
+		SubscriptionID: subscription.ID,
+		Actor:          admin,
+	})
+	require.NoError(t, err)
+	require.Len(t, deliveries, 1)
+	assert.Equal(t, entities.WebhookDeliverySucceeded, deliveries[0].Status)
+	assert.Equal(t, http.StatusOK, deliveries[0].LastStatusCode)
+
+	// Reprocesar los eventos no duplica las entregas
+	var event events.Event
+	require.NoError(t, json.Unmarshal(deliveries[0].Payload, &event))
+	require.NoError(t, usecases.NewWebhookFanout(webhookRepo).Handle(context.Background(), event))
+	deliveries, err = webhookRepo.ListDeliveries(context.Background(), subscription.ID, repositories.WebhookDeliveryFilter{})
+	require.NoError(t, err)
+	assert.Len(t, deliveries, 1)
+}
+
+func TestWebhookRetriesDeadLetterAndReplay(t *testing.T) {
+	crimeRepo := memory.NewMemoryCrimeRepository()
+	webhookRepo := memory.NewMemoryWebhookRepository()
+
+	partner := &receiver{secret: "secreto-compartido-de-prueba", status: http.StatusServiceUnavailable}
+	server := httptest.NewServer(partner)
+	defer server.Close()
+
+	subscription, err := usecases.NewCreateWebhookSubscriptionUseCase(webhookRepo).Execute(context.Background(), usecases.CreateWebhookSubscriptionInput{
+		URL:        server.URL,
+		EventTypes: []string{string(events.CrimeReported)},
+		Secret:     partner.secret,
+		Actor:      admin,
+	})
+	require.NoError(t, err)
+
+	seedCrimeWithEvent(t, crimeRepo)
+	dispatcher := usecases.NewOutboxDispatcher(crimeRepo, testDispatcherOptions())
+	dispatcher.SubscribeAll(usecases.NewWebhookFanout(webhookRepo))
+	_, err = dispatcher.DispatchPending(context.Background())
+	require.NoError(t, err)
+
+	now := time.Now().Add(time.Second)
+	clock := func() time.Time { return now }
+	deliver := usecases.NewDeliverWebhooksUseCaseWithClock(webhookRepo, webhooks.NewHTTPSender(time.Second), testDeliverOptions(), clock)
+
+	// Primer intento fallido; el reintento espera RetryBaseDelay
+	_, err = deliver.Execute(context.Background())
+	require.NoError(t, err)
+	_, err = deliver.Execute(context.Background())
+	require.NoError(t, err)
+	assert.Len(t, partner.received, 1)
+
+	// Segundo y tercer intento, tras lo cual la entrega pasa a dead
+	now = now.Add(time.Second)
+	_, err = deliver.Execute(context.Background())
+	require.NoError(t, err)
+	now = now.Add(2 * time.Second)
+	_, err = deliver.Execute(context.Background())
+	require.NoError(t, err)
+	now = now.Add(time.Hour)
+	_, err = deliver.Execute(context.Background())
+	require.NoError(t, err)
+	assert.Len(t, partner.received, 3)
+
+	listDeliveries := usecases.NewListWebhookDeliveriesUseCase(webhookRepo)
+	dead, err := listDeliveries.Execute(context.Background(), usecases.ListWebhookDeliveriesInput{
+		SubscriptionID: subscription.ID,
+		Status:         entities.WebhookDeliveryDead,
+		Actor:          admin,
+	})
+	require.NoError(t, err)
+	require.Len(t, dead, 1)
+	assert.Equal(t, 3, dead[0].Attempts)
+	assert.Equal(t, http.StatusServiceUnavailable, dead[0].LastStatusCode)
+	assert.NotEmpty(t, dead[0].LastError)
+
+	// El reenvío manual reinicia los reintentos y la entrega se completa
+	partner.status = http.StatusNoContent
+	replay := usecases.NewReplayWebhookDeliveryUseCaseWithClock(webhookRepo, clock)
+	_, err = replay.Execute(context.Background(), subscription.ID, dead[0].ID, moderator)
+	assert.ErrorIs(t, err, usecases.ErrForbidden)
+	_, err = replay.Execute(context.Background(), subscription.ID, "missing", admin)
+	assert.ErrorIs(t, err, usecases.ErrWebhookDeliveryNotFound)
+	replayed, err := replay.Execute(context.Background(), subscription.ID, dead[0].ID, admin)
+	require.NoError(t, err)
+	assert.Equal(t, entities.WebhookDeliveryPending, replayed.Status)
+	assert.Zero(t, replayed.Attempts)
+
+	delivered, err := deliver.Execute(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 1, delivered)
+	assert.Len(t, partner.received, 4)
+	assert.Equal(t, partner.bodies[0], partner.bodies[3], "el reenvío usa el mismo cuerpo")
+	assert.Equal(t, dead[0].ID, partner.received[3].Header.Get(webhooks.HeaderDelivery))
+
+	_, err = listDeliveries.Execute(context.Background(), usecases.ListWebhookDeliveriesInput{SubscriptionID: subscription.ID, Status: "lost", Actor: admin})
+	assert.ErrorIs(t, err, usecases.ErrInvalidDeliveryStatus)
+}
@@ -0,0 +1,130 @@
+package tests
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"go-crime_map_backend/internal/domain/entities"
+	memory "go-crime_map_backend/internal/infrastructure/repositories"
+	"go-crime_map_backend/internal/usecases"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestZoneStats(t *testing.T) {
+	ctx := context.Background()
+	crimeRepo := memory.NewMemoryCrimeRepository()
+	zoneRepo := memory.NewMemoryZoneRepository()
+	createZone := usecases.NewCreateZoneUseCase(zoneRepo, crimeRepo)
+
+	zone := func(name, kind string, population int, lon float64) *entities.Zone {
+		created, err := createZone.Execute(ctx, usecases.ZoneInput{
+			Name: name, Kind: kind, Population: population, Geometry: square(-34.60, lon, 0.01),
+		}, admin)
+		require.NoError(t, err)
+		return created
+	}
+	palermo := zone("Palermo", "barrio", 10000, -58.42)
+	boedo := zone("Boedo", "barrio", 0, -58.40)
+	caballito := zone("Caballito", "barrio", 2000, -58.38)
+	comuna := zone("Comuna 9", "comuna", 5000, -58.30)
+
+	from := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 0, 30)
+	n := 0
+	add := func(zone *entities.Zone, crimeType string, status entities.CrimeStatus, date time.Time) {
+		n++
+		require.NoError(t, crimeRepo.Create(ctx, &entities.Crime{
+			ID:          fmt.Sprintf("crime-%d", n),
+			Type:        crimeType,
+			Description: "delito de prueba",
+			Date:        date,
+			Status:      status,
+			ZoneID:      zone.ID,
+		}))
+	}
+	current, previous := from.Add(time.Hour), from.Add(-time.Hour)
+	add(palermo, "ROBO", entities.CrimeStatusVerified, current)
+	add(palermo, "ROBO", entities.CrimeStatusVerified, current)
+	add(palermo, "HURTO", entities.CrimeStatusVerified, current)
+	add(palermo, "ROBO", entities.CrimeStatusVerified, previous)
+	add(palermo, "ROBO", entities.CrimeStatusVerified, to) // El fin del período se excluye
+	add(boedo, "ROBO", entities.CrimeStatusVerified, current)
+	add(caballito, "ROBO", entities.CrimeStatusVerified, current)
+	add(caballito, "ROBO", entities.CrimeStatusReported, current)
+	add(caballito, "HURTO", entities.CrimeStatusVerified, previous)
+	add(caballito, "HURTO", entities.CrimeStatusVerified, previous)
+	add(comuna, "ROBO", entities.CrimeStatusVerified, current)
+
+	stats := usecases.NewGetZoneStatsUseCase(zoneRepo, crimeRepo)
+	execute := func(input usecases.ZoneStatsInput) []*entities.ZoneStats {
+		t.Helper()
+		input.From, input.To = from, to
+		result, err := stats.Execute(ctx, input)
+		require.NoError(t, err)
+		return result.Zones
+	}
+	names := func(zones []*entities.ZoneStats) []string {
+		result := make([]string, len(zones))
+		for i, zone := range zones {
+			result[i] = zone.Name
+		}
+		return result
+	}
+
+	// Por defecto el ranking es por cantidad; los empates se ordenan por nombre
+	barrios := execute(usecases.ZoneStatsInput{Kind: "barrio", Actor: citizen})
+	require.Equal(t, []string{"Palermo", "Boedo", "Caballito"}, names(barrios))
+	top := barrios[0]
+	assert.Equal(t, 3, top.Count)
+	assert.Equal(t, map[string]int{"ROBO": 2, "HURTO": 1}, top.ByType)
+	assert.Equal(t, 1, top.PreviousCount)
+	assert.Equal(t, 2, top.Change)
+	require.NotNil(t, top.RatePer1000)
+	assert.InDelta(t, 0.3, *top.RatePer1000, 1e-9)
+	require.NotNil(t, top.ChangePercent)
+	assert.InDelta(t, 200, *top.ChangePercent, 1e-9)
+
+	// Sin población no hay tasa y sin delitos en el período anterior no hay variación porcentual
+	assert.Nil(t, barrios[1].RatePer1000)
+	assert.Nil(t, barrios[1].ChangePercent)
+
+	// Los ciudadanos no cuentan los reportes sin verificar; los moderadores sí
+	assert.Equal(t, 1, barrios[2].Count)
+	assert.Equal(t, -1, barrios[2].Change)
+	assert.InDelta(t, -50, *barrios[2].ChangePercent, 1e-9)
+	staff := execute(usecases.ZoneStatsInput{Kind: "barrio", Actor: moderator})
+	assert.Equal(t, []string{"Palermo", "Caballito", "Boedo"}, names(staff))
+
+	// Los valores desconocidos van al final de los rankings
+	assert.Equal(t, []string{"Caballito", "Palermo", "Boedo"}, names(execute(usecases.ZoneStatsInput{Kind: "barrio", Sort: "rate", Actor: citizen})))
+	assert.Equal(t, []string{"Palermo", "Boedo", "Caballito"}, names(execute(usecases.ZoneStatsInput{Kind: "barrio", Sort: "change", Actor: citizen})))
+	assert.Equal(t, []string{"Palermo", "Caballito", "Boedo"}, names(execute(usecases.ZoneStatsInput{Kind: "barrio", Sort: "change_percent", Actor: citizen})))
+
+	// Sin tipo de zona se incluyen todas
+	all := execute(usecases.ZoneStatsInput{Sort: "name", Actor: citizen})
+	assert.Equal(t, []string{"Boedo", "Caballito", "Comuna 9", "Palermo"}, names(all))
+
+	hurtos := execute(usecases.ZoneStatsInput{Kind: "barrio", Types: []string{"HURTO"}, Limit: 1, Actor: citizen})
+	require.Len(t, hurtos, 1)
+	assert.Equal(t, "Palermo", hurtos[0].Name)
+	assert.Equal(t, 1, hurtos[0].Count)
+	assert.Equal(t, 0, hurtos[0].PreviousCount)
+
+	// Errores
+	_, err := stats.Execute(ctx, usecases.ZoneStatsInput{From: to, To: from, Actor: citizen})
+	assert.ErrorIs(t, err, usecases.ErrInvalidStatsPeriod)
+	_, err = stats.Execute(ctx, usecases.ZoneStatsInput{From: from.AddDate(-2, 0, 0), To: to, Actor: citizen})
+	assert.ErrorIs(t, err, usecases.ErrInvalidStatsPeriod)
+	_, err = stats.Execute(ctx, usecases.ZoneStatsInput{Sort: "peligro", Actor: citizen})
+	assert.ErrorIs(t, err, usecases.ErrInvalidStatsSort)
+	_, err = stats.Execute(ctx, usecases.ZoneStatsInput{Statuses: []entities.CrimeStatus{entities.CrimeStatusReported}, Actor: citizen})
+	assert.ErrorIs(t, err, usecases.ErrForbidden)
+	_, err = createZone.Execute(ctx, usecases.ZoneInput{
+		Name: "Flores", Kind: "barrio", Population: -1, Geometry: square(-34.63, -58.46, 0.01),
+	}, admin)
+	assert.ErrorIs(t, err, usecases.ErrInvalidZonePopulation)
+}
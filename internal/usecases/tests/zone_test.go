@@ -0,0 +1,200 @@
+package tests
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"go-crime_map_backend/internal/domain/entities"
+	memory "go-crime_map_backend/internal/infrastructure/repositories"
+	"go-crime_map_backend/internal/usecases"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// comunasGeoJSON tiene una comuna cuadrada con un parque excluido como hueco
+const comunasGeoJSON = `{
+	"type": "FeatureCollection",
+	"features": [{
+		"type": "Feature",
+		"properties": {"COMUNA": 1, "POBLACION": "205886"},
+		"geometry": {
+			"type": "Polygon",
+			"coordinates": [
+				[[-58.40, -34.62], [-58.36, -34.62], [-58.36, -34.58], [-58.40, -34.58], [-58.40, -34.62]],
+				[[-58.365, -34.585], [-58.362, -34.585], [-58.362, -34.582], [-58.365, -34.582], [-58.365, -34.585]]
+			]
+		}
+	}, {
+		"type": "Feature",
+		"properties": {"COMUNA": 2},
+		"geometry": {
+			"type": "MultiPolygon",
+			"coordinates": [[[[-58.36, -34.62], [-58.32, -34.62], [-58.32, -34.58], [-58.36, -34.58], [-58.36, -34.62]]]]
+		}
+	}]
+}`
+
+// square retorna un cuadrado de lado 2*half grados centrado en el punto
+func square(lat, lon, half float64) entities.MultiPolygon {
+	return entities.MultiPolygon{{{
+		{Latitude: lat - half, Longitude: lon - half},
+		{Latitude: lat - half, Longitude: lon + half},
+		{Latitude: lat + half, Longitude: lon + half},
+		{Latitude: lat + half, Longitude: lon - half},
+	}}}
+}
+
+// reportAt reporta un delito en la ubicación indicada
+func reportAt(t *testing.T, create *usecases.CreateCrimeUseCase, description string, lat, lon float64) *entities.Crime {
+	t.Helper()
+	crime, err := create.Execute(context.Background(), usecases.CreateCrimeInput{
+		Type:        "ROBO",
+		Description: description,
+		Location:    usecases.Location{Latitude: lat, Longitude: lon, Address: "Buenos Aires"},
+		Date:        time.Now().Add(-time.Hour),
+	})
+	require.NoError(t, err)
+	return crime
+}
+
+func TestZoneAssignment(t *testing.T) {
+	ctx := context.Background()
+	crimeRepo := memory.NewMemoryCrimeRepository()
+	zoneRepo := memory.NewMemoryZoneRepository()
+	create := usecases.NewCreateCrimeUseCaseWithZones(crimeRepo, usecases.NewZoneLocator(zoneRepo))
+
+	// Un delito reportado antes de cargar las zonas se asigna al importarlas
+	early := reportAt(t, create, "antes de las zonas", -34.60, -58.38)
+	assert.Empty(t, early.ZoneID)
+
+	result, err := usecases.NewImportZonesUseCase(zoneRepo, crimeRepo).Execute(ctx, usecases.ImportZonesInput{
+		Data:               []byte(comunasGeoJSON),
+		Kind:               "Comuna",
+		NameProperty:       "COMUNA",
+		PopulationProperty: "POBLACION",
+	}, admin)
+	require.NoError(t, err)
+	assert.Equal(t, 2, result.Created)
+	assert.Equal(t, 1, result.Relocated)
+	comuna1, comuna2 := result.Zones[0], result.Zones[1]
+	assert.Equal(t, 205886, comuna1.Population)
+	assert.Zero(t, comuna2.Population)
+	assert.Equal(t, "1", comuna1.Name)
+	assert.Equal(t, "comuna", comuna1.Kind)
+
+	stored, err := crimeRepo.GetByID(ctx, early.ID)
+	require.NoError(t, err)
+	assert.Equal(t, comuna1.ID, stored.ZoneID)
+
+	// Un barrio dentro de la comuna 1 es más específico que la comuna
+	barrio, err := usecases.NewCreateZoneUseCase(zoneRepo, crimeRepo).Execute(ctx, usecases.ZoneInput{
+		Name:     "San Nicolás",
+		Kind:     "barrio",
+		Geometry: square(-34.60, -58.38, 0.005),
+	}, admin)
+	require.NoError(t, err)
+
+	inBarrio := reportAt(t, create, "en el barrio", -34.601, -58.379)
+	inComuna2 := reportAt(t, create, "en la comuna 2", -34.60, -58.34)
+	inPark := reportAt(t, create, "en el parque", -34.5835, -58.3635)
+	outside := reportAt(t, create, "fuera de la ciudad", -34.70, -58.50)
+	assert.Equal(t, barrio.ID, inBarrio.ZoneID)
+	assert.Equal(t, comuna2.ID, inComuna2.ZoneID)
+	assert.Empty(t, inPark.ZoneID, "el hueco del polígono no pertenece a la comuna")
+	assert.Empty(t, outside.ZoneID)
+
+	stored, err = crimeRepo.GetByID(ctx, early.ID)
+	require.NoError(t, err)
+	assert.Equal(t, barrio.ID, stored.ZoneID, "al crear el barrio el delito pasa a la zona más pequeña")
+
+	// Al mover el delito se recalcula su zona
+	updated, err := usecases.NewUpdateCrimeUseCaseWithZones(crimeRepo, usecases.NewZoneLocator(zoneRepo)).Execute(ctx, usecases.UpdateCrimeInput{
+		CrimeID: outside.ID,
+		Data: usecases.CreateCrimeInput{
+			Type:        outside.Type,
+			Description: outside.Description,
+			Location:    usecases.Location{Latitude: -34.59, Longitude: -58.33, Address: "Buenos Aires"},
+			Date:        outside.Date,
+		},
+		Actor: moderator,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, comuna2.ID, updated.ZoneID)
+
+	list := usecases.NewListCrimesUseCase(crimeRepo)
+	crimes, err := list.Execute(ctx, usecases.ListCrimesInput{ZoneIDs: []string{comuna2.ID}, Actor: moderator})
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{inComuna2.ID, outside.ID}, crimeIDs(crimes))
+
+	// Al eliminar el barrio sus delitos vuelven a la comuna
+	require.NoError(t, usecases.NewDeleteZoneUseCase(zoneRepo, crimeRepo).Execute(ctx, barrio.ID, admin))
+	crimes, err = list.Execute(ctx, usecases.ListCrimesInput{ZoneIDs: []string{comuna1.ID}, Actor: moderator})
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{early.ID, inBarrio.ID}, crimeIDs(crimes))
+}
+
+func TestZoneValidation(t *testing.T) {
+	ctx := context.Background()
+	crimeRepo := memory.NewMemoryCrimeRepository()
+	zoneRepo := memory.NewMemoryZoneRepository()
+	create := usecases.NewCreateZoneUseCase(zoneRepo, crimeRepo)
+	importZones := usecases.NewImportZonesUseCase(zoneRepo, crimeRepo)
+	valid := usecases.ZoneInput{Name: "Palermo", Kind: "barrio", Geometry: square(-34.58, -58.42, 0.01)}
+
+	_, err := create.Execute(ctx, valid, moderator)
+	assert.ErrorIs(t, err, usecases.ErrForbidden)
+	_, err = importZones.Execute(ctx, usecases.ImportZonesInput{Data: []byte(comunasGeoJSON), Kind: "comuna"}, citizen)
+	assert.ErrorIs(t, err, usecases.ErrForbidden)
+
+	_, err = create.Execute(ctx, usecases.ZoneInput{Kind: "barrio", Geometry: valid.Geometry}, admin)
+	assert.ErrorIs(t, err, usecases.ErrInvalidZoneName)
+	_, err = create.Execute(ctx, usecases.ZoneInput{Name: "Palermo", Geometry: valid.Geometry}, admin)
+	assert.ErrorIs(t, err, usecases.ErrInvalidZoneKind)
+	_, err = create.Execute(ctx, usecases.ZoneInput{Name: "Palermo", Kind: "barrio", Geometry: entities.MultiPolygon{{{{Latitude: 1, Longitude: 1}}}}}, admin)
+	assert.ErrorIs(t, err, usecases.ErrInvalidZoneGeometry)
+
+	palermo, err := create.Execute(ctx, valid, admin)
+	require.NoError(t, err)
+	_, err = create.Execute(ctx, usecases.ZoneInput{Name: "PALERMO", Kind: "Barrio", Geometry: valid.Geometry}, admin)
+	assert.ErrorIs(t, err, usecases.ErrDuplicateZone)
+
+	for name, data := range map[string]string{
+		"no es JSON":         `{`,
+		"sin features":       `{"type": "FeatureCollection", "features": []}`,
+		"geometría inválida": `{"type": "Feature", "properties": {"name": "Centro"}, "geometry": {"type": "Point", "coordinates": [-58.4, -34.6]}}`,
+		"sin nombre":         `{"type": "Feature", "properties": {}, "geometry": {"type": "Polygon", "coordinates": [[[-58.4, -34.6], [-58.3, -34.6], [-58.3, -34.5], [-58.4, -34.6]]]}}`,
+	} {
+		_, err := importZones.Execute(ctx, usecases.ImportZonesInput{Data: []byte(data), Kind: "barrio"}, admin)
+		assert.ErrorIs(t, err, usecases.ErrInvalidZoneImport, name)
+	}
+
+	// Importar una zona existente reemplaza su geometría y conserva su ID
+	result, err := importZones.Execute(ctx, usecases.ImportZonesInput{
+		Data: []byte(`{"type": "Feature", "properties": {"name": "Palermo"}, "geometry": {"type": "Polygon", "coordinates": [[[-58.45, -34.60], [-58.40, -34.60], [-58.40, -34.55], [-58.45, -34.60]]]}}`),
+		Kind: "barrio",
+	}, admin)
+	require.NoError(t, err)
+	assert.Equal(t, 0, result.Created)
+	assert.Equal(t, 1, result.Updated)
+	assert.Equal(t, palermo.ID, result.Zones[0].ID)
+
+	zones, err := usecases.NewListZonesUseCase(zoneRepo).Execute(ctx, "barrio")
+	require.NoError(t, err)
+	require.Len(t, zones, 1)
+	assert.Len(t, zones[0].Geometry[0][0], 3)
+
+	_, err = usecases.NewGetZoneUseCase(zoneRepo).Execute(ctx, "inexistente")
+	assert.ErrorIs(t, err, usecases.ErrZoneNotFound)
+	assert.ErrorIs(t, usecases.NewDeleteZoneUseCase(zoneRepo, crimeRepo).Execute(ctx, "inexistente", admin), usecases.ErrZoneNotFound)
+}
+
+// crimeIDs retorna los IDs de los delitos
+func crimeIDs(crimes []*entities.Crime) []string {
+	ids := make([]string, len(crimes))
+	for i, crime := range crimes {
+		ids[i] = crime.ID
+	}
+	return ids
+}
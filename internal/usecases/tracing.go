@@ -0,0 +1,19 @@
+package usecases
+
+import (
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// tracer crea los spans de los casos de uso usando el TracerProvider global
+var tracer = otel.Tracer("go-crime_map_backend/internal/usecases")
+
+// endSpan registra el error en el span, si existe, y lo finaliza
+func endSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
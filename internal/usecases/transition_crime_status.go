@@ -0,0 +1,117 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"time"
+
+	"go-crime_map_backend/internal/domain/entities"
+	"go-crime_map_backend/internal/domain/events"
+	"go-crime_map_backend/internal/domain/repositories"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var (
+	// ErrInvalidTransition se retorna cuando el cambio de estado no está permitido
+	ErrInvalidTransition = errors.New("el cambio de estado no está permitido")
+
+	// ErrReasonRequired se retorna cuando el cambio de estado exige un motivo
+	ErrReasonRequired = errors.New("el cambio de estado requiere un motivo")
+
+	// ErrReasonTooLong se retorna cuando el motivo excede el límite de caracteres
+	ErrReasonTooLong = errors.New("el motivo no puede exceder los 500 caracteres")
+
+	// maxReasonLength define la longitud máxima permitida para el motivo
+	maxReasonLength = 500
+)
+
+// TransitionCrimeStatusInput representa los datos necesarios para cambiar el estado de un delito
+type TransitionCrimeStatusInput struct {
+	CrimeID string
+	Status  entities.CrimeStatus
+	Reason  string
+	Actor   entities.Actor
+}
+
+// TransitionCrimeStatusUseCase maneja la lógica de negocio del flujo de estados de un delito
+type TransitionCrimeStatusUseCase struct {
+	crimeRepo repositories.CrimeRepository
+}
+
+// NewTransitionCrimeStatusUseCase crea una nueva instancia del caso de uso
+func NewTransitionCrimeStatusUseCase(repo repositories.CrimeRepository) *TransitionCrimeStatusUseCase {
+	return &TransitionCrimeStatusUseCase{
+		crimeRepo: repo,
+	}
+}
+
+// Execute aplica la transición de estado si la máquina de estados y el rol del actor lo permiten
+func (uc *TransitionCrimeStatusUseCase) Execute(ctx context.Context, input TransitionCrimeStatusInput) (_ *entities.Crime, err error) {
+	ctx, span := tracer.Start(ctx, "TransitionCrimeStatusUseCase.Execute", trace.WithAttributes(
+		attribute.String("crime.id", input.CrimeID),
+		attribute.String("crime.status", string(input.Status)),
+	))
+	defer func() { endSpan(span, err) }()
+
+	if !input.Status.IsValid() {
+		return nil, ErrInvalidStatus
+	}
+
+	crime, err := uc.crimeRepo.GetByID(ctx, input.CrimeID)
+	if err != nil {
+		return nil, err
+	}
+	if crime == nil {
+		return nil, ErrCrimeNotFound
+	}
+
+	transition, ok := crime.Status.Transition(input.Status)
+	if !ok {
+		return nil, ErrInvalidTransition
+	}
+	if !transition.AllowsRole(input.Actor.Role) {
+		return nil, ErrForbidden
+	}
+
+	reason := strings.TrimSpace(input.Reason)
+	if transition.RequiresReason && reason == "" {
+		return nil, ErrReasonRequired
+	}
+	if len(reason) > maxReasonLength {
+		return nil, ErrReasonTooLong
+	}
+
+	change := &entities.CrimeStatusChange{
+		ID:        generateID(),
+		CrimeID:   crime.ID,
+		From:      crime.Status,
+		To:        input.Status,
+		Reason:    reason,
+		ActorID:   input.Actor.ID,
+		ChangedAt: time.Now(),
+	}
+	updated := *crime
+	updated.Status = change.To
+	updated.UpdatedAt = change.ChangedAt
+
+	eventCtx, err := raise(ctx, events.CrimeStatusChanged, crime.ID, events.CrimeStatusChangedPayload{Crime: &updated, Change: change})
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.crimeRepo.UpdateStatus(eventCtx, change); err != nil {
+		return nil, err
+	}
+
+	slog.InfoContext(ctx, "estado del delito actualizado",
+		slog.String("crime_id", crime.ID),
+		slog.String("from", string(change.From)),
+		slog.String("to", string(change.To)),
+		slog.String("actor_id", change.ActorID),
+	)
+
+	return &updated, nil
+}
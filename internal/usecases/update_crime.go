@@ -0,0 +1,117 @@
+package usecases
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"go-crime_map_backend/internal/domain/entities"
+	"go-crime_map_backend/internal/domain/events"
+	"go-crime_map_backend/internal/domain/repositories"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// UpdateCrimeInput representa los datos necesarios para corregir un delito
+type UpdateCrimeInput struct {
+	CrimeID string
+	Data    CreateCrimeInput
+	Actor   entities.Actor
+}
+
+// UpdateCrimeUseCase maneja la lógica de negocio para corregir los datos de un delito
+type UpdateCrimeUseCase struct {
+	crimeRepo repositories.CrimeRepository
+	zones     *ZoneLocator
+	locations *LocationResolver
+}
+
+// NewUpdateCrimeUseCase crea una nueva instancia del caso de uso
+func NewUpdateCrimeUseCase(repo repositories.CrimeRepository) *UpdateCrimeUseCase {
+	return NewUpdateCrimeUseCaseWithZones(repo, nil)
+}
+
+// NewUpdateCrimeUseCaseWithZones crea el caso de uso reasignando la zona del delito según
+// su nueva ubicación; sin localizador la zona no cambia
+func NewUpdateCrimeUseCaseWithZones(repo repositories.CrimeRepository, zones *ZoneLocator) *UpdateCrimeUseCase {
+	return NewUpdateCrimeUseCaseWithGeocoding(repo, zones, nil)
+}
+
+// NewUpdateCrimeUseCaseWithGeocoding crea el caso de uso completando la nueva ubicación con
+// el resolutor y volviendo a verificar que la dirección coincida con las coordenadas
+func NewUpdateCrimeUseCaseWithGeocoding(repo repositories.CrimeRepository, zones *ZoneLocator, locations *LocationResolver) *UpdateCrimeUseCase {
+	return &UpdateCrimeUseCase{
+		crimeRepo: repo,
+		zones:     zones,
+		locations: locations,
+	}
+}
+
+// Execute reemplaza los datos del delito. Solo disponible para moderadores; el estado
+// no se modifica aquí, se cambia mediante el flujo de estados
+func (uc *UpdateCrimeUseCase) Execute(ctx context.Context, input UpdateCrimeInput) (_ *entities.Crime, err error) {
+	ctx, span := tracer.Start(ctx, "UpdateCrimeUseCase.Execute",
+		trace.WithAttributes(attribute.String("crime.id", input.CrimeID)))
+	defer func() { endSpan(span, err) }()
+
+	if !input.Actor.IsStaff() {
+		return nil, ErrForbidden
+	}
+	if err := validateCreateCrimeInput(input.Data); err != nil {
+		return nil, err
+	}
+	location, addressMismatch, err := resolveLocation(ctx, uc.locations, input.Data.Location)
+	if err != nil {
+		return nil, err
+	}
+
+	crime, err := uc.crimeRepo.GetByID(ctx, input.CrimeID)
+	if err != nil {
+		return nil, err
+	}
+	if crime == nil {
+		return nil, ErrCrimeNotFound
+	}
+
+	updated := *crime
+	updated.Type = input.Data.Type
+	updated.Description = input.Data.Description
+	updated.Location = entities.Location{
+		Latitude:  location.Latitude,
+		Longitude: location.Longitude,
+		Address:   location.Address,
+	}
+	updated.AddressMismatch = addressMismatch
+	updated.Date = input.Data.Date
+	updated.Severity, updated.Weapon, updated.Violent = input.Data.classification()
+	updated.VictimCount = input.Data.VictimCount
+
+	// Sin cambios no se escribe, así no se generan revisiones ni eventos vacíos
+	changes := entities.DiffCrimes(crime, &updated)
+	if len(changes) == 0 {
+		return crime, nil
+	}
+	if uc.zones != nil {
+		if updated.ZoneID, err = uc.zones.Locate(ctx, updated.Location); err != nil {
+			return nil, err
+		}
+	}
+	updated.UpdatedAt = time.Now()
+
+	eventCtx, err := raise(ctx, events.CrimeUpdated, crime.ID, events.CrimeUpdatedPayload{Crime: &updated, Changes: changes})
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.crimeRepo.Update(eventCtx, &updated); err != nil {
+		return nil, err
+	}
+
+	slog.InfoContext(ctx, "delito actualizado",
+		slog.String("crime_id", crime.ID),
+		slog.Int("changes", len(changes)),
+		slog.String("actor_id", input.Actor.ID),
+	)
+
+	return &updated, nil
+}
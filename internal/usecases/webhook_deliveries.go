@@ -0,0 +1,132 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"go-crime_map_backend/internal/domain/entities"
+	"go-crime_map_backend/internal/domain/repositories"
+)
+
+const (
+	// defaultWebhookDeliveriesLimit es la cantidad de entregas retornadas si no se indica un límite
+	defaultWebhookDeliveriesLimit = 50
+
+	// maxWebhookDeliveriesLimit es la cantidad máxima de entregas por consulta
+	maxWebhookDeliveriesLimit = 200
+)
+
+var (
+	// ErrWebhookDeliveryNotFound se retorna cuando la entrega no existe en la suscripción
+	ErrWebhookDeliveryNotFound = errors.New("entrega de webhook no encontrada")
+
+	// ErrInvalidDeliveryStatus se retorna cuando el estado de entrega indicado no existe
+	ErrInvalidDeliveryStatus = errors.New("el estado de la entrega debe ser pending, succeeded o dead")
+)
+
+// ListWebhookDeliveriesInput representa los criterios para consultar el registro de entregas
+type ListWebhookDeliveriesInput struct {
+	SubscriptionID string
+	Status         entities.WebhookDeliveryStatus // Vacío para todos los estados
+	Limit          int                            // Cantidad máxima de entregas, 0 usa el valor por defecto
+	Actor          entities.Actor
+}
+
+// ListWebhookDeliveriesUseCase maneja la lógica de negocio para consultar las entregas de un webhook
+type ListWebhookDeliveriesUseCase struct {
+	webhookRepo repositories.WebhookRepository
+}
+
+// NewListWebhookDeliveriesUseCase crea una nueva instancia del caso de uso
+func NewListWebhookDeliveriesUseCase(repo repositories.WebhookRepository) *ListWebhookDeliveriesUseCase {
+	return &ListWebhookDeliveriesUseCase{
+		webhookRepo: repo,
+	}
+}
+
+// Execute obtiene las entregas de la suscripción, de la más reciente a la más antigua.
+// Solo disponible para administradores
+func (uc *ListWebhookDeliveriesUseCase) Execute(ctx context.Context, input ListWebhookDeliveriesInput) ([]*entities.WebhookDelivery, error) {
+	if input.Actor.Role != entities.RoleAdmin {
+		return nil, ErrForbidden
+	}
+	if input.Status != "" && !input.Status.IsValid() {
+		return nil, ErrInvalidDeliveryStatus
+	}
+
+	subscription, err := uc.webhookRepo.GetSubscription(ctx, input.SubscriptionID)
+	if err != nil {
+		return nil, err
+	}
+	if subscription == nil {
+		return nil, ErrWebhookNotFound
+	}
+
+	limit := input.Limit
+	if limit <= 0 {
+		limit = defaultWebhookDeliveriesLimit
+	}
+	if limit > maxWebhookDeliveriesLimit {
+		limit = maxWebhookDeliveriesLimit
+	}
+
+	return uc.webhookRepo.ListDeliveries(ctx, subscription.ID, repositories.WebhookDeliveryFilter{
+		Status: input.Status,
+		Limit:  limit,
+	})
+}
+
+// ReplayWebhookDeliveryUseCase maneja la lógica de negocio para reenviar una entrega
+type ReplayWebhookDeliveryUseCase struct {
+	webhookRepo repositories.WebhookRepository
+	now         func() time.Time
+}
+
+// NewReplayWebhookDeliveryUseCase crea una nueva instancia del caso de uso
+func NewReplayWebhookDeliveryUseCase(repo repositories.WebhookRepository) *ReplayWebhookDeliveryUseCase {
+	return NewReplayWebhookDeliveryUseCaseWithClock(repo, time.Now)
+}
+
+// NewReplayWebhookDeliveryUseCaseWithClock crea el caso de uso con un reloj propio, útil en pruebas
+func NewReplayWebhookDeliveryUseCaseWithClock(repo repositories.WebhookRepository, now func() time.Time) *ReplayWebhookDeliveryUseCase {
+	return &ReplayWebhookDeliveryUseCase{
+		webhookRepo: repo,
+		now:         now,
+	}
+}
+
+// Execute vuelve a encolar la entrega con los reintentos reiniciados, haya fallado o no.
+// El cuerpo enviado es el mismo, por lo que el receptor puede descartarla por su ID de evento.
+// Solo disponible para administradores
+func (uc *ReplayWebhookDeliveryUseCase) Execute(ctx context.Context, subscriptionID, deliveryID string, actor entities.Actor) (*entities.WebhookDelivery, error) {
+	if actor.Role != entities.RoleAdmin {
+		return nil, ErrForbidden
+	}
+
+	delivery, err := uc.webhookRepo.GetDelivery(ctx, subscriptionID, deliveryID)
+	if err != nil {
+		return nil, err
+	}
+	if delivery == nil {
+		return nil, ErrWebhookDeliveryNotFound
+	}
+
+	now := uc.now()
+	delivery.Status = entities.WebhookDeliveryPending
+	delivery.Attempts = 0
+	delivery.NextAttemptAt = &now
+	delivery.LastError = ""
+	delivery.LastStatusCode = 0
+	if err := uc.webhookRepo.UpdateDelivery(ctx, delivery); err != nil {
+		return nil, err
+	}
+
+	slog.InfoContext(ctx, "entrega de webhook reenviada",
+		slog.String("subscription_id", subscriptionID),
+		slog.String("delivery_id", deliveryID),
+		slog.String("actor_id", actor.ID),
+	)
+	return delivery, nil
+}
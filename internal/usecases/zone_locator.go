@@ -0,0 +1,111 @@
+package usecases
+
+import (
+	"context"
+
+	"go-crime_map_backend/internal/domain/entities"
+	"go-crime_map_backend/internal/domain/repositories"
+	"go-crime_map_backend/pkg/spatial"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// zoneIndexCellDegrees es el tamaño de las celdas del índice usado al reasignar zonas
+const zoneIndexCellDegrees = 0.02
+
+// ZoneLocator asigna a cada ubicación la zona que la contiene
+type ZoneLocator struct {
+	zoneRepo repositories.ZoneRepository
+}
+
+// NewZoneLocator crea un localizador sobre el repositorio de zonas
+func NewZoneLocator(repo repositories.ZoneRepository) *ZoneLocator {
+	return &ZoneLocator{
+		zoneRepo: repo,
+	}
+}
+
+// Locate retorna el ID de la zona que contiene la ubicación, vacío si ninguna la contiene.
+// Si varias zonas la contienen, como un barrio dentro de su comuna, gana la más pequeña
+func (l *ZoneLocator) Locate(ctx context.Context, location entities.Location) (_ string, err error) {
+	ctx, span := tracer.Start(ctx, "ZoneLocator.Locate")
+	defer func() { endSpan(span, err) }()
+
+	candidates, err := l.zoneRepo.FindZones(ctx, location)
+	if err != nil {
+		return "", err
+	}
+	zoneID := smallestZone(candidates, location, nil)
+	span.SetAttributes(attribute.String("zone.id", zoneID))
+	return zoneID, nil
+}
+
+// Relocate recalcula la zona de todos los delitos con las zonas actuales y guarda las que
+// cambiaron. Retorna la cantidad de delitos reasignados
+func (l *ZoneLocator) Relocate(ctx context.Context, crimeRepo repositories.CrimeRepository) (_ int, err error) {
+	ctx, span := tracer.Start(ctx, "ZoneLocator.Relocate")
+	defer func() { endSpan(span, err) }()
+
+	zones, err := l.zoneRepo.ListZones(ctx, "")
+	if err != nil {
+		return 0, err
+	}
+	crimes, err := crimeRepo.GetAll(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	// Las zonas se indexan en memoria para no consultar el repositorio por cada delito
+	byID := make(map[string]*entities.Zone, len(zones))
+	areas := make(map[string]float64, len(zones))
+	grid := spatial.NewGrid(zoneIndexCellDegrees)
+	for _, zone := range zones {
+		bounds := zone.Geometry.Bounds()
+		byID[zone.ID] = zone
+		areas[zone.ID] = zone.Geometry.AreaSquareMeters()
+		grid.Insert(zone.ID, spatial.Rect{
+			MinX: bounds.MinLongitude,
+			MinY: bounds.MinLatitude,
+			MaxX: bounds.MaxLongitude,
+			MaxY: bounds.MaxLatitude,
+		})
+	}
+
+	changes := make(map[string]string)
+	for _, crime := range crimes {
+		var candidates []*entities.Zone
+		for _, id := range grid.Query(crime.Location.Longitude, crime.Location.Latitude) {
+			candidates = append(candidates, byID[id])
+		}
+		if zoneID := smallestZone(candidates, crime.Location, areas); zoneID != crime.ZoneID {
+			changes[crime.ID] = zoneID
+		}
+	}
+	span.SetAttributes(attribute.Int("zone.relocated", len(changes)))
+	if len(changes) == 0 {
+		return 0, nil
+	}
+	if err := crimeRepo.AssignZones(ctx, changes); err != nil {
+		return 0, err
+	}
+	return len(changes), nil
+}
+
+// smallestZone retorna el ID de la zona de menor área entre las candidatas que contienen la
+// ubicación; a igual área gana el menor ID. areas guarda las áreas ya calculadas, puede ser nil
+func smallestZone(candidates []*entities.Zone, location entities.Location, areas map[string]float64) string {
+	best, bestArea := "", 0.0
+	for _, zone := range candidates {
+		if !zone.Contains(location) {
+			continue
+		}
+		area, cached := areas[zone.ID]
+		if !cached {
+			area = zone.Geometry.AreaSquareMeters()
+		}
+		if best == "" || area < bestArea || (area == bestArea && zone.ID < best) {
+			best, bestArea = zone.ID, area
+		}
+	}
+	return best
+}
@@ -0,0 +1,264 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
+	"time"
+
+	"go-crime_map_backend/internal/domain/entities"
+	"go-crime_map_backend/internal/domain/repositories"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	// ZoneStatsSortCount ordena las zonas por cantidad de delitos, de mayor a menor
+	ZoneStatsSortCount = "count"
+
+	// ZoneStatsSortRate ordena las zonas por delitos cada 1000 habitantes, de mayor a menor
+	ZoneStatsSortRate = "rate"
+
+	// ZoneStatsSortChange ordena las zonas por el aumento de delitos, de mayor a menor
+	ZoneStatsSortChange = "change"
+
+	// ZoneStatsSortChangePercent ordena las zonas por el aumento porcentual, de mayor a menor
+	ZoneStatsSortChangePercent = "change_percent"
+
+	// ZoneStatsSortSeverity ordena las zonas por la suma de los pesos de gravedad, de mayor a menor
+	ZoneStatsSortSeverity = "severity"
+
+	// ZoneStatsSortName ordena las zonas alfabéticamente
+	ZoneStatsSortName = "name"
+
+	// defaultStatsPeriod es la duración del período si no se indica su inicio
+	defaultStatsPeriod = 30 * 24 * time.Hour
+
+	// maxStatsPeriod es la duración máxima de un período
+	maxStatsPeriod = 366 * 24 * time.Hour
+)
+
+var (
+	// ErrInvalidStatsPeriod se retorna cuando el período de una estadística es inválido
+	ErrInvalidStatsPeriod = errors.New("el inicio del período debe ser anterior al fin y el período no puede exceder los 366 días")
+
+	// ErrInvalidStatsSort se retorna cuando el orden solicitado no existe
+	ErrInvalidStatsSort = errors.New("el orden debe ser count, rate, change, change_percent, severity o name")
+)
+
+// ZoneStatsInput representa los criterios de las estadísticas por zona
+type ZoneStatsInput struct {
+	From     time.Time // Inicio del período, por defecto 30 días antes del fin
+	To       time.Time // Fin del período, excluido; por defecto el instante actual
+	Kind     string    // Tipo de zona; vacío incluye todas
+	Types    []string
+	Statuses []entities.CrimeStatus
+	Sort     string // count (por defecto), rate, change, change_percent, severity o name
+	Limit    int    // Cantidad máxima de zonas, 0 retorna todas
+	Actor    entities.Actor
+}
+
+// ZoneStatsResult representa las estadísticas por zona de un período
+type ZoneStatsResult struct {
+	From         time.Time             `json:"from"`
+	To           time.Time             `json:"to"`
+	PreviousFrom time.Time             `json:"previous_from"` // Inicio del período anterior, que termina en From
+	Sort         string                `json:"sort"`
+	Zones        []*entities.ZoneStats `json:"zones"`
+}
+
+// GetZoneStatsUseCase maneja la lógica de negocio de las estadísticas por zona
+type GetZoneStatsUseCase struct {
+	zoneRepo  repositories.ZoneRepository
+	statsRepo repositories.StatsRepository
+}
+
+// NewGetZoneStatsUseCase crea una nueva instancia del caso de uso
+func NewGetZoneStatsUseCase(zoneRepo repositories.ZoneRepository, statsRepo repositories.StatsRepository) *GetZoneStatsUseCase {
+	return &GetZoneStatsUseCase{
+		zoneRepo:  zoneRepo,
+		statsRepo: statsRepo,
+	}
+}
+
+// Execute cuenta los delitos de cada zona en el período y en el período anterior de la misma
+// duración. Cada delito se cuenta en la zona que tiene asignada, la más pequeña que lo
+// contiene. Quienes no son moderadores solo cuentan los delitos públicos
+func (uc *GetZoneStatsUseCase) Execute(ctx context.Context, input ZoneStatsInput) (_ *ZoneStatsResult, err error) {
+	ctx, span := tracer.Start(ctx, "GetZoneStatsUseCase.Execute",
+		trace.WithAttributes(attribute.String("zone.kind", input.Kind)))
+	defer func() { endSpan(span, err) }()
+
+	from, to, err := statsPeriod(input.From, input.To)
+	if err != nil {
+		return nil, err
+	}
+	sortBy := input.Sort
+	if sortBy == "" {
+		sortBy = ZoneStatsSortCount
+	}
+	less, found := zoneStatsOrders[sortBy]
+	if !found {
+		return nil, ErrInvalidStatsSort
+	}
+	statuses, err := visibleStatuses(input.Statuses, input.Actor)
+	if err != nil {
+		return nil, err
+	}
+
+	kind := strings.ToLower(strings.TrimSpace(input.Kind))
+	zones, err := uc.zoneRepo.ListZones(ctx, kind)
+	if err != nil {
+		return nil, err
+	}
+	result := &ZoneStatsResult{From: from, To: to, PreviousFrom: from.Add(-to.Sub(from)), Sort: sortBy}
+	if len(zones) == 0 {
+		result.Zones = []*entities.ZoneStats{}
+		return result, nil
+	}
+	// Sin tipo de zona se cuentan todas, así que no hace falta filtrar por zona
+	var zoneIDs []string
+	if kind != "" {
+		for _, zone := range zones {
+			zoneIDs = append(zoneIDs, zone.ID)
+		}
+	}
+
+	filter := repositories.StatsFilter{
+		CrimeFilter: repositories.CrimeFilter{Statuses: statuses, Types: input.Types, ZoneIDs: zoneIDs},
+		From:        from,
+		To:          to,
+	}
+	current, err := uc.statsRepo.CountByZone(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	filter.From, filter.To = result.PreviousFrom, from
+	previous, err := uc.statsRepo.CountByZone(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	stats := buildZoneStats(zones, current, previous)
+	sort.SliceStable(stats, func(i, j int) bool { return less(stats[i], stats[j]) })
+	if input.Limit > 0 && len(stats) > input.Limit {
+		stats = stats[:input.Limit]
+	}
+	result.Zones = stats
+	return result, nil
+}
+
+// statsPeriod completa y valida el período de una estadística
+func statsPeriod(from, to time.Time) (time.Time, time.Time, error) {
+	if to.IsZero() {
+		to = time.Now()
+	}
+	if from.IsZero() {
+		from = to.Add(-defaultStatsPeriod)
+	}
+	if !from.Before(to) || to.Sub(from) > maxStatsPeriod {
+		return time.Time{}, time.Time{}, ErrInvalidStatsPeriod
+	}
+	return from, to, nil
+}
+
+// buildZoneStats arma las estadísticas de cada zona a partir de los conteos de ambos períodos;
+// las zonas sin delitos se incluyen con cero
+func buildZoneStats(zones []*entities.Zone, current, previous []repositories.ZoneTypeCount) []*entities.ZoneStats {
+	byID := make(map[string]*entities.ZoneStats, len(zones))
+	stats := make([]*entities.ZoneStats, 0, len(zones))
+	for _, zone := range zones {
+		zoneStats := &entities.ZoneStats{
+			ZoneID:     zone.ID,
+			Name:       zone.Name,
+			Kind:       zone.Kind,
+			Population: zone.Population,
+			ByType:     map[string]int{},
+		}
+		byID[zone.ID] = zoneStats
+		stats = append(stats, zoneStats)
+	}
+
+	for _, count := range current {
+		if zoneStats, found := byID[count.ZoneID]; found {
+			zoneStats.Count += count.Count
+			zoneStats.SeverityScore += count.Weight
+			zoneStats.ByType[count.Type] += count.Count
+		}
+	}
+	for _, count := range previous {
+		if zoneStats, found := byID[count.ZoneID]; found {
+			zoneStats.PreviousCount += count.Count
+		}
+	}
+
+	for _, zoneStats := range stats {
+		zoneStats.Change = zoneStats.Count - zoneStats.PreviousCount
+		if zoneStats.Population > 0 {
+			rate := float64(zoneStats.Count) * 1000 / float64(zoneStats.Population)
+			zoneStats.RatePer1000 = &rate
+		}
+		if zoneStats.PreviousCount > 0 {
+			percent := float64(zoneStats.Change) * 100 / float64(zoneStats.PreviousCount)
+			zoneStats.ChangePercent = &percent
+		}
+	}
+	return stats
+}
+
+// zoneStatsOrders define el orden de cada criterio. Los valores desconocidos van al final y
+// los empates se ordenan por nombre
+var zoneStatsOrders = map[string]func(a, b *entities.ZoneStats) bool{
+	ZoneStatsSortCount: func(a, b *entities.ZoneStats) bool {
+		if a.Count != b.Count {
+			return a.Count > b.Count
+		}
+		return zoneStatsByName(a, b)
+	},
+	ZoneStatsSortRate: func(a, b *entities.ZoneStats) bool {
+		return descendingOptional(a.RatePer1000, b.RatePer1000, a, b)
+	},
+	ZoneStatsSortChange: func(a, b *entities.ZoneStats) bool {
+		if a.Change != b.Change {
+			return a.Change > b.Change
+		}
+		return zoneStatsByName(a, b)
+	},
+	ZoneStatsSortChangePercent: func(a, b *entities.ZoneStats) bool {
+		return descendingOptional(a.ChangePercent, b.ChangePercent, a, b)
+	},
+	ZoneStatsSortSeverity: func(a, b *entities.ZoneStats) bool {
+		if a.SeverityScore != b.SeverityScore {
+			return a.SeverityScore > b.SeverityScore
+		}
+		return zoneStatsByName(a, b)
+	},
+	ZoneStatsSortName: zoneStatsByName,
+}
+
+// descendingOptional ordena de mayor a menor dejando al final los valores desconocidos
+func descendingOptional(x, y *float64, a, b *entities.ZoneStats) bool {
+	switch {
+	case x == nil && y == nil:
+		return zoneStatsByName(a, b)
+	case x == nil || y == nil:
+		return y == nil
+	case *x != *y:
+		return *x > *y
+	default:
+		return zoneStatsByName(a, b)
+	}
+}
+
+// zoneStatsByName ordena por nombre, luego por tipo de zona y por ID
+func zoneStatsByName(a, b *entities.ZoneStats) bool {
+	if a.Name != b.Name {
+		return a.Name < b.Name
+	}
+	if a.Kind != b.Kind {
+		return a.Kind < b.Kind
+	}
+	return a.ZoneID < b.ZoneID
+}
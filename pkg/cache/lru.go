@@ -0,0 +1,120 @@
+// Package cache implementa una caché en memoria con vencimiento y desalojo LRU
+package cache
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+// entry es un valor guardado en la caché
+type entry[V any] struct {
+	key       string
+	value     V
+	expiresAt time.Time
+}
+
+// LRU es una caché de capacidad fija cuyos valores vencen tras un tiempo. Al llenarse
+// desaloja el valor usado hace más tiempo. Es segura para uso concurrente
+type LRU[V any] struct {
+	mu       sync.Mutex
+	capacity int
+	ttl      time.Duration
+	items    map[string]*list.Element
+	order    *list.List // Del usado más recientemente al menos reciente
+	now      func() time.Time
+}
+
+// New crea una caché con la capacidad y el vencimiento indicados
+func New[V any](capacity int, ttl time.Duration) *LRU[V] {
+	return NewWithClock[V](capacity, ttl, time.Now)
+}
+
+// NewWithClock crea la caché con un reloj propio, útil en pruebas
+func NewWithClock[V any](capacity int, ttl time.Duration, now func() time.Time) *LRU[V] {
+	if capacity < 1 {
+		capacity = 1
+	}
+	return &LRU[V]{
+		capacity: capacity,
+		ttl:      ttl,
+		items:    make(map[string]*list.Element),
+		order:    list.New(),
+		now:      now,
+	}
+}
+
+// Get obtiene el valor de la clave si existe y no venció
+func (c *LRU[V]) Get(key string) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var zero V
+	element, found := c.items[key]
+	if !found {
+		return zero, false
+	}
+	item := element.Value.(*entry[V])
+	if !c.now().Before(item.expiresAt) {
+		c.remove(element)
+		return zero, false
+	}
+	c.order.MoveToFront(element)
+	return item.value, true
+}
+
+// Set guarda el valor de la clave, reemplazando el anterior y renovando su vencimiento
+func (c *LRU[V]) Set(key string, value V) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	expiresAt := c.now().Add(c.ttl)
+	if element, found := c.items[key]; found {
+		item := element.Value.(*entry[V])
+		item.value, item.expiresAt = value, expiresAt
+		c.order.MoveToFront(element)
+		return
+	}
+
+	c.items[key] = c.order.PushFront(&entry[V]{key: key, value: value, expiresAt: expiresAt})
+	for c.order.Len() > c.capacity {
+		c.remove(c.order.Back())
+	}
+}
+
+// DeleteFunc elimina los valores cuya clave cumple la condición y retorna cuántos eliminó
+func (c *LRU[V]) DeleteFunc(match func(key string) bool) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	deleted := 0
+	for key, element := range c.items {
+		if match(key) {
+			c.remove(element)
+			deleted++
+		}
+	}
+	return deleted
+}
+
+// Purge elimina todos los valores
+func (c *LRU[V]) Purge() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.items = make(map[string]*list.Element)
+	c.order.Init()
+}
+
+// Len retorna la cantidad de valores guardados, incluidos los vencidos que aún no se eliminaron
+func (c *LRU[V]) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.order.Len()
+}
+
+// remove elimina el elemento de la lista y del índice
+func (c *LRU[V]) remove(element *list.Element) {
+	c.order.Remove(element)
+	delete(c.items, element.Value.(*entry[V]).key)
+}
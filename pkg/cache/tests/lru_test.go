@@ -0,0 +1,47 @@
+package tests
+
+import (
+	"testing"
+	"time"
+
+	"go-crime_map_backend/pkg/cache"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLRU(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	lru := cache.NewWithClock[int](2, time.Minute, func() time.Time { return now })
+
+	lru.Set("a", 1)
+	lru.Set("b", 2)
+	value, found := lru.Get("a")
+	assert.True(t, found)
+	assert.Equal(t, 1, value)
+
+	// Al llenarse se desaloja el usado hace más tiempo
+	lru.Set("c", 3)
+	_, found = lru.Get("b")
+	assert.False(t, found)
+	assert.Equal(t, 2, lru.Len())
+
+	// Los valores vencen tras el TTL y al reemplazarlos se renueva el vencimiento
+	now = now.Add(45 * time.Second)
+	lru.Set("a", 10)
+	now = now.Add(30 * time.Second)
+	value, found = lru.Get("a")
+	assert.True(t, found)
+	assert.Equal(t, 10, value)
+	_, found = lru.Get("c")
+	assert.False(t, found)
+	assert.Equal(t, 1, lru.Len())
+
+	lru.Set("b", 2)
+	assert.Equal(t, 1, lru.DeleteFunc(func(key string) bool { return key == "a" }))
+	_, found = lru.Get("a")
+	assert.False(t, found)
+	assert.Equal(t, 1, lru.Len())
+
+	lru.Purge()
+	assert.Zero(t, lru.Len())
+}
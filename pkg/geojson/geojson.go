@@ -0,0 +1,186 @@
+// Package geojson implementa la lectura y escritura de los objetos GeoJSON (RFC 7946)
+// que usa la API: geometrías, features y colecciones de features
+package geojson
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Tipos de geometría soportados
+const (
+	TypePoint        = "Point"
+	TypePolygon      = "Polygon"
+	TypeMultiPolygon = "MultiPolygon"
+)
+
+var (
+	// ErrInvalidGeoJSON se retorna cuando el documento no es GeoJSON válido
+	ErrInvalidGeoJSON = errors.New("el documento no es GeoJSON válido")
+
+	// ErrUnsupportedGeometry se retorna cuando la geometría no es del tipo esperado
+	ErrUnsupportedGeometry = errors.New("tipo de geometría no soportado")
+)
+
+// Position es un punto [longitud, latitud]; la altitud, si existe, se descarta al leer
+type Position [2]float64
+
+// UnmarshalJSON lee una posición con al menos longitud y latitud
+func (p *Position) UnmarshalJSON(data []byte) error {
+	var values []float64
+	if err := json.Unmarshal(data, &values); err != nil {
+		return fmt.Errorf("%w: posición inválida", ErrInvalidGeoJSON)
+	}
+	if len(values) < 2 {
+		return fmt.Errorf("%w: la posición debe tener longitud y latitud", ErrInvalidGeoJSON)
+	}
+	p[0], p[1] = values[0], values[1]
+	return nil
+}
+
+// Geometry es una geometría GeoJSON con sus coordenadas sin interpretar
+type Geometry struct {
+	Type        string          `json:"type"`
+	Coordinates json.RawMessage `json:"coordinates"`
+}
+
+// NewPoint crea una geometría Point
+func NewPoint(position Position) Geometry {
+	return newGeometry(TypePoint, position)
+}
+
+// NewPolygon crea una geometría Polygon a partir de sus anillos
+func NewPolygon(rings [][]Position) Geometry {
+	return newGeometry(TypePolygon, rings)
+}
+
+// NewMultiPolygon crea una geometría MultiPolygon a partir de sus polígonos
+func NewMultiPolygon(polygons [][][]Position) Geometry {
+	return newGeometry(TypeMultiPolygon, polygons)
+}
+
+// newGeometry serializa las coordenadas; los tipos usados siempre se pueden serializar
+func newGeometry(geometryType string, coordinates any) Geometry {
+	data, _ := json.Marshal(coordinates)
+	return Geometry{Type: geometryType, Coordinates: data}
+}
+
+// Point retorna la posición de una geometría Point
+func (g Geometry) Point() (Position, error) {
+	var position Position
+	if g.Type != TypePoint {
+		return position, fmt.Errorf("%w: %q, se esperaba Point", ErrUnsupportedGeometry, g.Type)
+	}
+	if err := json.Unmarshal(g.Coordinates, &position); err != nil {
+		return position, fmt.Errorf("%w: coordenadas inválidas", ErrInvalidGeoJSON)
+	}
+	return position, nil
+}
+
+// MultiPolygon retorna los polígonos de una geometría Polygon o MultiPolygon; un Polygon
+// se retorna como un MultiPolygon de un solo polígono
+func (g Geometry) MultiPolygon() ([][][]Position, error) {
+	switch g.Type {
+	case TypePolygon:
+		var rings [][]Position
+		if err := json.Unmarshal(g.Coordinates, &rings); err != nil {
+			return nil, fmt.Errorf("%w: coordenadas inválidas", ErrInvalidGeoJSON)
+		}
+		return [][][]Position{rings}, nil
+	case TypeMultiPolygon:
+		var polygons [][][]Position
+		if err := json.Unmarshal(g.Coordinates, &polygons); err != nil {
+			return nil, fmt.Errorf("%w: coordenadas inválidas", ErrInvalidGeoJSON)
+		}
+		return polygons, nil
+	default:
+		return nil, fmt.Errorf("%w: %q, se esperaba Polygon o MultiPolygon", ErrUnsupportedGeometry, g.Type)
+	}
+}
+
+// Feature es una geometría con propiedades
+type Feature struct {
+	Type       string         `json:"type"`
+	ID         any            `json:"id,omitempty"`
+	Geometry   *Geometry      `json:"geometry"`
+	Properties map[string]any `json:"properties"`
+}
+
+// NewFeature crea una feature con la geometría y las propiedades indicadas
+func NewFeature(geometry Geometry, properties map[string]any) Feature {
+	if properties == nil {
+		properties = map[string]any{}
+	}
+	return Feature{Type: "Feature", Geometry: &geometry, Properties: properties}
+}
+
+// StringProperty retorna una propiedad como texto; los números se formatean sin exponente
+func (f Feature) StringProperty(name string) (string, bool) {
+	switch value := f.Properties[name].(type) {
+	case string:
+		return value, true
+	case float64:
+		return fmt.Sprintf("%.0f", value), value == float64(int64(value))
+	default:
+		return "", false
+	}
+}
+
+// NumberProperty retorna una propiedad numérica; acepta números y textos con un número
+func (f Feature) NumberProperty(name string) (float64, bool) {
+	switch value := f.Properties[name].(type) {
+	case float64:
+		return value, true
+	case string:
+		number, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+		return number, err == nil
+	default:
+		return 0, false
+	}
+}
+
+// FeatureCollection es una colección de features
+type FeatureCollection struct {
+	Type     string    `json:"type"`
+	Features []Feature `json:"features"`
+}
+
+// NewFeatureCollection crea una colección con las features indicadas
+func NewFeatureCollection(features []Feature) FeatureCollection {
+	if features == nil {
+		features = []Feature{}
+	}
+	return FeatureCollection{Type: "FeatureCollection", Features: features}
+}
+
+// ParseFeatureCollection lee una FeatureCollection; una Feature suelta se retorna como una
+// colección de una sola feature
+func ParseFeatureCollection(data []byte) (*FeatureCollection, error) {
+	var header struct {
+		Type string `json:"type"`
+	}
+	if err := json.Unmarshal(data, &header); err != nil {
+		return nil, ErrInvalidGeoJSON
+	}
+
+	switch header.Type {
+	case "FeatureCollection":
+		var collection FeatureCollection
+		if err := json.Unmarshal(data, &collection); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidGeoJSON, err)
+		}
+		return &collection, nil
+	case "Feature":
+		var feature Feature
+		if err := json.Unmarshal(data, &feature); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidGeoJSON, err)
+		}
+		collection := NewFeatureCollection([]Feature{feature})
+		return &collection, nil
+	default:
+		return nil, fmt.Errorf("%w: se esperaba FeatureCollection o Feature", ErrInvalidGeoJSON)
+	}
+}
@@ -0,0 +1,75 @@
+package tests
+
+import (
+	"encoding/json"
+	"testing"
+
+	"go-crime_map_backend/pkg/geojson"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseFeatureCollection(t *testing.T) {
+	collection, err := geojson.ParseFeatureCollection([]byte(`{
+		"type": "FeatureCollection",
+		"features": [{
+			"type": "Feature",
+			"id": 7,
+			"properties": {"nombre": "Recoleta", "comuna": 2},
+			"geometry": {"type": "Polygon", "coordinates": [[[-58.4, -34.6, 25], [-58.3, -34.6, 25], [-58.3, -34.5, 25], [-58.4, -34.6, 25]]]}
+		}]
+	}`))
+	require.NoError(t, err)
+	require.Len(t, collection.Features, 1)
+
+	feature := collection.Features[0]
+	name, ok := feature.StringProperty("nombre")
+	assert.True(t, ok)
+	assert.Equal(t, "Recoleta", name)
+	comuna, ok := feature.StringProperty("comuna")
+	assert.True(t, ok)
+	assert.Equal(t, "2", comuna)
+	number, ok := feature.NumberProperty("comuna")
+	assert.True(t, ok)
+	assert.Equal(t, 2.0, number)
+	_, ok = feature.NumberProperty("nombre")
+	assert.False(t, ok)
+
+	// Un Polygon se lee como un MultiPolygon de un polígono y la altitud se descarta
+	polygons, err := feature.Geometry.MultiPolygon()
+	require.NoError(t, err)
+	require.Len(t, polygons, 1)
+	assert.Equal(t, geojson.Position{-58.4, -34.6}, polygons[0][0][0])
+
+	_, err = feature.Geometry.Point()
+	assert.ErrorIs(t, err, geojson.ErrUnsupportedGeometry)
+}
+
+func TestParseFeatureCollectionErrors(t *testing.T) {
+	for name, data := range map[string]string{
+		"no es JSON":        `[`,
+		"geometría suelta":  `{"type": "Point", "coordinates": [1, 2]}`,
+		"posición truncada": `{"type": "Feature", "properties": {}, "geometry": {"type": "Point", "coordinates": [1]}}`,
+	} {
+		collection, err := geojson.ParseFeatureCollection([]byte(data))
+		if err == nil {
+			// La posición solo se lee al pedir las coordenadas de la geometría
+			_, err = collection.Features[0].Geometry.Point()
+		}
+		assert.ErrorIs(t, err, geojson.ErrInvalidGeoJSON, name)
+	}
+}
+
+func TestGeometryRoundTrip(t *testing.T) {
+	feature := geojson.NewFeature(geojson.NewPoint(geojson.Position{-58.38, -34.60}), map[string]any{"count": 3})
+	data, err := json.Marshal(geojson.NewFeatureCollection([]geojson.Feature{feature}))
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"type":"FeatureCollection","features":[{"type":"Feature","geometry":{"type":"Point","coordinates":[-58.38,-34.6]},"properties":{"count":3}}]}`, string(data))
+
+	collection, err := geojson.ParseFeatureCollection(data)
+	require.NoError(t, err)
+	point, err := collection.Features[0].Geometry.Point()
+	require.NoError(t, err)
+	assert.Equal(t, geojson.Position{-58.38, -34.60}, point)
+}
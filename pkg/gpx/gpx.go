@@ -0,0 +1,108 @@
+// Package gpx escribe documentos GPX 1.1 con waypoints de forma incremental, para cargar
+// puntos en navegadores GPS sin armar el documento completo en memoria
+package gpx
+
+import (
+	"encoding/xml"
+	"io"
+	"strconv"
+	"time"
+)
+
+// namespace es el espacio de nombres de GPX 1.1
+const namespace = "http://www.topografix.com/GPX/1/1"
+
+// Waypoint es un punto del documento
+type Waypoint struct {
+	Latitude    float64
+	Longitude   float64
+	Time        time.Time // Cero lo omite
+	Name        string
+	Description string
+	Symbol      string // Símbolo del punto en el GPS, vacío para el de por defecto
+	Type        string // Clasificación del punto
+}
+
+// Writer escribe un documento GPX; Close cierra el documento y debe llamarse al terminar
+type Writer struct {
+	encoder *xml.Encoder
+	err     error
+}
+
+// NewWriter escribe el encabezado del documento con la aplicación que lo genera y su nombre
+func NewWriter(w io.Writer, creator, name string) (*Writer, error) {
+	writer := &Writer{encoder: xml.NewEncoder(w)}
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return nil, err
+	}
+	writer.start("gpx",
+		xml.Attr{Name: xml.Name{Local: "version"}, Value: "1.1"},
+		xml.Attr{Name: xml.Name{Local: "creator"}, Value: creator},
+		xml.Attr{Name: xml.Name{Local: "xmlns"}, Value: namespace},
+	)
+	writer.start("metadata")
+	writer.element("name", name)
+	writer.end("metadata")
+	return writer, writer.err
+}
+
+// WriteWaypoint agrega un waypoint al documento. Los elementos siguen el orden del esquema
+// GPX 1.1, que los validadores exigen
+func (w *Writer) WriteWaypoint(waypoint Waypoint) error {
+	w.start("wpt",
+		xml.Attr{Name: xml.Name{Local: "lat"}, Value: strconv.FormatFloat(waypoint.Latitude, 'f', -1, 64)},
+		xml.Attr{Name: xml.Name{Local: "lon"}, Value: strconv.FormatFloat(waypoint.Longitude, 'f', -1, 64)},
+	)
+	if !waypoint.Time.IsZero() {
+		w.element("time", waypoint.Time.UTC().Format(time.RFC3339))
+	}
+	w.element("name", waypoint.Name)
+	if waypoint.Description != "" {
+		w.element("desc", waypoint.Description)
+	}
+	if waypoint.Symbol != "" {
+		w.element("sym", waypoint.Symbol)
+	}
+	if waypoint.Type != "" {
+		w.element("type", waypoint.Type)
+	}
+	w.end("wpt")
+	return w.err
+}
+
+// Flush envía al destino lo escrito hasta el momento
+func (w *Writer) Flush() error {
+	if w.err == nil {
+		w.err = w.encoder.Flush()
+	}
+	return w.err
+}
+
+// Close cierra el documento
+func (w *Writer) Close() error {
+	w.end("gpx")
+	return w.Flush()
+}
+
+// start abre un elemento; los errores se conservan hasta la siguiente operación pública
+func (w *Writer) start(name string, attrs ...xml.Attr) {
+	if w.err == nil {
+		w.err = w.encoder.EncodeToken(xml.StartElement{Name: xml.Name{Local: name}, Attr: attrs})
+	}
+}
+
+// end cierra un elemento
+func (w *Writer) end(name string) {
+	if w.err == nil {
+		w.err = w.encoder.EncodeToken(xml.EndElement{Name: xml.Name{Local: name}})
+	}
+}
+
+// element escribe un elemento con texto
+func (w *Writer) element(name, text string) {
+	w.start(name)
+	if w.err == nil {
+		w.err = w.encoder.EncodeToken(xml.CharData(text))
+	}
+	w.end(name)
+}
@@ -0,0 +1,50 @@
+package tests
+
+import (
+	"bytes"
+	"encoding/xml"
+	"testing"
+	"time"
+
+	"go-crime_map_backend/pkg/gpx"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriter(t *testing.T) {
+	var buf bytes.Buffer
+	writer, err := gpx.NewWriter(&buf, "tests", "Delitos")
+	require.NoError(t, err)
+	require.NoError(t, writer.WriteWaypoint(gpx.Waypoint{
+		Latitude:  -34.6037,
+		Longitude: -58.3816,
+		Time:      time.Date(2026, 3, 10, 12, 30, 0, 0, time.UTC),
+		Name:      "ROBO",
+		Symbol:    "Flag, Red",
+	}))
+	require.NoError(t, writer.WriteWaypoint(gpx.Waypoint{Latitude: -31.4201, Longitude: -64.1888, Name: "HURTO"}))
+	require.NoError(t, writer.Close())
+
+	var doc struct {
+		XMLName   xml.Name `xml:"http://www.topografix.com/GPX/1/1 gpx"`
+		Version   string   `xml:"version,attr"`
+		Name      string   `xml:"metadata>name"`
+		Waypoints []struct {
+			Lat    float64 `xml:"lat,attr"`
+			Lon    float64 `xml:"lon,attr"`
+			Time   string  `xml:"time"`
+			Name   string  `xml:"name"`
+			Symbol string  `xml:"sym"`
+		} `xml:"wpt"`
+	}
+	require.NoError(t, xml.Unmarshal(buf.Bytes(), &doc))
+	assert.Equal(t, "1.1", doc.Version)
+	assert.Equal(t, "Delitos", doc.Name)
+	require.Len(t, doc.Waypoints, 2)
+	assert.Equal(t, -34.6037, doc.Waypoints[0].Lat)
+	assert.Equal(t, -58.3816, doc.Waypoints[0].Lon)
+	assert.Equal(t, "2026-03-10T12:30:00Z", doc.Waypoints[0].Time)
+	assert.Equal(t, "Flag, Red", doc.Waypoints[0].Symbol)
+	assert.Empty(t, doc.Waypoints[1].Time, "sin fecha se omite el elemento")
+}
@@ -0,0 +1,142 @@
+// Package kml escribe documentos KML 2.2 de forma incremental, para exportar puntos a
+// Google Earth sin armar el documento completo en memoria
+package kml
+
+import (
+	"encoding/xml"
+	"io"
+	"strconv"
+	"time"
+)
+
+// namespace es el espacio de nombres de KML 2.2
+const namespace = "http://www.opengis.net/kml/2.2"
+
+// Style es un estilo de ícono reutilizable por los placemarks
+type Style struct {
+	ID    string
+	Color string  // Color del ícono en hexadecimal aabbggrr, como lo define KML
+	Icon  string  // URL del ícono
+	Scale float64 // Tamaño relativo del ícono, 1 si es cero
+}
+
+// Placemark es un punto del documento
+type Placemark struct {
+	Name        string
+	Description string
+	StyleID     string    // ID del estilo, vacío para el estilo por defecto
+	When        time.Time // Instante del punto para el control de tiempo; cero lo omite
+	Latitude    float64
+	Longitude   float64
+	Data        []Data // Datos adicionales, mostrados como tabla en el globo
+}
+
+// Data es un dato adicional de un placemark
+type Data struct {
+	Name  string
+	Value string
+}
+
+// Writer escribe un documento KML; Close cierra el documento y debe llamarse al terminar
+type Writer struct {
+	encoder *xml.Encoder
+	err     error
+}
+
+// NewWriter escribe el encabezado del documento con su nombre y sus estilos
+func NewWriter(w io.Writer, name string, styles []Style) (*Writer, error) {
+	writer := &Writer{encoder: xml.NewEncoder(w)}
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return nil, err
+	}
+	writer.start("kml", xml.Attr{Name: xml.Name{Local: "xmlns"}, Value: namespace})
+	writer.start("Document")
+	writer.element("name", name)
+	for _, style := range styles {
+		scale := style.Scale
+		if scale == 0 {
+			scale = 1
+		}
+		writer.start("Style", xml.Attr{Name: xml.Name{Local: "id"}, Value: style.ID})
+		writer.start("IconStyle")
+		writer.element("color", style.Color)
+		writer.element("scale", strconv.FormatFloat(scale, 'f', -1, 64))
+		writer.start("Icon")
+		writer.element("href", style.Icon)
+		writer.end("Icon")
+		writer.end("IconStyle")
+		writer.end("Style")
+	}
+	return writer, writer.err
+}
+
+// WritePlacemark agrega un punto al documento
+func (w *Writer) WritePlacemark(placemark Placemark) error {
+	w.start("Placemark")
+	w.element("name", placemark.Name)
+	if placemark.Description != "" {
+		w.element("description", placemark.Description)
+	}
+	if !placemark.When.IsZero() {
+		w.start("TimeStamp")
+		w.element("when", placemark.When.UTC().Format(time.RFC3339))
+		w.end("TimeStamp")
+	}
+	if placemark.StyleID != "" {
+		w.element("styleUrl", "#"+placemark.StyleID)
+	}
+	if len(placemark.Data) > 0 {
+		w.start("ExtendedData")
+		for _, data := range placemark.Data {
+			w.start("Data", xml.Attr{Name: xml.Name{Local: "name"}, Value: data.Name})
+			w.element("value", data.Value)
+			w.end("Data")
+		}
+		w.end("ExtendedData")
+	}
+	w.start("Point")
+	// KML ordena las coordenadas como longitud,latitud
+	w.element("coordinates", strconv.FormatFloat(placemark.Longitude, 'f', -1, 64)+","+
+		strconv.FormatFloat(placemark.Latitude, 'f', -1, 64))
+	w.end("Point")
+	w.end("Placemark")
+	return w.err
+}
+
+// Flush envía al destino lo escrito hasta el momento
+func (w *Writer) Flush() error {
+	if w.err == nil {
+		w.err = w.encoder.Flush()
+	}
+	return w.err
+}
+
+// Close cierra el documento
+func (w *Writer) Close() error {
+	w.end("Document")
+	w.end("kml")
+	return w.Flush()
+}
+
+// start abre un elemento; los errores se conservan hasta la siguiente operación pública
+func (w *Writer) start(name string, attrs ...xml.Attr) {
+	if w.err == nil {
+		w.err = w.encoder.EncodeToken(xml.StartElement{Name: xml.Name{Local: name}, Attr: attrs})
+	}
+}
+
+// end cierra un elemento
+func (w *Writer) end(name string) {
+	if w.err == nil {
+		w.err = w.encoder.EncodeToken(xml.EndElement{Name: xml.Name{Local: name}})
+	}
+}
+
+// element escribe un elemento con texto
+func (w *Writer) element(name, text string) {
+	w.start(name)
+	if w.err == nil {
+		w.err = w.encoder.EncodeToken(xml.CharData(text))
+	}
+	w.end(name)
+}
@@ -0,0 +1,61 @@
+package tests
+
+import (
+	"bytes"
+	"encoding/xml"
+	"testing"
+	"time"
+
+	"go-crime_map_backend/pkg/kml"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriter(t *testing.T) {
+	var buf bytes.Buffer
+	writer, err := kml.NewWriter(&buf, "Delitos", []kml.Style{{ID: "robo", Color: "ff0000ff", Icon: "icon.png"}})
+	require.NoError(t, err)
+	require.NoError(t, writer.WritePlacemark(kml.Placemark{
+		Name:      "Robo <1>",
+		StyleID:   "robo",
+		When:      time.Date(2026, 3, 10, 9, 30, 0, 0, time.FixedZone("ART", -3*3600)),
+		Latitude:  -34.6037,
+		Longitude: -58.3816,
+		Data:      []kml.Data{{Name: "status", Value: "VERIFIED"}},
+	}))
+	require.NoError(t, writer.Close())
+
+	// El documento es XML válido y escapa el texto
+	var doc struct {
+		Document struct {
+			Name   string `xml:"name"`
+			Styles []struct {
+				ID    string `xml:"id,attr"`
+				Color string `xml:"IconStyle>color"`
+			} `xml:"Style"`
+			Placemarks []struct {
+				Name        string `xml:"name"`
+				When        string `xml:"TimeStamp>when"`
+				StyleURL    string `xml:"styleUrl"`
+				Coordinates string `xml:"Point>coordinates"`
+				Data        []struct {
+					Name  string `xml:"name,attr"`
+					Value string `xml:"value"`
+				} `xml:"ExtendedData>Data"`
+			} `xml:"Placemark"`
+		} `xml:"Document"`
+	}
+	require.NoError(t, xml.Unmarshal(buf.Bytes(), &doc))
+	assert.Equal(t, "Delitos", doc.Document.Name)
+	require.Len(t, doc.Document.Styles, 1)
+	assert.Equal(t, "ff0000ff", doc.Document.Styles[0].Color)
+	require.Len(t, doc.Document.Placemarks, 1)
+	placemark := doc.Document.Placemarks[0]
+	assert.Equal(t, "Robo <1>", placemark.Name)
+	assert.Equal(t, "2026-03-10T12:30:00Z", placemark.When)
+	assert.Equal(t, "#robo", placemark.StyleURL)
+	assert.Equal(t, "-58.3816,-34.6037", placemark.Coordinates, "KML usa longitud,latitud")
+	require.Len(t, placemark.Data, 1)
+	assert.Equal(t, "VERIFIED", placemark.Data[0].Value)
+}
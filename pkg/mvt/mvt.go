@@ -0,0 +1,219 @@
+// Package mvt codifica tiles vectoriales en el formato Mapbox Vector Tile 2.1, serializados
+// en Protocol Buffers, y calcula la grilla de tiles de la proyección Web Mercator
+package mvt
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+// DefaultExtent es la cantidad de unidades por lado de un tile
+const DefaultExtent = 4096
+
+// Números de campo y tipos del esquema vector_tile.proto
+const (
+	wireVarint = 0
+	wireDouble = 1
+	wireBytes  = 2
+
+	tileLayers = 3
+
+	layerName     = 1
+	layerFeatures = 2
+	layerKeys     = 3
+	layerValues   = 4
+	layerExtent   = 5
+	layerVersion  = 15
+
+	featureTags     = 2
+	featureType     = 3
+	featureGeometry = 4
+
+	valueString = 1
+	valueDouble = 3
+	valueSint   = 6
+	valueBool   = 7
+
+	geomTypePoint = 1
+	commandMoveTo = 1
+)
+
+// Layer es una capa de un tile con features de tipo punto. Las claves y los valores de las
+// propiedades se comparten entre las features, como indica el formato
+type Layer struct {
+	name     string
+	extent   uint32
+	keys     []string
+	keyIndex map[string]uint32
+	values   [][]byte
+	valIndex map[string]uint32
+	features [][]byte
+}
+
+// NewLayer crea una capa vacía con el nombre y la resolución indicados
+func NewLayer(name string, extent uint32) *Layer {
+	return &Layer{
+		name:     name,
+		extent:   extent,
+		keyIndex: make(map[string]uint32),
+		valIndex: make(map[string]uint32),
+	}
+}
+
+// Len retorna la cantidad de features de la capa
+func (l *Layer) Len() int {
+	return len(l.features)
+}
+
+// AddPoint agrega una feature de tipo punto en las coordenadas del tile indicadas. Las
+// propiedades pueden ser string, bool, enteros o float64; se codifican ordenadas por clave
+// para que el mismo contenido produzca siempre los mismos bytes
+func (l *Layer) AddPoint(x, y int, properties map[string]any) error {
+	keys := make([]string, 0, len(properties))
+	for key := range properties {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var tags []uint64
+	for _, key := range keys {
+		value, err := encodeValue(properties[key])
+		if err != nil {
+			return fmt.Errorf("propiedad %s: %w", key, err)
+		}
+		tags = append(tags, uint64(l.key(key)), uint64(l.value(value)))
+	}
+
+	var feature buffer
+	if len(tags) > 0 {
+		feature.packed(featureTags, tags)
+	}
+	feature.varintField(featureType, geomTypePoint)
+	feature.packed(featureGeometry, []uint64{commandMoveTo | 1<<3, zigzag(int64(x)), zigzag(int64(y))})
+	l.features = append(l.features, feature)
+	return nil
+}
+
+// key retorna el índice de la clave, agregándola si es nueva
+func (l *Layer) key(key string) uint32 {
+	if index, found := l.keyIndex[key]; found {
+		return index
+	}
+	index := uint32(len(l.keys))
+	l.keys = append(l.keys, key)
+	l.keyIndex[key] = index
+	return index
+}
+
+// value retorna el índice del valor codificado, agregándolo si es nuevo
+func (l *Layer) value(encoded []byte) uint32 {
+	if index, found := l.valIndex[string(encoded)]; found {
+		return index
+	}
+	index := uint32(len(l.values))
+	l.values = append(l.values, encoded)
+	l.valIndex[string(encoded)] = index
+	return index
+}
+
+// marshal serializa la capa como mensaje Layer
+func (l *Layer) marshal() []byte {
+	var b buffer
+	b.varintField(layerVersion, 2)
+	b.bytesField(layerName, []byte(l.name))
+	for _, feature := range l.features {
+		b.bytesField(layerFeatures, feature)
+	}
+	for _, key := range l.keys {
+		b.bytesField(layerKeys, []byte(key))
+	}
+	for _, value := range l.values {
+		b.bytesField(layerValues, value)
+	}
+	b.varintField(layerExtent, uint64(l.extent))
+	return b
+}
+
+// Marshal serializa las capas como un tile; las capas sin features se omiten
+func Marshal(layers ...*Layer) []byte {
+	var b buffer
+	for _, layer := range layers {
+		if layer.Len() > 0 {
+			b.bytesField(tileLayers, layer.marshal())
+		}
+	}
+	return b
+}
+
+// encodeValue serializa un valor de propiedad como mensaje Value
+func encodeValue(value any) ([]byte, error) {
+	var b buffer
+	switch v := value.(type) {
+	case string:
+		b.bytesField(valueString, []byte(v))
+	case bool:
+		flag := uint64(0)
+		if v {
+			flag = 1
+		}
+		b.varintField(valueBool, flag)
+	case int:
+		b.varintField(valueSint, zigzag(int64(v)))
+	case int64:
+		b.varintField(valueSint, zigzag(v))
+	case float64:
+		b.tag(valueDouble, wireDouble)
+		bits := math.Float64bits(v)
+		for i := 0; i < 8; i++ {
+			b = append(b, byte(bits>>(8*i)))
+		}
+	default:
+		return nil, fmt.Errorf("tipo de valor no soportado %T", value)
+	}
+	return b, nil
+}
+
+// zigzag codifica un entero con signo para que los valores cercanos a cero ocupen pocos bytes
+func zigzag(v int64) uint64 {
+	return uint64((v << 1) ^ (v >> 63))
+}
+
+// buffer acumula un mensaje de Protocol Buffers
+type buffer []byte
+
+// varint agrega un entero sin signo de longitud variable
+func (b *buffer) varint(v uint64) {
+	for v >= 0x80 {
+		*b = append(*b, byte(v)|0x80)
+		v >>= 7
+	}
+	*b = append(*b, byte(v))
+}
+
+// tag agrega el número de campo y el tipo de codificación
+func (b *buffer) tag(field, wire int) {
+	b.varint(uint64(field<<3 | wire))
+}
+
+// varintField agrega un campo entero
+func (b *buffer) varintField(field int, v uint64) {
+	b.tag(field, wireVarint)
+	b.varint(v)
+}
+
+// bytesField agrega un campo de longitud delimitada: texto o un mensaje anidado
+func (b *buffer) bytesField(field int, data []byte) {
+	b.tag(field, wireBytes)
+	b.varint(uint64(len(data)))
+	*b = append(*b, data...)
+}
+
+// packed agrega un campo repetido de enteros en su forma empaquetada
+func (b *buffer) packed(field int, values []uint64) {
+	var packed buffer
+	for _, v := range values {
+		packed.varint(v)
+	}
+	b.bytesField(field, packed)
+}
@@ -0,0 +1,61 @@
+package tests
+
+import (
+	"testing"
+
+	"go-crime_map_backend/pkg/mvt"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMarshal(t *testing.T) {
+	layer := mvt.NewLayer("crimes", mvt.DefaultExtent)
+	require.NoError(t, layer.AddPoint(10, 20, map[string]any{"type": "ROBO"}))
+	assert.Error(t, layer.AddPoint(0, 0, map[string]any{"tags": []string{"a"}}))
+
+	feature := []byte{
+		0x12, 2, 0, 0, // tags: clave 0, valor 0
+		0x18, 1, // tipo punto
+		0x22, 3, 0x09, 20, 40, // MoveTo(1) con x=10 e y=20 en zigzag
+	}
+	var expected []byte
+	expected = append(expected, 0x78, 2)
+	expected = append(expected, 0x0A, 6)
+	expected = append(expected, "crimes"...)
+	expected = append(expected, 0x12, byte(len(feature)))
+	expected = append(expected, feature...)
+	expected = append(expected, 0x1A, 4)
+	expected = append(expected, "type"...)
+	expected = append(expected, 0x22, 6, 0x0A, 4)
+	expected = append(expected, "ROBO"...)
+	expected = append(expected, 0x28, 0x80, 0x20)
+	expected = append([]byte{0x1A, byte(len(expected))}, expected...)
+
+	assert.Equal(t, expected, mvt.Marshal(layer, mvt.NewLayer("vacía", mvt.DefaultExtent)))
+
+	// Las claves y los valores repetidos se comparten entre features
+	require.NoError(t, layer.AddPoint(30, 40, map[string]any{"type": "ROBO", "count": 3}))
+	assert.Equal(t, 2, layer.Len())
+	assert.Less(t, len(mvt.Marshal(layer)), 2*len(expected))
+}
+
+func TestTiles(t *testing.T) {
+	assert.True(t, mvt.TileID{Z: 0}.IsValid())
+	assert.False(t, mvt.TileID{Z: 1, X: 2}.IsValid())
+	assert.False(t, mvt.TileID{Z: mvt.MaxZoom + 1}.IsValid())
+
+	minLat, minLon, maxLat, maxLon := mvt.TileID{Z: 1, X: 0, Y: 0}.Bounds(mvt.DefaultExtent, 0)
+	assert.InDelta(t, 0, minLat, 1e-9)
+	assert.Equal(t, -180.0, minLon)
+	assert.InDelta(t, 85.0511, maxLat, 1e-4)
+	assert.InDelta(t, 0, maxLon, 1e-9)
+
+	// El Obelisco está en el tile 14/5534/9872, a 48 unidades de su borde este
+	obelisco := mvt.TileID{Z: 14, X: 5534, Y: 9872}
+	x, y := obelisco.Project(-34.6037, -58.3816, mvt.DefaultExtent)
+	assert.Equal(t, 4048, x)
+	assert.Equal(t, 1507, y)
+	assert.Equal(t, []mvt.TileID{obelisco}, mvt.TilesAt(-34.6037, -58.3816, 14, mvt.DefaultExtent, 0))
+	assert.Equal(t, []mvt.TileID{obelisco, {Z: 14, X: 5535, Y: 9872}}, mvt.TilesAt(-34.6037, -58.3816, 14, mvt.DefaultExtent, 64))
+}
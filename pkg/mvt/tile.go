@@ -0,0 +1,81 @@
+package mvt
+
+import "math"
+
+const (
+	// MaxZoom es el nivel de zoom máximo aceptado
+	MaxZoom = 22
+
+	// maxLatitude es la latitud en que la proyección Web Mercator se vuelve un cuadrado
+	maxLatitude = 85.05112878
+)
+
+// TileID identifica un tile de la grilla XYZ: x crece hacia el este e y hacia el sur
+type TileID struct {
+	Z, X, Y int
+}
+
+// IsValid indica si el zoom está entre 0 y MaxZoom y la columna y la fila dentro de la grilla
+func (t TileID) IsValid() bool {
+	if t.Z < 0 || t.Z > MaxZoom {
+		return false
+	}
+	n := 1 << t.Z
+	return t.X >= 0 && t.X < n && t.Y >= 0 && t.Y < n
+}
+
+// Bounds retorna la latitud y longitud mínimas y máximas que cubre el tile, ampliadas en
+// buffer unidades de extent por lado
+func (t TileID) Bounds(extent uint32, buffer int) (minLat, minLon, maxLat, maxLon float64) {
+	n := float64(int(1) << t.Z)
+	margin := float64(buffer) / float64(extent)
+	minLon = tileLongitude(float64(t.X)-margin, n)
+	maxLon = tileLongitude(float64(t.X+1)+margin, n)
+	maxLat = tileLatitude(float64(t.Y)-margin, n)
+	minLat = tileLatitude(float64(t.Y+1)+margin, n)
+	return minLat, math.Max(minLon, -180), maxLat, math.Min(maxLon, 180)
+}
+
+// Project convierte la coordenada en unidades del tile, con el origen en la esquina noroeste;
+// los puntos fuera del tile quedan fuera del rango 0 a extent
+func (t TileID) Project(lat, lon float64, extent uint32) (int, int) {
+	x, y := fractional(lat, lon, t.Z)
+	return int(math.Floor((x - float64(t.X)) * float64(extent))),
+		int(math.Floor((y - float64(t.Y)) * float64(extent)))
+}
+
+// TilesAt retorna los tiles del zoom indicado que incluyen la coordenada, considerando que
+// cada tile se amplía en buffer unidades de extent por lado
+func TilesAt(lat, lon float64, z int, extent uint32, buffer int) []TileID {
+	x, y := fractional(lat, lon, z)
+	margin := float64(buffer) / float64(extent)
+	last := (1 << z) - 1
+	var tiles []TileID
+	for tx := max(int(math.Floor(x-margin)), 0); tx <= min(int(math.Floor(x+margin)), last); tx++ {
+		for ty := max(int(math.Floor(y-margin)), 0); ty <= min(int(math.Floor(y+margin)), last); ty++ {
+			tiles = append(tiles, TileID{Z: z, X: tx, Y: ty})
+		}
+	}
+	return tiles
+}
+
+// fractional retorna la posición de la coordenada en la grilla del zoom, en tiles
+func fractional(lat, lon float64, z int) (float64, float64) {
+	n := float64(int(1) << z)
+	lat = math.Max(-maxLatitude, math.Min(maxLatitude, lat))
+	rad := lat * math.Pi / 180
+	x := (lon + 180) / 360 * n
+	y := (1 - math.Log(math.Tan(rad)+1/math.Cos(rad))/math.Pi) / 2 * n
+	return x, y
+}
+
+// tileLongitude retorna la longitud del borde oeste de la columna x
+func tileLongitude(x, n float64) float64 {
+	return x/n*360 - 180
+}
+
+// tileLatitude retorna la latitud del borde norte de la fila y
+func tileLatitude(y, n float64) float64 {
+	lat := math.Atan(math.Sinh(math.Pi*(1-2*y/n))) * 180 / math.Pi
+	return math.Max(-90, math.Min(90, lat))
+}
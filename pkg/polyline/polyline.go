@@ -0,0 +1,78 @@
+// Package polyline decodifica líneas en el formato de polilínea codificada (Encoded Polyline
+// Algorithm Format), usado por los servicios de rutas para transmitir recorridos
+package polyline
+
+import (
+	"errors"
+	"math"
+)
+
+// ErrInvalidPolyline se retorna cuando el texto no es una polilínea codificada válida
+var ErrInvalidPolyline = errors.New("polilínea codificada inválida")
+
+// Decode retorna los vértices de la polilínea como pares latitud, longitud. precision es la
+// cantidad de decimales de las coordenadas: 5 en el formato habitual y 6 en algunos servicios
+func Decode(encoded string, precision int) ([][2]float64, error) {
+	factor := math.Pow(10, float64(precision))
+	var (
+		points   [][2]float64
+		lat, lon int64
+	)
+	for i := 0; i < len(encoded); {
+		var deltas [2]int64
+		for k := range deltas {
+			var result int64
+			shift := uint(0)
+			for {
+				if i >= len(encoded) || shift > 60 {
+					return nil, ErrInvalidPolyline
+				}
+				b := int64(encoded[i]) - 63
+				i++
+				if b < 0 || b > 63 {
+					return nil, ErrInvalidPolyline
+				}
+				result |= (b & 0x1f) << shift
+				shift += 5
+				if b < 0x20 {
+					break
+				}
+			}
+			// El bit menos significativo indica el signo
+			if result&1 != 0 {
+				deltas[k] = ^(result >> 1)
+			} else {
+				deltas[k] = result >> 1
+			}
+		}
+		lat += deltas[0]
+		lon += deltas[1]
+		points = append(points, [2]float64{float64(lat) / factor, float64(lon) / factor})
+	}
+	return points, nil
+}
+
+// Encode codifica los vértices, pares latitud, longitud, con la precisión indicada
+func Encode(points [][2]float64, precision int) string {
+	factor := math.Pow(10, float64(precision))
+	var (
+		encoded          []byte
+		prevLat, prevLon int64
+	)
+	for _, point := range points {
+		lat, lon := int64(math.Round(point[0]*factor)), int64(math.Round(point[1]*factor))
+		for _, delta := range []int64{lat - prevLat, lon - prevLon} {
+			value := delta << 1
+			if delta < 0 {
+				value = ^value
+			}
+			for value >= 0x20 {
+				encoded = append(encoded, byte((0x20|(value&0x1f))+63))
+				value >>= 5
+			}
+			encoded = append(encoded, byte(value+63))
+		}
+		prevLat, prevLon = lat, lon
+	}
+	return string(encoded)
+}
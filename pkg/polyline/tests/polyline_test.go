@@ -0,0 +1,35 @@
+package tests
+
+import (
+	"testing"
+
+	"go-crime_map_backend/pkg/polyline"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecode(t *testing.T) {
+	// Ejemplo de la documentación del formato
+	points, err := polyline.Decode("_p~iF~ps|U_ulLnnqC_mqNvxq`@", 5)
+	require.NoError(t, err)
+	require.Len(t, points, 3)
+	assert.InDelta(t, 38.5, points[0][0], 1e-9)
+	assert.InDelta(t, -120.2, points[0][1], 1e-9)
+	assert.InDelta(t, 40.7, points[1][0], 1e-9)
+	assert.InDelta(t, -120.95, points[1][1], 1e-9)
+	assert.InDelta(t, 43.252, points[2][0], 1e-9)
+	assert.InDelta(t, -126.453, points[2][1], 1e-9)
+
+	assert.Equal(t, "_p~iF~ps|U_ulLnnqC_mqNvxq`@", polyline.Encode(points, 5))
+
+	route := [][2]float64{{-34.603722, -58.381592}, {-34.608333, -58.371234}}
+	decoded, err := polyline.Decode(polyline.Encode(route, 6), 6)
+	require.NoError(t, err)
+	assert.Equal(t, route, decoded)
+
+	_, err = polyline.Decode("_p~iF~ps|U_", 5)
+	assert.ErrorIs(t, err, polyline.ErrInvalidPolyline, "vértice incompleto")
+	_, err = polyline.Decode("_p~iF ~ps|U", 5)
+	assert.ErrorIs(t, err, polyline.ErrInvalidPolyline, "carácter fuera del alfabeto")
+}
@@ -0,0 +1,286 @@
+// Package proj convierte a WGS84 las coordenadas de los sistemas de referencia habituales en
+// los datos oficiales: geográficas, Transversa de Mercator (UTM y Gauss-Krüger, como las fajas
+// POSGAR y Campo Inchauspe) y Mercator, incluida la de los mapas web
+package proj
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Projection es el método de proyección de un sistema de referencia
+type Projection string
+
+// Proyecciones soportadas; los sistemas geográficos no tienen proyección
+const (
+	ProjectionNone               Projection = ""
+	ProjectionTransverseMercator Projection = "transverse_mercator"
+	ProjectionMercator           Projection = "mercator"
+	ProjectionWebMercator        Projection = "web_mercator"
+)
+
+// ErrUnsupportedCRS se retorna cuando el sistema de referencia no se puede convertir a WGS84
+var ErrUnsupportedCRS = errors.New("sistema de coordenadas no soportado")
+
+// Ellipsoid es la figura de la Tierra de un datum
+type Ellipsoid struct {
+	SemiMajor         float64 // Semieje mayor en metros
+	InverseFlattening float64 // Inversa del achatamiento, 0 para una esfera
+}
+
+// Elipsoides de los datums soportados
+var (
+	WGS84Ellipsoid    = Ellipsoid{SemiMajor: 6378137, InverseFlattening: 298.257223563}
+	GRS80             = Ellipsoid{SemiMajor: 6378137, InverseFlattening: 298.257222101}
+	International1924 = Ellipsoid{SemiMajor: 6378388, InverseFlattening: 297}
+)
+
+// eccentricitySquared retorna el cuadrado de la primera excentricidad
+func (e Ellipsoid) eccentricitySquared() float64 {
+	if e.InverseFlattening == 0 {
+		return 0
+	}
+	f := 1 / e.InverseFlattening
+	return f * (2 - f)
+}
+
+// CRS es un sistema de referencia de coordenadas. Los ángulos se expresan en grados y las
+// distancias en metros
+type CRS struct {
+	Name             string
+	Projection       Projection
+	Ellipsoid        Ellipsoid
+	LatitudeOfOrigin float64
+	CentralMeridian  float64
+	ScaleFactor      float64
+	FalseEasting     float64
+	FalseNorthing    float64
+	StandardParallel float64 // Paralelo de escala verdadera de Mercator, si no usa ScaleFactor
+	PrimeMeridian    float64 // Longitud del meridiano de origen respecto de Greenwich
+	Unit             float64 // Metros o grados por unidad de las coordenadas, 1 si es cero
+
+	// ToWGS84 son los parámetros de Helmert del datum: dx, dy, dz en metros y, si son siete,
+	// rx, ry, rz en segundos de arco y la escala en partes por millón. Vacío si el datum
+	// coincide con WGS84
+	ToWGS84 []float64
+}
+
+// Geographic indica si las coordenadas son longitud y latitud
+func (c *CRS) Geographic() bool {
+	return c.Projection == ProjectionNone
+}
+
+// LatLon convierte un par x, y del sistema (longitud y latitud si es geográfico, este y norte
+// si es proyectado) a latitud y longitud WGS84 en grados
+func (c *CRS) LatLon(x, y float64) (lat, lon float64) {
+	unit := c.Unit
+	if unit == 0 {
+		unit = 1
+	}
+	x, y = x*unit, y*unit
+
+	var phi, lambda float64 // Radianes en el datum del sistema
+	switch c.Projection {
+	case ProjectionTransverseMercator:
+		phi, lambda = c.inverseTransverseMercator(x, y)
+	case ProjectionMercator:
+		phi, lambda = c.inverseMercator(x, y)
+	case ProjectionWebMercator:
+		lambda = (x-c.FalseEasting)/c.Ellipsoid.SemiMajor + radians(c.CentralMeridian)
+		phi = math.Atan(math.Sinh((y - c.FalseNorthing) / c.Ellipsoid.SemiMajor))
+	default:
+		phi, lambda = radians(y), radians(x)
+	}
+	lambda += radians(c.PrimeMeridian)
+	if len(c.ToWGS84) > 0 {
+		phi, lambda = c.shiftDatum(phi, lambda)
+	}
+	return degrees(phi), normalizeLongitude(degrees(lambda))
+}
+
+// inverseTransverseMercator aplica las fórmulas inversas de Snyder (USGS 1395), con error
+// submilimétrico en fajas de pocos grados como UTM y Gauss-Krüger
+func (c *CRS) inverseTransverseMercator(x, y float64) (float64, float64) {
+	a, e2 := c.Ellipsoid.SemiMajor, c.Ellipsoid.eccentricitySquared()
+	k0 := c.ScaleFactor
+	if k0 == 0 {
+		k0 = 1
+	}
+	ep2 := e2 / (1 - e2)
+
+	m := meridianArc(a, e2, radians(c.LatitudeOfOrigin)) + (y-c.FalseNorthing)/k0
+	mu := m / (a * (1 - e2/4 - 3*e2*e2/64 - 5*e2*e2*e2/256))
+	e1 := (1 - math.Sqrt(1-e2)) / (1 + math.Sqrt(1-e2))
+	phi1 := mu + (3*e1/2-27*math.Pow(e1, 3)/32)*math.Sin(2*mu) +
+		(21*e1*e1/16-55*math.Pow(e1, 4)/32)*math.Sin(4*mu) +
+		(151*math.Pow(e1, 3)/96)*math.Sin(6*mu) +
+		(1097*math.Pow(e1, 4)/512)*math.Sin(8*mu)
+
+	sin, cos, tan := math.Sin(phi1), math.Cos(phi1), math.Tan(phi1)
+	c1 := ep2 * cos * cos
+	t1 := tan * tan
+	n1 := a / math.Sqrt(1-e2*sin*sin)
+	r1 := a * (1 - e2) / math.Pow(1-e2*sin*sin, 1.5)
+	d := (x - c.FalseEasting) / (n1 * k0)
+
+	phi := phi1 - (n1*tan/r1)*(d*d/2-
+		(5+3*t1+10*c1-4*c1*c1-9*ep2)*math.Pow(d, 4)/24+
+		(61+90*t1+298*c1+45*t1*t1-252*ep2-3*c1*c1)*math.Pow(d, 6)/720)
+	lambda := radians(c.CentralMeridian) + (d-
+		(1+2*t1+c1)*math.Pow(d, 3)/6+
+		(5-2*c1+28*t1-3*c1*c1+8*ep2+24*t1*t1)*math.Pow(d, 5)/120)/cos
+	return phi, lambda
+}
+
+// meridianArc retorna la distancia en metros por el meridiano desde el ecuador hasta phi
+func meridianArc(a, e2, phi float64) float64 {
+	e4, e6 := e2*e2, e2*e2*e2
+	return a * ((1-e2/4-3*e4/64-5*e6/256)*phi -
+		(3*e2/8+3*e4/32+45*e6/1024)*math.Sin(2*phi) +
+		(15*e4/256+45*e6/1024)*math.Sin(4*phi) -
+		(35*e6/3072)*math.Sin(6*phi))
+}
+
+// inverseMercator invierte la proyección de Mercator sobre el elipsoide, con la escala dada
+// por ScaleFactor o por el paralelo estándar
+func (c *CRS) inverseMercator(x, y float64) (float64, float64) {
+	a, e2 := c.Ellipsoid.SemiMajor, c.Ellipsoid.eccentricitySquared()
+	e := math.Sqrt(e2)
+	k0 := c.ScaleFactor
+	if k0 == 0 {
+		sin := math.Sin(radians(c.StandardParallel))
+		k0 = math.Cos(radians(c.StandardParallel)) / math.Sqrt(1-e2*sin*sin)
+	}
+
+	t := math.Exp(-(y - c.FalseNorthing) / (a * k0))
+	phi := math.Pi/2 - 2*math.Atan(t)
+	for i := 0; i < 10; i++ {
+		sin := e * math.Sin(phi)
+		next := math.Pi/2 - 2*math.Atan(t*math.Pow((1-sin)/(1+sin), e/2))
+		if math.Abs(next-phi) < 1e-12 {
+			phi = next
+			break
+		}
+		phi = next
+	}
+	return phi, (x-c.FalseEasting)/(a*k0) + radians(c.CentralMeridian)
+}
+
+// shiftDatum convierte la latitud y la longitud del datum del sistema a WGS84 pasando por
+// coordenadas geocéntricas, con la transformación de Helmert en la convención de vector de
+// posición que usa TOWGS84
+func (c *CRS) shiftDatum(phi, lambda float64) (float64, float64) {
+	a, e2 := c.Ellipsoid.SemiMajor, c.Ellipsoid.eccentricitySquared()
+	sin := math.Sin(phi)
+	n := a / math.Sqrt(1-e2*sin*sin)
+	x := n * math.Cos(phi) * math.Cos(lambda)
+	y := n * math.Cos(phi) * math.Sin(lambda)
+	z := n * (1 - e2) * sin
+
+	params := make([]float64, 7)
+	copy(params, c.ToWGS84)
+	arcsec := math.Pi / (180 * 3600)
+	rx, ry, rz := params[3]*arcsec, params[4]*arcsec, params[5]*arcsec
+	s := 1 + params[6]*1e-6
+	x, y, z = params[0]+s*(x-rz*y+ry*z),
+		params[1]+s*(rz*x+y-rx*z),
+		params[2]+s*(-ry*x+rx*y+z)
+
+	// Conversión inversa iterativa sobre el elipsoide WGS84
+	a, e2 = WGS84Ellipsoid.SemiMajor, WGS84Ellipsoid.eccentricitySquared()
+	p := math.Hypot(x, y)
+	lambda = math.Atan2(y, x)
+	phi = math.Atan2(z, p*(1-e2))
+	for i := 0; i < 10; i++ {
+		sin := math.Sin(phi)
+		n := a / math.Sqrt(1-e2*sin*sin)
+		h := p/math.Cos(phi) - n
+		phi = math.Atan2(z, p*(1-e2*n/(n+h)))
+	}
+	return phi, lambda
+}
+
+// EPSG retorna los sistemas de referencia más usados en la Argentina por su código EPSG
+func EPSG(code int) (*CRS, error) {
+	switch {
+	case code == 4326:
+		return &CRS{Name: "WGS 84", Ellipsoid: WGS84Ellipsoid}, nil
+	case code == 4674, code == 4190, code == 5340:
+		// SIRGAS 2000, POSGAR 98 y POSGAR 2007 coinciden con WGS84 a nivel de centímetros
+		return &CRS{Name: "EPSG:" + strconv.Itoa(code), Ellipsoid: GRS80}, nil
+	case code == 4221:
+		return &CRS{Name: "Campo Inchauspe", Ellipsoid: International1924, ToWGS84: campoInchauspeToWGS84}, nil
+	case code == 3857 || code == 900913:
+		return &CRS{Name: "WGS 84 / Pseudo-Mercator", Projection: ProjectionWebMercator, Ellipsoid: WGS84Ellipsoid}, nil
+	case code >= 32601 && code <= 32660, code >= 32701 && code <= 32760:
+		zone := code % 100
+		crs := &CRS{
+			Name:            "WGS 84 / UTM zone " + strconv.Itoa(zone),
+			Projection:      ProjectionTransverseMercator,
+			Ellipsoid:       WGS84Ellipsoid,
+			CentralMeridian: float64(zone*6 - 183),
+			ScaleFactor:     0.9996,
+			FalseEasting:    500000,
+		}
+		if code > 32700 {
+			crs.Name += "S"
+			crs.FalseNorthing = 10000000
+		} else {
+			crs.Name += "N"
+		}
+		return crs, nil
+	case code >= 5343 && code <= 5349:
+		return argentinaBand("POSGAR 2007", code-5342, GRS80, nil), nil
+	case code >= 22171 && code <= 22177:
+		return argentinaBand("POSGAR 98", code-22170, GRS80, nil), nil
+	case code >= 22181 && code <= 22187:
+		return argentinaBand("POSGAR 94", code-22180, WGS84Ellipsoid, nil), nil
+	case code >= 22191 && code <= 22197:
+		return argentinaBand("Campo Inchauspe", code-22190, International1924, campoInchauspeToWGS84), nil
+	}
+	return nil, fmt.Errorf("%w: EPSG:%d", ErrUnsupportedCRS, code)
+}
+
+// campoInchauspeToWGS84 es la transformación EPSG:1127 de Campo Inchauspe a WGS84, con una
+// precisión de unos 5 metros
+var campoInchauspeToWGS84 = []float64{-148, 136, 90}
+
+// argentinaBand retorna la faja Gauss-Krüger de la Argentina: meridianos centrales cada 3°
+// desde -72° y falso este con el número de faja en los millones
+func argentinaBand(datum string, band int, ellipsoid Ellipsoid, toWGS84 []float64) *CRS {
+	return &CRS{
+		Name:             datum + " / Argentina " + strconv.Itoa(band),
+		Projection:       ProjectionTransverseMercator,
+		Ellipsoid:        ellipsoid,
+		LatitudeOfOrigin: -90,
+		CentralMeridian:  float64(-75 + 3*band),
+		ScaleFactor:      1,
+		FalseEasting:     float64(band)*1000000 + 500000,
+		ToWGS84:          toWGS84,
+	}
+}
+
+// normalizeName simplifica un nombre de datum o de proyección para compararlo
+func normalizeName(name string) string {
+	name = strings.ToLower(name)
+	name = strings.TrimPrefix(name, "d_")
+	var b strings.Builder
+	for _, r := range name {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+func radians(deg float64) float64 { return deg * math.Pi / 180 }
+
+func degrees(rad float64) float64 { return rad * 180 / math.Pi }
+
+// normalizeLongitude lleva la longitud al rango [-180, 180)
+func normalizeLongitude(lon float64) float64 {
+	return math.Mod(math.Mod(lon+180, 360)+360, 360) - 180
+}
@@ -0,0 +1,75 @@
+package tests
+
+import (
+	"math"
+	"testing"
+
+	"go-crime_map_backend/internal/domain/entities"
+	"go-crime_map_backend/pkg/proj"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransverseMercator(t *testing.T) {
+	// Ejemplo de la guía 7-2 de EPSG: OSGB 1936 / British National Grid
+	wkt := `PROJCS["OSGB 1936 / British National Grid",GEOGCS["OSGB 1936",DATUM["OSGB_1936",` +
+		`SPHEROID["Airy 1830",6377563.396,299.3249646],TOWGS84[0,0,0,0,0,0,0]],PRIMEM["Greenwich",0],` +
+		`UNIT["degree",0.0174532925199433]],PROJECTION["Transverse_Mercator"],PARAMETER["latitude_of_origin",49],` +
+		`PARAMETER["central_meridian",-2],PARAMETER["scale_factor",0.9996012717],PARAMETER["false_easting",400000],` +
+		`PARAMETER["false_northing",-100000],UNIT["metre",1],AUTHORITY["EPSG","27700"]]`
+	crs, err := proj.ParseWKT(wkt)
+	require.NoError(t, err)
+	assert.Equal(t, proj.ProjectionTransverseMercator, crs.Projection)
+	lat, lon := crs.LatLon(577274.99, 69740.49)
+	assert.InDelta(t, 50.5, lat, 1e-6)
+	assert.InDelta(t, 0.5, lon, 1e-6)
+}
+
+func TestArgentinaBands(t *testing.T) {
+	// El .prj de ESRI de POSGAR 2007 faja 5 equivale a EPSG:5347
+	wkt := `PROJCS["POSGAR_2007_Argentina_5",GEOGCS["GCS_POSGAR_2007",DATUM["D_POSGAR_2007",` +
+		`SPHEROID["GRS_1980",6378137.0,298.257222101]],PRIMEM["Greenwich",0.0],UNIT["Degree",0.0174532925199433]],` +
+		`PROJECTION["Gauss_Kruger"],PARAMETER["False_Easting",5500000.0],PARAMETER["False_Northing",0.0],` +
+		`PARAMETER["Central_Meridian",-60.0],PARAMETER["Scale_Factor",1.0],PARAMETER["Latitude_Of_Origin",-90.0],UNIT["Meter",1.0]]`
+	parsed, err := proj.ParseWKT(wkt)
+	require.NoError(t, err)
+	byCode, err := proj.EPSG(5347)
+	require.NoError(t, err)
+	assert.Equal(t, byCode.CentralMeridian, parsed.CentralMeridian)
+	assert.Equal(t, byCode.FalseEasting, parsed.FalseEasting)
+	assert.Empty(t, parsed.ToWGS84)
+
+	// Sobre el meridiano central la longitud es la del meridiano y el norte crece hacia el ecuador
+	lat, lon := parsed.LatLon(5500000, 6170000)
+	assert.InDelta(t, -60, lon, 1e-9)
+	assert.InDelta(t, -34.6, lat, 0.2)
+	north, _ := parsed.LatLon(5500000, 6171000)
+	assert.InDelta(t, 1000, (north-lat)*111320, 5, "un kilómetro al norte")
+
+	// Campo Inchauspe se desplaza decenas de metros respecto de WGS84
+	inchauspe, err := proj.EPSG(4221)
+	require.NoError(t, err)
+	shiftedLat, shiftedLon := inchauspe.LatLon(-58.3816, -34.6037)
+	shift := entities.DistanceMeters(entities.Coordinate{Latitude: -34.6037, Longitude: -58.3816},
+		entities.Coordinate{Latitude: shiftedLat, Longitude: shiftedLon})
+	assert.Greater(t, shift, 50.0)
+	assert.Less(t, shift, 150.0)
+}
+
+func TestWebMercator(t *testing.T) {
+	crs, err := proj.EPSG(3857)
+	require.NoError(t, err)
+	const radius = 6378137.0
+	lat, lon := -34.6037, -58.3816
+	x := radius * lon * math.Pi / 180
+	y := radius * math.Log(math.Tan(math.Pi/4+lat*math.Pi/360))
+	gotLat, gotLon := crs.LatLon(x, y)
+	assert.InDelta(t, lat, gotLat, 1e-9)
+	assert.InDelta(t, lon, gotLon, 1e-9)
+
+	_, err = proj.ParseWKT(`PROJCS["Lambert",GEOGCS["WGS 84",DATUM["WGS_1984",SPHEROID["WGS 84",6378137,298.257223563]]],PROJECTION["Lambert_Conformal_Conic_2SP"]]`)
+	assert.ErrorIs(t, err, proj.ErrUnsupportedCRS)
+	_, err = proj.EPSG(2000)
+	assert.ErrorIs(t, err, proj.ErrUnsupportedCRS)
+}
@@ -0,0 +1,255 @@
+package proj
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// node es un elemento WKT: una palabra clave con sus valores, que pueden ser textos entre
+// comillas, números o elementos anidados
+type node struct {
+	keyword  string
+	strings  []string
+	numbers  []float64
+	children []*node
+}
+
+// child retorna el primer elemento anidado con alguna de las palabras clave
+func (n *node) child(keywords ...string) *node {
+	if n == nil {
+		return nil
+	}
+	for _, child := range n.children {
+		for _, keyword := range keywords {
+			if child.keyword == keyword {
+				return child
+			}
+		}
+	}
+	return nil
+}
+
+// name retorna el primer texto del elemento
+func (n *node) name() string {
+	if n == nil || len(n.strings) == 0 {
+		return ""
+	}
+	return n.strings[0]
+}
+
+// number retorna el número en la posición indicada
+func (n *node) number(i int) (float64, bool) {
+	if n == nil || i >= len(n.numbers) {
+		return 0, false
+	}
+	return n.numbers[i], true
+}
+
+// parseWKT lee un documento WKT 1, en la variante OGC o en la de ESRI
+func parseWKT(wkt string) (*node, error) {
+	p := &wktParser{input: strings.TrimSpace(wkt)}
+	root, err := p.parse()
+	if err != nil {
+		return nil, err
+	}
+	p.skipSpaces()
+	if p.pos != len(p.input) {
+		return nil, fmt.Errorf("contenido inesperado en la posición %d", p.pos)
+	}
+	return root, nil
+}
+
+// wktParser recorre el documento WKT
+type wktParser struct {
+	input string
+	pos   int
+}
+
+func (p *wktParser) skipSpaces() {
+	for p.pos < len(p.input) && unicode.IsSpace(rune(p.input[p.pos])) {
+		p.pos++
+	}
+}
+
+// parse lee un elemento PALABRA[valor, valor, ...]; acepta corchetes o paréntesis
+func (p *wktParser) parse() (*node, error) {
+	p.skipSpaces()
+	start := p.pos
+	for p.pos < len(p.input) && (unicode.IsLetter(rune(p.input[p.pos])) || unicode.IsDigit(rune(p.input[p.pos])) || p.input[p.pos] == '_') {
+		p.pos++
+	}
+	if start == p.pos {
+		return nil, fmt.Errorf("se esperaba una palabra clave en la posición %d", p.pos)
+	}
+	n := &node{keyword: strings.ToUpper(p.input[start:p.pos])}
+	p.skipSpaces()
+	if p.pos >= len(p.input) || (p.input[p.pos] != '[' && p.input[p.pos] != '(') {
+		// Palabras clave sin valores, como los ejes NORTH o EAST
+		return n, nil
+	}
+	closing := byte(']')
+	if p.input[p.pos] == '(' {
+		closing = ')'
+	}
+	p.pos++
+
+	for {
+		p.skipSpaces()
+		if p.pos >= len(p.input) {
+			return nil, fmt.Errorf("falta cerrar %s", n.keyword)
+		}
+		switch c := p.input[p.pos]; {
+		case c == closing:
+			p.pos++
+			return n, nil
+		case c == ',':
+			p.pos++
+		case c == '"':
+			end := strings.IndexByte(p.input[p.pos+1:], '"')
+			if end < 0 {
+				return nil, fmt.Errorf("texto sin cerrar en %s", n.keyword)
+			}
+			n.strings = append(n.strings, p.input[p.pos+1:p.pos+1+end])
+			p.pos += end + 2
+		case c == '-' || c == '+' || c == '.' || (c >= '0' && c <= '9'):
+			start := p.pos
+			for p.pos < len(p.input) && strings.IndexByte("+-.eE0123456789", p.input[p.pos]) >= 0 {
+				p.pos++
+			}
+			value, err := strconv.ParseFloat(p.input[start:p.pos], 64)
+			if err != nil {
+				return nil, fmt.Errorf("número inválido en %s", n.keyword)
+			}
+			n.numbers = append(n.numbers, value)
+		default:
+			child, err := p.parse()
+			if err != nil {
+				return nil, err
+			}
+			n.children = append(n.children, child)
+		}
+	}
+}
+
+// ParseWKT lee el sistema de referencia de un archivo .prj. Si la proyección no está
+// soportada pero el documento indica su código EPSG, se usa el sistema de ese código
+func ParseWKT(wkt string) (*CRS, error) {
+	root, err := parseWKT(wkt)
+	if err != nil {
+		return nil, fmt.Errorf("%w: WKT inválido: %v", ErrUnsupportedCRS, err)
+	}
+	crs, err := crsFromNode(root)
+	if err != nil {
+		if authority := root.child("AUTHORITY"); authority != nil && len(authority.strings) == 2 &&
+			strings.EqualFold(authority.strings[0], "EPSG") {
+			if code, convErr := strconv.Atoi(authority.strings[1]); convErr == nil {
+				if byCode, codeErr := EPSG(code); codeErr == nil {
+					return byCode, nil
+				}
+			}
+		}
+		return nil, err
+	}
+	return crs, nil
+}
+
+// crsFromNode interpreta un elemento GEOGCS o PROJCS
+func crsFromNode(root *node) (*CRS, error) {
+	geographic := root
+	switch root.keyword {
+	case "GEOGCS":
+	case "PROJCS":
+		if geographic = root.child("GEOGCS"); geographic == nil {
+			return nil, fmt.Errorf("%w: falta el sistema geográfico de %q", ErrUnsupportedCRS, root.name())
+		}
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedCRS, root.keyword)
+	}
+
+	crs := &CRS{Name: root.name()}
+	datum := geographic.child("DATUM")
+	spheroid := datum.child("SPHEROID", "ELLIPSOID")
+	a, okA := spheroid.number(0)
+	inverseFlattening, okF := spheroid.number(1)
+	if datum == nil || !okA || !okF || a <= 0 {
+		return nil, fmt.Errorf("%w: falta el elipsoide del datum", ErrUnsupportedCRS)
+	}
+	crs.Ellipsoid = Ellipsoid{SemiMajor: a, InverseFlattening: inverseFlattening}
+	crs.PrimeMeridian, _ = geographic.child("PRIMEM").number(0)
+
+	var err error
+	if crs.ToWGS84, err = datumShift(datum, crs.Ellipsoid); err != nil {
+		return nil, err
+	}
+
+	if root.keyword == "GEOGCS" {
+		// La unidad angular se expresa en radianes
+		if unit, ok := geographic.child("UNIT").number(0); ok && unit > 0 {
+			crs.Unit = degrees(unit)
+		}
+		return crs, nil
+	}
+
+	unit, ok := root.child("UNIT").number(0)
+	if !ok || unit <= 0 {
+		unit = 1
+	}
+	crs.Unit = unit
+	params := make(map[string]float64)
+	for _, child := range root.children {
+		if child.keyword == "PARAMETER" {
+			if value, ok := child.number(0); ok {
+				params[normalizeName(child.name())] = value
+			}
+		}
+	}
+	crs.LatitudeOfOrigin = params["latitudeoforigin"]
+	crs.CentralMeridian = params["centralmeridian"]
+	if value, found := params["longitudeofcenter"]; found {
+		crs.CentralMeridian = value
+	}
+	crs.ScaleFactor = params["scalefactor"]
+	crs.StandardParallel = params["standardparallel1"]
+	// Los falsos este y norte están en la unidad del sistema
+	crs.FalseEasting = params["falseeasting"] * unit
+	crs.FalseNorthing = params["falsenorthing"] * unit
+
+	name := normalizeName(crs.Name)
+	switch projection := normalizeName(root.child("PROJECTION").name()); projection {
+	case "transversemercator", "gausskruger":
+		crs.Projection = ProjectionTransverseMercator
+	case "mercatorauxiliarysphere", "popularvisualisationpseudomercator":
+		crs.Projection = ProjectionWebMercator
+	case "mercator", "mercator1sp", "mercator2sp":
+		crs.Projection = ProjectionMercator
+		if strings.Contains(name, "pseudomercator") || strings.Contains(name, "webmercator") {
+			crs.Projection = ProjectionWebMercator
+		}
+	default:
+		return nil, fmt.Errorf("%w: proyección %q", ErrUnsupportedCRS, root.child("PROJECTION").name())
+	}
+	return crs, nil
+}
+
+// datumShift retorna los parámetros de Helmert del datum: los de TOWGS84 si el documento
+// los indica, los de los datums conocidos o ninguno si el datum usa el elipsoide de WGS84 o
+// GRS80, cuyas realizaciones modernas (SIRGAS, POSGAR) difieren de WGS84 en centímetros
+func datumShift(datum *node, ellipsoid Ellipsoid) ([]float64, error) {
+	if towgs84 := datum.child("TOWGS84"); towgs84 != nil && len(towgs84.numbers) >= 3 {
+		for _, value := range towgs84.numbers {
+			if value != 0 {
+				return towgs84.numbers, nil
+			}
+		}
+		return nil, nil
+	}
+	if strings.Contains(normalizeName(datum.name()), "campoinchauspe") {
+		return campoInchauspeToWGS84, nil
+	}
+	if ellipsoid.SemiMajor == WGS84Ellipsoid.SemiMajor {
+		return nil, nil
+	}
+	return nil, fmt.Errorf("%w: el datum %q no indica su transformación a WGS84", ErrUnsupportedCRS, datum.name())
+}
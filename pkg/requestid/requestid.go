@@ -0,0 +1,23 @@
+package requestid
+
+import "context"
+
+// Header es la cabecera HTTP que transporta el identificador de la solicitud
+const Header = "X-Request-ID"
+
+// contextKey es el tipo de la clave del identificador en el contexto
+type contextKey struct{}
+
+// WithRequestID retorna un contexto que transporta el identificador de la solicitud
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, contextKey{}, id)
+}
+
+// FromContext obtiene el identificador de la solicitud del contexto, vacío si no existe
+func FromContext(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	id, _ := ctx.Value(contextKey{}).(string)
+	return id
+}
@@ -0,0 +1,90 @@
+package shapefile
+
+import (
+	"encoding/binary"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// readTable lee las columnas y los registros del .dbf (dBase III). Retorna los atributos de
+// cada registro y si el registro está marcado como eliminado
+func readTable(data []byte, cpg string) ([]Field, []map[string]string, []bool, error) {
+	if len(data) < 32 {
+		return nil, nil, nil, fmt.Errorf("%w: el .dbf no tiene un encabezado válido", ErrInvalidShapefile)
+	}
+	count := int(binary.LittleEndian.Uint32(data[4:8]))
+	headerLength := int(binary.LittleEndian.Uint16(data[8:10]))
+	recordLength := int(binary.LittleEndian.Uint16(data[10:12]))
+	if headerLength < 33 || headerLength > len(data) || recordLength < 1 {
+		return nil, nil, nil, fmt.Errorf("%w: el .dbf no tiene un encabezado válido", ErrInvalidShapefile)
+	}
+	decode := decoder(cpg)
+
+	// Cada columna se describe en 32 bytes hasta el terminador 0x0D
+	var fields []Field
+	width := 1 // El primer byte de cada registro es la marca de eliminado
+	for offset := 32; offset+32 <= headerLength && data[offset] != 0x0D; offset += 32 {
+		descriptor := data[offset : offset+32]
+		name := descriptor[:11]
+		if end := strings.IndexByte(string(name), 0); end >= 0 {
+			name = name[:end]
+		}
+		field := Field{
+			Name:     strings.ToUpper(strings.TrimSpace(decode(name))),
+			Type:     descriptor[11],
+			Length:   int(descriptor[16]),
+			Decimals: int(descriptor[17]),
+		}
+		fields = append(fields, field)
+		width += field.Length
+	}
+	if width > recordLength {
+		return nil, nil, nil, fmt.Errorf("%w: las columnas del .dbf exceden el largo de los registros", ErrInvalidShapefile)
+	}
+	if headerLength+count*recordLength > len(data) {
+		return nil, nil, nil, fmt.Errorf("%w: el .dbf está truncado", ErrInvalidShapefile)
+	}
+
+	attributes := make([]map[string]string, count)
+	deleted := make([]bool, count)
+	for i := range attributes {
+		record := data[headerLength+i*recordLength : headerLength+(i+1)*recordLength]
+		deleted[i] = record[0] == '*'
+		values := make(map[string]string, len(fields))
+		offset := 1
+		for _, field := range fields {
+			values[field.Name] = strings.TrimSpace(decode(record[offset : offset+field.Length]))
+			offset += field.Length
+		}
+		attributes[i] = values
+	}
+	return fields, attributes, deleted, nil
+}
+
+// decoder retorna la función que convierte los textos del .dbf a UTF-8 según el .cpg
+func decoder(cpg string) func([]byte) string {
+	switch strings.ToUpper(strings.TrimSpace(cpg)) {
+	case "UTF-8", "UTF8", "65001":
+		return func(b []byte) string { return string(b) }
+	case "":
+		// Sin .cpg se acepta UTF-8 si el texto es válido, si no se asume Latin-1
+		return func(b []byte) string {
+			if utf8.Valid(b) {
+				return string(b)
+			}
+			return latin1(b)
+		}
+	}
+	return latin1
+}
+
+// latin1 convierte un texto ISO-8859-1 a UTF-8; cubre también los caracteres del español
+// de Windows-1252
+func latin1(b []byte) string {
+	runes := make([]rune, len(b))
+	for i, c := range b {
+		runes[i] = rune(c)
+	}
+	return string(runes)
+}
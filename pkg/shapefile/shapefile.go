@@ -0,0 +1,276 @@
+// Package shapefile lee shapefiles de ESRI: las geometrías del .shp, los atributos del .dbf
+// y el sistema de coordenadas del .prj, tal como se publican comprimidos en un zip
+package shapefile
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+)
+
+// ShapeType es el tipo de geometría de un registro
+type ShapeType int
+
+// Tipos de geometría de la especificación de ESRI. Las variantes Z y M se leen como sus
+// equivalentes en dos dimensiones
+const (
+	ShapeNull        ShapeType = 0
+	ShapePoint       ShapeType = 1
+	ShapePolyLine    ShapeType = 3
+	ShapePolygon     ShapeType = 5
+	ShapeMultiPoint  ShapeType = 8
+	ShapePointZ      ShapeType = 11
+	ShapePolyLineZ   ShapeType = 13
+	ShapePolygonZ    ShapeType = 15
+	ShapeMultiPointZ ShapeType = 18
+	ShapePointM      ShapeType = 21
+	ShapePolyLineM   ShapeType = 23
+	ShapePolygonM    ShapeType = 25
+	ShapeMultiPointM ShapeType = 28
+)
+
+// fileCode es el número que identifica a los archivos .shp
+const fileCode = 9994
+
+// ErrInvalidShapefile se retorna cuando los archivos no forman un shapefile válido
+var ErrInvalidShapefile = errors.New("shapefile inválido")
+
+// Base retorna el tipo en dos dimensiones, sin Z ni M
+func (t ShapeType) Base() ShapeType {
+	switch t {
+	case ShapePointZ, ShapePointM:
+		return ShapePoint
+	case ShapePolyLineZ, ShapePolyLineM:
+		return ShapePolyLine
+	case ShapePolygonZ, ShapePolygonM:
+		return ShapePolygon
+	case ShapeMultiPointZ, ShapeMultiPointM:
+		return ShapeMultiPoint
+	}
+	return t
+}
+
+// Point es un vértice en las coordenadas del archivo: X es la longitud o el este e Y la
+// latitud o el norte
+type Point struct {
+	X float64
+	Y float64
+}
+
+// Field describe una columna de atributos del .dbf
+type Field struct {
+	Name     string
+	Type     byte // C texto, N y F números, D fechas AAAAMMDD, L lógicos
+	Length   int
+	Decimals int
+}
+
+// Record es un elemento del shapefile con su geometría y sus atributos
+type Record struct {
+	Number     int // Número del registro, desde 1
+	Type       ShapeType
+	Parts      [][]Point         // Anillos o líneas; un punto es una parte de un vértice
+	Attributes map[string]string // Valores por nombre de columna en mayúsculas, sin espacios al final
+}
+
+// Attribute retorna el valor de la columna sin distinguir mayúsculas
+func (r Record) Attribute(name string) string {
+	return r.Attributes[strings.ToUpper(name)]
+}
+
+// File es un shapefile leído
+type File struct {
+	Name       string // Nombre del .shp sin extensión
+	Type       ShapeType
+	Projection string // Contenido del .prj en WKT, vacío si no existe
+	Fields     []Field
+	Records    []Record
+}
+
+// Read lee un shapefile a partir del contenido de sus archivos. El .dbf es opcional y, sin
+// .cpg, los textos se leen como UTF-8 si son válidos y si no como Latin-1
+func Read(name string, shp, dbf []byte, prj, cpg string) (*File, error) {
+	file := &File{Name: name, Projection: strings.TrimSpace(prj)}
+	shapes, shapeType, err := readShapes(shp)
+	if err != nil {
+		return nil, err
+	}
+	file.Type = shapeType
+
+	var attributes []map[string]string
+	var deleted []bool
+	if dbf != nil {
+		if file.Fields, attributes, deleted, err = readTable(dbf, cpg); err != nil {
+			return nil, err
+		}
+		if len(attributes) != len(shapes) {
+			return nil, fmt.Errorf("%w: el .shp tiene %d registros y el .dbf %d", ErrInvalidShapefile, len(shapes), len(attributes))
+		}
+	}
+
+	file.Records = make([]Record, 0, len(shapes))
+	for i, record := range shapes {
+		if attributes != nil {
+			if deleted[i] {
+				continue
+			}
+			record.Attributes = attributes[i]
+		}
+		file.Records = append(file.Records, record)
+	}
+	return file, nil
+}
+
+// readShapes lee los registros del .shp
+func readShapes(data []byte) ([]Record, ShapeType, error) {
+	if len(data) < 100 || binary.BigEndian.Uint32(data[0:4]) != fileCode {
+		return nil, 0, fmt.Errorf("%w: el .shp no tiene un encabezado válido", ErrInvalidShapefile)
+	}
+	shapeType := ShapeType(binary.LittleEndian.Uint32(data[32:36]))
+
+	var records []Record
+	for offset := 100; offset < len(data); {
+		if offset+8 > len(data) {
+			return nil, 0, fmt.Errorf("%w: el .shp está truncado", ErrInvalidShapefile)
+		}
+		number := int(binary.BigEndian.Uint32(data[offset : offset+4]))
+		length := int(binary.BigEndian.Uint32(data[offset+4:offset+8])) * 2 // Longitud en palabras de 16 bits
+		offset += 8
+		if length < 4 || offset+length > len(data) {
+			return nil, 0, fmt.Errorf("%w: el registro %d está truncado", ErrInvalidShapefile, number)
+		}
+		record, err := readShape(data[offset : offset+length])
+		if err != nil {
+			return nil, 0, fmt.Errorf("%w: registro %d: %v", ErrInvalidShapefile, number, err)
+		}
+		record.Number = number
+		records = append(records, record)
+		offset += length
+	}
+	return records, shapeType, nil
+}
+
+// readShape lee la geometría de un registro
+func readShape(content []byte) (Record, error) {
+	record := Record{Type: ShapeType(binary.LittleEndian.Uint32(content[0:4]))}
+	body := content[4:]
+	switch record.Type.Base() {
+	case ShapeNull:
+		return record, nil
+	case ShapePoint:
+		if len(body) < 16 {
+			return record, errors.New("punto incompleto")
+		}
+		record.Parts = [][]Point{{readPoint(body)}}
+		return record, nil
+	case ShapeMultiPoint:
+		// Rectángulo (32 bytes) y cantidad de puntos
+		if len(body) < 36 {
+			return record, errors.New("multipunto incompleto")
+		}
+		count := int(binary.LittleEndian.Uint32(body[32:36]))
+		if count < 0 || 36+count*16 > len(body) {
+			return record, errors.New("multipunto incompleto")
+		}
+		points := make([]Point, count)
+		for i := range points {
+			points[i] = readPoint(body[36+i*16:])
+		}
+		record.Parts = [][]Point{points}
+		return record, nil
+	case ShapePolyLine, ShapePolygon:
+		// Rectángulo (32 bytes), cantidad de partes y de puntos, índices de las partes y puntos
+		if len(body) < 40 {
+			return record, errors.New("geometría incompleta")
+		}
+		numParts := int(binary.LittleEndian.Uint32(body[32:36]))
+		numPoints := int(binary.LittleEndian.Uint32(body[36:40]))
+		pointsOffset := 40 + numParts*4
+		if numParts < 0 || numPoints < 0 || pointsOffset+numPoints*16 > len(body) {
+			return record, errors.New("geometría incompleta")
+		}
+		record.Parts = make([][]Point, numParts)
+		for i := range record.Parts {
+			start := int(binary.LittleEndian.Uint32(body[40+i*4:]))
+			end := numPoints
+			if i+1 < numParts {
+				end = int(binary.LittleEndian.Uint32(body[44+i*4:]))
+			}
+			if start < 0 || start > end || end > numPoints {
+				return record, errors.New("índices de partes inválidos")
+			}
+			part := make([]Point, end-start)
+			for j := range part {
+				part[j] = readPoint(body[pointsOffset+(start+j)*16:])
+			}
+			record.Parts[i] = part
+		}
+		return record, nil
+	}
+	return record, fmt.Errorf("tipo de geometría %d no soportado", record.Type)
+}
+
+// readPoint lee un par de coordenadas X, Y
+func readPoint(data []byte) Point {
+	return Point{
+		X: math.Float64frombits(binary.LittleEndian.Uint64(data[0:8])),
+		Y: math.Float64frombits(binary.LittleEndian.Uint64(data[8:16])),
+	}
+}
+
+// Polygons agrupa los anillos de un polígono: según la especificación los contornos se
+// recorren en sentido horario y los huecos en sentido antihorario. Cada hueco se asigna al
+// contorno que contiene su primer vértice; un hueco sin contorno se toma como contorno, para
+// tolerar archivos con la orientación invertida
+func (r Record) Polygons() [][][]Point {
+	var polygons [][][]Point
+	var holes [][]Point
+	for _, ring := range r.Parts {
+		if len(ring) == 0 {
+			continue
+		}
+		if signedArea(ring) <= 0 {
+			polygons = append(polygons, [][]Point{ring})
+		} else {
+			holes = append(holes, ring)
+		}
+	}
+	for _, hole := range holes {
+		owner := -1
+		for i, polygon := range polygons {
+			if ringContains(polygon[0], hole[0]) {
+				owner = i
+				break
+			}
+		}
+		if owner < 0 {
+			polygons = append(polygons, [][]Point{hole})
+			continue
+		}
+		polygons[owner] = append(polygons[owner], hole)
+	}
+	return polygons
+}
+
+// signedArea calcula el doble del área con signo del anillo: negativa en sentido horario
+func signedArea(ring []Point) float64 {
+	sum := 0.0
+	for i, j := 0, len(ring)-1; i < len(ring); j, i = i, i+1 {
+		sum += ring[j].X*ring[i].Y - ring[i].X*ring[j].Y
+	}
+	return sum
+}
+
+// ringContains indica si el punto está dentro del anillo usando ray casting
+func ringContains(ring []Point, point Point) bool {
+	inside := false
+	for i, j := 0, len(ring)-1; i < len(ring); j, i = i, i+1 {
+		a, b := ring[i], ring[j]
+		if (a.Y > point.Y) != (b.Y > point.Y) && point.X < (b.X-a.X)*(point.Y-a.Y)/(b.Y-a.Y)+a.X {
+			inside = !inside
+		}
+	}
+	return inside
+}
@@ -0,0 +1,74 @@
+package shapefile
+
+import (
+	"archive/zip"
+	"bytes"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+)
+
+// MaxUncompressedBytes limita lo que se descomprime de cada archivo del zip, para no agotar
+// la memoria con archivos manipulados
+const MaxUncompressedBytes = 256 << 20
+
+// ReadZip lee el shapefile de un zip. El zip debe tener un solo .shp; el .dbf, el .prj y el
+// .cpg se buscan con el mismo nombre, en la misma carpeta
+func ReadZip(data []byte) (*File, error) {
+	archive, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, fmt.Errorf("%w: el archivo no es un zip válido", ErrInvalidShapefile)
+	}
+
+	files := make(map[string]*zip.File, len(archive.File))
+	var shp string
+	for _, file := range archive.File {
+		name := file.Name
+		// Se ignoran las carpetas y los metadatos que agrega macOS
+		if file.FileInfo().IsDir() || strings.HasPrefix(name, "__MACOSX/") || strings.HasPrefix(path.Base(name), "._") {
+			continue
+		}
+		lower := strings.ToLower(name)
+		files[lower] = file
+		if strings.HasSuffix(lower, ".shp") {
+			if shp != "" {
+				return nil, fmt.Errorf("%w: el zip debe contener un solo .shp", ErrInvalidShapefile)
+			}
+			shp = lower
+		}
+	}
+	if shp == "" {
+		return nil, fmt.Errorf("%w: el zip no contiene un .shp", ErrInvalidShapefile)
+	}
+
+	base := strings.TrimSuffix(shp, ".shp")
+	read := func(ext string) ([]byte, error) {
+		file, found := files[base+ext]
+		if !found {
+			return nil, nil
+		}
+		reader, err := file.Open()
+		if err != nil {
+			return nil, fmt.Errorf("%w: no se pudo leer %s: %v", ErrInvalidShapefile, path.Base(file.Name), err)
+		}
+		defer reader.Close()
+		content, err := io.ReadAll(io.LimitReader(reader, MaxUncompressedBytes+1))
+		if err != nil {
+			return nil, fmt.Errorf("%w: no se pudo leer %s: %v", ErrInvalidShapefile, path.Base(file.Name), err)
+		}
+		if len(content) > MaxUncompressedBytes {
+			return nil, fmt.Errorf("%w: %s excede los %d MB", ErrInvalidShapefile, path.Base(file.Name), MaxUncompressedBytes>>20)
+		}
+		return content, nil
+	}
+
+	contents := make(map[string][]byte, 4)
+	for _, ext := range []string{".shp", ".dbf", ".prj", ".cpg"} {
+		if contents[ext], err = read(ext); err != nil {
+			return nil, err
+		}
+	}
+	name := path.Base(files[shp].Name)
+	return Read(name[:len(name)-len(".shp")], contents[".shp"], contents[".dbf"], string(contents[".prj"]), string(contents[".cpg"]))
+}
@@ -0,0 +1,135 @@
+package spatial
+
+import (
+	"math"
+	"sort"
+)
+
+// Noise es la etiqueta de los puntos que no pertenecen a ningún cluster
+const Noise = -1
+
+// Point representa un punto del plano
+type Point struct {
+	X, Y float64
+}
+
+// DBSCAN agrupa los puntos por densidad: un punto es núcleo si tiene al menos minPts puntos,
+// incluido él mismo, a distancia eps o menor, y cada cluster reúne los núcleos alcanzables
+// entre sí y sus vecinos. Retorna la etiqueta de cada punto, desde 0, o Noise. Los vecinos
+// se buscan en una grilla de celdas de lado eps, por lo que cada consulta solo compara los
+// puntos de nueve celdas
+func DBSCAN(points []Point, eps float64, minPts int) []int {
+	labels := make([]int, len(points))
+	for i := range labels {
+		labels[i] = Noise
+	}
+	if eps <= 0 || len(points) == 0 {
+		return labels
+	}
+
+	cells := make(map[cellKey][]int)
+	cellOf := func(p Point) cellKey {
+		return cellKey{int64(math.Floor(p.X / eps)), int64(math.Floor(p.Y / eps))}
+	}
+	for i, p := range points {
+		key := cellOf(p)
+		cells[key] = append(cells[key], i)
+	}
+	// neighbors reutiliza el mismo buffer en cada consulta; el resultado es válido hasta la
+	// siguiente
+	buffer := make([]int, 0, 64)
+	neighbors := func(i int) []int {
+		center := cellOf(points[i])
+		buffer = buffer[:0]
+		for dx := int64(-1); dx <= 1; dx++ {
+			for dy := int64(-1); dy <= 1; dy++ {
+				for _, j := range cells[cellKey{center.x + dx, center.y + dy}] {
+					if math.Hypot(points[i].X-points[j].X, points[i].Y-points[j].Y) <= eps {
+						buffer = append(buffer, j)
+					}
+				}
+			}
+		}
+		return buffer
+	}
+
+	// Cada punto entra en la cola de expansión una sola vez, así la cola no supera la
+	// cantidad de puntos aunque todos sean vecinos entre sí
+	visited := make([]bool, len(points))
+	queued := make([]bool, len(points))
+	var seeds []int
+	enqueue := func(candidates []int) {
+		for _, m := range candidates {
+			if !queued[m] {
+				queued[m] = true
+				seeds = append(seeds, m)
+			}
+		}
+	}
+	cluster := 0
+	for i := range points {
+		if visited[i] {
+			continue
+		}
+		visited[i] = true
+		if len(neighbors(i)) < minPts {
+			continue
+		}
+
+		labels[i] = cluster
+		queued[i] = true
+		seeds = seeds[:0]
+		enqueue(buffer)
+		for k := 0; k < len(seeds); k++ {
+			j := seeds[k]
+			if labels[j] == Noise {
+				labels[j] = cluster
+			}
+			if visited[j] {
+				continue
+			}
+			visited[j] = true
+			if more := neighbors(j); len(more) >= minPts {
+				enqueue(more)
+			}
+		}
+		cluster++
+	}
+	return labels
+}
+
+// ConvexHull retorna la envolvente convexa de los puntos en sentido antihorario, sin repetir
+// el primer vértice. Retorna menos de tres vértices si los puntos son colineales
+func ConvexHull(points []Point) []Point {
+	sorted := append([]Point(nil), points...)
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].X != sorted[j].X {
+			return sorted[i].X < sorted[j].X
+		}
+		return sorted[i].Y < sorted[j].Y
+	})
+	if len(sorted) < 3 {
+		return sorted
+	}
+
+	// Cadena monótona de Andrew: se arman la mitad inferior y la superior
+	cross := func(o, a, b Point) float64 {
+		return (a.X-o.X)*(b.Y-o.Y) - (a.Y-o.Y)*(b.X-o.X)
+	}
+	hull := make([]Point, 0, 2*len(sorted))
+	for _, p := range sorted {
+		for len(hull) >= 2 && cross(hull[len(hull)-2], hull[len(hull)-1], p) <= 0 {
+			hull = hull[:len(hull)-1]
+		}
+		hull = append(hull, p)
+	}
+	lower := len(hull) + 1
+	for i := len(sorted) - 2; i >= 0; i-- {
+		p := sorted[i]
+		for len(hull) >= lower && cross(hull[len(hull)-2], hull[len(hull)-1], p) <= 0 {
+			hull = hull[:len(hull)-1]
+		}
+		hull = append(hull, p)
+	}
+	return hull[:len(hull)-1]
+}
@@ -0,0 +1,107 @@
+package spatial
+
+import "math"
+
+// DensityGrid es una estimación de densidad por kernel evaluada en el centro de cada celda
+// de una grilla regular
+type DensityGrid struct {
+	MinX, MinY float64   // Esquina inferior izquierda de la grilla
+	CellSize   float64   // Lado de cada celda
+	Cols, Rows int       // Cantidad de celdas en cada eje
+	Values     []float64 // Densidad de cada celda, fila por fila desde MinY
+}
+
+// KernelDensity estima la densidad de los puntos con un kernel gaussiano de desviación
+// bandwidth sobre una grilla de celdas de lado cellSize que cubre los puntos con un margen
+// de tres desviaciones. El kernel se trunca a tres desviaciones. Retorna nil sin puntos o
+// con parámetros no positivos
+func KernelDensity(points []Point, bandwidth, cellSize float64) *DensityGrid {
+	if len(points) == 0 || bandwidth <= 0 || cellSize <= 0 {
+		return nil
+	}
+	reach := 3 * bandwidth
+	minX, minY, maxX, maxY := math.Inf(1), math.Inf(1), math.Inf(-1), math.Inf(-1)
+	for _, p := range points {
+		minX, minY = math.Min(minX, p.X), math.Min(minY, p.Y)
+		maxX, maxY = math.Max(maxX, p.X), math.Max(maxY, p.Y)
+	}
+
+	grid := &DensityGrid{
+		MinX:     minX - reach,
+		MinY:     minY - reach,
+		CellSize: cellSize,
+		Cols:     int(math.Ceil((maxX-minX+2*reach)/cellSize)) + 1,
+		Rows:     int(math.Ceil((maxY-minY+2*reach)/cellSize)) + 1,
+	}
+	grid.Values = make([]float64, grid.Cols*grid.Rows)
+
+	norm := 1 / (2 * math.Pi * bandwidth * bandwidth)
+	span := int(math.Ceil(reach / cellSize))
+	for _, p := range points {
+		col, row := grid.Cell(p)
+		for r := max(0, row-span); r <= min(grid.Rows-1, row+span); r++ {
+			for c := max(0, col-span); c <= min(grid.Cols-1, col+span); c++ {
+				center := grid.Center(c, r)
+				d := math.Hypot(center.X-p.X, center.Y-p.Y)
+				if d <= reach {
+					grid.Values[r*grid.Cols+c] += norm * math.Exp(-d*d/(2*bandwidth*bandwidth))
+				}
+			}
+		}
+	}
+	return grid
+}
+
+// Cell retorna la columna y la fila de la celda que contiene el punto
+func (g *DensityGrid) Cell(p Point) (int, int) {
+	return int(math.Floor((p.X - g.MinX) / g.CellSize)), int(math.Floor((p.Y - g.MinY) / g.CellSize))
+}
+
+// Center retorna el centro de la celda
+func (g *DensityGrid) Center(col, row int) Point {
+	return Point{X: g.MinX + (float64(col)+0.5)*g.CellSize, Y: g.MinY + (float64(row)+0.5)*g.CellSize}
+}
+
+// Max retorna la densidad máxima de la grilla
+func (g *DensityGrid) Max() float64 {
+	peak := 0.0
+	for _, value := range g.Values {
+		peak = math.Max(peak, value)
+	}
+	return peak
+}
+
+// Regions agrupa en regiones las celdas con densidad mayor o igual a threshold que se tocan
+// por un lado. Retorna la región de cada celda, desde 0, o Noise si está por debajo del umbral
+func (g *DensityGrid) Regions(threshold float64) []int {
+	regions := make([]int, len(g.Values))
+	for i := range regions {
+		regions[i] = Noise
+	}
+
+	region := 0
+	for start, value := range g.Values {
+		if value < threshold || regions[start] != Noise {
+			continue
+		}
+		regions[start] = region
+		stack := []int{start}
+		for len(stack) > 0 {
+			cell := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			col, row := cell%g.Cols, cell/g.Cols
+			for _, next := range [][2]int{{col - 1, row}, {col + 1, row}, {col, row - 1}, {col, row + 1}} {
+				if next[0] < 0 || next[0] >= g.Cols || next[1] < 0 || next[1] >= g.Rows {
+					continue
+				}
+				neighbor := next[1]*g.Cols + next[0]
+				if regions[neighbor] == Noise && g.Values[neighbor] >= threshold {
+					regions[neighbor] = region
+					stack = append(stack, neighbor)
+				}
+			}
+		}
+		region++
+	}
+	return regions
+}
@@ -0,0 +1,121 @@
+package spatial
+
+import "math"
+
+// GiStar calcula el estadístico Gi* de Getis-Ord de cada valor con pesos binarios: los
+// vecinos de i, que deben incluir a i, pesan 1 y el resto 0. El resultado es un z-score;
+// los valores positivos indican concentraciones de valores altos y los negativos de valores
+// bajos. Si todos los valores son iguales o un elemento es vecino de todos, su z-score es 0
+func GiStar(values []float64, neighbors [][]int) []float64 {
+	sums := make([]float64, len(values))
+	weights := make([]int, len(values))
+	for i, members := range neighbors {
+		for _, j := range members {
+			sums[i] += values[j]
+		}
+		weights[i] = len(members)
+	}
+	return GiStarSums(values, sums, weights)
+}
+
+// GiStarSums calcula el Gi* a partir de la suma de los valores de los vecinos de cada
+// elemento y de la cantidad de vecinos, para los casos en que listar los vecinos es costoso
+func GiStarSums(values, sums []float64, weights []int) []float64 {
+	n := float64(len(values))
+	scores := make([]float64, len(values))
+	if len(values) < 2 {
+		return scores
+	}
+
+	sum, sumSquares := 0.0, 0.0
+	for _, value := range values {
+		sum += value
+		sumSquares += value * value
+	}
+	mean := sum / n
+	deviation := math.Sqrt(math.Max(0, sumSquares/n-mean*mean))
+	if deviation == 0 {
+		return scores
+	}
+
+	for i, local := range sums {
+		w := float64(weights[i])
+		// Con pesos binarios la suma de los pesos al cuadrado es igual a la suma de los pesos
+		variance := (n*w - w*w) / (n - 1)
+		if variance <= 0 {
+			continue
+		}
+		scores[i] = (local - mean*w) / (deviation * math.Sqrt(variance))
+	}
+	return scores
+}
+
+// GridDiskSums recibe los valores de una grilla fila por fila y retorna, para cada celda, la
+// suma de los valores de las celdas cuyos centros están a reach celdas o menos, incluida ella
+// misma, y la cantidad de esas celdas. Usa sumas acumuladas sobre la dimensión más larga de
+// la grilla, por lo que el costo es el de recorrer cada celda una vez por fila del disco y no
+// depende de la cantidad de pares vecinos
+func GridDiskSums(values []float64, cols int, reach float64) ([]float64, []int) {
+	sums := make([]float64, len(values))
+	counts := make([]int, len(values))
+	if len(values) == 0 || cols <= 0 {
+		return sums, counts
+	}
+	rows := len(values) / cols
+
+	// El disco es simétrico: si hay más filas que columnas se recorre la grilla traspuesta
+	transposed := rows > cols
+	grid := values
+	if transposed {
+		grid = make([]float64, len(values))
+		for r := 0; r < rows; r++ {
+			for c := 0; c < cols; c++ {
+				grid[c*rows+r] = values[r*cols+c]
+			}
+		}
+		rows, cols = cols, rows
+	}
+
+	prefix := make([]float64, rows*(cols+1))
+	for r := 0; r < rows; r++ {
+		for c := 0; c < cols; c++ {
+			prefix[r*(cols+1)+c+1] = prefix[r*(cols+1)+c] + grid[r*cols+c]
+		}
+	}
+	span := min(int(reach), rows-1)
+	halfWidths := make([]int, span+1)
+	for dy := range halfWidths {
+		halfWidths[dy] = int(math.Sqrt(reach*reach - float64(dy*dy)))
+	}
+
+	for r := 0; r < rows; r++ {
+		for c := 0; c < cols; c++ {
+			sum, count := 0.0, 0
+			for rr := max(0, r-span); rr <= min(rows-1, r+span); rr++ {
+				w := halfWidths[abs(rr-r)]
+				lo, hi := max(0, c-w), min(cols-1, c+w)
+				sum += prefix[rr*(cols+1)+hi+1] - prefix[rr*(cols+1)+lo]
+				count += hi - lo + 1
+			}
+			i := r*cols + c
+			if transposed {
+				i = c*rows + r
+			}
+			sums[i], counts[i] = sum, count
+		}
+	}
+	return sums, counts
+}
+
+// abs retorna el valor absoluto de un entero
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
+
+// PValue retorna el p-valor bilateral de un z-score según la distribución normal estándar
+func PValue(z float64) float64 {
+	return math.Erfc(math.Abs(z) / math.Sqrt2)
+}
@@ -0,0 +1,125 @@
+// Package spatial implementa índices y algoritmos espaciales en memoria para coordenadas planas
+package spatial
+
+import (
+	"math"
+	"sort"
+)
+
+// maxCellsPerRect limita las celdas que ocupa un rectángulo; los más grandes se guardan
+// aparte y se comparan en cada consulta
+const maxCellsPerRect = 1024
+
+// Rect representa un rectángulo alineado con los ejes, bordes incluidos
+type Rect struct {
+	MinX, MinY, MaxX, MaxY float64
+}
+
+// Contains indica si el punto está dentro del rectángulo
+func (r Rect) Contains(x, y float64) bool {
+	return x >= r.MinX && x <= r.MaxX && y >= r.MinY && y <= r.MaxY
+}
+
+// cellKey identifica una celda de la grilla
+type cellKey struct {
+	x, y int64
+}
+
+// Grid es un índice de rectángulos sobre una grilla uniforme: cada rectángulo se registra en
+// las celdas que cubre, por lo que una consulta por punto solo compara los rectángulos de su
+// celda. No es seguro para uso concurrente
+type Grid struct {
+	cellSize  float64
+	rects     map[string]Rect
+	cells     map[cellKey]map[string]struct{}
+	oversized map[string]struct{}
+}
+
+// NewGrid crea un índice vacío con celdas del tamaño indicado, en las unidades de las coordenadas
+func NewGrid(cellSize float64) *Grid {
+	return &Grid{
+		cellSize:  cellSize,
+		rects:     make(map[string]Rect),
+		cells:     make(map[cellKey]map[string]struct{}),
+		oversized: make(map[string]struct{}),
+	}
+}
+
+// Insert registra el rectángulo con el ID indicado, reemplazando el anterior si existía
+func (g *Grid) Insert(id string, rect Rect) {
+	g.Remove(id)
+	g.rects[id] = rect
+
+	minX, minY := g.cell(rect.MinX, rect.MinY)
+	maxX, maxY := g.cell(rect.MaxX, rect.MaxY)
+	if (maxX-minX+1)*(maxY-minY+1) > maxCellsPerRect {
+		g.oversized[id] = struct{}{}
+		return
+	}
+	for x := minX; x <= maxX; x++ {
+		for y := minY; y <= maxY; y++ {
+			key := cellKey{x, y}
+			if g.cells[key] == nil {
+				g.cells[key] = make(map[string]struct{})
+			}
+			g.cells[key][id] = struct{}{}
+		}
+	}
+}
+
+// Remove elimina el rectángulo con el ID indicado, si existe
+func (g *Grid) Remove(id string) {
+	rect, exists := g.rects[id]
+	if !exists {
+		return
+	}
+	delete(g.rects, id)
+	if _, big := g.oversized[id]; big {
+		delete(g.oversized, id)
+		return
+	}
+
+	minX, minY := g.cell(rect.MinX, rect.MinY)
+	maxX, maxY := g.cell(rect.MaxX, rect.MaxY)
+	for x := minX; x <= maxX; x++ {
+		for y := minY; y <= maxY; y++ {
+			key := cellKey{x, y}
+			delete(g.cells[key], id)
+			if len(g.cells[key]) == 0 {
+				delete(g.cells, key)
+			}
+		}
+	}
+}
+
+// Query retorna, ordenados, los IDs de los rectángulos que contienen el punto
+func (g *Grid) Query(x, y float64) []string {
+	var ids []string
+	for id := range g.cells[cellKey{g.cellIndex(x), g.cellIndex(y)}] {
+		if g.rects[id].Contains(x, y) {
+			ids = append(ids, id)
+		}
+	}
+	for id := range g.oversized {
+		if g.rects[id].Contains(x, y) {
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// Len retorna la cantidad de rectángulos registrados
+func (g *Grid) Len() int {
+	return len(g.rects)
+}
+
+// cell retorna la celda que contiene el punto
+func (g *Grid) cell(x, y float64) (int64, int64) {
+	return g.cellIndex(x), g.cellIndex(y)
+}
+
+// cellIndex retorna el índice de la celda en un eje
+func (g *Grid) cellIndex(value float64) int64 {
+	return int64(math.Floor(value / g.cellSize))
+}
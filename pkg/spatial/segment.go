@@ -0,0 +1,31 @@
+package spatial
+
+import "math"
+
+// SegmentDistance calcula la distancia del punto p al segmento entre a y b
+func SegmentDistance(p, a, b Point) float64 {
+	dx, dy := b.X-a.X, b.Y-a.Y
+	lengthSq := dx*dx + dy*dy
+	if lengthSq == 0 {
+		return math.Hypot(p.X-a.X, p.Y-a.Y)
+	}
+	// Proyección de p sobre la recta, acotada a los extremos del segmento
+	t := math.Max(0, math.Min(1, ((p.X-a.X)*dx+(p.Y-a.Y)*dy)/lengthSq))
+	return math.Hypot(p.X-(a.X+t*dx), p.Y-(a.Y+t*dy))
+}
+
+// PolylineDistance calcula la distancia del punto p a la línea formada por los vértices.
+// Con un solo vértice es la distancia a ese vértice; sin vértices es infinita
+func PolylineDistance(p Point, vertices []Point) float64 {
+	switch len(vertices) {
+	case 0:
+		return math.Inf(1)
+	case 1:
+		return math.Hypot(p.X-vertices[0].X, p.Y-vertices[0].Y)
+	}
+	distance := math.Inf(1)
+	for i := 1; i < len(vertices); i++ {
+		distance = math.Min(distance, SegmentDistance(p, vertices[i-1], vertices[i]))
+	}
+	return distance
+}
@@ -0,0 +1,87 @@
+package tests
+
+import (
+	"math"
+	"runtime"
+	"testing"
+
+	"go-crime_map_backend/pkg/spatial"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDBSCAN(t *testing.T) {
+	points := []spatial.Point{
+		{X: 0, Y: 0}, {X: 5, Y: 0}, {X: 0, Y: 5}, {X: 5, Y: 5}, // Núcleo denso
+		{X: 14, Y: 5}, // Borde: vecino de un núcleo sin vecinos suficientes
+		{X: 100, Y: 100}, {X: 103, Y: 100}, {X: 100, Y: 103},
+		{X: 500, Y: 500}, // Aislado
+	}
+	labels := spatial.DBSCAN(points, 10, 3)
+
+	assert.Equal(t, []int{0, 0, 0, 0, 0, 1, 1, 1, spatial.Noise}, labels)
+	assert.Equal(t, []int{spatial.Noise, spatial.Noise}, spatial.DBSCAN(points[:2], 10, 3))
+	assert.Empty(t, spatial.DBSCAN(nil, 10, 3))
+}
+
+func TestDBSCANDenseCloud(t *testing.T) {
+	// Todos los puntos son vecinos entre sí: la cola de expansión no debe repetirlos
+	points := make([]spatial.Point, 8000)
+	for i := range points {
+		points[i] = spatial.Point{X: float64(i%100) * 10, Y: float64(i/100) * 10}
+	}
+	var before, after runtime.MemStats
+	runtime.ReadMemStats(&before)
+	labels := spatial.DBSCAN(points, 5000, 5)
+	runtime.ReadMemStats(&after)
+
+	for _, label := range labels {
+		require.Equal(t, 0, label)
+	}
+	assert.Less(t, after.TotalAlloc-before.TotalAlloc, uint64(16<<20), "la memoria crece con los puntos, no con los pares")
+}
+
+func TestConvexHull(t *testing.T) {
+	hull := spatial.ConvexHull([]spatial.Point{
+		{X: 0, Y: 0}, {X: 2, Y: 0}, {X: 1, Y: 1}, {X: 2, Y: 2}, {X: 0, Y: 2}, {X: 1, Y: 0},
+	})
+	assert.Equal(t, []spatial.Point{{X: 0, Y: 0}, {X: 2, Y: 0}, {X: 2, Y: 2}, {X: 0, Y: 2}}, hull)
+
+	// Los puntos alineados no delimitan un área
+	assert.Len(t, spatial.ConvexHull([]spatial.Point{{X: 0, Y: 0}, {X: 1, Y: 1}, {X: 2, Y: 2}}), 2)
+}
+
+func TestKernelDensity(t *testing.T) {
+	points := []spatial.Point{{X: 0, Y: 0}, {X: 10, Y: 0}, {X: 0, Y: 10}, {X: 1000, Y: 1000}}
+	grid := spatial.KernelDensity(points, 50, 25)
+	require.NotNil(t, grid)
+
+	// La densidad es mayor donde se concentran los puntos
+	col, row := grid.Cell(points[0])
+	isolatedCol, isolatedRow := grid.Cell(points[3])
+	assert.Greater(t, grid.Values[row*grid.Cols+col], grid.Values[isolatedRow*grid.Cols+isolatedCol])
+
+	// Con un umbral bajo se separan dos regiones; con uno alto solo queda la densa
+	regions := grid.Regions(grid.Max() * 0.2)
+	assert.NotEqual(t, spatial.Noise, regions[row*grid.Cols+col])
+	assert.NotEqual(t, spatial.Noise, regions[isolatedRow*grid.Cols+isolatedCol])
+	assert.NotEqual(t, regions[row*grid.Cols+col], regions[isolatedRow*grid.Cols+isolatedCol])
+
+	regions = grid.Regions(grid.Max() * 0.5)
+	assert.Equal(t, 0, regions[row*grid.Cols+col])
+	assert.Equal(t, spatial.Noise, regions[isolatedRow*grid.Cols+isolatedCol])
+
+	assert.Nil(t, spatial.KernelDensity(nil, 50, 25))
+}
+
+func TestPolylineDistance(t *testing.T) {
+	a, b := spatial.Point{X: 0, Y: 0}, spatial.Point{X: 10, Y: 0}
+	assert.InDelta(t, 3, spatial.SegmentDistance(spatial.Point{X: 5, Y: 3}, a, b), 1e-9)
+	assert.InDelta(t, 5, spatial.SegmentDistance(spatial.Point{X: 13, Y: 4}, a, b), 1e-9, "más allá del extremo")
+	assert.InDelta(t, 5, spatial.SegmentDistance(spatial.Point{X: 3, Y: 4}, a, a), 1e-9, "segmento degenerado")
+
+	path := []spatial.Point{a, b, {X: 10, Y: 10}}
+	assert.InDelta(t, 2, spatial.PolylineDistance(spatial.Point{X: 12, Y: 5}, path), 1e-9)
+	assert.True(t, math.IsInf(spatial.PolylineDistance(a, nil), 1))
+}
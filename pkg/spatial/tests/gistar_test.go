@@ -0,0 +1,66 @@
+package tests
+
+import (
+	"math"
+	"testing"
+
+	"go-crime_map_backend/pkg/spatial"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGiStar(t *testing.T) {
+	// Valores sobre una línea; cada elemento es vecino de los adyacentes y de sí mismo
+	values := []float64{0, 0, 0, 0, 9, 10, 11, 0, 0, 0, 0}
+	neighbors := make([][]int, len(values))
+	for i := range values {
+		for j := max(0, i-1); j <= min(len(values)-1, i+1); j++ {
+			neighbors[i] = append(neighbors[i], j)
+		}
+	}
+	scores := spatial.GiStar(values, neighbors)
+
+	assert.Greater(t, scores[5], 2.576, "el centro de la concentración es significativo al 99 %")
+	assert.Greater(t, scores[5], scores[4])
+	assert.Less(t, scores[0], 0.0)
+	assert.InDelta(t, scores[1], scores[9], 1e-9)
+
+	// Sin variación no hay concentraciones
+	assert.Equal(t, []float64{0, 0, 0}, spatial.GiStar([]float64{3, 3, 3}, [][]int{{0, 1}, {0, 1, 2}, {1, 2}}))
+
+	assert.InDelta(t, 0.05, spatial.PValue(1.959964), 1e-6)
+	assert.InDelta(t, 0.05, spatial.PValue(-1.959964), 1e-6)
+	assert.Equal(t, 1.0, spatial.PValue(0))
+}
+
+func TestGridDiskSums(t *testing.T) {
+	// Las sumas acumuladas coinciden con listar los vecinos de cada celda, también en una
+	// grilla con más filas que columnas
+	for _, size := range [][2]int{{7, 4}, {3, 9}} {
+		cols, rows := size[0], size[1]
+		values := make([]float64, cols*rows)
+		for i := range values {
+			values[i] = float64((i * 7) % 5)
+		}
+		for _, reach := range []float64{0, 1, 1.5, 2.3, 20} {
+			neighbors := make([][]int, len(values))
+			for i := range values {
+				for j := range values {
+					if math.Hypot(float64(i%cols-j%cols), float64(i/cols-j/cols)) <= reach {
+						neighbors[i] = append(neighbors[i], j)
+					}
+				}
+			}
+			sums, weights := spatial.GridDiskSums(values, cols, reach)
+			for i, members := range neighbors {
+				expected := 0.0
+				for _, j := range members {
+					expected += values[j]
+				}
+				assert.InDelta(t, expected, sums[i], 1e-9)
+				assert.Equal(t, len(members), weights[i])
+			}
+			assert.Equal(t, spatial.GiStar(values, neighbors), spatial.GiStarSums(values, sums, weights))
+		}
+	}
+}
@@ -0,0 +1,29 @@
+package tests
+
+import (
+	"testing"
+
+	"go-crime_map_backend/pkg/spatial"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGridQuery(t *testing.T) {
+	grid := spatial.NewGrid(0.1)
+	grid.Insert("centro", spatial.Rect{MinX: -58.40, MinY: -34.62, MaxX: -58.36, MaxY: -34.59})
+	grid.Insert("palermo", spatial.Rect{MinX: -58.44, MinY: -34.59, MaxX: -58.40, MaxY: -34.56})
+	grid.Insert("pais", spatial.Rect{MinX: -73.6, MinY: -55.1, MaxX: -53.6, MaxY: -21.8})
+
+	assert.Equal(t, []string{"centro", "pais"}, grid.Query(-58.38, -34.60))
+	assert.Equal(t, []string{"centro", "pais", "palermo"}, grid.Query(-58.40, -34.59), "los bordes están incluidos")
+	assert.Empty(t, grid.Query(2.35, 48.85))
+
+	grid.Insert("centro", spatial.Rect{MinX: 2.3, MinY: 48.8, MaxX: 2.4, MaxY: 48.9})
+	assert.Equal(t, []string{"pais"}, grid.Query(-58.38, -34.60))
+	assert.Equal(t, []string{"centro"}, grid.Query(2.35, 48.85))
+
+	grid.Remove("pais")
+	grid.Remove("inexistente")
+	assert.Empty(t, grid.Query(-58.38, -34.60))
+	assert.Equal(t, 2, grid.Len())
+}